@@ -0,0 +1,320 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/angusgmorrison/hexagonal/internal/primitive"
+	"github.com/angusgmorrison/hexagonal/internal/service/authservice"
+	"github.com/angusgmorrison/hexagonal/internal/service/classservice"
+)
+
+// app runs hexctl's commands against the class and auth services.
+type app struct {
+	classService classservice.Interface
+	authService  authservice.Interface
+	stdin        io.Reader
+	stdout       io.Writer
+	format       outputFormat
+}
+
+// run executes the command named by the first argument.
+func (a *app) run(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return usageError{message: "no command given"}
+	}
+
+	switch command, args := args[0], args[1:]; command {
+	case "enroll":
+		return a.enroll(ctx, args)
+	case "unenroll":
+		return a.unenroll(ctx, args)
+	case "roster":
+		return a.roster(ctx, args)
+	case "courses":
+		if len(args) == 0 || args[0] != "list" {
+			return usageError{message: `usage: hexctl courses list`}
+		}
+
+		return a.listCourses(ctx, args[1:])
+	case "keys":
+		return a.keys(ctx, args)
+	default:
+		return usageError{message: fmt.Sprintf("unknown command %q", command)}
+	}
+}
+
+// enroll enrolls the students listed in a CSV file in a course.
+func (a *app) enroll(ctx context.Context, args []string) error {
+	var (
+		flags       = newFlagSet("enroll")
+		courseCode  = flags.String("course", "", "")
+		sectionCode = flags.String("section", "", "")
+		voucherCode = flags.String("voucher", "", "")
+		path        = flags.String("file", "", "")
+	)
+
+	if err := flags.Parse(args); err != nil || *courseCode == "" || *path == "" || flags.NArg() > 0 {
+		return usageError{
+			message: "usage: hexctl enroll -course CODE [-section CODE] [-voucher CODE] -file STUDENTS.csv",
+		}
+	}
+
+	students, err := a.readStudentsFile(*path, columnName, columnBirthdate, columnEmail)
+	if err != nil {
+		return err
+	}
+
+	er := classservice.EnrollmentRequest{
+		CourseCode:  *courseCode,
+		SectionCode: *sectionCode,
+		VoucherCode: *voucherCode,
+		Students:    students,
+	}
+
+	if err := a.classService.Enroll(ctx, er); err != nil {
+		return fmt.Errorf("enroll: %w", err)
+	}
+
+	fmt.Fprintf(a.stdout, "Enrolled %d student(s) in %s\n", len(students), *courseCode)
+
+	return nil
+}
+
+// unenroll withdraws students from a course. Students are listed in a CSV file,
+// as arguments, or both.
+func (a *app) unenroll(ctx context.Context, args []string) error {
+	var (
+		flags      = newFlagSet("unenroll")
+		courseCode = flags.String("course", "", "")
+		path       = flags.String("file", "", "")
+	)
+
+	if err := flags.Parse(args); err != nil || *courseCode == "" || (*path == "" && flags.NArg() == 0) {
+		return usageError{message: "usage: hexctl unenroll -course CODE [-file STUDENTS.csv] [EMAIL...]"}
+	}
+
+	var students classservice.Students
+
+	if *path != "" {
+		fromFile, err := a.readStudentsFile(*path, columnEmail)
+		if err != nil {
+			return err
+		}
+
+		students = append(students, fromFile...)
+	}
+
+	for _, email := range flags.Args() {
+		students = append(students, classservice.Student{Email: primitive.EmailAddress(email)})
+	}
+
+	if err := a.classService.Unenroll(ctx, *courseCode, students); err != nil {
+		return fmt.Errorf("unenroll: %w", err)
+	}
+
+	fmt.Fprintf(a.stdout, "Unenrolled %d student(s) from %s\n", len(students), *courseCode)
+
+	return nil
+}
+
+// roster prints everyone on the roster of a course.
+func (a *app) roster(ctx context.Context, args []string) error {
+	var (
+		flags      = newFlagSet("roster")
+		courseCode = flags.String("course", "", "")
+	)
+
+	if err := flags.Parse(args); err != nil || *courseCode == "" || flags.NArg() > 0 {
+		return usageError{message: "usage: hexctl roster -course CODE"}
+	}
+
+	class, err := a.classService.GetClass(ctx, *courseCode)
+	if err != nil {
+		return fmt.Errorf("roster: %w", err)
+	}
+
+	return writeTable(a.stdout, a.format, rosterTable(class))
+}
+
+// listCourses prints every course and the size of its roster.
+func (a *app) listCourses(ctx context.Context, args []string) error {
+	if len(args) > 0 {
+		return usageError{message: "usage: hexctl courses list"}
+	}
+
+	classes, err := a.classService.ListClasses(ctx)
+	if err != nil {
+		return fmt.Errorf("courses list: %w", err)
+	}
+
+	return writeTable(a.stdout, a.format, coursesTable(classes))
+}
+
+// keys executes the API key subcommand named by the first argument.
+func (a *app) keys(ctx context.Context, args []string) error {
+	const keysUsage = "usage: hexctl keys create -name NAME | keys list | keys revoke ID"
+
+	if len(args) == 0 {
+		return usageError{message: keysUsage}
+	}
+
+	switch subcommand, args := args[0], args[1:]; subcommand {
+	case "create":
+		return a.createKey(ctx, args)
+	case "list":
+		return a.listKeys(ctx, args)
+	case "revoke":
+		return a.revokeKey(ctx, args)
+	default:
+		return usageError{message: keysUsage}
+	}
+}
+
+// createKey issues a new API key and prints it. The key can't be recovered
+// afterwards.
+func (a *app) createKey(ctx context.Context, args []string) error {
+	var (
+		flags = newFlagSet("keys create")
+		name  = flags.String("name", "", "")
+	)
+
+	if err := flags.Parse(args); err != nil || *name == "" || flags.NArg() > 0 {
+		return usageError{message: "usage: hexctl keys create -name NAME"}
+	}
+
+	apiKey, key, err := a.authService.CreateAPIKey(ctx, *name)
+	if err != nil {
+		return fmt.Errorf("keys create: %w", err)
+	}
+
+	fmt.Fprintf(a.stdout, "Created API key %d (%s). It won't be shown again:\n%s\n", apiKey.ID, apiKey.Name, key)
+
+	return nil
+}
+
+// listKeys prints every API key, including those that were revoked.
+func (a *app) listKeys(ctx context.Context, args []string) error {
+	if len(args) > 0 {
+		return usageError{message: "usage: hexctl keys list"}
+	}
+
+	apiKeys, err := a.authService.ListAPIKeys(ctx)
+	if err != nil {
+		return fmt.Errorf("keys list: %w", err)
+	}
+
+	return writeTable(a.stdout, a.format, keysTable(apiKeys))
+}
+
+// revokeKey revokes the API key with the given ID, so that it no longer
+// authenticates requests.
+func (a *app) revokeKey(ctx context.Context, args []string) error {
+	if len(args) != 1 {
+		return usageError{message: "usage: hexctl keys revoke ID"}
+	}
+
+	id, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return usageError{message: fmt.Sprintf("invalid API key ID %q", args[0])}
+	}
+
+	if err := a.authService.RevokeAPIKey(ctx, id); err != nil {
+		return fmt.Errorf("keys revoke: %w", err)
+	}
+
+	fmt.Fprintf(a.stdout, "Revoked API key %d\n", id)
+
+	return nil
+}
+
+// readStudentsFile reads students from the CSV file at path, or from standard
+// input if path is "-".
+func (a *app) readStudentsFile(path string, required ...string) (classservice.Students, error) {
+	if path == "-" {
+		return readStudents(a.stdin, required...)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open students file: %w", err)
+	}
+	defer f.Close()
+
+	students, err := readStudents(f, required...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return students, nil
+}
+
+func rosterTable(class classservice.Class) table {
+	t := table{header: []string{"email", "name", "birthdate", "status", "section", "attendance"}}
+
+	for _, entry := range class.Roster() {
+		var attendance string
+		if percentage, ok := entry.Attendance.Percentage(); ok {
+			attendance = strconv.FormatFloat(math.Round(percentage*10)/10, 'f', -1, 64) + "%"
+		}
+
+		t.rows = append(t.rows, []string{
+			string(entry.Email),
+			entry.Name,
+			time.Time(entry.Birthdate).Format(primitive.BirthdateLayout),
+			string(entry.Status),
+			entry.SectionCode,
+			attendance,
+		})
+	}
+
+	return t
+}
+
+func coursesTable(classes []classservice.Class) table {
+	t := table{header: []string{"code", "capacity", "enrolled", "pending", "awaiting_payment", "fee", "cancelled"}}
+
+	for _, class := range classes {
+		var fee string
+		if !class.Fee.IsZero() {
+			fee = class.Fee.String()
+		}
+
+		t.rows = append(t.rows, []string{
+			class.Code,
+			strconv.FormatUint(uint64(class.Capacity), 10),
+			strconv.Itoa(len(class.Students)),
+			strconv.Itoa(len(class.Pending)),
+			strconv.Itoa(len(class.AwaitingPayment)),
+			fee,
+			strconv.FormatBool(class.Cancelled),
+		})
+	}
+
+	return t
+}
+
+func keysTable(apiKeys authservice.APIKeys) table {
+	t := table{header: []string{"id", "name", "created_at", "revoked_at"}}
+
+	for _, apiKey := range apiKeys {
+		var revokedAt string
+		if apiKey.Revoked() {
+			revokedAt = apiKey.RevokedAt.UTC().Format(time.RFC3339)
+		}
+
+		t.rows = append(t.rows, []string{
+			strconv.FormatInt(apiKey.ID, 10),
+			apiKey.Name,
+			apiKey.CreatedAt.UTC().Format(time.RFC3339),
+			revokedAt,
+		})
+	}
+
+	return t
+}
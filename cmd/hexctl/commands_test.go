@@ -0,0 +1,361 @@
+//go:build unit
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/angusgmorrison/hexagonal/internal/primitive"
+	"github.com/angusgmorrison/hexagonal/internal/service/authservice"
+	"github.com/angusgmorrison/hexagonal/internal/service/classservice"
+	"github.com/stretchr/testify/require"
+)
+
+const studentsCSV = `email,name,birthdate
+angus@example.com,Angus Morrison,1990-03-04
+r.tifft@gmail.com,Ramdas Tifft,1985-11-22
+`
+
+func TestEnroll(t *testing.T) {
+	t.Parallel()
+
+	var (
+		classService = classservice.NewMockInterface(t)
+		stdout       bytes.Buffer
+		a            = app{
+			classService: classService,
+			stdin:        strings.NewReader(studentsCSV),
+			stdout:       &stdout,
+			format:       formatTable,
+		}
+		ctx = context.Background()
+	)
+
+	classService.On("Enroll", ctx, classservice.EnrollmentRequest{
+		CourseCode:  "SICP",
+		VoucherCode: "SPRING",
+		Students: classservice.Students{
+			{Name: "Angus Morrison", Birthdate: birthdate(t, "1990-03-04"), Email: "angus@example.com"},
+			{Name: "Ramdas Tifft", Birthdate: birthdate(t, "1985-11-22"), Email: "r.tifft@gmail.com"},
+		},
+	}).Return(nil)
+
+	err := a.run(ctx, []string{"enroll", "-course", "SICP", "-voucher", "SPRING", "-file", "-"})
+	require.NoError(t, err)
+	require.Equal(t, "Enrolled 2 student(s) in SICP\n", stdout.String())
+}
+
+func TestUnenroll(t *testing.T) {
+	t.Parallel()
+
+	var (
+		classService = classservice.NewMockInterface(t)
+		stdout       bytes.Buffer
+		a            = app{
+			classService: classService,
+			stdin:        strings.NewReader("email\nangus@example.com\n"),
+			stdout:       &stdout,
+			format:       formatTable,
+		}
+		ctx = context.Background()
+	)
+
+	classService.On("Unenroll", ctx, "SICP", classservice.Students{
+		{Email: "angus@example.com"},
+		{Email: "r.tifft@gmail.com"},
+	}).Return(nil)
+
+	err := a.run(ctx, []string{"unenroll", "-course", "SICP", "-file", "-", "r.tifft@gmail.com"})
+	require.NoError(t, err)
+	require.Equal(t, "Unenrolled 2 student(s) from SICP\n", stdout.String())
+}
+
+func TestRoster(t *testing.T) {
+	t.Parallel()
+
+	class := classservice.Class{
+		Course: classservice.Course{Code: "SICP", Capacity: 2},
+		Students: classservice.Students{
+			{ID: 1, Name: "Angus Morrison", Birthdate: birthdate(t, "1990-03-04"), Email: "angus@example.com"},
+		},
+		Pending: classservice.Students{
+			{ID: 2, Name: "Ramdas Tifft", Birthdate: birthdate(t, "1985-11-22"), Email: "r.tifft@gmail.com"},
+		},
+		Attendance: classservice.StudentAttendance{1: {Attended: 2, Recorded: 3}},
+	}
+
+	testCases := []struct {
+		format outputFormat
+		want   string
+	}{
+		{
+			format: formatTable,
+			want: "EMAIL              NAME            BIRTHDATE   STATUS    SECTION  ATTENDANCE\n" +
+				"angus@example.com  Angus Morrison  1990-03-04  enrolled           66.7%\n" +
+				"r.tifft@gmail.com  Ramdas Tifft    1985-11-22  pending            \n",
+		},
+		{
+			format: formatCSV,
+			want: "email,name,birthdate,status,section,attendance\n" +
+				"angus@example.com,Angus Morrison,1990-03-04,enrolled,,66.7%\n" +
+				"r.tifft@gmail.com,Ramdas Tifft,1985-11-22,pending,,\n",
+		},
+		{
+			format: formatJSON,
+			want: `[
+  {
+    "attendance": "66.7%",
+    "birthdate": "1990-03-04",
+    "email": "angus@example.com",
+    "name": "Angus Morrison",
+    "section": "",
+    "status": "enrolled"
+  },
+  {
+    "attendance": "",
+    "birthdate": "1985-11-22",
+    "email": "r.tifft@gmail.com",
+    "name": "Ramdas Tifft",
+    "section": "",
+    "status": "pending"
+  }
+]
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(string(tc.format), func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				classService = classservice.NewMockInterface(t)
+				stdout       bytes.Buffer
+				a            = app{classService: classService, stdout: &stdout, format: tc.format}
+				ctx          = context.Background()
+			)
+
+			classService.On("GetClass", ctx, "SICP").Return(class, nil)
+
+			err := a.run(ctx, []string{"roster", "-course", "SICP"})
+			require.NoError(t, err)
+			require.Equal(t, tc.want, stdout.String())
+		})
+	}
+}
+
+func TestWriteTableEscapesCSVFormulas(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+
+	err := writeTable(&out, formatCSV, table{
+		header: []string{"=email", "name"},
+		rows:   [][]string{{"angus@example.com", "=HYPERLINK(\"http://evil\")"}, {"@SUM(A1)", "-1+1"}},
+	})
+	require.NoError(t, err)
+	require.Equal(t,
+		"'=email,name\n"+
+			"angus@example.com,\"'=HYPERLINK(\"\"http://evil\"\")\"\n"+
+			"'@SUM(A1),'-1+1\n",
+		out.String())
+}
+
+func TestListCourses(t *testing.T) {
+	t.Parallel()
+
+	var (
+		classService = classservice.NewMockInterface(t)
+		stdout       bytes.Buffer
+		a            = app{classService: classService, stdout: &stdout, format: formatCSV}
+		ctx          = context.Background()
+		fee, err     = primitive.NewMoney(25000, "GBP")
+	)
+
+	require.NoError(t, err)
+
+	classService.On("ListClasses", ctx).Return([]classservice.Class{
+		{
+			Course:   classservice.Course{Code: "SICP", Capacity: 30, Fee: fee},
+			Students: classservice.Students{{ID: 1}, {ID: 2}},
+			Pending:  classservice.Students{{ID: 3}},
+		},
+		{Course: classservice.Course{Code: "TAOCP", Capacity: 10, Cancelled: true}},
+	}, nil)
+
+	err = a.run(ctx, []string{"courses", "list"})
+	require.NoError(t, err)
+	require.Equal(t,
+		"code,capacity,enrolled,pending,awaiting_payment,fee,cancelled\n"+
+			"SICP,30,2,1,0,"+fee.String()+",false\n"+
+			"TAOCP,10,0,0,0,,true\n",
+		stdout.String())
+}
+
+func TestKeys(t *testing.T) {
+	t.Parallel()
+
+	t.Run("create", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			authService = authservice.NewMockInterface(t)
+			stdout      bytes.Buffer
+			a           = app{authService: authService, stdout: &stdout, format: formatTable}
+			ctx         = context.Background()
+		)
+
+		authService.On("CreateAPIKey", ctx, "ci").Return(authservice.APIKey{ID: 1, Name: "ci"}, "hxk_k3y", nil)
+
+		err := a.run(ctx, []string{"keys", "create", "-name", "ci"})
+		require.NoError(t, err)
+		require.Equal(t, "Created API key 1 (ci). It won't be shown again:\nhxk_k3y\n", stdout.String())
+	})
+
+	t.Run("list", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			authService = authservice.NewMockInterface(t)
+			stdout      bytes.Buffer
+			a           = app{authService: authService, stdout: &stdout, format: formatCSV}
+			ctx         = context.Background()
+			createdAt   = time.Date(2026, time.October, 1, 9, 0, 0, 0, time.UTC)
+			revokedAt   = time.Date(2026, time.October, 18, 12, 0, 0, 0, time.UTC)
+		)
+
+		authService.On("ListAPIKeys", ctx).Return(authservice.APIKeys{
+			{ID: 1, Name: "ci", CreatedAt: createdAt},
+			{ID: 2, Name: "billing", CreatedAt: createdAt, RevokedAt: revokedAt},
+		}, nil)
+
+		err := a.run(ctx, []string{"keys", "list"})
+		require.NoError(t, err)
+		require.Equal(t,
+			"id,name,created_at,revoked_at\n"+
+				"1,ci,2026-10-01T09:00:00Z,\n"+
+				"2,billing,2026-10-01T09:00:00Z,2026-10-18T12:00:00Z\n",
+			stdout.String())
+	})
+
+	t.Run("revoke", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			authService = authservice.NewMockInterface(t)
+			stdout      bytes.Buffer
+			a           = app{authService: authService, stdout: &stdout, format: formatTable}
+			ctx         = context.Background()
+		)
+
+		authService.On("RevokeAPIKey", ctx, int64(2)).Return(nil)
+
+		err := a.run(ctx, []string{"keys", "revoke", "2"})
+		require.NoError(t, err)
+		require.Equal(t, "Revoked API key 2\n", stdout.String())
+	})
+}
+
+func TestUsageErrors(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+		args []string
+	}{
+		{name: "no command", args: nil},
+		{name: "unknown command", args: []string{"transfer"}},
+		{name: "courses without list", args: []string{"courses"}},
+		{name: "enroll without course", args: []string{"enroll", "-file", "students.csv"}},
+		{name: "enroll without file", args: []string{"enroll", "-course", "SICP"}},
+		{name: "unenroll without students", args: []string{"unenroll", "-course", "SICP"}},
+		{name: "roster with unknown flag", args: []string{"roster", "-course", "SICP", "-v"}},
+		{name: "keys without subcommand", args: []string{"keys"}},
+		{name: "keys create without name", args: []string{"keys", "create"}},
+		{name: "keys revoke with malformed ID", args: []string{"keys", "revoke", "ci"}},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			a := app{
+				classService: classservice.NewMockInterface(t),
+				authService:  authservice.NewMockInterface(t),
+				stdout:       &bytes.Buffer{},
+			}
+
+			err := a.run(context.Background(), tc.args)
+
+			var usageErr usageError
+			require.ErrorAs(t, err, &usageErr)
+		})
+	}
+}
+
+func TestReadStudents(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		csv      string
+		required []string
+		wantErr  string
+	}{
+		{
+			name:    "empty",
+			csv:     "",
+			wantErr: "students CSV is empty",
+		},
+		{
+			name:    "no students",
+			csv:     "email\n",
+			wantErr: "students CSV lists no students",
+		},
+		{
+			name:     "missing column",
+			csv:      "name,email\nAngus Morrison,angus@example.com\n",
+			required: []string{columnName, columnBirthdate, columnEmail},
+			wantErr:  `students CSV has no "birthdate" column`,
+		},
+		{
+			name:     "missing value",
+			csv:      "email\nangus@example.com\n\"\"\n",
+			required: []string{columnEmail},
+			wantErr:  "line 3: email is required",
+		},
+		{
+			name:    "malformed birthdate",
+			csv:     "email,birthdate\nangus@example.com,04/03/1990\n",
+			wantErr: "line 2: birthdate must be formatted as 2006-01-02",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := readStudents(strings.NewReader(tc.csv), tc.required...)
+			require.EqualError(t, err, tc.wantErr)
+		})
+	}
+}
+
+func birthdate(t *testing.T, raw string) primitive.Birthdate {
+	t.Helper()
+
+	bd, err := primitive.ParseBirthdate(raw)
+	require.NoError(t, err)
+
+	return bd
+}
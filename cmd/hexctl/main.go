@@ -0,0 +1,143 @@
+// Command hexctl is an administrative client for enrollment operations. It
+// drives the class service directly against the database configured by the
+// environment, so support staff can enroll and unenroll students and inspect
+// rosters without crafting HTTP requests by hand. It also issues and revokes
+// the API keys with which clients authenticate to the REST API.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+
+	"github.com/angusgmorrison/hexagonal/internal/bootstrap"
+	"github.com/angusgmorrison/hexagonal/internal/envconfig"
+	"github.com/angusgmorrison/hexagonal/internal/storage/sql/database"
+	"github.com/go-playground/validator/v10"
+)
+
+const usage = `Usage: hexctl [-format table|json|csv] <command> [flags] [arguments]
+
+Commands:
+  enroll -course CODE [-section CODE] [-voucher CODE] -file STUDENTS.csv
+      Enroll the students listed in a CSV file with the columns name,
+      birthdate and email. Use "-file -" to read from standard input.
+  unenroll -course CODE [-file STUDENTS.csv] [EMAIL...]
+      Withdraw students, identified by email address, from a course. The CSV
+      file needs only an email column.
+  roster -course CODE
+      Print the students enrolled in, pending approval for and awaiting
+      payment for a course.
+  courses list
+      Print every course and the number of students on its roster.
+  keys create -name NAME
+      Issue an API key for a REST client. The key is printed once and can't
+      be recovered.
+  keys list
+      Print every API key, including those that were revoked.
+  keys revoke ID
+      Revoke an API key, so that it no longer authenticates requests.
+
+Flags:
+`
+
+func main() {
+	logger := log.New(os.Stderr, "hexctl ", log.LstdFlags)
+
+	if err := run(logger); err != nil {
+		fmt.Fprintf(os.Stderr, "hexctl: %s\n", err)
+
+		var usageErr usageError
+		if errors.As(err, &usageErr) {
+			os.Exit(2)
+		}
+
+		os.Exit(1)
+	}
+}
+
+func run(logger *log.Logger) error {
+	format := flag.String("format", string(formatTable), "The output format: table, json or csv")
+
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+
+	flag.Parse()
+
+	outputFormat, err := parseOutputFormat(*format)
+	if err != nil {
+		return err
+	}
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+
+		return usageError{message: "no command given"}
+	}
+
+	envConfig, err := envconfig.New()
+	if err != nil {
+		return fmt.Errorf("create envconfig: %w", err)
+	}
+
+	db, err := database.New(envConfig.DB)
+	if err != nil {
+		return fmt.Errorf("create database: %w", err)
+	}
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			logger.Printf("Failed to close database: %v", err)
+		}
+	}()
+
+	validate := validator.New()
+
+	classService, err := bootstrap.NewClassService(logger, envConfig, validate, db)
+	if err != nil {
+		return fmt.Errorf("create class service: %w", err)
+	}
+
+	authService, err := bootstrap.NewAuthService(logger, envConfig, validate, db)
+	if err != nil {
+		return fmt.Errorf("create auth service: %w", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	a := app{
+		classService: classService,
+		authService:  authService,
+		stdin:        os.Stdin,
+		stdout:       os.Stdout,
+		format:       outputFormat,
+	}
+
+	return a.run(ctx, flag.Args())
+}
+
+// usageError is returned when hexctl is invoked incorrectly.
+type usageError struct {
+	message string
+}
+
+func (ue usageError) Error() string {
+	return ue.message
+}
+
+// newFlagSet returns a FlagSet for a subcommand that reports errors instead of
+// exiting, so that they're handled like any other failure.
+func newFlagSet(name string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+
+	return flags
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/angusgmorrison/hexagonal/pkg/csvformula"
+)
+
+// outputFormat selects how tables are written.
+type outputFormat string
+
+const (
+	formatTable outputFormat = "table"
+	formatJSON  outputFormat = "json"
+	formatCSV   outputFormat = "csv"
+)
+
+func parseOutputFormat(format string) (outputFormat, error) {
+	switch f := outputFormat(format); f {
+	case formatTable, formatJSON, formatCSV:
+		return f, nil
+	default:
+		return "", usageError{message: fmt.Sprintf("unknown output format %q", format)}
+	}
+}
+
+// table is the output of a command, independent of its format.
+type table struct {
+	header []string
+	rows   [][]string
+}
+
+// writeTable writes t to w in the given format. JSON output is an array with
+// one object per row, keyed by column name. CSV cells are escaped by
+// csvformula.Escape, since names and emails are chosen by students.
+func writeTable(w io.Writer, format outputFormat, t table) error {
+	switch format {
+	case formatJSON:
+		objects := make([]map[string]string, 0, len(t.rows))
+
+		for _, row := range t.rows {
+			object := make(map[string]string, len(t.header))
+			for i, column := range t.header {
+				object[column] = row[i]
+			}
+
+			objects = append(objects, object)
+		}
+
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(objects)
+	case formatCSV:
+		cw := csv.NewWriter(w)
+
+		if err := cw.Write(csvformula.EscapeRecord(t.header)); err != nil {
+			return err
+		}
+
+		for _, row := range t.rows {
+			if err := cw.Write(csvformula.EscapeRecord(row)); err != nil {
+				return err
+			}
+		}
+
+		cw.Flush()
+
+		return cw.Error()
+	default:
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+		fmt.Fprintln(tw, strings.ToUpper(strings.Join(t.header, "\t")))
+
+		for _, row := range t.rows {
+			fmt.Fprintln(tw, strings.Join(row, "\t"))
+		}
+
+		return tw.Flush()
+	}
+}
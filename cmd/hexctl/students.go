@@ -0,0 +1,97 @@
+package main
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/angusgmorrison/hexagonal/internal/primitive"
+	"github.com/angusgmorrison/hexagonal/internal/service/classservice"
+)
+
+// Columns of a students CSV file.
+const (
+	columnName      = "name"
+	columnBirthdate = "birthdate"
+	columnEmail     = "email"
+)
+
+// readStudents reads students from CSV whose first record is a header naming
+// the columns. The columns may appear in any order, columns other than name,
+// birthdate and email are ignored, and each of the required columns must be
+// present. Birthdates are formatted as YYYY-MM-DD.
+func readStudents(r io.Reader, required ...string) (classservice.Students, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("students CSV is empty")
+		}
+
+		return nil, fmt.Errorf("read header: %w", err)
+	}
+
+	indices := make(map[string]int, len(header))
+	for i, column := range header {
+		indices[strings.ToLower(strings.TrimSpace(column))] = i
+	}
+
+	for _, column := range required {
+		if _, ok := indices[column]; !ok {
+			return nil, fmt.Errorf("students CSV has no %q column", column)
+		}
+	}
+
+	field := func(record []string, column string) string {
+		if i, ok := indices[column]; ok {
+			return strings.TrimSpace(record[i])
+		}
+
+		return ""
+	}
+
+	var students classservice.Students
+
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("read student: %w", err)
+		}
+
+		line, _ := cr.FieldPos(0)
+
+		for _, column := range required {
+			if field(record, column) == "" {
+				return nil, fmt.Errorf("line %d: %s is required", line, column)
+			}
+		}
+
+		student := classservice.Student{
+			Name:  field(record, columnName),
+			Email: primitive.EmailAddress(field(record, columnEmail)),
+		}
+
+		if rawBirthdate := field(record, columnBirthdate); rawBirthdate != "" {
+			student.Birthdate, err = primitive.ParseBirthdate(rawBirthdate)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: birthdate must be formatted as %s", line, primitive.BirthdateLayout)
+			}
+		}
+
+		students = append(students, student)
+	}
+
+	if len(students) == 0 {
+		return nil, errors.New("students CSV lists no students")
+	}
+
+	return students, nil
+}
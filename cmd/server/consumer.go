@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/angusgmorrison/hexagonal/internal/handler/queue"
+)
+
+// Delays before the enrollment command consumer is restarted. The delay
+// doubles with each consecutive failure, up to maxConsumerRestartDelay.
+const (
+	minConsumerRestartDelay = time.Second
+	maxConsumerRestartDelay = time.Minute
+)
+
+// consumeEnrollmentCommands runs the consumer until ctx is cancelled,
+// restarting it whenever it stops, so that a failed subscription doesn't stop
+// the server consuming commands for the rest of its life.
+func consumeEnrollmentCommands(ctx context.Context, logger *log.Logger, consumer *queue.Consumer) {
+	delay := minConsumerRestartDelay
+
+	for {
+		started := time.Now()
+
+		err := consumer.Run(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+
+		// A consumer that ran for a while before stopping isn't failing
+		// repeatedly, so it's restarted promptly.
+		if time.Since(started) > maxConsumerRestartDelay {
+			delay = minConsumerRestartDelay
+		}
+
+		logger.Printf("Enrollment command consumer stopped: %v. Restarting in %s", err, delay)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+
+		delay = min(2*delay, maxConsumerRestartDelay)
+	}
+}
@@ -1,15 +1,26 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"net"
 	"os"
 
+	"github.com/angusgmorrison/hexagonal/internal/bootstrap"
 	"github.com/angusgmorrison/hexagonal/internal/envconfig"
+	"github.com/angusgmorrison/hexagonal/internal/eventbus"
+	"github.com/angusgmorrison/hexagonal/internal/handler/graphql"
+	"github.com/angusgmorrison/hexagonal/internal/handler/grpc"
+	"github.com/angusgmorrison/hexagonal/internal/handler/queue"
 	"github.com/angusgmorrison/hexagonal/internal/handler/rest"
 	"github.com/angusgmorrison/hexagonal/internal/service/classservice"
-	"github.com/angusgmorrison/hexagonal/internal/storage/sql/classrepo"
+	"github.com/angusgmorrison/hexagonal/internal/service/importservice"
+	"github.com/angusgmorrison/hexagonal/internal/service/instructorservice"
+	"github.com/angusgmorrison/hexagonal/internal/storage/sql/commandrepo"
 	"github.com/angusgmorrison/hexagonal/internal/storage/sql/database"
+	"github.com/angusgmorrison/hexagonal/internal/storage/sql/importrepo"
+	"github.com/angusgmorrison/hexagonal/internal/storage/sql/instructorrepo"
 	"github.com/go-playground/validator/v10"
 )
 
@@ -40,12 +51,123 @@ func run(logger *log.Logger) error {
 		}
 	}()
 
+	validate := validator.New()
+
+	// Availability updates are carried in process from the class service to
+	// the clients streaming them.
+	availabilityBus := eventbus.New()
+
+	classService, err := bootstrap.NewClassService(
+		logger,
+		envConfig,
+		validate,
+		db,
+		classservice.WithAvailabilityPublisher(availabilityBus),
+	)
+	if err != nil {
+		return fmt.Errorf("create class service: %w", err)
+	}
+
 	var (
-		validate     = validator.New()
-		classRepo    = classrepo.NewAtomic(db)
-		classService = classservice.New(logger, validate, classRepo)
-		server       = rest.NewServer(logger, envConfig, classService)
+		instructorRepo    = instructorrepo.NewAtomic(db)
+		instructorService = instructorservice.New(logger, validate, instructorRepo)
+		importRepo        = importrepo.NewAtomic(db)
+		importService     = importservice.New(
+			logger,
+			validate,
+			importRepo,
+			classService,
+			importservice.WithBatchSize(envConfig.Imports.BatchSize),
+			importservice.WithStallTimeout(envConfig.Imports.StallTimeout),
+			importservice.WithMaxRows(envConfig.Imports.MaxRows),
+			importservice.WithMaxConcurrent(envConfig.Imports.MaxConcurrent),
+		)
+		graphQLHandler = graphql.NewHandler(logger, classService)
+		serverOpts     = []rest.Option{
+			rest.WithImports(importService),
+			rest.WithGraphQL(graphQLHandler),
+			rest.WithAvailabilityStream(availabilityBus),
+		}
 	)
 
+	var grpcOpts []grpc.Option
+
+	if envConfig.Auth.Required {
+		authService, err := bootstrap.NewAuthService(logger, envConfig, validate, db)
+		if err != nil {
+			return fmt.Errorf("create auth service: %w", err)
+		}
+
+		serverOpts = append(serverOpts, rest.WithAuthentication(authService))
+		grpcOpts = append(grpcOpts, grpc.WithAuthentication(authService))
+	}
+
+	server := rest.NewServer(logger, envConfig, classService, instructorService, serverOpts...)
+
+	// Imports still being processed when the server stops are given a grace
+	// period to finish, before the database is closed.
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), envConfig.Imports.ShutdownGracePeriod)
+		defer cancel()
+
+		if err := importService.Shutdown(ctx); err != nil {
+			logger.Printf("Imports were interrupted: %v", err)
+		}
+	}()
+
+	if timeout := envConfig.Imports.StallTimeout; timeout > 0 {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		go failStalledImports(ctx, logger, importService, timeout)
+	}
+
+	if interval := envConfig.Enrollment.ReservationSweepInterval; interval > 0 {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		go sweepExpiredReservations(ctx, logger, classService, interval)
+	}
+
+	broker, closeBroker, err := bootstrap.NewBroker(logger, envConfig)
+	if err != nil {
+		return fmt.Errorf("create queue broker: %w", err)
+	}
+
+	defer closeBroker()
+
+	if broker != nil {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		consumer := queue.NewConsumer(
+			logger,
+			classService,
+			broker,
+			commandrepo.NewResultStore(db),
+			envConfig.Queue.EnrollmentSubject,
+			queue.WithReplySubject(envConfig.Queue.ReplySubject),
+		)
+
+		go consumeEnrollmentCommands(ctx, logger, consumer)
+	}
+
+	if envConfig.GRPC.Port != 0 {
+		grpcServer := grpc.NewServer(logger, envConfig, classService, grpcOpts...)
+
+		lis, err := net.Listen("tcp", grpcServer.Address())
+		if err != nil {
+			return fmt.Errorf("listen for gRPC: %w", err)
+		}
+
+		go func() {
+			if err := grpcServer.Serve(lis); err != nil {
+				logger.Printf("gRPC server failed: %v", err)
+			}
+		}()
+
+		defer grpcServer.GracefulStop()
+	}
+
 	return server.Run()
 }
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/angusgmorrison/hexagonal/internal/service/classservice"
+	"github.com/angusgmorrison/hexagonal/internal/service/importservice"
+)
+
+// sweepExpiredReservations releases expired reservations every interval until
+// ctx is cancelled. Failures are logged and retried on the next tick.
+func sweepExpiredReservations(
+	ctx context.Context,
+	logger *log.Logger,
+	classService classservice.Interface,
+	interval time.Duration,
+) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			released, err := classService.ReleaseExpiredReservations(ctx)
+			if err != nil {
+				logger.Printf("Failed to release expired reservations: %v", err)
+
+				continue
+			}
+
+			if released > 0 {
+				logger.Printf("Released %d expired reservations", released)
+			}
+		}
+	}
+}
+
+// failStalledImports marks stalled imports as failed immediately and then
+// every interval until ctx is cancelled, so that imports interrupted by a
+// server stopping don't appear to run forever. Failures are logged and retried
+// on the next tick.
+func failStalledImports(
+	ctx context.Context,
+	logger *log.Logger,
+	importService importservice.Interface,
+	interval time.Duration,
+) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		failed, err := importService.FailStalledImports(ctx)
+		switch {
+		case err != nil:
+			logger.Printf("Failed to mark stalled imports as failed: %v", err)
+		case failed > 0:
+			logger.Printf("Marked %d stalled imports as failed", failed)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
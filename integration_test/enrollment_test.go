@@ -61,7 +61,7 @@ func TestEnrollment(t *testing.T) {
 			context.Background(),
 			infra.db,
 			[]enrollments.Row{
-				{CourseID: course.ID, StudentID: enrolledStudent.ID},
+				{CourseID: course.ID, StudentID: enrolledStudent.ID, Status: enrollments.StatusActive},
 			},
 		)
 		require.NoError(err, "insert enrollment")
@@ -88,7 +88,8 @@ func TestEnrollment(t *testing.T) {
 
 		assert.Len(resBodyBytes, 0, "unexpected response body")
 
-		gotStudents, err := students.OnCourse(context.Background(), infra.db, course.ID)
+		gotStudents, err := students.OnCourse(
+			context.Background(), infra.db, course.ID, enrollments.StatusActive)
 		require.NoError(err, "get students on course")
 
 		assert.Len(gotStudents, 2)
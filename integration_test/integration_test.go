@@ -16,8 +16,10 @@ import (
 	"github.com/angusgmorrison/hexagonal/internal/handler/rest"
 	server "github.com/angusgmorrison/hexagonal/internal/handler/rest"
 	"github.com/angusgmorrison/hexagonal/internal/service/classservice"
+	"github.com/angusgmorrison/hexagonal/internal/service/instructorservice"
 	"github.com/angusgmorrison/hexagonal/internal/storage/sql/classrepo"
 	"github.com/angusgmorrison/hexagonal/internal/storage/sql/database"
+	"github.com/angusgmorrison/hexagonal/internal/storage/sql/instructorrepo"
 	"github.com/go-playground/validator/v10"
 )
 
@@ -59,10 +61,11 @@ func NewServer(logger *log.Logger) (*server.Server, error) {
 	}
 
 	var (
-		atomicRepo = classrepo.NewAtomic(db)
-		validate   = validator.New()
-		service    = classservice.New(logger, validate, atomicRepo)
-		server     = rest.NewServer(logger, envConfig, service)
+		atomicRepo        = classrepo.NewAtomic(db)
+		validate          = validator.New()
+		service           = classservice.New(logger, validate, atomicRepo)
+		instructorService = instructorservice.New(logger, validate, instructorrepo.NewAtomic(db))
+		server            = rest.NewServer(logger, envConfig, service, instructorService)
 	)
 
 	return server, nil
@@ -104,7 +107,7 @@ func defaultAppRoot() string {
 }
 
 func enrollmentURL() string {
-	return serverURL() + "/enroll"
+	return serverURL() + "/v1/enroll"
 }
 
 func serverURL() string {
@@ -0,0 +1,63 @@
+// Package fakebilling provides an in-memory implementation of
+// classservice.Billing that issues invoices without charging anyone. It is
+// useful in development and tests, where no payment provider is available.
+// Payments are reported by calling the payment callback, as a real provider
+// would.
+package fakebilling
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+
+	"github.com/angusgmorrison/hexagonal/internal/service/classservice"
+)
+
+// invoiceIDBytes is the number of random bytes in an invoice ID. IDs must be
+// unique across every process that issues invoices, and across restarts, so
+// they can't be sequential.
+const invoiceIDBytes = 8
+
+// Provider satisfies classservice.Billing. It is safe for concurrent use.
+type Provider struct {
+	mu       sync.Mutex
+	invoices []classservice.Invoice
+}
+
+var _ classservice.Billing = (*Provider)(nil)
+
+// New returns a Provider with no invoices.
+func New() *Provider {
+	return &Provider{}
+}
+
+// Issue records the invoice, assigning it a random ID.
+func (p *Provider) Issue(_ context.Context, inv classservice.Invoice) (classservice.Invoice, error) {
+	id := make([]byte, invoiceIDBytes)
+	if _, err := rand.Read(id); err != nil {
+		return classservice.Invoice{}, fmt.Errorf("Issue: generate invoice ID: %w", err)
+	}
+
+	inv.ID = "inv_" + hex.EncodeToString(id)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.invoices = append(p.invoices, inv)
+
+	return inv, nil
+}
+
+// Invoices returns the invoices issued by the Provider, in the order they were
+// issued.
+func (p *Provider) Invoices() []classservice.Invoice {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	invoices := make([]classservice.Invoice, len(p.invoices))
+	copy(invoices, p.invoices)
+
+	return invoices
+}
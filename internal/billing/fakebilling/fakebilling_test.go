@@ -0,0 +1,45 @@
+//go:build unit
+
+package fakebilling
+
+import (
+	"context"
+	"testing"
+
+	"github.com/angusgmorrison/hexagonal/internal/service/classservice"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProvider(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("Issue assigns unique IDs", func(t *testing.T) {
+		t.Parallel()
+
+		p := New()
+
+		first, err := p.Issue(ctx, classservice.Invoice{CourseCode: "SICP"})
+		require.NoError(t, err)
+		second, err := p.Issue(ctx, classservice.Invoice{CourseCode: "LISP"})
+		require.NoError(t, err)
+
+		require.Regexp(t, `^inv_[0-9a-f]{16}$`, first.ID)
+		require.NotEqual(t, first.ID, second.ID)
+		require.Equal(t, []classservice.Invoice{first, second}, p.Invoices())
+	})
+
+	t.Run("IDs are unique across providers", func(t *testing.T) {
+		t.Parallel()
+
+		// Each process, and each restart, has its own Provider, but they share
+		// the invoices table.
+		first, err := New().Issue(ctx, classservice.Invoice{CourseCode: "SICP"})
+		require.NoError(t, err)
+		second, err := New().Issue(ctx, classservice.Invoice{CourseCode: "SICP"})
+		require.NoError(t, err)
+
+		require.NotEqual(t, first.ID, second.ID)
+	})
+}
@@ -0,0 +1,83 @@
+package bootstrap
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/angusgmorrison/hexagonal/internal/envconfig"
+	"github.com/angusgmorrison/hexagonal/internal/service/authservice"
+	"github.com/angusgmorrison/hexagonal/internal/storage/sql"
+	"github.com/angusgmorrison/hexagonal/internal/storage/sql/authrepo"
+	"github.com/go-playground/validator/v10"
+)
+
+// NewAuthService returns an auth service backed by db, which verifies tokens
+// using every key configured by envConfig: the HS256 secret, the RS256 public
+// key and the keys of the JWKS file. Keys configured directly apply to tokens
+// with any "kid" header.
+func NewAuthService(
+	logger *log.Logger,
+	envConfig envconfig.EnvConfig,
+	validate *validator.Validate,
+	db sql.Database,
+) (authservice.Interface, error) {
+	keys, err := newVerificationKeys(envConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []authservice.Option{authservice.WithVerificationKeys(keys...)}
+
+	if issuer := envConfig.Auth.JWTIssuer; issuer != "" {
+		opts = append(opts, authservice.WithIssuer(issuer))
+	}
+
+	if audience := envConfig.Auth.JWTAudience; audience != "" {
+		opts = append(opts, authservice.WithAudience(audience))
+	}
+
+	return authservice.New(logger, validate, authrepo.NewAtomic(db), opts...), nil
+}
+
+func newVerificationKeys(envConfig envconfig.EnvConfig) ([]authservice.VerificationKey, error) {
+	var (
+		cfg  = envConfig.Auth
+		keys []authservice.VerificationKey
+	)
+
+	if cfg.JWTHS256Secret != "" {
+		keys = append(keys, authservice.HS256Key("", []byte(cfg.JWTHS256Secret)))
+	}
+
+	if cfg.JWTRS256PublicKeyPath != "" {
+		pem, err := os.ReadFile(filepath.Join(envConfig.App.Root, cfg.JWTRS256PublicKeyPath))
+		if err != nil {
+			return nil, fmt.Errorf("read RS256 public key: %w", err)
+		}
+
+		key, err := authservice.RS256KeyFromPEM("", pem)
+		if err != nil {
+			return nil, fmt.Errorf("parse RS256 public key: %w", err)
+		}
+
+		keys = append(keys, key)
+	}
+
+	if cfg.JWTJWKSPath != "" {
+		data, err := os.ReadFile(filepath.Join(envConfig.App.Root, cfg.JWTJWKSPath))
+		if err != nil {
+			return nil, fmt.Errorf("read JWKS: %w", err)
+		}
+
+		jwks, err := authservice.ParseJWKS(data)
+		if err != nil {
+			return nil, fmt.Errorf("parse JWKS: %w", err)
+		}
+
+		keys = append(keys, jwks...)
+	}
+
+	return keys, nil
+}
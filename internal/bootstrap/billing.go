@@ -0,0 +1,28 @@
+package bootstrap
+
+import (
+	"fmt"
+
+	"github.com/angusgmorrison/hexagonal/internal/billing/fakebilling"
+	"github.com/angusgmorrison/hexagonal/internal/envconfig"
+)
+
+// newBilling returns the fake payment provider if it is selected by the
+// BILLING_PROVIDER environment variable, or nil if billing is disabled. A
+// provider can only report payments if BILLING_CALLBACK_TOKEN is set, so it's
+// required whenever billing is enabled.
+func newBilling(envConfig envconfig.EnvConfig) (*fakebilling.Provider, error) {
+	if envConfig.Billing.Provider != "none" && envConfig.Billing.CallbackToken == "" {
+		return nil, fmt.Errorf("BILLING_CALLBACK_TOKEN must be set to use billing provider %q",
+			envConfig.Billing.Provider)
+	}
+
+	switch envConfig.Billing.Provider {
+	case "none":
+		return nil, nil
+	case "fake":
+		return fakebilling.New(), nil
+	default:
+		return nil, fmt.Errorf("unknown billing provider %q", envConfig.Billing.Provider)
+	}
+}
@@ -0,0 +1,71 @@
+// Package bootstrap assembles the application's services from the environment
+// configuration, so that every command that uses them is wired the same way.
+package bootstrap
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+
+	"github.com/angusgmorrison/hexagonal/internal/envconfig"
+	"github.com/angusgmorrison/hexagonal/internal/service/classservice"
+	"github.com/angusgmorrison/hexagonal/internal/storage/file/rulefile"
+	"github.com/angusgmorrison/hexagonal/internal/storage/sql"
+	"github.com/angusgmorrison/hexagonal/internal/storage/sql/classrepo"
+	"github.com/go-playground/validator/v10"
+)
+
+// NewClassService returns a class service backed by db, with the notifier,
+// billing provider, grading scale and enrollment rules selected by envConfig.
+// Any extra options are applied after those derived from envConfig.
+func NewClassService(
+	logger *log.Logger,
+	envConfig envconfig.EnvConfig,
+	validate *validator.Validate,
+	db sql.Database,
+	extra ...classservice.Option,
+) (classservice.Interface, error) {
+	notifier, err := newNotifier(logger, envConfig)
+	if err != nil {
+		return nil, fmt.Errorf("create notifier: %w", err)
+	}
+
+	billing, err := newBilling(envConfig)
+	if err != nil {
+		return nil, fmt.Errorf("create billing: %w", err)
+	}
+
+	opts := []classservice.Option{
+		classservice.WithDefaultMaxCourseLoad(envConfig.Enrollment.DefaultMaxCourseLoad),
+		classservice.WithReservationTTL(envConfig.Enrollment.ReservationTTL),
+		classservice.WithNotifier(notifier),
+	}
+
+	if billing != nil {
+		opts = append(opts, classservice.WithBilling(billing))
+	}
+
+	if envConfig.Grading.Scale != "" {
+		scale, err := classservice.ParseGradingScale(envConfig.Grading.Scale, envConfig.Grading.PassingPoints)
+		if err != nil {
+			return nil, fmt.Errorf("create grading scale: %w", err)
+		}
+
+		opts = append(opts, classservice.WithGradingScale(scale))
+	}
+
+	if envConfig.Enrollment.RulesPath != "" {
+		rulesPath := filepath.Join(envConfig.App.Root, envConfig.Enrollment.RulesPath)
+
+		ruleSource, err := rulefile.New(rulesPath)
+		if err != nil {
+			return nil, fmt.Errorf("create enrollment rule source: %w", err)
+		}
+
+		opts = append(opts, classservice.WithRuleSource(ruleSource))
+	}
+
+	opts = append(opts, extra...)
+
+	return classservice.New(logger, validate, classrepo.NewAtomic(db), opts...), nil
+}
@@ -0,0 +1,46 @@
+package bootstrap
+
+import (
+	"fmt"
+	"log"
+	"net/mail"
+	"path/filepath"
+
+	"github.com/angusgmorrison/hexagonal/internal/envconfig"
+	"github.com/angusgmorrison/hexagonal/internal/notifier/email"
+	"github.com/angusgmorrison/hexagonal/internal/notifier/email/emlfile"
+	"github.com/angusgmorrison/hexagonal/internal/notifier/email/smtpmail"
+	"github.com/angusgmorrison/hexagonal/internal/notifier/lognotifier"
+	"github.com/angusgmorrison/hexagonal/internal/service/classservice"
+)
+
+// newNotifier returns the classservice.Notifier selected by the MAIL_TRANSPORT
+// environment variable.
+func newNotifier(logger *log.Logger, envConfig envconfig.EnvConfig) (classservice.Notifier, error) {
+	cfg := envConfig.Mail
+
+	var sender email.Sender
+
+	switch cfg.Transport {
+	case "log":
+		return lognotifier.New(logger), nil
+	case "smtp":
+		sender = smtpmail.New(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword)
+	case "file":
+		fileSender, err := emlfile.New(filepath.Join(envConfig.App.Root, cfg.Dir))
+		if err != nil {
+			return nil, err
+		}
+
+		sender = fileSender
+	default:
+		return nil, fmt.Errorf("unknown mail transport %q", cfg.Transport)
+	}
+
+	from, err := mail.ParseAddress(cfg.From)
+	if err != nil {
+		return nil, fmt.Errorf("parse MAIL_FROM: %w", err)
+	}
+
+	return email.New(*from, sender)
+}
@@ -0,0 +1,44 @@
+package bootstrap
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+
+	"github.com/angusgmorrison/hexagonal/internal/envconfig"
+	"github.com/angusgmorrison/hexagonal/internal/handler/queue"
+	"github.com/nats-io/nats.go"
+)
+
+// NewBroker returns the queue.Broker selected by the QUEUE_DRIVER environment
+// variable, and a function that releases its connection. The broker is nil if
+// the driver is "none".
+func NewBroker(logger *log.Logger, envConfig envconfig.EnvConfig) (queue.Broker, func(), error) {
+	cfg := envConfig.Queue
+
+	switch cfg.Driver {
+	case "none":
+		return nil, func() {}, nil
+	case "file":
+		broker, err := queue.NewFileBroker(
+			logger,
+			filepath.Join(envConfig.App.Root, cfg.Dir),
+			cfg.PollInterval,
+			cfg.MaxAttempts,
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return broker, func() {}, nil
+	case "nats":
+		conn, err := nats.Connect(cfg.URL, nats.Name(envConfig.App.Name))
+		if err != nil {
+			return nil, nil, fmt.Errorf("connect to NATS: %w", err)
+		}
+
+		return queue.NewNATSBroker(logger, conn, cfg.Group), conn.Close, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown queue driver %q", cfg.Driver)
+	}
+}
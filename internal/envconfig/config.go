@@ -25,9 +25,18 @@ func New() (EnvConfig, error) {
 
 // EnvConfig represents the environment variables of the running application.
 type EnvConfig struct {
-	App  App
-	HTTP HTTP
-	DB   DB
+	App        App
+	HTTP       HTTP
+	GRPC       GRPC
+	DB         DB
+	Enrollment Enrollment
+	Grading    Grading
+	Mail       Mail
+	Billing    Billing
+	Imports    Imports
+	Calendar   Calendar
+	Queue      Queue
+	Auth       Auth
 }
 
 // App represents environment variables related to the identity and general
@@ -47,6 +56,21 @@ type HTTP struct {
 	ReadTimeout         time.Duration `envconfig:"SERVER_READ_TIMEOUT" default:"5s"`
 	WriteTimeout        time.Duration `envconfig:"SERVER_WRITE_TIMEOUT" default:"5s"`
 	ShutdownGracePeriod time.Duration `envconfig:"SERVER_SHUTDOWN_GRACE_PERIOD" default:"0s"`
+
+	// LegacyRoutesSunset is the time, in RFC 3339 format, after which the
+	// unversioned REST routes will be removed. It's advertised in the Sunset
+	// header of their responses.
+	LegacyRoutesSunset time.Time `envconfig:"SERVER_LEGACY_ROUTES_SUNSET" default:"2027-04-18T00:00:00Z"`
+}
+
+// GRPC represents environment variables that configure the gRPC server, which
+// runs alongside the HTTP server.
+type GRPC struct {
+	Host string `envconfig:"GRPC_HOST" default:""`
+
+	// Port is the port on which the gRPC server listens. Zero disables the
+	// gRPC server.
+	Port int `envconfig:"GRPC_PORT" default:"50051"`
 }
 
 // DB represents all DB-related environment variables.
@@ -64,6 +88,169 @@ type DB struct {
 	SSLMode         string        `envconfig:"DB_SSL_MODE" default:"require"`
 }
 
+// Enrollment represents environment variables that configure enrollment
+// policy.
+type Enrollment struct {
+	// DefaultMaxCourseLoad is the number of courses a student may be enrolled
+	// in at once, unless overridden for that student. Zero means unlimited.
+	DefaultMaxCourseLoad uint32 `envconfig:"ENROLLMENT_DEFAULT_MAX_COURSE_LOAD" default:"0"`
+
+	// RulesPath is the location of a JSON file mapping course codes to
+	// declarative enrollment rules, relative to the application root. If
+	// empty, no rules apply.
+	RulesPath string `envconfig:"ENROLLMENT_RULES_PATH" default:""`
+
+	// ReservationTTL is the length of time for which a reservation holds a
+	// place in a course.
+	ReservationTTL time.Duration `envconfig:"ENROLLMENT_RESERVATION_TTL" default:"10m"`
+
+	// ReservationSweepInterval is how often expired reservations are
+	// released. Zero disables the sweeper.
+	ReservationSweepInterval time.Duration `envconfig:"ENROLLMENT_RESERVATION_SWEEP_INTERVAL" default:"1m"`
+}
+
+// Grading represents environment variables that configure the grades awarded
+// to students who complete a course.
+type Grading struct {
+	// Scale is a comma-separated list of LETTER=VALUE pairs defining the grades
+	// that may be awarded, where VALUE is a number of points, "pass" or
+	// "fail". If empty, the default four-point letter grade scale applies.
+	Scale string `envconfig:"GRADING_SCALE" default:""`
+
+	// PassingPoints is the minimum number of points a grade on Scale must be
+	// worth to pass the course.
+	PassingPoints float64 `envconfig:"GRADING_PASSING_POINTS" default:"0.7"`
+}
+
+// Mail represents environment variables that configure the delivery of
+// notifications to students.
+type Mail struct {
+	// Transport selects how notifications are delivered: "log" writes them to
+	// the application log, "smtp" sends them through the SMTP server, and
+	// "file" writes them as .eml files to Dir.
+	Transport string `envconfig:"MAIL_TRANSPORT" default:"log"`
+
+	// From is the address from which notifications are sent.
+	From string `envconfig:"MAIL_FROM" default:"Registrar <registrar@hexagonal.test>"`
+
+	// Dir is the directory to which the "file" transport writes messages,
+	// relative to the application root.
+	Dir string `envconfig:"MAIL_DIR" default:"tmp/mail"`
+
+	SMTPHost     string `envconfig:"SMTP_HOST" default:"localhost"`
+	SMTPPort     int    `envconfig:"SMTP_PORT" default:"587"`
+	SMTPUsername string `envconfig:"SMTP_USERNAME" default:""`
+	SMTPPassword string `envconfig:"SMTP_PASSWORD" default:""`
+}
+
+// Billing represents environment variables that configure how students are
+// charged for courses with a fee.
+type Billing struct {
+	// Provider selects the payment provider that issues invoices: "none"
+	// refuses enrollment in courses with a fee, and "fake" issues invoices in
+	// memory without charging anyone.
+	Provider string `envconfig:"BILLING_PROVIDER" default:"none"`
+
+	// CallbackToken is the secret the payment provider must present in the
+	// X-Callback-Token header when reporting a payment. It's required unless
+	// Provider is "none".
+	CallbackToken string `envconfig:"BILLING_CALLBACK_TOKEN" default:""`
+}
+
+// Imports represents environment variables that configure bulk enrollment
+// imports.
+type Imports struct {
+	// BatchSize is the number of rows enrolled between updates to an import's
+	// progress.
+	BatchSize int `envconfig:"IMPORT_BATCH_SIZE" default:"100"`
+
+	// StallTimeout is the time after which an unfinished import whose progress
+	// hasn't been saved is presumed interrupted and marked failed. Stalled
+	// imports are checked for at startup and every StallTimeout thereafter.
+	// Zero disables the check.
+	StallTimeout time.Duration `envconfig:"IMPORT_STALL_TIMEOUT" default:"10m"`
+
+	// ShutdownGracePeriod is the time that imports still running when the
+	// server stops are given to finish.
+	ShutdownGracePeriod time.Duration `envconfig:"IMPORT_SHUTDOWN_GRACE_PERIOD" default:"30s"`
+
+	// MaxRows is the largest number of rows an import may list.
+	MaxRows int `envconfig:"IMPORT_MAX_ROWS" default:"10000"`
+
+	// MaxConcurrent is the number of imports that may be processed at once.
+	// Imports started while that many are running are refused.
+	MaxConcurrent int `envconfig:"IMPORT_MAX_CONCURRENT" default:"4"`
+}
+
+// Calendar represents environment variables that configure the calendar feeds
+// of students' class schedules.
+type Calendar struct {
+	// SessionDuration is the length of each class session. Zero omits end
+	// times from calendar events.
+	SessionDuration time.Duration `envconfig:"CALENDAR_SESSION_DURATION" default:"1h"`
+}
+
+// Queue represents environment variables that configure the consumption of
+// enrollment commands from a message queue.
+type Queue struct {
+	// Driver selects the message queue: "none" consumes no commands, "file"
+	// consumes commands appended to files in Dir, and "nats" consumes commands
+	// from the NATS server at URL.
+	Driver string `envconfig:"QUEUE_DRIVER" default:"none"`
+
+	// Dir is the directory in which the "file" driver stores messages,
+	// relative to the application root.
+	Dir string `envconfig:"QUEUE_DIR" default:"tmp/queue"`
+
+	// PollInterval is how often the "file" driver checks for new messages.
+	PollInterval time.Duration `envconfig:"QUEUE_POLL_INTERVAL" default:"1s"`
+
+	// MaxAttempts is the number of times the "file" driver delivers a message
+	// whose handler fails before moving it to the subject's dead-letter file.
+	MaxAttempts int `envconfig:"QUEUE_MAX_ATTEMPTS" default:"5"`
+
+	// URL is the address of the NATS server.
+	URL string `envconfig:"QUEUE_URL" default:"nats://localhost:4222"`
+
+	// Group is the NATS queue group joined by the consumer, so that each
+	// command is handled by only one server process.
+	Group string `envconfig:"QUEUE_GROUP" default:"hexagonal"`
+
+	// EnrollmentSubject is the subject from which enrollment commands are
+	// consumed.
+	EnrollmentSubject string `envconfig:"QUEUE_ENROLLMENT_SUBJECT" default:"enrollments.commands"`
+
+	// ReplySubject is the subject to which results are published for commands
+	// that don't name their own.
+	ReplySubject string `envconfig:"QUEUE_REPLY_SUBJECT" default:"enrollments.results"`
+}
+
+// Auth represents environment variables that configure the authentication of
+// REST and gRPC clients, which present either a JWT bearer token or an API key.
+type Auth struct {
+	// Required enables authentication of REST and gRPC clients. If false,
+	// every route and RPC is open to anyone who can reach the server.
+	Required bool `envconfig:"AUTH_REQUIRED" default:"true"`
+
+	// JWTHS256Secret is the shared secret that verifies tokens signed using
+	// HS256. If empty, no shared secret is accepted.
+	JWTHS256Secret string `envconfig:"AUTH_JWT_HS256_SECRET" default:""`
+
+	// JWTRS256PublicKeyPath is the location of a PEM-encoded RSA public key
+	// that verifies tokens signed using RS256, relative to the application
+	// root.
+	JWTRS256PublicKeyPath string `envconfig:"AUTH_JWT_RS256_PUBLIC_KEY_PATH" default:""`
+
+	// JWTJWKSPath is the location of a JSON Web Key Set whose keys verify
+	// tokens, relative to the application root.
+	JWTJWKSPath string `envconfig:"AUTH_JWT_JWKS_PATH" default:""`
+
+	// JWTIssuer and JWTAudience, if set, must match the "iss" and "aud"
+	// claims of tokens.
+	JWTIssuer   string `envconfig:"AUTH_JWT_ISSUER" default:""`
+	JWTAudience string `envconfig:"AUTH_JWT_AUDIENCE" default:""`
+}
+
 // URL returns the URL of the database.
 func (db DB) URL() string {
 	return fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=%s&timezone=UTC",
@@ -0,0 +1,81 @@
+// Package eventbus provides an in-process publish-subscribe bus that carries
+// the class service's availability updates to the handlers that stream them to
+// clients. Updates aren't persisted or shared between processes.
+package eventbus
+
+import (
+	"context"
+	"sync"
+
+	"github.com/angusgmorrison/hexagonal/internal/service/classservice"
+)
+
+// Bus fans availability updates out to the subscribers of each course. It
+// satisfies classservice.AvailabilityPublisher.
+//
+// Subscribers only care about the latest availability of a course, so each
+// subscription buffers a single update. If a subscriber falls behind, the
+// update it hasn't received is replaced by the newer one, and publishing never
+// blocks.
+type Bus struct {
+	mu            sync.Mutex
+	subscriptions map[string]map[chan classservice.Availability]struct{}
+}
+
+var _ classservice.AvailabilityPublisher = (*Bus)(nil)
+
+// New returns a Bus with no subscribers.
+func New() *Bus {
+	return &Bus{subscriptions: make(map[string]map[chan classservice.Availability]struct{})}
+}
+
+// PublishAvailability sends a to the subscribers of its course.
+func (b *Bus) PublishAvailability(_ context.Context, a classservice.Availability) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.subscriptions[a.CourseCode] {
+		// Discard any update the subscriber hasn't yet received.
+		select {
+		case <-ch:
+		default:
+		}
+
+		ch <- a
+	}
+}
+
+// SubscribeAvailability returns a channel that receives updates to the
+// availability of the course with the given code, and a function that ends the
+// subscription and closes the channel.
+func (b *Bus) SubscribeAvailability(courseCode string) (<-chan classservice.Availability, func()) {
+	ch := make(chan classservice.Availability, 1)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.subscriptions[courseCode] == nil {
+		b.subscriptions[courseCode] = make(map[chan classservice.Availability]struct{})
+	}
+
+	b.subscriptions[courseCode][ch] = struct{}{}
+
+	var once sync.Once
+
+	unsubscribe := func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+
+			delete(b.subscriptions[courseCode], ch)
+
+			if len(b.subscriptions[courseCode]) == 0 {
+				delete(b.subscriptions, courseCode)
+			}
+
+			close(ch)
+		})
+	}
+
+	return ch, unsubscribe
+}
@@ -0,0 +1,64 @@
+//go:build unit
+
+package eventbus
+
+import (
+	"context"
+	"testing"
+
+	"github.com/angusgmorrison/hexagonal/internal/service/classservice"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBus(t *testing.T) {
+	t.Parallel()
+
+	t.Run("delivers updates to the subscribers of their course", func(t *testing.T) {
+		t.Parallel()
+
+		bus := New()
+
+		sicp, unsubscribeSICP := bus.SubscribeAvailability("SICP")
+		defer unsubscribeSICP()
+
+		taocp, unsubscribeTAOCP := bus.SubscribeAvailability("TAOCP")
+		defer unsubscribeTAOCP()
+
+		update := classservice.Availability{CourseCode: "SICP", AvailableSpaces: 3}
+		bus.PublishAvailability(context.Background(), update)
+
+		require.Equal(t, update, <-sicp)
+		require.Empty(t, taocp, "update delivered to other course")
+	})
+
+	t.Run("replaces updates a subscriber hasn't received", func(t *testing.T) {
+		t.Parallel()
+
+		bus := New()
+
+		ch, unsubscribe := bus.SubscribeAvailability("SICP")
+		defer unsubscribe()
+
+		bus.PublishAvailability(context.Background(), classservice.Availability{CourseCode: "SICP", AvailableSpaces: 3})
+		bus.PublishAvailability(context.Background(), classservice.Availability{CourseCode: "SICP", AvailableSpaces: 2})
+
+		require.Equal(t, uint32(2), (<-ch).AvailableSpaces)
+		require.Empty(t, ch)
+	})
+
+	t.Run("closes the channel on unsubscribing", func(t *testing.T) {
+		t.Parallel()
+
+		bus := New()
+
+		ch, unsubscribe := bus.SubscribeAvailability("SICP")
+		unsubscribe()
+		unsubscribe()
+
+		_, ok := <-ch
+		require.False(t, ok, "channel open")
+
+		bus.PublishAvailability(context.Background(), classservice.Availability{CourseCode: "SICP"})
+		require.Empty(t, bus.subscriptions)
+	})
+}
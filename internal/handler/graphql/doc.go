@@ -0,0 +1,5 @@
+// Package graphql provides a handler that serves a schema-first GraphQL API
+// over the class service, letting clients fetch a course, its roster and each
+// student's other enrollments in a single request. The schema is defined in
+// schema.graphql.
+package graphql
@@ -0,0 +1,101 @@
+package graphql
+
+import (
+	"errors"
+
+	"github.com/angusgmorrison/hexagonal/internal/service/classservice"
+	"github.com/go-playground/validator/v10"
+)
+
+// Codes reported in the extensions of GraphQL errors, which let clients handle
+// classes of failure without parsing error messages.
+const (
+	codeBadUserInput       = "BAD_USER_INPUT"
+	codeNotFound           = "NOT_FOUND"
+	codeConflict           = "CONFLICT"
+	codeFailedPrecondition = "FAILED_PRECONDITION"
+	codeInternal           = "INTERNAL"
+)
+
+// resolverError is an error returned by a resolver, reported with its code in
+// the errors of a GraphQL response.
+type resolverError struct {
+	code    string
+	message string
+}
+
+func (e resolverError) Error() string {
+	return e.message
+}
+
+// Extensions satisfies the interface graph-gophers/graphql-go uses to attach
+// extensions to errors.
+func (e resolverError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": e.code}
+}
+
+// errorFromService converts an error returned by the class service to a
+// resolverError. Errors the service doesn't document are reported as INTERNAL
+// without revealing their details.
+func errorFromService(err error) error {
+	code := codeFromError(err)
+	if code == codeInternal {
+		return resolverError{code: code, message: "internal error"}
+	}
+
+	return resolverError{code: code, message: err.Error()}
+}
+
+// codeFromError returns the code that best describes err.
+func codeFromError(err error) string {
+	var (
+		// Malformed requests.
+		validationErrs    validator.ValidationErrors
+		invalidVoucherErr classservice.InvalidVoucherError
+
+		// Missing resources.
+		courseNotFoundErr  classservice.CourseNotFoundError
+		sectionNotFoundErr classservice.SectionNotFoundError
+		unregisteredErr    classservice.UnregisteredStudentsError
+		voucherNotFoundErr classservice.VoucherNotFoundError
+
+		// Conflicts with existing enrollments.
+		alreadyEnrolledErr classservice.AlreadyEnrolledError
+		alreadyReservedErr classservice.AlreadyReservedError
+		voucherRedeemedErr classservice.VoucherAlreadyRedeemedError
+
+		// Unmet preconditions.
+		oversubscribedErr       classservice.OversubscribedError
+		courseLoadErr           classservice.CourseLoadExceededError
+		voucherExhaustedErr     classservice.VoucherExhaustedError
+		ruleViolationErr        classservice.RuleViolationError
+		prerequisitesErr        classservice.PrerequisitesNotMetError
+		cancelledErr            classservice.CourseCancelledError
+		voucherNotApplicableErr classservice.VoucherNotApplicableError
+	)
+
+	switch {
+	case errors.As(err, &validationErrs),
+		errors.As(err, &invalidVoucherErr):
+		return codeBadUserInput
+	case errors.As(err, &courseNotFoundErr),
+		errors.As(err, &sectionNotFoundErr),
+		errors.As(err, &unregisteredErr),
+		errors.As(err, &voucherNotFoundErr):
+		return codeNotFound
+	case errors.As(err, &alreadyEnrolledErr),
+		errors.As(err, &alreadyReservedErr),
+		errors.As(err, &voucherRedeemedErr):
+		return codeConflict
+	case errors.As(err, &oversubscribedErr),
+		errors.As(err, &courseLoadErr),
+		errors.As(err, &voucherExhaustedErr),
+		errors.As(err, &ruleViolationErr),
+		errors.As(err, &prerequisitesErr),
+		errors.As(err, &cancelledErr),
+		errors.As(err, &voucherNotApplicableErr):
+		return codeFailedPrecondition
+	default:
+		return codeInternal
+	}
+}
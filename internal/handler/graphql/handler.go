@@ -0,0 +1,100 @@
+package graphql
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/angusgmorrison/hexagonal/internal/service/classservice"
+	graphqllib "github.com/graph-gophers/graphql-go"
+)
+
+//go:embed schema.graphql
+var _schema string
+
+// Limits on the cost of a request. The schema is cyclic, since courses list
+// students and students list the courses they're enrolled in, so without a
+// depth limit a small query could load the whole database.
+const (
+	// maxQueryDepth allows a course's roster to be listed with each student's
+	// enrollments and the code of each enrolled course, but no further.
+	maxQueryDepth = 5
+
+	// maxParallelism is the number of resolvers run concurrently per request.
+	maxParallelism = 10
+
+	// maxRequestBytes is the largest request body accepted.
+	maxRequestBytes = 64 << 10
+
+	// maxStudentEmails is the largest number of email addresses the students
+	// query accepts.
+	maxStudentEmails = 100
+)
+
+// Handler serves GraphQL requests over HTTP.
+type Handler struct {
+	logger *log.Logger
+	schema *graphqllib.Schema
+
+	// classService is the interface by which resolvers communicate requests to
+	// business logic.
+	classService classservice.Interface
+}
+
+// NewHandler returns a Handler that resolves queries and mutations using the
+// class service. It panics if the schema doesn't match the resolvers.
+func NewHandler(logger *log.Logger, classService classservice.Interface) *Handler {
+	root := &rootResolver{
+		logger:       logger,
+		classService: classService,
+	}
+
+	return &Handler{
+		logger: logger,
+		schema: graphqllib.MustParseSchema(
+			_schema,
+			root,
+			graphqllib.MaxDepth(maxQueryDepth),
+			graphqllib.MaxParallelism(maxParallelism),
+		),
+		classService: classService,
+	}
+}
+
+// request represents the body of a GraphQL request.
+type request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// ServeHTTP executes the GraphQL request in the body of r. Each request gets
+// its own loaders, so that the students and classes it refers to are fetched
+// in as few calls to the class service as possible, but results are never
+// shared between requests. Bodies larger than maxRequestBytes are refused.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req request
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBytes)).Decode(&req); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			http.Error(w, "GraphQL request too large", http.StatusRequestEntityTooLarge)
+
+			return
+		}
+
+		http.Error(w, "malformed GraphQL request", http.StatusBadRequest)
+
+		return
+	}
+
+	ctx := withLoaders(r.Context(), newLoaders(h.classService))
+	resp := h.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		h.logger.Printf("Failed to write GraphQL response: %s", err)
+	}
+}
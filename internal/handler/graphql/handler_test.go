@@ -0,0 +1,461 @@
+//go:build unit
+
+package graphql
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/angusgmorrison/hexagonal/internal/primitive"
+	"github.com/angusgmorrison/hexagonal/internal/service/classservice"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCourseQuery(t *testing.T) {
+	t.Parallel()
+
+	t.Run("resolves the roster and each student's enrollments in one batch", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			classService = classservice.NewMockInterface(t)
+			handler      = NewHandler(newTestLogger(t), classService)
+			alice        = testStudent(t, 1, "Alice", "alice@example.com")
+			bob          = testStudent(t, 2, "Bob", "bob@example.com")
+			carol        = testStudent(t, 3, "Carol", "carol@example.com")
+			sicp         = classservice.Class{
+				Course:          classservice.Course{Code: "SICP", Capacity: 30},
+				Students:        classservice.Students{alice, bob},
+				Pending:         classservice.Students{carol},
+				AwaitingPayment: classservice.Students{},
+			}
+			taocp = classservice.Class{
+				Course:   classservice.Course{Code: "TAOCP", Capacity: 10},
+				Students: classservice.Students{alice, bob},
+			}
+		)
+
+		classService.On("GetClass", mock.Anything, "SICP").Return(sicp, nil).Once()
+		classService.On("GetClass", mock.Anything, "TAOCP").Return(taocp, nil).Once()
+		classService.On(
+			"GetStudents",
+			mock.Anything,
+			[]primitive.EmailAddress{alice.Email, bob.Email, carol.Email},
+		).Return(classservice.Students{alice, bob, carol}, nil).Once()
+		classService.On(
+			"GetEnrollments",
+			mock.Anything,
+			classservice.Students{alice, bob, carol},
+		).Return(classservice.StudentEnrollments{
+			alice.ID: {
+				{CourseCode: "SICP", Status: classservice.EnrollmentActive},
+				{CourseCode: "TAOCP", SectionCode: "A", Status: classservice.EnrollmentActive},
+			},
+			bob.ID: {
+				{CourseCode: "SICP", Status: classservice.EnrollmentActive},
+				{CourseCode: "TAOCP", Status: classservice.EnrollmentAwaitingPayment},
+			},
+			carol.ID: {
+				{CourseCode: "SICP", Status: classservice.EnrollmentPending},
+			},
+		}, nil).Once()
+
+		got := execute(t, handler, `{
+			course(code: "SICP") {
+				code
+				capacity
+				fee { amount }
+				students {
+					email
+					enrollments { course { code capacity } sectionCode status }
+				}
+				pending {
+					name
+					birthdate
+					enrollments { status }
+				}
+			}
+		}`, nil)
+
+		require.JSONEq(t, `{
+			"data": {
+				"course": {
+					"code": "SICP",
+					"capacity": 30,
+					"fee": null,
+					"students": [
+						{
+							"email": "alice@example.com",
+							"enrollments": [
+								{"course": {"code": "SICP", "capacity": 30}, "sectionCode": null, "status": "ACTIVE"},
+								{"course": {"code": "TAOCP", "capacity": 10}, "sectionCode": "A", "status": "ACTIVE"}
+							]
+						},
+						{
+							"email": "bob@example.com",
+							"enrollments": [
+								{"course": {"code": "SICP", "capacity": 30}, "sectionCode": null, "status": "ACTIVE"},
+								{"course": {"code": "TAOCP", "capacity": 10}, "sectionCode": null, "status": "AWAITING_PAYMENT"}
+							]
+						}
+					],
+					"pending": [
+						{"name": "Carol", "birthdate": "1990-03-04", "enrollments": [{"status": "PENDING"}]}
+					]
+				}
+			}
+		}`, got)
+	})
+
+	t.Run("resolves null if the course doesn't exist", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			classService = classservice.NewMockInterface(t)
+			handler      = NewHandler(newTestLogger(t), classService)
+		)
+
+		classService.On("GetClass", mock.Anything, "SICP").
+			Return(classservice.Class{}, classservice.CourseNotFoundError{CourseCode: "SICP"})
+
+		got := execute(t, handler, `{ course(code: "SICP") { code } }`, nil)
+
+		require.JSONEq(t, `{"data": {"course": null}}`, got)
+	})
+
+	t.Run("hides the details of internal errors", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			classService = classservice.NewMockInterface(t)
+			handler      = NewHandler(newTestLogger(t), classService)
+		)
+
+		classService.On("GetClass", mock.Anything, "SICP").
+			Return(classservice.Class{}, assertionError{})
+
+		got := execute(t, handler, `{ course(code: "SICP") { code } }`, nil)
+
+		require.JSONEq(t, `{
+			"errors": [{
+				"message": "internal error",
+				"path": ["course"],
+				"extensions": {"code": "INTERNAL"}
+			}],
+			"data": {"course": null}
+		}`, got)
+	})
+}
+
+func TestStudentsQuery(t *testing.T) {
+	t.Parallel()
+
+	var (
+		classService = classservice.NewMockInterface(t)
+		handler      = NewHandler(newTestLogger(t), classService)
+		alice        = testStudent(t, 1, "Alice", "alice@example.com")
+		bob          = testStudent(t, 2, "Bob", "bob@example.com")
+	)
+
+	classService.On(
+		"GetStudents",
+		mock.Anything,
+		[]primitive.EmailAddress{alice.Email, bob.Email, "unregistered@example.com"},
+	).Return(classservice.Students{alice, bob}, nil).Once()
+	classService.On(
+		"GetEnrollments",
+		mock.Anything,
+		classservice.Students{alice, bob},
+	).Return(classservice.StudentEnrollments{
+		alice.ID: {{CourseCode: "SICP", Status: classservice.EnrollmentActive}},
+	}, nil).Once()
+
+	got := execute(t, handler, `query Students($emails: [String!]!) {
+		students(emails: $emails) {
+			email
+			enrollments { sectionCode status }
+		}
+	}`, map[string]interface{}{
+		"emails": []string{"bob@example.com", "unregistered@example.com", "alice@example.com"},
+	})
+
+	require.JSONEq(t, `{
+		"data": {
+			"students": [
+				{"email": "bob@example.com", "enrollments": []},
+				{"email": "alice@example.com", "enrollments": [{"sectionCode": null, "status": "ACTIVE"}]}
+			]
+		}
+	}`, got)
+}
+
+func TestStudentsQueryLimit(t *testing.T) {
+	t.Parallel()
+
+	var (
+		handler = NewHandler(newTestLogger(t), classservice.NewMockInterface(t))
+		emails  = make([]string, maxStudentEmails+1)
+	)
+
+	for i := range emails {
+		emails[i] = fmt.Sprintf("student%d@example.com", i)
+	}
+
+	got := execute(t, handler, `query Students($emails: [String!]!) {
+		students(emails: $emails) { email }
+	}`, map[string]interface{}{"emails": emails})
+
+	require.JSONEq(t, fmt.Sprintf(`{
+		"errors": [{
+			"message": "at most %d emails may be given, got %d",
+			"path": ["students"],
+			"extensions": {"code": "BAD_USER_INPUT"}
+		}],
+		"data": null
+	}`, maxStudentEmails, len(emails)), got)
+}
+
+func TestMoneyAmount(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		amount  int64
+		want    int32
+		wantErr bool
+	}{
+		{name: "in range", amount: 2500, want: 2500},
+		{name: "largest Int", amount: math.MaxInt32, want: math.MaxInt32},
+		{name: "too large", amount: math.MaxInt32 + 1, wantErr: true},
+		{name: "too small", amount: math.MinInt32 - 1, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := &moneyResolver{money: primitive.Money{Amount: tc.amount, Currency: "GBP"}}
+
+			got, err := r.Amount()
+			if tc.wantErr {
+				var resolverErr resolverError
+				require.ErrorAs(t, err, &resolverErr)
+				require.Equal(t, codeInternal, resolverErr.code)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestEnrollMutation(t *testing.T) {
+	t.Parallel()
+
+	const mutation = `mutation Enroll($input: EnrollInput!) {
+		enroll(input: $input) {
+			code
+			students { email }
+		}
+	}`
+
+	input := map[string]interface{}{
+		"input": map[string]interface{}{
+			"courseCode":  "SICP",
+			"voucherCode": "SPRING",
+			"students": []map[string]interface{}{
+				{"name": "Alice", "birthdate": "1990-03-04", "email": "alice@example.com"},
+			},
+		},
+	}
+
+	t.Run("enrolls the students and resolves the updated course", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			classService = classservice.NewMockInterface(t)
+			handler      = NewHandler(newTestLogger(t), classService)
+			alice        = testStudent(t, 0, "Alice", "alice@example.com")
+		)
+
+		classService.On("Enroll", mock.Anything, classservice.EnrollmentRequest{
+			CourseCode:  "SICP",
+			VoucherCode: "SPRING",
+			Students:    classservice.Students{alice},
+		}).Return(nil)
+		classService.On("GetClass", mock.Anything, "SICP").Return(classservice.Class{
+			Course:   classservice.Course{Code: "SICP"},
+			Students: classservice.Students{alice},
+		}, nil)
+
+		got := execute(t, handler, mutation, input)
+
+		require.JSONEq(t, `{
+			"data": {
+				"enroll": {"code": "SICP", "students": [{"email": "alice@example.com"}]}
+			}
+		}`, got)
+	})
+
+	t.Run("reports the class of failure", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			classService = classservice.NewMockInterface(t)
+			handler      = NewHandler(newTestLogger(t), classService)
+		)
+
+		classService.On("Enroll", mock.Anything, mock.AnythingOfType("classservice.EnrollmentRequest")).
+			Return(classservice.OversubscribedError{CourseCode: "SICP"})
+
+		var resp struct {
+			Errors []struct {
+				Extensions map[string]string `json:"extensions"`
+			} `json:"errors"`
+		}
+
+		require.NoError(t, json.Unmarshal([]byte(execute(t, handler, mutation, input)), &resp))
+		require.Len(t, resp.Errors, 1)
+		require.Equal(t, map[string]string{"code": codeFailedPrecondition}, resp.Errors[0].Extensions)
+	})
+}
+
+func TestServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	t.Run("responds 400 Bad Request to malformed requests", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			handler = NewHandler(newTestLogger(t), classservice.NewMockInterface(t))
+			r       = httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewBufferString("{"))
+			w       = httptest.NewRecorder()
+		)
+
+		handler.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("responds 413 Request Entity Too Large to oversized requests", func(t *testing.T) {
+		t.Parallel()
+
+		body, err := json.Marshal(request{Query: "{" + strings.Repeat(" ", maxRequestBytes) + "}"})
+		require.NoError(t, err)
+
+		var (
+			handler = NewHandler(newTestLogger(t), classservice.NewMockInterface(t))
+			r       = httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewReader(body))
+			w       = httptest.NewRecorder()
+		)
+
+		handler.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	})
+
+	t.Run("refuses queries nested too deeply", func(t *testing.T) {
+		t.Parallel()
+
+		const query = `{
+			course(code: "SICP") {
+				students {
+					enrollments {
+						course {
+							students { name }
+						}
+					}
+				}
+			}
+		}`
+
+		var (
+			// The mock fails the test if the query is resolved.
+			handler = NewHandler(newTestLogger(t), classservice.NewMockInterface(t))
+			resp    struct {
+				Errors []struct {
+					Message string `json:"message"`
+				} `json:"errors"`
+			}
+		)
+
+		require.NoError(t, json.Unmarshal([]byte(execute(t, handler, query, nil)), &resp))
+		require.NotEmpty(t, resp.Errors)
+		require.Contains(t, resp.Errors[0].Message, "exceeds max depth")
+	})
+}
+
+func TestCodeFromError(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		err  error
+		want string
+	}{
+		{err: classservice.InvalidVoucherError{Code: "SPRING"}, want: codeBadUserInput},
+		{err: classservice.CourseNotFoundError{CourseCode: "SICP"}, want: codeNotFound},
+		{err: classservice.UnregisteredStudentsError{}, want: codeNotFound},
+		{err: classservice.AlreadyEnrolledError{}, want: codeConflict},
+		{err: classservice.CourseCancelledError{CourseCode: "SICP"}, want: codeFailedPrecondition},
+		{err: classservice.PrerequisitesNotMetError{}, want: codeFailedPrecondition},
+		{err: assertionError{}, want: codeInternal},
+	}
+
+	for _, tc := range testCases {
+		require.Equal(t, tc.want, codeFromError(tc.err), "%T", tc.err)
+	}
+}
+
+// execute posts the query to the handler, returning the body of the response.
+func execute(t *testing.T, handler http.Handler, query string, variables map[string]interface{}) string {
+	t.Helper()
+
+	body, err := json.Marshal(request{Query: query, Variables: variables})
+	require.NoError(t, err)
+
+	var (
+		r = httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewReader(body))
+		w = httptest.NewRecorder()
+	)
+
+	handler.ServeHTTP(w, r)
+
+	require.Equal(t, http.StatusOK, w.Code, "unexpected status code")
+	require.Equal(t, "application/json", w.Header().Get("content-type"))
+
+	return w.Body.String()
+}
+
+func newTestLogger(t *testing.T) *log.Logger {
+	t.Helper()
+
+	return log.New(os.Stdout, t.Name()+" ", log.LstdFlags)
+}
+
+func testStudent(t *testing.T, id int64, name string, email primitive.EmailAddress) classservice.Student {
+	t.Helper()
+
+	birthdate, err := primitive.ParseBirthdate("1990-03-04")
+	require.NoError(t, err)
+
+	return classservice.Student{ID: id, Name: name, Birthdate: birthdate, Email: email}
+}
+
+type assertionError struct{}
+
+func (assertionError) Error() string {
+	return "assertion error"
+}
@@ -0,0 +1,167 @@
+package graphql
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/angusgmorrison/hexagonal/internal/primitive"
+	"github.com/angusgmorrison/hexagonal/internal/service/classservice"
+)
+
+type loadersKey struct{}
+
+// loaders fetch and cache the data needed to resolve a single GraphQL request.
+type loaders struct {
+	students *studentLoader
+	classes  *classLoader
+}
+
+func newLoaders(classService classservice.Interface) *loaders {
+	return &loaders{
+		students: newStudentLoader(classService),
+		classes:  newClassLoader(classService),
+	}
+}
+
+func withLoaders(ctx context.Context, l *loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+// loadersFrom returns the loaders of the request that ctx belongs to.
+func loadersFrom(ctx context.Context) *loaders {
+	l, ok := ctx.Value(loadersKey{}).(*loaders)
+	if !ok {
+		panic("graphql: context has no loaders")
+	}
+
+	return l
+}
+
+// studentRecord is a registered student and their current enrollments.
+type studentRecord struct {
+	student     classservice.Student
+	enrollments classservice.Enrollments
+}
+
+// studentLoader loads registered students and their enrollments by email
+// address, batching requests to avoid querying once per student.
+//
+// Resolvers queue the email addresses of every student they're about to
+// resolve. The first load then fetches all queued students with one call to
+// GetStudents and one to GetEnrollments, and later loads of the same students
+// are served from the cache.
+type studentLoader struct {
+	classService classservice.Interface
+
+	mu      sync.Mutex
+	queued  map[primitive.EmailAddress]bool
+	records map[primitive.EmailAddress]*studentRecord
+}
+
+func newStudentLoader(classService classservice.Interface) *studentLoader {
+	return &studentLoader{
+		classService: classService,
+		queued:       make(map[primitive.EmailAddress]bool),
+		records:      make(map[primitive.EmailAddress]*studentRecord),
+	}
+}
+
+// queue adds the email addresses to the next batch to be loaded, unless they
+// have been loaded already.
+func (l *studentLoader) queue(emails ...primitive.EmailAddress) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.queueLocked(emails)
+}
+
+func (l *studentLoader) queueLocked(emails []primitive.EmailAddress) {
+	for _, email := range emails {
+		if _, ok := l.records[email]; !ok {
+			l.queued[email] = true
+		}
+	}
+}
+
+// load returns the registered student with the given email address and their
+// enrollments, loading the student along with every queued student if they
+// haven't been loaded already. The record is nil if no such student is
+// registered.
+func (l *studentLoader) load(ctx context.Context, email primitive.EmailAddress) (*studentRecord, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if record, ok := l.records[email]; ok {
+		return record, nil
+	}
+
+	l.queueLocked([]primitive.EmailAddress{email})
+
+	emails := make([]primitive.EmailAddress, 0, len(l.queued))
+	for queued := range l.queued {
+		emails = append(emails, queued)
+	}
+
+	sort.Slice(emails, func(i, j int) bool { return emails[i] < emails[j] })
+
+	students, err := l.classService.GetStudents(ctx, emails)
+	if err != nil {
+		return nil, err
+	}
+
+	enrollments, err := l.classService.GetEnrollments(ctx, students)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, email := range emails {
+		l.records[email] = nil
+	}
+
+	for _, student := range students {
+		l.records[student.Email] = &studentRecord{
+			student:     student,
+			enrollments: enrollments[student.ID],
+		}
+	}
+
+	l.queued = make(map[primitive.EmailAddress]bool)
+
+	return l.records[email], nil
+}
+
+// classLoader loads classes by course code, fetching each class at most once
+// per request.
+type classLoader struct {
+	classService classservice.Interface
+
+	mu      sync.Mutex
+	classes map[string]classservice.Class
+}
+
+func newClassLoader(classService classservice.Interface) *classLoader {
+	return &classLoader{
+		classService: classService,
+		classes:      make(map[string]classservice.Class),
+	}
+}
+
+// load returns the class of the course with the given code.
+func (l *classLoader) load(ctx context.Context, courseCode string) (classservice.Class, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if class, ok := l.classes[courseCode]; ok {
+		return class, nil
+	}
+
+	class, err := l.classService.GetClass(ctx, courseCode)
+	if err != nil {
+		return classservice.Class{}, err
+	}
+
+	l.classes[courseCode] = class
+
+	return class, nil
+}
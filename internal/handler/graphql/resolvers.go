@@ -0,0 +1,362 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/angusgmorrison/hexagonal/internal/primitive"
+	"github.com/angusgmorrison/hexagonal/internal/service/classservice"
+	graphqllib "github.com/graph-gophers/graphql-go"
+)
+
+// rootResolver resolves the fields of the Query and Mutation types.
+type rootResolver struct {
+	logger       *log.Logger
+	classService classservice.Interface
+}
+
+// Course resolves the course with the given code, or null if no such course
+// exists.
+func (r *rootResolver) Course(ctx context.Context, args struct{ Code string }) (*courseResolver, error) {
+	class, err := loadersFrom(ctx).classes.load(ctx, args.Code)
+	if err != nil {
+		var notFoundErr classservice.CourseNotFoundError
+		if errors.As(err, &notFoundErr) {
+			return nil, nil
+		}
+
+		r.logger.Printf("Getting course failed: %s", err)
+
+		return nil, errorFromService(err)
+	}
+
+	return newCourseResolver(ctx, r.logger, class), nil
+}
+
+// Student resolves the registered student with the given email address, or
+// null if no such student exists.
+func (r *rootResolver) Student(ctx context.Context, args struct{ Email string }) (*studentResolver, error) {
+	record, err := loadersFrom(ctx).students.load(ctx, primitive.EmailAddress(args.Email))
+	if err != nil {
+		r.logger.Printf("Getting student failed: %s", err)
+
+		return nil, errorFromService(err)
+	}
+
+	if record == nil {
+		return nil, nil
+	}
+
+	return &studentResolver{logger: r.logger, student: record.student}, nil
+}
+
+// Students resolves the registered students with the given email addresses,
+// loading them all at once. At most maxStudentEmails addresses may be given.
+func (r *rootResolver) Students(ctx context.Context, args struct{ Emails []string }) ([]*studentResolver, error) {
+	if len(args.Emails) > maxStudentEmails {
+		return nil, resolverError{
+			code:    codeBadUserInput,
+			message: fmt.Sprintf("at most %d emails may be given, got %d", maxStudentEmails, len(args.Emails)),
+		}
+	}
+
+	emails := make([]primitive.EmailAddress, 0, len(args.Emails))
+	for _, email := range args.Emails {
+		emails = append(emails, primitive.EmailAddress(email))
+	}
+
+	studentLoader := loadersFrom(ctx).students
+	studentLoader.queue(emails...)
+
+	resolvers := make([]*studentResolver, 0, len(emails))
+
+	for _, email := range emails {
+		record, err := studentLoader.load(ctx, email)
+		if err != nil {
+			r.logger.Printf("Getting students failed: %s", err)
+
+			return nil, errorFromService(err)
+		}
+
+		if record != nil {
+			resolvers = append(resolvers, &studentResolver{logger: r.logger, student: record.student})
+		}
+	}
+
+	return resolvers, nil
+}
+
+// enrollInput represents the EnrollInput type.
+type enrollInput struct {
+	CourseCode  string
+	SectionCode *string
+	VoucherCode *string
+	Students    []studentInput
+}
+
+// studentInput represents the StudentInput type.
+type studentInput struct {
+	Name      string
+	Birthdate string
+	Email     string
+}
+
+// Enroll enrolls the students in the input in a course and resolves the
+// course's updated roster.
+func (r *rootResolver) Enroll(ctx context.Context, args struct{ Input enrollInput }) (*courseResolver, error) {
+	er := classservice.EnrollmentRequest{
+		CourseCode: args.Input.CourseCode,
+		Students:   make(classservice.Students, 0, len(args.Input.Students)),
+	}
+
+	if args.Input.SectionCode != nil {
+		er.SectionCode = *args.Input.SectionCode
+	}
+
+	if args.Input.VoucherCode != nil {
+		er.VoucherCode = *args.Input.VoucherCode
+	}
+
+	for _, student := range args.Input.Students {
+		birthdate, err := primitive.ParseBirthdate(student.Birthdate)
+		if err != nil {
+			return nil, resolverError{
+				code:    codeBadUserInput,
+				message: "student " + strconv.Quote(student.Email) + ": birthdate must be formatted as " + primitive.BirthdateLayout,
+			}
+		}
+
+		er.Students = append(er.Students, classservice.Student{
+			Name:      student.Name,
+			Birthdate: birthdate,
+			Email:     primitive.EmailAddress(student.Email),
+		})
+	}
+
+	if err := r.classService.Enroll(ctx, er); err != nil {
+		r.logger.Printf("Enrollment failed: %s", err)
+
+		return nil, errorFromService(err)
+	}
+
+	// The class loader may hold the roster from before the enrollment, so the
+	// updated class is fetched directly.
+	class, err := r.classService.GetClass(ctx, er.CourseCode)
+	if err != nil {
+		r.logger.Printf("Getting course failed: %s", err)
+
+		return nil, errorFromService(err)
+	}
+
+	return newCourseResolver(ctx, r.logger, class), nil
+}
+
+// courseResolver resolves the fields of the Course type.
+type courseResolver struct {
+	logger *log.Logger
+	class  classservice.Class
+}
+
+// newCourseResolver returns a resolver for the class, queuing everyone on its
+// roster to be loaded together should their enrollments be requested.
+func newCourseResolver(ctx context.Context, logger *log.Logger, class classservice.Class) *courseResolver {
+	studentLoader := loadersFrom(ctx).students
+	studentLoader.queue(class.Students.EmailAddresses()...)
+	studentLoader.queue(class.Pending.EmailAddresses()...)
+	studentLoader.queue(class.AwaitingPayment.EmailAddresses()...)
+
+	return &courseResolver{logger: logger, class: class}
+}
+
+func (r *courseResolver) Code() string {
+	return r.class.Code
+}
+
+func (r *courseResolver) Capacity() (int32, error) {
+	return toInt(int64(r.class.Capacity))
+}
+
+func (r *courseResolver) Cancelled() bool {
+	return r.class.Cancelled
+}
+
+func (r *courseResolver) Fee() *moneyResolver {
+	if r.class.Fee.IsZero() {
+		return nil
+	}
+
+	return &moneyResolver{money: r.class.Fee}
+}
+
+func (r *courseResolver) Sections() []*sectionResolver {
+	resolvers := make([]*sectionResolver, 0, len(r.class.Sections))
+	for _, section := range r.class.Sections {
+		resolvers = append(resolvers, &sectionResolver{section: section})
+	}
+
+	return resolvers
+}
+
+func (r *courseResolver) Instructors() []*instructorResolver {
+	resolvers := make([]*instructorResolver, 0, len(r.class.Instructors))
+	for _, instructor := range r.class.Instructors {
+		resolvers = append(resolvers, &instructorResolver{instructor: instructor})
+	}
+
+	return resolvers
+}
+
+func (r *courseResolver) Students() []*studentResolver {
+	return r.studentResolvers(r.class.Students)
+}
+
+func (r *courseResolver) Pending() []*studentResolver {
+	return r.studentResolvers(r.class.Pending)
+}
+
+func (r *courseResolver) AwaitingPayment() []*studentResolver {
+	return r.studentResolvers(r.class.AwaitingPayment)
+}
+
+func (r *courseResolver) studentResolvers(students classservice.Students) []*studentResolver {
+	resolvers := make([]*studentResolver, 0, len(students))
+	for _, student := range students {
+		resolvers = append(resolvers, &studentResolver{logger: r.logger, student: student})
+	}
+
+	return resolvers
+}
+
+// sectionResolver resolves the fields of the Section type.
+type sectionResolver struct {
+	section classservice.Section
+}
+
+func (r *sectionResolver) Code() string {
+	return r.section.Code
+}
+
+func (r *sectionResolver) Capacity() (int32, error) {
+	return toInt(int64(r.section.Capacity))
+}
+
+// moneyResolver resolves the fields of the Money type.
+type moneyResolver struct {
+	money primitive.Money
+}
+
+func (r *moneyResolver) Amount() (int32, error) {
+	return toInt(r.money.Amount)
+}
+
+func (r *moneyResolver) Currency() string {
+	return string(r.money.Currency)
+}
+
+// instructorResolver resolves the fields of the Instructor type.
+type instructorResolver struct {
+	instructor classservice.Instructor
+}
+
+func (r *instructorResolver) ID() graphqllib.ID {
+	return graphqllib.ID(strconv.FormatInt(r.instructor.ID, 10))
+}
+
+func (r *instructorResolver) Name() string {
+	return r.instructor.Name
+}
+
+func (r *instructorResolver) Email() string {
+	return string(r.instructor.Email)
+}
+
+// studentResolver resolves the fields of the Student type.
+type studentResolver struct {
+	logger  *log.Logger
+	student classservice.Student
+}
+
+func (r *studentResolver) Name() string {
+	return r.student.Name
+}
+
+func (r *studentResolver) Birthdate() string {
+	return time.Time(r.student.Birthdate).Format(primitive.BirthdateLayout)
+}
+
+func (r *studentResolver) Email() string {
+	return string(r.student.Email)
+}
+
+// Enrollments resolves the student's current enrollments, which are loaded
+// together with those of every other student queued by the request.
+func (r *studentResolver) Enrollments(ctx context.Context) ([]*enrollmentResolver, error) {
+	record, err := loadersFrom(ctx).students.load(ctx, r.student.Email)
+	if err != nil {
+		r.logger.Printf("Getting enrollments failed: %s", err)
+
+		return nil, errorFromService(err)
+	}
+
+	if record == nil {
+		return []*enrollmentResolver{}, nil
+	}
+
+	resolvers := make([]*enrollmentResolver, 0, len(record.enrollments))
+	for _, enrollment := range record.enrollments {
+		resolvers = append(resolvers, &enrollmentResolver{logger: r.logger, enrollment: enrollment})
+	}
+
+	return resolvers, nil
+}
+
+// enrollmentResolver resolves the fields of the Enrollment type.
+type enrollmentResolver struct {
+	logger     *log.Logger
+	enrollment classservice.Enrollment
+}
+
+func (r *enrollmentResolver) Course(ctx context.Context) (*courseResolver, error) {
+	class, err := loadersFrom(ctx).classes.load(ctx, r.enrollment.CourseCode)
+	if err != nil {
+		r.logger.Printf("Getting course failed: %s", err)
+
+		return nil, errorFromService(err)
+	}
+
+	return newCourseResolver(ctx, r.logger, class), nil
+}
+
+func (r *enrollmentResolver) SectionCode() *string {
+	if r.enrollment.SectionCode == "" {
+		return nil
+	}
+
+	return &r.enrollment.SectionCode
+}
+
+func (r *enrollmentResolver) Status() string {
+	switch r.enrollment.Status {
+	case classservice.EnrollmentPending:
+		return "PENDING"
+	case classservice.EnrollmentAwaitingPayment:
+		return "AWAITING_PAYMENT"
+	default:
+		return "ACTIVE"
+	}
+}
+
+// toInt converts n to a GraphQL Int, which is a signed 32-bit integer. Values
+// outside its range are reported as an error rather than wrapping around.
+func toInt(n int64) (int32, error) {
+	if n < math.MinInt32 || n > math.MaxInt32 {
+		return 0, resolverError{code: codeInternal, message: "value is out of the range of Int"}
+	}
+
+	return int32(n), nil
+}
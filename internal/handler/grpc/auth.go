@@ -0,0 +1,99 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/angusgmorrison/hexagonal/internal/service/authservice"
+	grpclib "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// apiKeyMetadataKey is the metadata key under which clients present API keys.
+// Tokens are presented under "authorization" using the Bearer scheme, as in
+// the REST API.
+const apiKeyMetadataKey = "x-api-key"
+
+// authenticateUnary requires unary RPCs to present a bearer token or an API
+// key, and puts the authservice.Principal they identify into the context
+// passed to the handler.
+func (s *Server) authenticateUnary(
+	ctx context.Context,
+	req any,
+	_ *grpclib.UnaryServerInfo,
+	handler grpclib.UnaryHandler,
+) (any, error) {
+	ctx, err := s.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+// authenticateStream is like authenticateUnary, for streaming RPCs.
+func (s *Server) authenticateStream(
+	srv any,
+	stream grpclib.ServerStream,
+	_ *grpclib.StreamServerInfo,
+	handler grpclib.StreamHandler,
+) error {
+	ctx, err := s.authenticate(stream.Context())
+	if err != nil {
+		return err
+	}
+
+	return handler(srv, &authenticatedStream{ServerStream: stream, ctx: ctx})
+}
+
+// authenticate returns a copy of ctx carrying the principal identified by the
+// RPC's metadata. RPCs without valid credentials fail with Unauthenticated.
+func (s *Server) authenticate(ctx context.Context) (context.Context, error) {
+	principal, err := s.authenticatePrincipal(ctx)
+	if err != nil {
+		s.logger.Printf("Authentication failed: %s", err)
+
+		var credentialsErr authservice.InvalidCredentialsError
+		if !errors.As(err, &credentialsErr) {
+			return nil, status.Error(codes.Internal, "internal error")
+		}
+
+		return nil, status.Error(codes.Unauthenticated, "invalid credentials")
+	}
+
+	return authservice.NewContext(ctx, principal), nil
+}
+
+func (s *Server) authenticatePrincipal(ctx context.Context) (authservice.Principal, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	if keys := md.Get(apiKeyMetadataKey); len(keys) > 0 && keys[0] != "" {
+		return s.authService.AuthenticateAPIKey(ctx, keys[0])
+	}
+
+	var authorization string
+	if values := md.Get("authorization"); len(values) > 0 {
+		authorization = values[0]
+	}
+
+	scheme, token, ok := strings.Cut(authorization, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return authservice.Principal{}, authservice.InvalidCredentialsError{Reason: "no credentials presented"}
+	}
+
+	return s.authService.AuthenticateToken(ctx, token)
+}
+
+// authenticatedStream replaces the context of a ServerStream with one carrying
+// the authenticated principal.
+type authenticatedStream struct {
+	grpclib.ServerStream
+	ctx context.Context
+}
+
+func (as *authenticatedStream) Context() context.Context {
+	return as.ctx
+}
@@ -0,0 +1,114 @@
+//go:build unit
+
+package grpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/angusgmorrison/hexagonal/internal/handler/grpc/classpb"
+	"github.com/angusgmorrison/hexagonal/internal/service/authservice"
+	"github.com/angusgmorrison/hexagonal/internal/service/classservice"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestAuthentication(t *testing.T) {
+	t.Parallel()
+
+	req := &classpb.GetClassRequest{CourseCode: "TAOCP"}
+
+	// carriesPrincipal matches contexts carrying want.
+	carriesPrincipal := func(want authservice.Principal) any {
+		return mock.MatchedBy(func(ctx context.Context) bool {
+			got, ok := authservice.FromContext(ctx)
+
+			return ok && got == want
+		})
+	}
+
+	t.Run("puts the principal identified by a bearer token into the context", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			classService = classservice.NewMockInterface(t)
+			authService  = authservice.NewMockInterface(t)
+			client       = newTestClient(t, classService, WithAuthentication(authService))
+			principal    = authservice.Principal{Kind: authservice.PrincipalKindToken, Subject: "angus"}
+			ctx          = metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer t0k3n")
+		)
+
+		authService.On("AuthenticateToken", mock.Anything, "t0k3n").Return(principal, nil)
+		classService.On("GetClass", carriesPrincipal(principal), "TAOCP").Return(classservice.Class{}, nil)
+
+		_, err := client.GetClass(ctx, req)
+		require.NoError(t, err)
+	})
+
+	t.Run("puts the principal identified by an API key into the context", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			classService = classservice.NewMockInterface(t)
+			authService  = authservice.NewMockInterface(t)
+			client       = newTestClient(t, classService, WithAuthentication(authService))
+			principal    = authservice.Principal{Kind: authservice.PrincipalKindAPIKey, Subject: "ci"}
+			ctx          = metadata.AppendToOutgoingContext(context.Background(), apiKeyMetadataKey, "hxk_k3y")
+		)
+
+		authService.On("AuthenticateAPIKey", mock.Anything, "hxk_k3y").Return(principal, nil)
+		classService.On("GetClass", carriesPrincipal(principal), "TAOCP").Return(classservice.Class{}, nil)
+
+		_, err := client.GetClass(ctx, req)
+		require.NoError(t, err)
+	})
+
+	t.Run("rejects RPCs without credentials", func(t *testing.T) {
+		t.Parallel()
+
+		client := newTestClient(t, classservice.NewMockInterface(t), WithAuthentication(authservice.NewMockInterface(t)))
+
+		_, err := client.GetClass(context.Background(), req)
+		require.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	testCases := []struct {
+		name     string
+		err      error
+		wantCode codes.Code
+	}{
+		{
+			name:     "invalid credentials",
+			err:      authservice.InvalidCredentialsError{Reason: "token is expired"},
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "unexpected error",
+			err:      errors.New("connection refused"),
+			wantCode: codes.Internal,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				authService = authservice.NewMockInterface(t)
+				client      = newTestClient(t, classservice.NewMockInterface(t), WithAuthentication(authService))
+				ctx         = metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer t0k3n")
+			)
+
+			authService.On("AuthenticateToken", mock.Anything, "t0k3n").Return(authservice.Principal{}, tc.err)
+
+			_, err := client.GetClass(ctx, req)
+			require.Equal(t, tc.wantCode, status.Code(err))
+		})
+	}
+}
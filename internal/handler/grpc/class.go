@@ -0,0 +1,234 @@
+package grpc
+
+import (
+	"context"
+	"log"
+	"math"
+	"time"
+
+	"github.com/angusgmorrison/hexagonal/internal/handler/grpc/classpb"
+	"github.com/angusgmorrison/hexagonal/internal/primitive"
+	"github.com/angusgmorrison/hexagonal/internal/service/classservice"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// classHandler implements classpb.ClassServiceServer.
+type classHandler struct {
+	classpb.UnimplementedClassServiceServer
+
+	logger       *log.Logger
+	classService classservice.Interface
+}
+
+var _ classpb.ClassServiceServer = (*classHandler)(nil)
+
+// Enroll enrolls the students in the request in a class.
+func (h *classHandler) Enroll(ctx context.Context, req *classpb.EnrollRequest) (*classpb.EnrollResponse, error) {
+	students, err := studentsFromProto(req.GetStudents())
+	if err != nil {
+		return nil, err
+	}
+
+	er := classservice.EnrollmentRequest{
+		CourseCode:  req.GetCourseCode(),
+		SectionCode: req.GetSectionCode(),
+		VoucherCode: req.GetVoucherCode(),
+		Students:    students,
+	}
+
+	if err := h.classService.Enroll(ctx, er); err != nil {
+		h.logger.Printf("Enrollment failed: %s", err)
+
+		return nil, statusFromError(err)
+	}
+
+	return &classpb.EnrollResponse{}, nil
+}
+
+// ApproveEnrollment approves the pending enrollment of a student in a class.
+func (h *classHandler) ApproveEnrollment(
+	ctx context.Context,
+	req *classpb.ApproveEnrollmentRequest,
+) (*classpb.ApproveEnrollmentResponse, error) {
+	email := primitive.EmailAddress(req.GetEmail())
+
+	if err := h.classService.ApproveEnrollment(ctx, req.GetCourseCode(), email); err != nil {
+		h.logger.Printf("Approval failed: %s", err)
+
+		return nil, statusFromError(err)
+	}
+
+	return &classpb.ApproveEnrollmentResponse{}, nil
+}
+
+// RejectEnrollment rejects the pending enrollment of a student in a class.
+func (h *classHandler) RejectEnrollment(
+	ctx context.Context,
+	req *classpb.RejectEnrollmentRequest,
+) (*classpb.RejectEnrollmentResponse, error) {
+	email := primitive.EmailAddress(req.GetEmail())
+
+	if err := h.classService.RejectEnrollment(ctx, req.GetCourseCode(), email); err != nil {
+		h.logger.Printf("Rejection failed: %s", err)
+
+		return nil, statusFromError(err)
+	}
+
+	return &classpb.RejectEnrollmentResponse{}, nil
+}
+
+// Transfer moves the students in the request from one class to another.
+func (h *classHandler) Transfer(ctx context.Context, req *classpb.TransferRequest) (*classpb.TransferResponse, error) {
+	students, err := studentsFromProto(req.GetStudents())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.classService.Transfer(ctx, req.GetFromCourseCode(), req.GetToCourseCode(), students); err != nil {
+		h.logger.Printf("Transfer failed: %s", err)
+
+		return nil, statusFromError(err)
+	}
+
+	return &classpb.TransferResponse{}, nil
+}
+
+// GetClass responds with the roster of a class.
+func (h *classHandler) GetClass(ctx context.Context, req *classpb.GetClassRequest) (*classpb.GetClassResponse, error) {
+	class, err := h.classService.GetClass(ctx, req.GetCourseCode())
+	if err != nil {
+		h.logger.Printf("Getting class failed: %s", err)
+
+		return nil, statusFromError(err)
+	}
+
+	return &classpb.GetClassResponse{Class: classToProto(class)}, nil
+}
+
+// GetTranscript responds with the transcript of a student.
+func (h *classHandler) GetTranscript(
+	ctx context.Context,
+	req *classpb.GetTranscriptRequest,
+) (*classpb.GetTranscriptResponse, error) {
+	transcript, err := h.classService.GetTranscript(ctx, primitive.EmailAddress(req.GetEmail()))
+	if err != nil {
+		h.logger.Printf("Getting transcript failed: %s", err)
+
+		return nil, statusFromError(err)
+	}
+
+	return &classpb.GetTranscriptResponse{Transcript: transcriptToProto(transcript)}, nil
+}
+
+// studentsFromProto converts the students in a request to their domain
+// representation, returning an InvalidArgument status if any birthdate is
+// malformed.
+func studentsFromProto(pbStudents []*classpb.Student) (classservice.Students, error) {
+	students := make(classservice.Students, 0, len(pbStudents))
+
+	for _, pbStudent := range pbStudents {
+		birthdate, err := primitive.ParseBirthdate(pbStudent.GetBirthdate())
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument,
+				"student %q: birthdate must be formatted as %s", pbStudent.GetEmail(), primitive.BirthdateLayout)
+		}
+
+		students = append(students, classservice.Student{
+			Name:      pbStudent.GetName(),
+			Birthdate: birthdate,
+			Email:     primitive.EmailAddress(pbStudent.GetEmail()),
+		})
+	}
+
+	return students, nil
+}
+
+func studentToProto(student classservice.Student) *classpb.Student {
+	return &classpb.Student{
+		Name:      student.Name,
+		Birthdate: time.Time(student.Birthdate).Format(primitive.BirthdateLayout),
+		Email:     string(student.Email),
+	}
+}
+
+func classToProto(class classservice.Class) *classpb.Class {
+	sectionCodes := make(map[int64]string)
+
+	for _, section := range class.Sections {
+		for _, students := range []classservice.Students{section.Students, section.Pending, section.AwaitingPayment} {
+			for _, student := range students {
+				sectionCodes[student.ID] = section.Code
+			}
+		}
+	}
+
+	rosterStudents := func(students classservice.Students) []*classpb.RosterStudent {
+		roster := make([]*classpb.RosterStudent, 0, len(students))
+
+		for _, student := range students {
+			rs := &classpb.RosterStudent{
+				Student:     studentToProto(student),
+				SectionCode: sectionCodes[student.ID],
+			}
+
+			if percentage, ok := class.Attendance[student.ID].Percentage(); ok {
+				rs.AttendancePercentage = proto.Float64(math.Round(percentage*10) / 10)
+			}
+
+			roster = append(roster, rs)
+		}
+
+		return roster
+	}
+
+	instructors := make([]*classpb.Instructor, 0, len(class.Instructors))
+
+	for _, instructor := range class.Instructors {
+		instructors = append(instructors, &classpb.Instructor{
+			Id:    instructor.ID,
+			Name:  instructor.Name,
+			Email: string(instructor.Email),
+		})
+	}
+
+	pbClass := &classpb.Class{
+		CourseCode:      class.Code,
+		Capacity:        class.Capacity,
+		Cancelled:       class.Cancelled,
+		Instructors:     instructors,
+		Students:        rosterStudents(class.Students),
+		Pending:         rosterStudents(class.Pending),
+		AwaitingPayment: rosterStudents(class.AwaitingPayment),
+	}
+
+	if !class.Fee.IsZero() {
+		pbClass.Fee = &classpb.Money{Amount: class.Fee.Amount, Currency: string(class.Fee.Currency)}
+	}
+
+	return pbClass
+}
+
+func transcriptToProto(transcript classservice.Transcript) *classpb.Transcript {
+	pbTranscript := &classpb.Transcript{
+		Student: studentToProto(transcript.Student),
+		Courses: make([]*classpb.TranscriptEntry, 0, len(transcript.Entries)),
+	}
+
+	if gpa, ok := transcript.GPA(); ok {
+		pbTranscript.Gpa = proto.Float64(math.Round(gpa*100) / 100)
+	}
+
+	for _, entry := range transcript.Entries {
+		pbTranscript.Courses = append(pbTranscript.Courses, &classpb.TranscriptEntry{
+			CourseCode:  entry.CourseCode,
+			Grade:       entry.Grade.Letter,
+			Passed:      entry.Grade.Passing,
+			CompletedAt: timestamppb.New(entry.CompletedAt),
+		})
+	}
+
+	return pbTranscript
+}
@@ -0,0 +1,351 @@
+//go:build unit
+
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/angusgmorrison/hexagonal/internal/envconfig"
+	"github.com/angusgmorrison/hexagonal/internal/handler/grpc/classpb"
+	"github.com/angusgmorrison/hexagonal/internal/primitive"
+	"github.com/angusgmorrison/hexagonal/internal/service/classservice"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	grpclib "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestEnroll(t *testing.T) {
+	t.Parallel()
+
+	req := &classpb.EnrollRequest{
+		CourseCode:  "SICP",
+		SectionCode: "A",
+		VoucherCode: "WELCOME10",
+		Students: []*classpb.Student{
+			{Name: "Ramdas Tifft", Birthdate: "1991-10-03", Email: "r.tifft@gmail.com"},
+		},
+	}
+
+	t.Run("enrolls the students", func(t *testing.T) {
+		t.Parallel()
+
+		classService := classservice.NewMockInterface(t)
+		client := newTestClient(t, classService)
+
+		classService.On(
+			"Enroll",
+			mock.Anything,
+			classservice.EnrollmentRequest{
+				CourseCode:  "SICP",
+				SectionCode: "A",
+				VoucherCode: "WELCOME10",
+				Students: classservice.Students{
+					{Name: "Ramdas Tifft", Birthdate: birthdate(t, "1991-10-03"), Email: "r.tifft@gmail.com"},
+				},
+			},
+		).Return(nil)
+
+		_, err := client.Enroll(context.Background(), req)
+		require.NoError(t, err)
+	})
+
+	t.Run("rejects malformed birthdates", func(t *testing.T) {
+		t.Parallel()
+
+		client := newTestClient(t, classservice.NewMockInterface(t))
+
+		badReq := proto.Clone(req).(*classpb.EnrollRequest)
+		badReq.Students[0].Birthdate = "03/10/1991"
+
+		_, err := client.Enroll(context.Background(), badReq)
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("maps domain errors to status codes", func(t *testing.T) {
+		t.Parallel()
+
+		classService := classservice.NewMockInterface(t)
+		client := newTestClient(t, classService)
+
+		classService.On("Enroll", mock.Anything, mock.AnythingOfType("classservice.EnrollmentRequest")).
+			Return(classservice.OversubscribedError{CourseCode: "SICP"})
+
+		_, err := client.Enroll(context.Background(), req)
+		require.Equal(t, codes.ResourceExhausted, status.Code(err))
+		require.Equal(t, classservice.OversubscribedError{CourseCode: "SICP"}.Error(), status.Convert(err).Message())
+	})
+}
+
+func TestApproveAndRejectEnrollment(t *testing.T) {
+	t.Parallel()
+
+	classService := classservice.NewMockInterface(t)
+	client := newTestClient(t, classService)
+
+	classService.On("ApproveEnrollment", mock.Anything, "SICP", primitive.EmailAddress("r.tifft@gmail.com")).
+		Return(nil)
+	classService.On("RejectEnrollment", mock.Anything, "SICP", primitive.EmailAddress("km1996@gmail.com")).
+		Return(classservice.EnrollmentNotPendingError{CourseCode: "SICP"})
+
+	_, err := client.ApproveEnrollment(context.Background(), &classpb.ApproveEnrollmentRequest{
+		CourseCode: "SICP",
+		Email:      "r.tifft@gmail.com",
+	})
+	require.NoError(t, err)
+
+	_, err = client.RejectEnrollment(context.Background(), &classpb.RejectEnrollmentRequest{
+		CourseCode: "SICP",
+		Email:      "km1996@gmail.com",
+	})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestGetClass(t *testing.T) {
+	t.Parallel()
+
+	t.Run("responds with the roster", func(t *testing.T) {
+		t.Parallel()
+
+		classService := classservice.NewMockInterface(t)
+		client := newTestClient(t, classService)
+
+		enrolled := classservice.Student{
+			ID:        1,
+			Name:      "Berthe Archibald",
+			Birthdate: birthdate(t, "1987-09-03"),
+			Email:     "berthe@archibaldindustries.com",
+		}
+		pending := classservice.Student{
+			ID:        2,
+			Name:      "Ramdas Tifft",
+			Birthdate: birthdate(t, "1991-10-03"),
+			Email:     "r.tifft@gmail.com",
+		}
+
+		classService.On("GetClass", mock.Anything, "TAOCP").Return(classservice.Class{
+			Course: classservice.Course{
+				Code:     "TAOCP",
+				Capacity: 6,
+				Fee:      primitive.Money{Amount: 12500, Currency: "GBP"},
+				Sections: classservice.Sections{
+					{Code: "A", Capacity: 6, Students: classservice.Students{enrolled}},
+				},
+			},
+			Students:    classservice.Students{enrolled},
+			Pending:     classservice.Students{pending},
+			Instructors: classservice.Instructors{{ID: 1, Name: "Donald Knuth", Email: "knuth@stanford.edu"}},
+			Attendance:  classservice.StudentAttendance{enrolled.ID: {Attended: 2, Recorded: 3}},
+		}, nil)
+
+		resp, err := client.GetClass(context.Background(), &classpb.GetClassRequest{CourseCode: "TAOCP"})
+		require.NoError(t, err)
+
+		want := &classpb.Class{
+			CourseCode:  "TAOCP",
+			Capacity:    6,
+			Fee:         &classpb.Money{Amount: 12500, Currency: "GBP"},
+			Instructors: []*classpb.Instructor{{Id: 1, Name: "Donald Knuth", Email: "knuth@stanford.edu"}},
+			Students: []*classpb.RosterStudent{
+				{
+					Student: &classpb.Student{
+						Name:      "Berthe Archibald",
+						Birthdate: "1987-09-03",
+						Email:     "berthe@archibaldindustries.com",
+					},
+					SectionCode:          "A",
+					AttendancePercentage: proto.Float64(66.7),
+				},
+			},
+			Pending: []*classpb.RosterStudent{
+				{Student: &classpb.Student{Name: "Ramdas Tifft", Birthdate: "1991-10-03", Email: "r.tifft@gmail.com"}},
+			},
+		}
+		require.Empty(t, cmpProto(want, resp.GetClass()))
+	})
+
+	t.Run("responds NotFound if the course doesn't exist", func(t *testing.T) {
+		t.Parallel()
+
+		classService := classservice.NewMockInterface(t)
+		client := newTestClient(t, classService)
+
+		classService.On("GetClass", mock.Anything, "TAOCP").
+			Return(classservice.Class{}, fmt.Errorf("GetClass: %w", classservice.CourseNotFoundError{CourseCode: "TAOCP"}))
+
+		_, err := client.GetClass(context.Background(), &classpb.GetClassRequest{CourseCode: "TAOCP"})
+		require.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
+
+func TestGetTranscript(t *testing.T) {
+	t.Parallel()
+
+	classService := classservice.NewMockInterface(t)
+	client := newTestClient(t, classService)
+
+	completedAt := time.Date(2022, time.December, 16, 12, 0, 0, 0, time.UTC)
+	student := classservice.Student{
+		ID:        1,
+		Name:      "Ramdas Tifft",
+		Birthdate: birthdate(t, "1991-10-03"),
+		Email:     "r.tifft@gmail.com",
+	}
+
+	classService.On("GetTranscript", mock.Anything, primitive.EmailAddress("r.tifft@gmail.com")).
+		Return(classservice.Transcript{
+			Student: student,
+			Entries: []classservice.TranscriptEntry{
+				{
+					CourseCode:  "SICP",
+					Grade:       classservice.Grade{Letter: "B", Points: 3, Passing: true},
+					CompletedAt: completedAt,
+				},
+				{
+					CourseCode:  "TAOCP",
+					Grade:       classservice.Grade{Letter: "P", PassFail: true, Passing: true},
+					CompletedAt: completedAt,
+				},
+			},
+		}, nil)
+
+	resp, err := client.GetTranscript(context.Background(), &classpb.GetTranscriptRequest{Email: "r.tifft@gmail.com"})
+	require.NoError(t, err)
+
+	want := &classpb.Transcript{
+		Student: &classpb.Student{Name: "Ramdas Tifft", Birthdate: "1991-10-03", Email: "r.tifft@gmail.com"},
+		Gpa:     proto.Float64(3),
+		Courses: []*classpb.TranscriptEntry{
+			{CourseCode: "SICP", Grade: "B", Passed: true, CompletedAt: timestamppb.New(completedAt)},
+			{CourseCode: "TAOCP", Grade: "P", Passed: true, CompletedAt: timestamppb.New(completedAt)},
+		},
+	}
+	require.Empty(t, cmpProto(want, resp.GetTranscript()))
+}
+
+func TestCodeFromError(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		err  error
+		want codes.Code
+	}{
+		{err: context.Canceled, want: codes.Canceled},
+		{err: context.DeadlineExceeded, want: codes.DeadlineExceeded},
+		{err: validator.ValidationErrors{}, want: codes.InvalidArgument},
+		{err: classservice.InvalidRuleError{}, want: codes.InvalidArgument},
+		{err: classservice.UnknownGradeError{}, want: codes.InvalidArgument},
+		{err: classservice.InvalidVoucherError{}, want: codes.InvalidArgument},
+		{err: classservice.EmptyScheduleError{}, want: codes.InvalidArgument},
+		{err: classservice.ScheduleTooLongError{}, want: codes.InvalidArgument},
+		{err: classservice.CourseNotFoundError{}, want: codes.NotFound},
+		{err: classservice.SectionNotFoundError{}, want: codes.NotFound},
+		{err: classservice.SessionNotFoundError{}, want: codes.NotFound},
+		{err: classservice.UnregisteredStudentsError{}, want: codes.NotFound},
+		{err: classservice.InstructorNotFoundError{}, want: codes.NotFound},
+		{err: classservice.InvoiceNotFoundError{}, want: codes.NotFound},
+		{err: classservice.VoucherNotFoundError{}, want: codes.NotFound},
+		{err: classservice.CertificateNotFoundError{}, want: codes.NotFound},
+		{err: classservice.CourseNotPassedError{}, want: codes.NotFound},
+		{err: classservice.AlreadyEnrolledError{}, want: codes.AlreadyExists},
+		{err: classservice.AlreadyReservedError{}, want: codes.AlreadyExists},
+		{err: classservice.AlreadyAssignedError{}, want: codes.AlreadyExists},
+		{err: classservice.VoucherCodeTakenError{}, want: codes.AlreadyExists},
+		{err: classservice.VoucherAlreadyRedeemedError{}, want: codes.AlreadyExists},
+		{err: classservice.OversubscribedError{}, want: codes.ResourceExhausted},
+		{err: classservice.CourseLoadExceededError{}, want: codes.ResourceExhausted},
+		{err: classservice.VoucherExhaustedError{}, want: codes.ResourceExhausted},
+		{err: classservice.RuleViolationError{}, want: codes.FailedPrecondition},
+		{err: classservice.PrerequisitesNotMetError{}, want: codes.FailedPrecondition},
+		{err: classservice.EnrollmentNotPendingError{}, want: codes.FailedPrecondition},
+		{err: classservice.NotEnrolledError{}, want: codes.FailedPrecondition},
+		{err: classservice.NotAssignedError{}, want: codes.FailedPrecondition},
+		{err: classservice.CourseCancelledError{}, want: codes.FailedPrecondition},
+		{err: classservice.NotAwaitingPaymentError{}, want: codes.FailedPrecondition},
+		{err: classservice.TransferRequiresApprovalError{}, want: codes.FailedPrecondition},
+		{err: classservice.VoucherNotApplicableError{}, want: codes.FailedPrecondition},
+		{err: errors.New("connection refused"), want: codes.Internal},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(fmt.Sprintf("%T", tc.err), func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tc.want, codeFromError(fmt.Errorf("wrapped: %w", tc.err)))
+		})
+	}
+
+	t.Run("hides the details of internal errors", func(t *testing.T) {
+		t.Parallel()
+
+		err := statusFromError(errors.New("connection refused"))
+		require.Equal(t, "internal error", status.Convert(err).Message())
+	})
+}
+
+// newTestClient starts a Server backed by classService on an in-memory
+// listener and returns a client connected to it.
+func newTestClient(t *testing.T, classService classservice.Interface, opts ...Option) classpb.ClassServiceClient {
+	t.Helper()
+
+	var (
+		logger = log.New(os.Stdout, t.Name()+" ", log.LstdFlags)
+		server = NewServer(logger, envconfig.EnvConfig{}, classService, opts...)
+		lis    = bufconn.Listen(1 << 20)
+	)
+
+	go func() {
+		_ = server.Serve(lis)
+	}()
+
+	t.Cleanup(server.GracefulStop)
+
+	conn, err := grpclib.NewClient(
+		"passthrough:///bufconn",
+		grpclib.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpclib.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err, "dial test server")
+
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	return classpb.NewClassServiceClient(conn)
+}
+
+func birthdate(t *testing.T, s string) primitive.Birthdate {
+	t.Helper()
+
+	bd, err := primitive.ParseBirthdate(s)
+	require.NoError(t, err, "parse birthdate")
+
+	return bd
+}
+
+// cmpProto returns a description of the differences between two messages, or
+// the empty string if they're equal.
+func cmpProto(want, got proto.Message) string {
+	if proto.Equal(want, got) {
+		return ""
+	}
+
+	return fmt.Sprintf("want %v\ngot  %v", want, got)
+}
@@ -0,0 +1,1225 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: classpb/class.proto
+
+package classpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Student identifies a student. Birthdate is formatted as YYYY-MM-DD.
+type Student struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Birthdate     string                 `protobuf:"bytes,2,opt,name=birthdate,proto3" json:"birthdate,omitempty"`
+	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Student) Reset() {
+	*x = Student{}
+	mi := &file_classpb_class_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Student) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Student) ProtoMessage() {}
+
+func (x *Student) ProtoReflect() protoreflect.Message {
+	mi := &file_classpb_class_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Student.ProtoReflect.Descriptor instead.
+func (*Student) Descriptor() ([]byte, []int) {
+	return file_classpb_class_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Student) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Student) GetBirthdate() string {
+	if x != nil {
+		return x.Birthdate
+	}
+	return ""
+}
+
+func (x *Student) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+// Money is an amount in minor units of an ISO 4217 currency.
+type Money struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Amount        int64                  `protobuf:"varint,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency      string                 `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Money) Reset() {
+	*x = Money{}
+	mi := &file_classpb_class_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Money) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Money) ProtoMessage() {}
+
+func (x *Money) ProtoReflect() protoreflect.Message {
+	mi := &file_classpb_class_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Money.ProtoReflect.Descriptor instead.
+func (*Money) Descriptor() ([]byte, []int) {
+	return file_classpb_class_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Money) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Money) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type EnrollRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	CourseCode string                 `protobuf:"bytes,1,opt,name=course_code,json=courseCode,proto3" json:"course_code,omitempty"`
+	// section_code is required if the course has sections.
+	SectionCode string `protobuf:"bytes,2,opt,name=section_code,json=sectionCode,proto3" json:"section_code,omitempty"`
+	// voucher_code is optional.
+	VoucherCode   string     `protobuf:"bytes,3,opt,name=voucher_code,json=voucherCode,proto3" json:"voucher_code,omitempty"`
+	Students      []*Student `protobuf:"bytes,4,rep,name=students,proto3" json:"students,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnrollRequest) Reset() {
+	*x = EnrollRequest{}
+	mi := &file_classpb_class_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnrollRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrollRequest) ProtoMessage() {}
+
+func (x *EnrollRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_classpb_class_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrollRequest.ProtoReflect.Descriptor instead.
+func (*EnrollRequest) Descriptor() ([]byte, []int) {
+	return file_classpb_class_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *EnrollRequest) GetCourseCode() string {
+	if x != nil {
+		return x.CourseCode
+	}
+	return ""
+}
+
+func (x *EnrollRequest) GetSectionCode() string {
+	if x != nil {
+		return x.SectionCode
+	}
+	return ""
+}
+
+func (x *EnrollRequest) GetVoucherCode() string {
+	if x != nil {
+		return x.VoucherCode
+	}
+	return ""
+}
+
+func (x *EnrollRequest) GetStudents() []*Student {
+	if x != nil {
+		return x.Students
+	}
+	return nil
+}
+
+type EnrollResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnrollResponse) Reset() {
+	*x = EnrollResponse{}
+	mi := &file_classpb_class_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnrollResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrollResponse) ProtoMessage() {}
+
+func (x *EnrollResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_classpb_class_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrollResponse.ProtoReflect.Descriptor instead.
+func (*EnrollResponse) Descriptor() ([]byte, []int) {
+	return file_classpb_class_proto_rawDescGZIP(), []int{3}
+}
+
+type ApproveEnrollmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CourseCode    string                 `protobuf:"bytes,1,opt,name=course_code,json=courseCode,proto3" json:"course_code,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproveEnrollmentRequest) Reset() {
+	*x = ApproveEnrollmentRequest{}
+	mi := &file_classpb_class_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveEnrollmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveEnrollmentRequest) ProtoMessage() {}
+
+func (x *ApproveEnrollmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_classpb_class_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveEnrollmentRequest.ProtoReflect.Descriptor instead.
+func (*ApproveEnrollmentRequest) Descriptor() ([]byte, []int) {
+	return file_classpb_class_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ApproveEnrollmentRequest) GetCourseCode() string {
+	if x != nil {
+		return x.CourseCode
+	}
+	return ""
+}
+
+func (x *ApproveEnrollmentRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+type ApproveEnrollmentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproveEnrollmentResponse) Reset() {
+	*x = ApproveEnrollmentResponse{}
+	mi := &file_classpb_class_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveEnrollmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveEnrollmentResponse) ProtoMessage() {}
+
+func (x *ApproveEnrollmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_classpb_class_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveEnrollmentResponse.ProtoReflect.Descriptor instead.
+func (*ApproveEnrollmentResponse) Descriptor() ([]byte, []int) {
+	return file_classpb_class_proto_rawDescGZIP(), []int{5}
+}
+
+type RejectEnrollmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CourseCode    string                 `protobuf:"bytes,1,opt,name=course_code,json=courseCode,proto3" json:"course_code,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RejectEnrollmentRequest) Reset() {
+	*x = RejectEnrollmentRequest{}
+	mi := &file_classpb_class_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RejectEnrollmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RejectEnrollmentRequest) ProtoMessage() {}
+
+func (x *RejectEnrollmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_classpb_class_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RejectEnrollmentRequest.ProtoReflect.Descriptor instead.
+func (*RejectEnrollmentRequest) Descriptor() ([]byte, []int) {
+	return file_classpb_class_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *RejectEnrollmentRequest) GetCourseCode() string {
+	if x != nil {
+		return x.CourseCode
+	}
+	return ""
+}
+
+func (x *RejectEnrollmentRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+type RejectEnrollmentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RejectEnrollmentResponse) Reset() {
+	*x = RejectEnrollmentResponse{}
+	mi := &file_classpb_class_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RejectEnrollmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RejectEnrollmentResponse) ProtoMessage() {}
+
+func (x *RejectEnrollmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_classpb_class_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RejectEnrollmentResponse.ProtoReflect.Descriptor instead.
+func (*RejectEnrollmentResponse) Descriptor() ([]byte, []int) {
+	return file_classpb_class_proto_rawDescGZIP(), []int{7}
+}
+
+type TransferRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	FromCourseCode string                 `protobuf:"bytes,1,opt,name=from_course_code,json=fromCourseCode,proto3" json:"from_course_code,omitempty"`
+	ToCourseCode   string                 `protobuf:"bytes,2,opt,name=to_course_code,json=toCourseCode,proto3" json:"to_course_code,omitempty"`
+	Students       []*Student             `protobuf:"bytes,3,rep,name=students,proto3" json:"students,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *TransferRequest) Reset() {
+	*x = TransferRequest{}
+	mi := &file_classpb_class_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferRequest) ProtoMessage() {}
+
+func (x *TransferRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_classpb_class_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferRequest.ProtoReflect.Descriptor instead.
+func (*TransferRequest) Descriptor() ([]byte, []int) {
+	return file_classpb_class_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *TransferRequest) GetFromCourseCode() string {
+	if x != nil {
+		return x.FromCourseCode
+	}
+	return ""
+}
+
+func (x *TransferRequest) GetToCourseCode() string {
+	if x != nil {
+		return x.ToCourseCode
+	}
+	return ""
+}
+
+func (x *TransferRequest) GetStudents() []*Student {
+	if x != nil {
+		return x.Students
+	}
+	return nil
+}
+
+type TransferResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransferResponse) Reset() {
+	*x = TransferResponse{}
+	mi := &file_classpb_class_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferResponse) ProtoMessage() {}
+
+func (x *TransferResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_classpb_class_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferResponse.ProtoReflect.Descriptor instead.
+func (*TransferResponse) Descriptor() ([]byte, []int) {
+	return file_classpb_class_proto_rawDescGZIP(), []int{9}
+}
+
+type GetClassRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CourseCode    string                 `protobuf:"bytes,1,opt,name=course_code,json=courseCode,proto3" json:"course_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetClassRequest) Reset() {
+	*x = GetClassRequest{}
+	mi := &file_classpb_class_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetClassRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetClassRequest) ProtoMessage() {}
+
+func (x *GetClassRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_classpb_class_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetClassRequest.ProtoReflect.Descriptor instead.
+func (*GetClassRequest) Descriptor() ([]byte, []int) {
+	return file_classpb_class_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetClassRequest) GetCourseCode() string {
+	if x != nil {
+		return x.CourseCode
+	}
+	return ""
+}
+
+type GetClassResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Class         *Class                 `protobuf:"bytes,1,opt,name=class,proto3" json:"class,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetClassResponse) Reset() {
+	*x = GetClassResponse{}
+	mi := &file_classpb_class_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetClassResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetClassResponse) ProtoMessage() {}
+
+func (x *GetClassResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_classpb_class_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetClassResponse.ProtoReflect.Descriptor instead.
+func (*GetClassResponse) Descriptor() ([]byte, []int) {
+	return file_classpb_class_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetClassResponse) GetClass() *Class {
+	if x != nil {
+		return x.Class
+	}
+	return nil
+}
+
+// Class is the roster of a course. Fee is unset if the course is free.
+type Class struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	CourseCode      string                 `protobuf:"bytes,1,opt,name=course_code,json=courseCode,proto3" json:"course_code,omitempty"`
+	Capacity        uint32                 `protobuf:"varint,2,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	Cancelled       bool                   `protobuf:"varint,3,opt,name=cancelled,proto3" json:"cancelled,omitempty"`
+	Fee             *Money                 `protobuf:"bytes,4,opt,name=fee,proto3" json:"fee,omitempty"`
+	Instructors     []*Instructor          `protobuf:"bytes,5,rep,name=instructors,proto3" json:"instructors,omitempty"`
+	Students        []*RosterStudent       `protobuf:"bytes,6,rep,name=students,proto3" json:"students,omitempty"`
+	Pending         []*RosterStudent       `protobuf:"bytes,7,rep,name=pending,proto3" json:"pending,omitempty"`
+	AwaitingPayment []*RosterStudent       `protobuf:"bytes,8,rep,name=awaiting_payment,json=awaitingPayment,proto3" json:"awaiting_payment,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Class) Reset() {
+	*x = Class{}
+	mi := &file_classpb_class_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Class) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Class) ProtoMessage() {}
+
+func (x *Class) ProtoReflect() protoreflect.Message {
+	mi := &file_classpb_class_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Class.ProtoReflect.Descriptor instead.
+func (*Class) Descriptor() ([]byte, []int) {
+	return file_classpb_class_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Class) GetCourseCode() string {
+	if x != nil {
+		return x.CourseCode
+	}
+	return ""
+}
+
+func (x *Class) GetCapacity() uint32 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+func (x *Class) GetCancelled() bool {
+	if x != nil {
+		return x.Cancelled
+	}
+	return false
+}
+
+func (x *Class) GetFee() *Money {
+	if x != nil {
+		return x.Fee
+	}
+	return nil
+}
+
+func (x *Class) GetInstructors() []*Instructor {
+	if x != nil {
+		return x.Instructors
+	}
+	return nil
+}
+
+func (x *Class) GetStudents() []*RosterStudent {
+	if x != nil {
+		return x.Students
+	}
+	return nil
+}
+
+func (x *Class) GetPending() []*RosterStudent {
+	if x != nil {
+		return x.Pending
+	}
+	return nil
+}
+
+func (x *Class) GetAwaitingPayment() []*RosterStudent {
+	if x != nil {
+		return x.AwaitingPayment
+	}
+	return nil
+}
+
+type Instructor struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Instructor) Reset() {
+	*x = Instructor{}
+	mi := &file_classpb_class_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Instructor) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Instructor) ProtoMessage() {}
+
+func (x *Instructor) ProtoReflect() protoreflect.Message {
+	mi := &file_classpb_class_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Instructor.ProtoReflect.Descriptor instead.
+func (*Instructor) Descriptor() ([]byte, []int) {
+	return file_classpb_class_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Instructor) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Instructor) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Instructor) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+// RosterStudent is a student on a class roster. section_code is empty if the
+// course has no sections, or if a pending student named no section.
+// attendance_percentage is rounded to one decimal place, and is unset if no
+// attendance has been recorded for the student.
+type RosterStudent struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Student              *Student               `protobuf:"bytes,1,opt,name=student,proto3" json:"student,omitempty"`
+	SectionCode          string                 `protobuf:"bytes,2,opt,name=section_code,json=sectionCode,proto3" json:"section_code,omitempty"`
+	AttendancePercentage *float64               `protobuf:"fixed64,3,opt,name=attendance_percentage,json=attendancePercentage,proto3,oneof" json:"attendance_percentage,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *RosterStudent) Reset() {
+	*x = RosterStudent{}
+	mi := &file_classpb_class_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RosterStudent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RosterStudent) ProtoMessage() {}
+
+func (x *RosterStudent) ProtoReflect() protoreflect.Message {
+	mi := &file_classpb_class_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RosterStudent.ProtoReflect.Descriptor instead.
+func (*RosterStudent) Descriptor() ([]byte, []int) {
+	return file_classpb_class_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RosterStudent) GetStudent() *Student {
+	if x != nil {
+		return x.Student
+	}
+	return nil
+}
+
+func (x *RosterStudent) GetSectionCode() string {
+	if x != nil {
+		return x.SectionCode
+	}
+	return ""
+}
+
+func (x *RosterStudent) GetAttendancePercentage() float64 {
+	if x != nil && x.AttendancePercentage != nil {
+		return *x.AttendancePercentage
+	}
+	return 0
+}
+
+type GetTranscriptRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTranscriptRequest) Reset() {
+	*x = GetTranscriptRequest{}
+	mi := &file_classpb_class_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTranscriptRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTranscriptRequest) ProtoMessage() {}
+
+func (x *GetTranscriptRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_classpb_class_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTranscriptRequest.ProtoReflect.Descriptor instead.
+func (*GetTranscriptRequest) Descriptor() ([]byte, []int) {
+	return file_classpb_class_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetTranscriptRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+type GetTranscriptResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transcript    *Transcript            `protobuf:"bytes,1,opt,name=transcript,proto3" json:"transcript,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTranscriptResponse) Reset() {
+	*x = GetTranscriptResponse{}
+	mi := &file_classpb_class_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTranscriptResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTranscriptResponse) ProtoMessage() {}
+
+func (x *GetTranscriptResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_classpb_class_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTranscriptResponse.ProtoReflect.Descriptor instead.
+func (*GetTranscriptResponse) Descriptor() ([]byte, []int) {
+	return file_classpb_class_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetTranscriptResponse) GetTranscript() *Transcript {
+	if x != nil {
+		return x.Transcript
+	}
+	return nil
+}
+
+// Transcript lists the courses a student has completed. gpa is rounded to two
+// decimal places, and is unset if the student has no grades worth points.
+type Transcript struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Student       *Student               `protobuf:"bytes,1,opt,name=student,proto3" json:"student,omitempty"`
+	Gpa           *float64               `protobuf:"fixed64,2,opt,name=gpa,proto3,oneof" json:"gpa,omitempty"`
+	Courses       []*TranscriptEntry     `protobuf:"bytes,3,rep,name=courses,proto3" json:"courses,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Transcript) Reset() {
+	*x = Transcript{}
+	mi := &file_classpb_class_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Transcript) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Transcript) ProtoMessage() {}
+
+func (x *Transcript) ProtoReflect() protoreflect.Message {
+	mi := &file_classpb_class_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Transcript.ProtoReflect.Descriptor instead.
+func (*Transcript) Descriptor() ([]byte, []int) {
+	return file_classpb_class_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Transcript) GetStudent() *Student {
+	if x != nil {
+		return x.Student
+	}
+	return nil
+}
+
+func (x *Transcript) GetGpa() float64 {
+	if x != nil && x.Gpa != nil {
+		return *x.Gpa
+	}
+	return 0
+}
+
+func (x *Transcript) GetCourses() []*TranscriptEntry {
+	if x != nil {
+		return x.Courses
+	}
+	return nil
+}
+
+type TranscriptEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CourseCode    string                 `protobuf:"bytes,1,opt,name=course_code,json=courseCode,proto3" json:"course_code,omitempty"`
+	Grade         string                 `protobuf:"bytes,2,opt,name=grade,proto3" json:"grade,omitempty"`
+	Passed        bool                   `protobuf:"varint,3,opt,name=passed,proto3" json:"passed,omitempty"`
+	CompletedAt   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TranscriptEntry) Reset() {
+	*x = TranscriptEntry{}
+	mi := &file_classpb_class_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TranscriptEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TranscriptEntry) ProtoMessage() {}
+
+func (x *TranscriptEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_classpb_class_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TranscriptEntry.ProtoReflect.Descriptor instead.
+func (*TranscriptEntry) Descriptor() ([]byte, []int) {
+	return file_classpb_class_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *TranscriptEntry) GetCourseCode() string {
+	if x != nil {
+		return x.CourseCode
+	}
+	return ""
+}
+
+func (x *TranscriptEntry) GetGrade() string {
+	if x != nil {
+		return x.Grade
+	}
+	return ""
+}
+
+func (x *TranscriptEntry) GetPassed() bool {
+	if x != nil {
+		return x.Passed
+	}
+	return false
+}
+
+func (x *TranscriptEntry) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+var File_classpb_class_proto protoreflect.FileDescriptor
+
+const file_classpb_class_proto_rawDesc = "" +
+	"\n" +
+	"\x13classpb/class.proto\x12\x12hexagonal.class.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"Q\n" +
+	"\aStudent\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1c\n" +
+	"\tbirthdate\x18\x02 \x01(\tR\tbirthdate\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\";\n" +
+	"\x05Money\x12\x16\n" +
+	"\x06amount\x18\x01 \x01(\x03R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\"\xaf\x01\n" +
+	"\rEnrollRequest\x12\x1f\n" +
+	"\vcourse_code\x18\x01 \x01(\tR\n" +
+	"courseCode\x12!\n" +
+	"\fsection_code\x18\x02 \x01(\tR\vsectionCode\x12!\n" +
+	"\fvoucher_code\x18\x03 \x01(\tR\vvoucherCode\x127\n" +
+	"\bstudents\x18\x04 \x03(\v2\x1b.hexagonal.class.v1.StudentR\bstudents\"\x10\n" +
+	"\x0eEnrollResponse\"Q\n" +
+	"\x18ApproveEnrollmentRequest\x12\x1f\n" +
+	"\vcourse_code\x18\x01 \x01(\tR\n" +
+	"courseCode\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\"\x1b\n" +
+	"\x19ApproveEnrollmentResponse\"P\n" +
+	"\x17RejectEnrollmentRequest\x12\x1f\n" +
+	"\vcourse_code\x18\x01 \x01(\tR\n" +
+	"courseCode\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\"\x1a\n" +
+	"\x18RejectEnrollmentResponse\"\x9a\x01\n" +
+	"\x0fTransferRequest\x12(\n" +
+	"\x10from_course_code\x18\x01 \x01(\tR\x0efromCourseCode\x12$\n" +
+	"\x0eto_course_code\x18\x02 \x01(\tR\ftoCourseCode\x127\n" +
+	"\bstudents\x18\x03 \x03(\v2\x1b.hexagonal.class.v1.StudentR\bstudents\"\x12\n" +
+	"\x10TransferResponse\"2\n" +
+	"\x0fGetClassRequest\x12\x1f\n" +
+	"\vcourse_code\x18\x01 \x01(\tR\n" +
+	"courseCode\"C\n" +
+	"\x10GetClassResponse\x12/\n" +
+	"\x05class\x18\x01 \x01(\v2\x19.hexagonal.class.v1.ClassR\x05class\"\x9b\x03\n" +
+	"\x05Class\x12\x1f\n" +
+	"\vcourse_code\x18\x01 \x01(\tR\n" +
+	"courseCode\x12\x1a\n" +
+	"\bcapacity\x18\x02 \x01(\rR\bcapacity\x12\x1c\n" +
+	"\tcancelled\x18\x03 \x01(\bR\tcancelled\x12+\n" +
+	"\x03fee\x18\x04 \x01(\v2\x19.hexagonal.class.v1.MoneyR\x03fee\x12@\n" +
+	"\vinstructors\x18\x05 \x03(\v2\x1e.hexagonal.class.v1.InstructorR\vinstructors\x12=\n" +
+	"\bstudents\x18\x06 \x03(\v2!.hexagonal.class.v1.RosterStudentR\bstudents\x12;\n" +
+	"\apending\x18\a \x03(\v2!.hexagonal.class.v1.RosterStudentR\apending\x12L\n" +
+	"\x10awaiting_payment\x18\b \x03(\v2!.hexagonal.class.v1.RosterStudentR\x0fawaitingPayment\"F\n" +
+	"\n" +
+	"Instructor\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\"\xbd\x01\n" +
+	"\rRosterStudent\x125\n" +
+	"\astudent\x18\x01 \x01(\v2\x1b.hexagonal.class.v1.StudentR\astudent\x12!\n" +
+	"\fsection_code\x18\x02 \x01(\tR\vsectionCode\x128\n" +
+	"\x15attendance_percentage\x18\x03 \x01(\x01H\x00R\x14attendancePercentage\x88\x01\x01B\x18\n" +
+	"\x16_attendance_percentage\",\n" +
+	"\x14GetTranscriptRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\"W\n" +
+	"\x15GetTranscriptResponse\x12>\n" +
+	"\n" +
+	"transcript\x18\x01 \x01(\v2\x1e.hexagonal.class.v1.TranscriptR\n" +
+	"transcript\"\xa1\x01\n" +
+	"\n" +
+	"Transcript\x125\n" +
+	"\astudent\x18\x01 \x01(\v2\x1b.hexagonal.class.v1.StudentR\astudent\x12\x15\n" +
+	"\x03gpa\x18\x02 \x01(\x01H\x00R\x03gpa\x88\x01\x01\x12=\n" +
+	"\acourses\x18\x03 \x03(\v2#.hexagonal.class.v1.TranscriptEntryR\acoursesB\x06\n" +
+	"\x04_gpa\"\x9f\x01\n" +
+	"\x0fTranscriptEntry\x12\x1f\n" +
+	"\vcourse_code\x18\x01 \x01(\tR\n" +
+	"courseCode\x12\x14\n" +
+	"\x05grade\x18\x02 \x01(\tR\x05grade\x12\x16\n" +
+	"\x06passed\x18\x03 \x01(\bR\x06passed\x12=\n" +
+	"\fcompleted_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt2\xd4\x04\n" +
+	"\fClassService\x12O\n" +
+	"\x06Enroll\x12!.hexagonal.class.v1.EnrollRequest\x1a\".hexagonal.class.v1.EnrollResponse\x12p\n" +
+	"\x11ApproveEnrollment\x12,.hexagonal.class.v1.ApproveEnrollmentRequest\x1a-.hexagonal.class.v1.ApproveEnrollmentResponse\x12m\n" +
+	"\x10RejectEnrollment\x12+.hexagonal.class.v1.RejectEnrollmentRequest\x1a,.hexagonal.class.v1.RejectEnrollmentResponse\x12U\n" +
+	"\bTransfer\x12#.hexagonal.class.v1.TransferRequest\x1a$.hexagonal.class.v1.TransferResponse\x12U\n" +
+	"\bGetClass\x12#.hexagonal.class.v1.GetClassRequest\x1a$.hexagonal.class.v1.GetClassResponse\x12d\n" +
+	"\rGetTranscript\x12(.hexagonal.class.v1.GetTranscriptRequest\x1a).hexagonal.class.v1.GetTranscriptResponseBCZAgithub.com/angusgmorrison/hexagonal/internal/handler/grpc/classpbb\x06proto3"
+
+var (
+	file_classpb_class_proto_rawDescOnce sync.Once
+	file_classpb_class_proto_rawDescData []byte
+)
+
+func file_classpb_class_proto_rawDescGZIP() []byte {
+	file_classpb_class_proto_rawDescOnce.Do(func() {
+		file_classpb_class_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_classpb_class_proto_rawDesc), len(file_classpb_class_proto_rawDesc)))
+	})
+	return file_classpb_class_proto_rawDescData
+}
+
+var file_classpb_class_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
+var file_classpb_class_proto_goTypes = []any{
+	(*Student)(nil),                   // 0: hexagonal.class.v1.Student
+	(*Money)(nil),                     // 1: hexagonal.class.v1.Money
+	(*EnrollRequest)(nil),             // 2: hexagonal.class.v1.EnrollRequest
+	(*EnrollResponse)(nil),            // 3: hexagonal.class.v1.EnrollResponse
+	(*ApproveEnrollmentRequest)(nil),  // 4: hexagonal.class.v1.ApproveEnrollmentRequest
+	(*ApproveEnrollmentResponse)(nil), // 5: hexagonal.class.v1.ApproveEnrollmentResponse
+	(*RejectEnrollmentRequest)(nil),   // 6: hexagonal.class.v1.RejectEnrollmentRequest
+	(*RejectEnrollmentResponse)(nil),  // 7: hexagonal.class.v1.RejectEnrollmentResponse
+	(*TransferRequest)(nil),           // 8: hexagonal.class.v1.TransferRequest
+	(*TransferResponse)(nil),          // 9: hexagonal.class.v1.TransferResponse
+	(*GetClassRequest)(nil),           // 10: hexagonal.class.v1.GetClassRequest
+	(*GetClassResponse)(nil),          // 11: hexagonal.class.v1.GetClassResponse
+	(*Class)(nil),                     // 12: hexagonal.class.v1.Class
+	(*Instructor)(nil),                // 13: hexagonal.class.v1.Instructor
+	(*RosterStudent)(nil),             // 14: hexagonal.class.v1.RosterStudent
+	(*GetTranscriptRequest)(nil),      // 15: hexagonal.class.v1.GetTranscriptRequest
+	(*GetTranscriptResponse)(nil),     // 16: hexagonal.class.v1.GetTranscriptResponse
+	(*Transcript)(nil),                // 17: hexagonal.class.v1.Transcript
+	(*TranscriptEntry)(nil),           // 18: hexagonal.class.v1.TranscriptEntry
+	(*timestamppb.Timestamp)(nil),     // 19: google.protobuf.Timestamp
+}
+var file_classpb_class_proto_depIdxs = []int32{
+	0,  // 0: hexagonal.class.v1.EnrollRequest.students:type_name -> hexagonal.class.v1.Student
+	0,  // 1: hexagonal.class.v1.TransferRequest.students:type_name -> hexagonal.class.v1.Student
+	12, // 2: hexagonal.class.v1.GetClassResponse.class:type_name -> hexagonal.class.v1.Class
+	1,  // 3: hexagonal.class.v1.Class.fee:type_name -> hexagonal.class.v1.Money
+	13, // 4: hexagonal.class.v1.Class.instructors:type_name -> hexagonal.class.v1.Instructor
+	14, // 5: hexagonal.class.v1.Class.students:type_name -> hexagonal.class.v1.RosterStudent
+	14, // 6: hexagonal.class.v1.Class.pending:type_name -> hexagonal.class.v1.RosterStudent
+	14, // 7: hexagonal.class.v1.Class.awaiting_payment:type_name -> hexagonal.class.v1.RosterStudent
+	0,  // 8: hexagonal.class.v1.RosterStudent.student:type_name -> hexagonal.class.v1.Student
+	17, // 9: hexagonal.class.v1.GetTranscriptResponse.transcript:type_name -> hexagonal.class.v1.Transcript
+	0,  // 10: hexagonal.class.v1.Transcript.student:type_name -> hexagonal.class.v1.Student
+	18, // 11: hexagonal.class.v1.Transcript.courses:type_name -> hexagonal.class.v1.TranscriptEntry
+	19, // 12: hexagonal.class.v1.TranscriptEntry.completed_at:type_name -> google.protobuf.Timestamp
+	2,  // 13: hexagonal.class.v1.ClassService.Enroll:input_type -> hexagonal.class.v1.EnrollRequest
+	4,  // 14: hexagonal.class.v1.ClassService.ApproveEnrollment:input_type -> hexagonal.class.v1.ApproveEnrollmentRequest
+	6,  // 15: hexagonal.class.v1.ClassService.RejectEnrollment:input_type -> hexagonal.class.v1.RejectEnrollmentRequest
+	8,  // 16: hexagonal.class.v1.ClassService.Transfer:input_type -> hexagonal.class.v1.TransferRequest
+	10, // 17: hexagonal.class.v1.ClassService.GetClass:input_type -> hexagonal.class.v1.GetClassRequest
+	15, // 18: hexagonal.class.v1.ClassService.GetTranscript:input_type -> hexagonal.class.v1.GetTranscriptRequest
+	3,  // 19: hexagonal.class.v1.ClassService.Enroll:output_type -> hexagonal.class.v1.EnrollResponse
+	5,  // 20: hexagonal.class.v1.ClassService.ApproveEnrollment:output_type -> hexagonal.class.v1.ApproveEnrollmentResponse
+	7,  // 21: hexagonal.class.v1.ClassService.RejectEnrollment:output_type -> hexagonal.class.v1.RejectEnrollmentResponse
+	9,  // 22: hexagonal.class.v1.ClassService.Transfer:output_type -> hexagonal.class.v1.TransferResponse
+	11, // 23: hexagonal.class.v1.ClassService.GetClass:output_type -> hexagonal.class.v1.GetClassResponse
+	16, // 24: hexagonal.class.v1.ClassService.GetTranscript:output_type -> hexagonal.class.v1.GetTranscriptResponse
+	19, // [19:25] is the sub-list for method output_type
+	13, // [13:19] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
+}
+
+func init() { file_classpb_class_proto_init() }
+func file_classpb_class_proto_init() {
+	if File_classpb_class_proto != nil {
+		return
+	}
+	file_classpb_class_proto_msgTypes[14].OneofWrappers = []any{}
+	file_classpb_class_proto_msgTypes[17].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_classpb_class_proto_rawDesc), len(file_classpb_class_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   19,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_classpb_class_proto_goTypes,
+		DependencyIndexes: file_classpb_class_proto_depIdxs,
+		MessageInfos:      file_classpb_class_proto_msgTypes,
+	}.Build()
+	File_classpb_class_proto = out.File
+	file_classpb_class_proto_goTypes = nil
+	file_classpb_class_proto_depIdxs = nil
+}
@@ -0,0 +1,4 @@
+// Package grpc provides a gRPC server and handlers that expose the class
+// service alongside the RESTful HTTP server. The protobuf definitions and the
+// code generated from them live in the classpb subpackage.
+package grpc
@@ -0,0 +1,107 @@
+package grpc
+
+import (
+	"fmt"
+	"log"
+	"net"
+
+	"github.com/angusgmorrison/hexagonal/internal/envconfig"
+	"github.com/angusgmorrison/hexagonal/internal/handler/grpc/classpb"
+	"github.com/angusgmorrison/hexagonal/internal/service/authservice"
+	"github.com/angusgmorrison/hexagonal/internal/service/classservice"
+	grpclib "google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+// Server serves the gRPC API.
+type Server struct {
+	config envconfig.EnvConfig
+	logger *log.Logger
+
+	// server routes incoming RPCs to the correct handler.
+	server *grpclib.Server
+
+	// classService is the interface by which handlers communicate requests to
+	// business logic.
+	classService classservice.Interface
+
+	// authService authenticates clients, if configured. Otherwise, every RPC
+	// is open to anyone who can reach the server.
+	authService authservice.Interface
+}
+
+// Option configures optional behaviour of the Server returned by NewServer.
+type Option func(*Server)
+
+// WithAuthentication requires clients to authenticate every RPC, including
+// reflection, using authService. Clients present a bearer token in the
+// "authorization" metadata or an API key in the "x-api-key" metadata.
+func WithAuthentication(authService authservice.Interface) Option {
+	return func(s *Server) {
+		s.authService = authService
+	}
+}
+
+// NewServer returns a new gRPC server configured using the provided config.
+func NewServer(
+	logger *log.Logger,
+	envConfig envconfig.EnvConfig,
+	classService classservice.Interface,
+	opts ...Option,
+) *Server {
+	server := Server{
+		config:       envConfig,
+		logger:       logger,
+		classService: classService,
+	}
+
+	for _, opt := range opts {
+		opt(&server)
+	}
+
+	var serverOpts []grpclib.ServerOption
+	if server.authService != nil {
+		serverOpts = append(serverOpts,
+			grpclib.UnaryInterceptor(server.authenticateUnary),
+			grpclib.StreamInterceptor(server.authenticateStream),
+		)
+	}
+
+	server.server = grpclib.NewServer(serverOpts...)
+
+	classpb.RegisterClassServiceServer(server.server, &classHandler{
+		logger:       logger,
+		classService: classService,
+	})
+
+	// Reflection lets tools such as grpcurl discover the API without a copy
+	// of the protobuf definitions.
+	reflection.Register(server.server)
+
+	return &server
+}
+
+// Address returns the address at which the Server listens, as configured by
+// GRPC_HOST and GRPC_PORT.
+func (s *Server) Address() string {
+	return fmt.Sprintf("%s:%d", s.config.GRPC.Host, s.config.GRPC.Port)
+}
+
+// Serve accepts connections on the listener, blocking until the listener
+// fails or the Server is stopped.
+func (s *Server) Serve(lis net.Listener) error {
+	s.logger.Printf("Starting gRPC server at %s\n", lis.Addr())
+
+	if err := s.server.Serve(lis); err != nil {
+		return fmt.Errorf("serve gRPC: %w", err)
+	}
+
+	return nil
+}
+
+// GracefulStop stops the Server from accepting new connections and blocks
+// until all pending RPCs have finished.
+func (s *Server) GracefulStop() {
+	s.logger.Println("Stopping gRPC server gracefully...")
+	s.server.GracefulStop()
+}
@@ -0,0 +1,117 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/angusgmorrison/hexagonal/internal/service/classservice"
+	"github.com/go-playground/validator/v10"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// statusFromError converts an error returned by the class service to a gRPC
+// status, whose code describes the class of failure and whose message is the
+// error's own. Errors the service doesn't document are reported as Internal
+// without revealing their details.
+func statusFromError(err error) error {
+	code := codeFromError(err)
+	if code == codes.Internal {
+		return status.Error(code, "internal error")
+	}
+
+	return status.Error(code, err.Error())
+}
+
+// codeFromError returns the gRPC status code that best describes err.
+func codeFromError(err error) codes.Code {
+	var (
+		// Malformed requests.
+		validationErrs    validator.ValidationErrors
+		invalidRuleErr    classservice.InvalidRuleError
+		unknownGradeErr   classservice.UnknownGradeError
+		invalidVoucherErr classservice.InvalidVoucherError
+		emptyScheduleErr  classservice.EmptyScheduleError
+		longScheduleErr   classservice.ScheduleTooLongError
+
+		// Missing resources.
+		courseNotFoundErr      classservice.CourseNotFoundError
+		sectionNotFoundErr     classservice.SectionNotFoundError
+		sessionNotFoundErr     classservice.SessionNotFoundError
+		unregisteredErr        classservice.UnregisteredStudentsError
+		instructorNotFoundErr  classservice.InstructorNotFoundError
+		invoiceNotFoundErr     classservice.InvoiceNotFoundError
+		voucherNotFoundErr     classservice.VoucherNotFoundError
+		certificateNotFoundErr classservice.CertificateNotFoundError
+		notPassedErr           classservice.CourseNotPassedError
+
+		// Conflicts with existing resources.
+		alreadyEnrolledErr  classservice.AlreadyEnrolledError
+		alreadyReservedErr  classservice.AlreadyReservedError
+		alreadyAssignedErr  classservice.AlreadyAssignedError
+		voucherCodeTakenErr classservice.VoucherCodeTakenError
+		voucherRedeemedErr  classservice.VoucherAlreadyRedeemedError
+
+		// Exhausted capacity.
+		oversubscribedErr   classservice.OversubscribedError
+		courseLoadErr       classservice.CourseLoadExceededError
+		voucherExhaustedErr classservice.VoucherExhaustedError
+
+		// Unmet preconditions.
+		ruleViolationErr        classservice.RuleViolationError
+		prerequisitesErr        classservice.PrerequisitesNotMetError
+		notPendingErr           classservice.EnrollmentNotPendingError
+		notEnrolledErr          classservice.NotEnrolledError
+		notAssignedErr          classservice.NotAssignedError
+		cancelledErr            classservice.CourseCancelledError
+		notAwaitingErr          classservice.NotAwaitingPaymentError
+		transferApprovalErr     classservice.TransferRequiresApprovalError
+		voucherNotApplicableErr classservice.VoucherNotApplicableError
+	)
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return codes.Canceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return codes.DeadlineExceeded
+	case errors.As(err, &validationErrs),
+		errors.As(err, &invalidRuleErr),
+		errors.As(err, &unknownGradeErr),
+		errors.As(err, &invalidVoucherErr),
+		errors.As(err, &emptyScheduleErr),
+		errors.As(err, &longScheduleErr):
+		return codes.InvalidArgument
+	case errors.As(err, &courseNotFoundErr),
+		errors.As(err, &sectionNotFoundErr),
+		errors.As(err, &sessionNotFoundErr),
+		errors.As(err, &unregisteredErr),
+		errors.As(err, &instructorNotFoundErr),
+		errors.As(err, &invoiceNotFoundErr),
+		errors.As(err, &voucherNotFoundErr),
+		errors.As(err, &certificateNotFoundErr),
+		errors.As(err, &notPassedErr):
+		return codes.NotFound
+	case errors.As(err, &alreadyEnrolledErr),
+		errors.As(err, &alreadyReservedErr),
+		errors.As(err, &alreadyAssignedErr),
+		errors.As(err, &voucherCodeTakenErr),
+		errors.As(err, &voucherRedeemedErr):
+		return codes.AlreadyExists
+	case errors.As(err, &oversubscribedErr),
+		errors.As(err, &courseLoadErr),
+		errors.As(err, &voucherExhaustedErr):
+		return codes.ResourceExhausted
+	case errors.As(err, &ruleViolationErr),
+		errors.As(err, &prerequisitesErr),
+		errors.As(err, &notPendingErr),
+		errors.As(err, &notEnrolledErr),
+		errors.As(err, &notAssignedErr),
+		errors.As(err, &cancelledErr),
+		errors.As(err, &notAwaitingErr),
+		errors.As(err, &transferApprovalErr),
+		errors.As(err, &voucherNotApplicableErr):
+		return codes.FailedPrecondition
+	default:
+		return codes.Internal
+	}
+}
@@ -0,0 +1,26 @@
+package queue
+
+import "context"
+
+// Message is a message consumed from a Broker. Reply names the subject to which
+// a response should be published, and is empty if the publisher asked for
+// none.
+type Message struct {
+	Subject string
+	Reply   string
+	Data    []byte
+}
+
+// Handler processes a message. Returning an error asks the Broker to deliver
+// the message again, if it's able to.
+type Handler func(ctx context.Context, msg Message) error
+
+// Broker is a message queue.
+type Broker interface {
+	// Subscribe passes each message published to subject to handle, one at a
+	// time, until ctx is done or the subscription fails.
+	Subscribe(ctx context.Context, subject string, handle Handler) error
+
+	// Publish sends data to the subscribers of subject.
+	Publish(ctx context.Context, subject string, data []byte) error
+}
@@ -0,0 +1,252 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/angusgmorrison/hexagonal/internal/primitive"
+	"github.com/angusgmorrison/hexagonal/internal/service/classservice"
+	"github.com/go-playground/validator/v10"
+)
+
+// DefaultReplySubject is the subject to which results are published when a
+// command doesn't name its own reply subject.
+const DefaultReplySubject = "enrollments.results"
+
+// enrollCommand asks for students to be enrolled in a class. ID identifies the
+// command across redeliveries, and must be unique to each command.
+type enrollCommand struct {
+	ID          string           `json:"id"`
+	CourseCode  string           `json:"course_code"`
+	SectionCode string           `json:"section_code"`
+	VoucherCode string           `json:"voucher_code"`
+	Students    []commandStudent `json:"students"`
+}
+
+type commandStudent struct {
+	Name      string                 `json:"name"`
+	Birthdate primitive.Birthdate    `json:"birthdate"`
+	Email     primitive.EmailAddress `json:"email"`
+}
+
+func (cmd enrollCommand) toDomain() classservice.EnrollmentRequest {
+	students := make(classservice.Students, 0, len(cmd.Students))
+	for _, s := range cmd.Students {
+		students = append(students, classservice.Student{
+			Name:      s.Name,
+			Birthdate: s.Birthdate,
+			Email:     s.Email,
+		})
+	}
+
+	return classservice.EnrollmentRequest{
+		RequestID:   cmd.ID,
+		CourseCode:  cmd.CourseCode,
+		SectionCode: cmd.SectionCode,
+		VoucherCode: cmd.VoucherCode,
+		Students:    students,
+	}
+}
+
+// Option configures optional behaviour of the Consumer returned by
+// NewConsumer.
+type Option func(*Consumer)
+
+// WithReplySubject sets the subject to which results are published when a
+// command doesn't name its own reply subject. The default is
+// DefaultReplySubject.
+func WithReplySubject(subject string) Option {
+	return func(c *Consumer) {
+		c.replySubject = subject
+	}
+}
+
+// Consumer enrolls students in response to the commands published to a
+// subject, and publishes the result of each.
+type Consumer struct {
+	logger       *log.Logger
+	classService classservice.Interface
+	broker       Broker
+	results      ResultStore
+	subject      string
+	replySubject string
+}
+
+// NewConsumer returns a Consumer of the enrollment commands published to
+// subject.
+func NewConsumer(
+	logger *log.Logger,
+	classService classservice.Interface,
+	broker Broker,
+	results ResultStore,
+	subject string,
+	opts ...Option,
+) *Consumer {
+	c := &Consumer{
+		logger:       logger,
+		classService: classService,
+		broker:       broker,
+		results:      results,
+		subject:      subject,
+		replySubject: DefaultReplySubject,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Run consumes commands until ctx is done or the subscription fails.
+func (c *Consumer) Run(ctx context.Context) error {
+	c.logger.Printf("Consuming enrollment commands from %s", c.subject)
+
+	if err := c.broker.Subscribe(ctx, c.subject, c.handle); err != nil {
+		return fmt.Errorf("Run: %w", err)
+	}
+
+	return nil
+}
+
+// handle enrolls the students named by a command and publishes the result.
+// Commands that have already been processed are answered from the result
+// store. Malformed commands can never succeed, so they're logged and dropped,
+// while unexpected failures are returned so that the command is delivered
+// again.
+func (c *Consumer) handle(ctx context.Context, msg Message) error {
+	var cmd enrollCommand
+	if err := json.Unmarshal(msg.Data, &cmd); err != nil {
+		c.logger.Printf("Dropping malformed enrollment command: %s", err)
+
+		return nil
+	}
+
+	if cmd.ID == "" {
+		c.logger.Printf("Dropping enrollment command without an ID")
+
+		return nil
+	}
+
+	result, ok, err := c.results.LoadResult(ctx, cmd.ID)
+	if err != nil {
+		return fmt.Errorf("load result of command %q: %w", cmd.ID, err)
+	}
+
+	if !ok {
+		result, err = c.enroll(ctx, cmd)
+		if err != nil {
+			return err
+		}
+
+		if err := c.results.SaveResult(ctx, result); err != nil {
+			return fmt.Errorf("save result of command %q: %w", cmd.ID, err)
+		}
+	}
+
+	return c.publish(ctx, msg.Reply, result)
+}
+
+// enroll carries out a command. Rejections by the class service are reported
+// in the result, while other errors are returned.
+//
+// The class service records the command's ID in the same transaction as the
+// enrollment, so a command redelivered after its students were enrolled but
+// before its result was saved is refused as a duplicate. It's then answered
+// with the result that was recorded.
+func (c *Consumer) enroll(ctx context.Context, cmd enrollCommand) (Result, error) {
+	result := Result{CommandID: cmd.ID, Status: ResultAccepted}
+
+	err := c.classService.Enroll(ctx, cmd.toDomain())
+
+	var duplicateErr classservice.DuplicateRequestError
+	if errors.As(err, &duplicateErr) {
+		c.logger.Printf("Enrollment command %q was already carried out", cmd.ID)
+
+		recorded, ok, err := c.results.LoadResult(ctx, cmd.ID)
+		if err != nil {
+			return Result{}, fmt.Errorf("load result of command %q: %w", cmd.ID, err)
+		}
+
+		if !ok {
+			return Result{}, fmt.Errorf("enroll command %q: %w", cmd.ID, duplicateErr)
+		}
+
+		return recorded, nil
+	}
+
+	if err != nil {
+		if !isRejection(err) {
+			return Result{}, fmt.Errorf("enroll command %q: %w", cmd.ID, err)
+		}
+
+		c.logger.Printf("Enrollment command %q rejected: %s", cmd.ID, err)
+
+		result.Status = ResultRejected
+		result.Error = err.Error()
+	}
+
+	return result, nil
+}
+
+func (c *Consumer) publish(ctx context.Context, reply string, result Result) error {
+	if reply == "" {
+		reply = c.replySubject
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("marshal result of command %q: %w", result.CommandID, err)
+	}
+
+	if err := c.broker.Publish(ctx, reply, data); err != nil {
+		return fmt.Errorf("publish result of command %q: %w", result.CommandID, err)
+	}
+
+	return nil
+}
+
+// isRejection reports whether err is a refusal by the class service to enroll
+// the students in a command, which would be repeated if the command were
+// retried.
+func isRejection(err error) bool {
+	var (
+		validationErrs          validator.ValidationErrors
+		courseNotFoundErr       classservice.CourseNotFoundError
+		sectionNotFoundErr      classservice.SectionNotFoundError
+		unregisteredErr         classservice.UnregisteredStudentsError
+		alreadyEnrolledErr      classservice.AlreadyEnrolledError
+		oversubscribedErr       classservice.OversubscribedError
+		courseLoadErr           classservice.CourseLoadExceededError
+		ruleViolationErr        classservice.RuleViolationError
+		invalidRuleErr          classservice.InvalidRuleError
+		prerequisitesErr        classservice.PrerequisitesNotMetError
+		cancelledErr            classservice.CourseCancelledError
+		invalidVoucherErr       classservice.InvalidVoucherError
+		voucherNotFoundErr      classservice.VoucherNotFoundError
+		voucherExhaustedErr     classservice.VoucherExhaustedError
+		voucherNotApplicableErr classservice.VoucherNotApplicableError
+		voucherRedeemedErr      classservice.VoucherAlreadyRedeemedError
+	)
+
+	return errors.As(err, &validationErrs) ||
+		errors.As(err, &courseNotFoundErr) ||
+		errors.As(err, &sectionNotFoundErr) ||
+		errors.As(err, &unregisteredErr) ||
+		errors.As(err, &alreadyEnrolledErr) ||
+		errors.As(err, &oversubscribedErr) ||
+		errors.As(err, &courseLoadErr) ||
+		errors.As(err, &ruleViolationErr) ||
+		errors.As(err, &invalidRuleErr) ||
+		errors.As(err, &prerequisitesErr) ||
+		errors.As(err, &cancelledErr) ||
+		errors.As(err, &invalidVoucherErr) ||
+		errors.As(err, &voucherNotFoundErr) ||
+		errors.As(err, &voucherExhaustedErr) ||
+		errors.As(err, &voucherNotApplicableErr) ||
+		errors.As(err, &voucherRedeemedErr) ||
+		errors.Is(err, classservice.ErrNoBilling)
+}
@@ -0,0 +1,263 @@
+//go:build unit
+
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/angusgmorrison/hexagonal/internal/primitive"
+	"github.com/angusgmorrison/hexagonal/internal/service/classservice"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// stubBroker delivers the messages it was created with to each subscriber, and
+// records the messages published to it.
+type stubBroker struct {
+	messages  []Message
+	published []Message
+}
+
+func (sb *stubBroker) Subscribe(ctx context.Context, subject string, handle Handler) error {
+	for _, msg := range sb.messages {
+		if err := handle(ctx, msg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (sb *stubBroker) Publish(_ context.Context, subject string, data []byte) error {
+	sb.published = append(sb.published, Message{Subject: subject, Data: data})
+
+	return nil
+}
+
+const enrollCommandJSON = `{
+	"id": "cmd-1",
+	"course_code": "SICP",
+	"section_code": "A",
+	"students": [{"name": "Angus Morrison", "birthdate": "1990-03-04", "email": "angus@example.com"}]
+}`
+
+func expectedEnrollmentRequest(t *testing.T) classservice.EnrollmentRequest {
+	t.Helper()
+
+	return classservice.EnrollmentRequest{
+		RequestID:   "cmd-1",
+		CourseCode:  "SICP",
+		SectionCode: "A",
+		Students: classservice.Students{
+			{
+				Name:      "Angus Morrison",
+				Birthdate: primitive.Birthdate(time.Date(1990, time.March, 4, 0, 0, 0, 0, time.UTC)),
+				Email:     "angus@example.com",
+			},
+		},
+	}
+}
+
+func TestConsumer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("enrolls students and publishes the accepted result", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger       = log.New(os.Stdout, "TestConsumer ", log.LstdFlags)
+			classService = classservice.NewMockInterface(t)
+			results      = NewMemoryResultStore()
+			broker       = &stubBroker{messages: []Message{{Data: []byte(enrollCommandJSON)}}}
+			consumer     = NewConsumer(logger, classService, broker, results, "enrollments.commands")
+		)
+
+		classService.On("Enroll", mock.Anything, expectedEnrollmentRequest(t)).Return(nil)
+
+		require.NoError(t, consumer.Run(context.Background()))
+
+		require.Len(t, broker.published, 1)
+		require.Equal(t, DefaultReplySubject, broker.published[0].Subject)
+		require.JSONEq(t, `{"id": "cmd-1", "status": "accepted"}`, string(broker.published[0].Data))
+
+		result, ok, err := results.LoadResult(context.Background(), "cmd-1")
+		require.NoError(t, err)
+		require.True(t, ok, "result not stored")
+		require.Equal(t, ResultAccepted, result.Status)
+	})
+
+	t.Run("publishes the rejected result to the message's reply subject", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger       = log.New(os.Stdout, "TestConsumer ", log.LstdFlags)
+			classService = classservice.NewMockInterface(t)
+			broker       = &stubBroker{messages: []Message{{Reply: "inbox", Data: []byte(enrollCommandJSON)}}}
+			consumer     = NewConsumer(logger, classService, broker, NewMemoryResultStore(), "enrollments.commands")
+			rejection    = classservice.CourseNotFoundError{CourseCode: "SICP"}
+		)
+
+		classService.On("Enroll", mock.Anything, mock.Anything).Return(rejection)
+
+		require.NoError(t, consumer.Run(context.Background()))
+
+		require.Len(t, broker.published, 1)
+		require.Equal(t, "inbox", broker.published[0].Subject)
+
+		var result Result
+		require.NoError(t, json.Unmarshal(broker.published[0].Data, &result))
+		require.Equal(t, Result{CommandID: "cmd-1", Status: ResultRejected, Error: rejection.Error()}, result)
+	})
+
+	t.Run("answers redelivered commands without enrolling again", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger       = log.New(os.Stdout, "TestConsumer ", log.LstdFlags)
+			classService = classservice.NewMockInterface(t)
+			msg          = Message{Data: []byte(enrollCommandJSON)}
+			broker       = &stubBroker{messages: []Message{msg, msg}}
+			consumer     = NewConsumer(
+				logger,
+				classService,
+				broker,
+				NewMemoryResultStore(),
+				"enrollments.commands",
+				WithReplySubject("results"),
+			)
+		)
+
+		classService.On("Enroll", mock.Anything, mock.Anything).Return(nil).Once()
+
+		require.NoError(t, consumer.Run(context.Background()))
+
+		require.Len(t, broker.published, 2)
+
+		for _, published := range broker.published {
+			require.Equal(t, "results", published.Subject)
+			require.JSONEq(t, `{"id": "cmd-1", "status": "accepted"}`, string(published.Data))
+		}
+	})
+
+	t.Run("answers commands that were carried out before their result was saved", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger       = log.New(os.Stdout, "TestConsumer ", log.LstdFlags)
+			classService = classservice.NewMockInterface(t)
+			results      = NewMemoryResultStore()
+			broker       = &stubBroker{messages: []Message{{Data: []byte(enrollCommandJSON)}}}
+			consumer     = NewConsumer(logger, classService, broker, results, "enrollments.commands")
+		)
+
+		// An earlier delivery enrolled the students, and the class service
+		// recorded the command with them, but the process stopped before the
+		// consumer loaded the result.
+		classService.On("Enroll", mock.Anything, mock.Anything).
+			Return(func(ctx context.Context, _ classservice.EnrollmentRequest) error {
+				require.NoError(t, results.SaveResult(ctx, Result{CommandID: "cmd-1", Status: ResultAccepted}))
+
+				return classservice.DuplicateRequestError{RequestID: "cmd-1"}
+			})
+
+		require.NoError(t, consumer.Run(context.Background()))
+
+		require.Len(t, broker.published, 1)
+		require.JSONEq(t, `{"id": "cmd-1", "status": "accepted"}`, string(broker.published[0].Data))
+	})
+
+	t.Run("asks for redelivery when enrollment fails unexpectedly", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger       = log.New(os.Stdout, "TestConsumer ", log.LstdFlags)
+			classService = classservice.NewMockInterface(t)
+			results      = NewMemoryResultStore()
+			broker       = &stubBroker{messages: []Message{{Data: []byte(enrollCommandJSON)}}}
+			consumer     = NewConsumer(logger, classService, broker, results, "enrollments.commands")
+			dbErr        = errors.New("connection reset")
+		)
+
+		classService.On("Enroll", mock.Anything, mock.Anything).Return(dbErr)
+
+		err := consumer.Run(context.Background())
+		require.ErrorIs(t, err, dbErr)
+		require.Empty(t, broker.published)
+
+		_, ok, err := results.LoadResult(context.Background(), "cmd-1")
+		require.NoError(t, err)
+		require.False(t, ok, "result of failed command stored")
+	})
+
+	t.Run("drops malformed commands", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger   = log.New(os.Stdout, "TestConsumer ", log.LstdFlags)
+			broker   = &stubBroker{messages: []Message{{Data: []byte(`{"course_code": "SICP"}`)}, {Data: []byte(`[`)}}}
+			consumer = NewConsumer(
+				logger,
+				classservice.NewMockInterface(t),
+				broker,
+				NewMemoryResultStore(),
+				"enrollments.commands",
+			)
+		)
+
+		require.NoError(t, consumer.Run(context.Background()))
+		require.Empty(t, broker.published)
+	})
+}
+
+func TestIsRejection(t *testing.T) {
+	t.Parallel()
+
+	validationErr := validator.New().Struct(classservice.EnrollmentRequest{})
+	require.Error(t, validationErr, "validate empty EnrollmentRequest")
+
+	// Every error that Enroll documents, besides DuplicateRequestError, would
+	// be returned again if the command were retried.
+	testCases := []struct {
+		err  error
+		want bool
+	}{
+		{err: validationErr, want: true},
+		{err: classservice.CourseNotFoundError{}, want: true},
+		{err: classservice.SectionNotFoundError{}, want: true},
+		{err: classservice.UnregisteredStudentsError{}, want: true},
+		{err: classservice.AlreadyEnrolledError{}, want: true},
+		{err: classservice.OversubscribedError{}, want: true},
+		{err: classservice.CourseLoadExceededError{}, want: true},
+		{err: classservice.RuleViolationError{}, want: true},
+		{err: classservice.InvalidRuleError{}, want: true},
+		{err: classservice.PrerequisitesNotMetError{}, want: true},
+		{err: classservice.CourseCancelledError{}, want: true},
+		{err: classservice.InvalidVoucherError{}, want: true},
+		{err: classservice.VoucherNotFoundError{}, want: true},
+		{err: classservice.VoucherExhaustedError{}, want: true},
+		{err: classservice.VoucherNotApplicableError{}, want: true},
+		{err: classservice.VoucherAlreadyRedeemedError{}, want: true},
+		{err: classservice.ErrNoBilling, want: true},
+		{err: fmt.Errorf("Enroll: %w", classservice.UnregisteredStudentsError{}), want: true},
+		{err: errors.New("connection reset"), want: false},
+		{err: context.DeadlineExceeded, want: false},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(fmt.Sprintf("%T", tc.err), func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tc.want, isRejection(tc.err))
+		})
+	}
+}
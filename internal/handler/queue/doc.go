@@ -0,0 +1,10 @@
+// Package queue is a driving adapter that enrolls students in response to
+// commands consumed from a message queue, so that upstream systems can submit
+// enrollments asynchronously. Queues are reached through the Broker
+// abstraction, which has a file-backed implementation for development and
+// single-host deployments, and a NATS implementation.
+//
+// Commands are delivered at least once, so each carries an ID, and the result
+// of every command is recorded in a ResultStore. A redelivered command is
+// answered with its recorded result instead of being enrolled again.
+package queue
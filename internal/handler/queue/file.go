@@ -0,0 +1,286 @@
+package queue
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// subjectPattern matches the subjects that FileBroker can store. Subjects name
+// files, so they mustn't contain path separators.
+var subjectPattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// fileRecord is a message as stored by FileBroker.
+type fileRecord struct {
+	Reply string          `json:"reply,omitempty"`
+	Data  json.RawMessage `json:"data"`
+}
+
+// FileBroker is a Broker that stores the messages published to each subject in
+// a file of the same name, with the extension ".jsonl", in its directory. Each
+// line of the file is a JSON object with the message as its "data" and,
+// optionally, a "reply" subject, so other processes on the same host can
+// publish messages by appending lines to the file. Message data must be JSON.
+//
+// Subscribers record the offset of the next message to be handled in a file
+// with the extension ".offset", which is advanced only once a message has been
+// handled successfully. Messages are therefore delivered at least once: a
+// message whose handler fails is delivered again after the poll interval, and
+// a message being handled when the process stops is delivered again when it
+// restarts. Only one subscriber should consume a subject at a time.
+//
+// A message whose handler fails on every one of maxAttempts consecutive
+// deliveries is moved to the subject's dead-letter file, with the extension
+// ".dead.jsonl", so that it doesn't hold up the messages behind it. Lines of
+// the dead-letter file can be appended to the subject's file to retry them.
+// Failed deliveries are counted in memory, so the count starts again when the
+// process restarts.
+type FileBroker struct {
+	logger       *log.Logger
+	dir          string
+	pollInterval time.Duration
+	maxAttempts  int
+
+	// mu serializes appends, so that concurrent publishers in this process
+	// can't interleave their lines.
+	mu sync.Mutex
+}
+
+var _ Broker = (*FileBroker)(nil)
+
+// NewFileBroker returns a FileBroker that stores messages in dir, creating it
+// if necessary, that checks for new messages every pollInterval, and that
+// delivers a failing message at most maxAttempts times.
+func NewFileBroker(
+	logger *log.Logger,
+	dir string,
+	pollInterval time.Duration,
+	maxAttempts int,
+) (*FileBroker, error) {
+	if maxAttempts < 1 {
+		return nil, fmt.Errorf("maxAttempts must be at least 1, got %d", maxAttempts)
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create queue directory: %w", err)
+	}
+
+	return &FileBroker{
+		logger:       logger,
+		dir:          dir,
+		pollInterval: pollInterval,
+		maxAttempts:  maxAttempts,
+	}, nil
+}
+
+// Subscribe passes each message in the subject's file to handle, starting from
+// the recorded offset, and polls for new messages until ctx is done. Lines that
+// aren't valid messages are logged and skipped.
+func (fb *FileBroker) Subscribe(ctx context.Context, subject string, handle Handler) error {
+	if !subjectPattern.MatchString(subject) {
+		return fmt.Errorf("invalid subject %q", subject)
+	}
+
+	offset, err := fb.readOffset(subject)
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(fb.pollInterval)
+	defer ticker.Stop()
+
+	// failures counts the failed deliveries of the message at offset.
+	failures := 0
+
+	for {
+		offset, err = fb.consume(ctx, subject, offset, &failures, handle)
+		if err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// consume handles the complete lines of the subject's file that follow offset,
+// and returns the offset of the first line left unhandled. It stops early if a
+// handler fails, so that the message is delivered again on the next poll,
+// unless the message has now failed maxAttempts times, in which case it's
+// dead-lettered and consumption continues. failures counts the failed
+// deliveries of the message at offset.
+func (fb *FileBroker) consume(
+	ctx context.Context,
+	subject string,
+	offset int64,
+	failures *int,
+	handle Handler,
+) (int64, error) {
+	f, err := os.Open(fb.messagesPath(subject))
+	if errors.Is(err, os.ErrNotExist) {
+		return offset, nil
+	}
+
+	if err != nil {
+		return offset, fmt.Errorf("open %s messages: %w", subject, err)
+	}
+	defer f.Close()
+
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return offset, fmt.Errorf("seek %s messages: %w", subject, err)
+	}
+
+	reader := bufio.NewReader(f)
+
+	for ctx.Err() == nil {
+		line, err := reader.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			// The last line is incomplete, or being written, until it ends in
+			// a newline.
+			return offset, nil
+		}
+
+		if err != nil {
+			return offset, fmt.Errorf("read %s messages: %w", subject, err)
+		}
+
+		var record fileRecord
+		if trimmed := bytes.TrimSpace(line); len(trimmed) > 0 {
+			if err := json.Unmarshal(trimmed, &record); err != nil || len(record.Data) == 0 {
+				fb.logger.Printf("Skipping malformed %s message at offset %d", subject, offset)
+			} else if err := handle(ctx, Message{Subject: subject, Reply: record.Reply, Data: record.Data}); err != nil {
+				*failures++
+				if *failures < fb.maxAttempts {
+					fb.logger.Printf("Handling %s message at offset %d failed, will retry: %v", subject, offset, err)
+
+					return offset, nil
+				}
+
+				fb.logger.Printf("Handling %s message at offset %d failed %d times, dead-lettering: %v",
+					subject, offset, *failures, err)
+
+				if err := fb.publish(deadLetterSubject(subject), record.Reply, record.Data); err != nil {
+					return offset, fmt.Errorf("dead-letter %s message at offset %d: %w", subject, offset, err)
+				}
+			}
+		}
+
+		offset += int64(len(line))
+		*failures = 0
+
+		if err := fb.writeOffset(subject, offset); err != nil {
+			return offset, err
+		}
+	}
+
+	return offset, nil
+}
+
+// Publish appends data to the subject's file.
+func (fb *FileBroker) Publish(_ context.Context, subject string, data []byte) error {
+	return fb.publish(subject, "", data)
+}
+
+// PublishRequest appends data to the subject's file with a subject to which
+// the subscriber should reply.
+func (fb *FileBroker) PublishRequest(_ context.Context, subject, reply string, data []byte) error {
+	return fb.publish(subject, reply, data)
+}
+
+func (fb *FileBroker) publish(subject, reply string, data []byte) error {
+	if !subjectPattern.MatchString(subject) {
+		return fmt.Errorf("invalid subject %q", subject)
+	}
+
+	if !json.Valid(data) {
+		return fmt.Errorf("publish to %s: message isn't JSON", subject)
+	}
+
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, data); err != nil {
+		return fmt.Errorf("publish to %s: %w", subject, err)
+	}
+
+	line, err := json.Marshal(fileRecord{Reply: reply, Data: buf.Bytes()})
+	if err != nil {
+		return fmt.Errorf("publish to %s: %w", subject, err)
+	}
+
+	fb.mu.Lock()
+	defer fb.mu.Unlock()
+
+	f, err := os.OpenFile(fb.messagesPath(subject), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("open %s messages: %w", subject, err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("publish to %s: %w", subject, err)
+	}
+
+	return nil
+}
+
+func (fb *FileBroker) readOffset(subject string) (int64, error) {
+	raw, err := os.ReadFile(fb.offsetPath(subject))
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+
+	if err != nil {
+		return 0, fmt.Errorf("read %s offset: %w", subject, err)
+	}
+
+	offset, err := strconv.ParseInt(string(bytes.TrimSpace(raw)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("parse %s offset: %w", subject, err)
+	}
+
+	return offset, nil
+}
+
+// writeOffset records the offset by replacing the offset file, so that it's
+// never left partially written.
+func (fb *FileBroker) writeOffset(subject string, offset int64) error {
+	path := fb.offsetPath(subject)
+	tmp := path + ".tmp"
+
+	if err := os.WriteFile(tmp, []byte(strconv.FormatInt(offset, 10)), 0o644); err != nil {
+		return fmt.Errorf("write %s offset: %w", subject, err)
+	}
+
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("write %s offset: %w", subject, err)
+	}
+
+	return nil
+}
+
+// deadLetterSubject returns the subject whose file holds the messages of
+// subject that couldn't be handled.
+func deadLetterSubject(subject string) string {
+	return subject + ".dead"
+}
+
+func (fb *FileBroker) messagesPath(subject string) string {
+	return filepath.Join(fb.dir, subject+".jsonl")
+}
+
+func (fb *FileBroker) offsetPath(subject string) string {
+	return filepath.Join(fb.dir, subject+".offset")
+}
@@ -0,0 +1,179 @@
+//go:build unit
+
+package queue
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileBroker(t *testing.T) {
+	t.Parallel()
+
+	const (
+		subject     = "enrollments.commands"
+		maxAttempts = 5
+	)
+
+	newBroker := func(t *testing.T, dir string) *FileBroker {
+		t.Helper()
+
+		logger := log.New(os.Stdout, "TestFileBroker ", log.LstdFlags)
+
+		broker, err := NewFileBroker(logger, dir, time.Millisecond, maxAttempts)
+		require.NoError(t, err, "create broker")
+
+		return broker
+	}
+
+	// consumeN subscribes to subject until n messages have been handled
+	// successfully, and returns them.
+	consumeN := func(t *testing.T, broker *FileBroker, n int, handle Handler) []Message {
+		t.Helper()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		var handled []Message
+
+		err := broker.Subscribe(ctx, subject, func(ctx context.Context, msg Message) error {
+			if err := handle(ctx, msg); err != nil {
+				return err
+			}
+
+			handled = append(handled, msg)
+			if len(handled) == n {
+				cancel()
+			}
+
+			return nil
+		})
+		require.NoError(t, err, "subscribe")
+		require.Len(t, handled, n, "timed out")
+
+		return handled
+	}
+
+	succeed := func(context.Context, Message) error { return nil }
+
+	t.Run("delivers published messages with their reply subjects", func(t *testing.T) {
+		t.Parallel()
+
+		broker := newBroker(t, t.TempDir())
+
+		require.NoError(t, broker.Publish(context.Background(), subject, []byte(`{"id": "1"}`)))
+		require.NoError(t, broker.PublishRequest(context.Background(), subject, "inbox", []byte(`{"id": "2"}`)))
+
+		handled := consumeN(t, broker, 2, succeed)
+
+		require.Equal(t, Message{Subject: subject, Data: []byte(`{"id":"1"}`)}, handled[0])
+		require.Equal(t, Message{Subject: subject, Reply: "inbox", Data: []byte(`{"id":"2"}`)}, handled[1])
+	})
+
+	t.Run("resumes from the recorded offset", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		broker := newBroker(t, dir)
+
+		require.NoError(t, broker.Publish(context.Background(), subject, []byte(`{"id": "1"}`)))
+		consumeN(t, broker, 1, succeed)
+
+		require.NoError(t, broker.Publish(context.Background(), subject, []byte(`{"id": "2"}`)))
+
+		handled := consumeN(t, newBroker(t, dir), 1, succeed)
+		require.JSONEq(t, `{"id": "2"}`, string(handled[0].Data))
+	})
+
+	t.Run("redelivers messages whose handler fails", func(t *testing.T) {
+		t.Parallel()
+
+		broker := newBroker(t, t.TempDir())
+
+		require.NoError(t, broker.Publish(context.Background(), subject, []byte(`{"id": "1"}`)))
+
+		attempts := 0
+		handled := consumeN(t, broker, 1, func(context.Context, Message) error {
+			attempts++
+			if attempts < 3 {
+				return errors.New("try again")
+			}
+
+			return nil
+		})
+
+		require.Equal(t, 3, attempts)
+		require.JSONEq(t, `{"id": "1"}`, string(handled[0].Data))
+	})
+
+	t.Run("dead-letters messages that fail on every attempt", func(t *testing.T) {
+		t.Parallel()
+
+		broker := newBroker(t, t.TempDir())
+
+		require.NoError(t, broker.PublishRequest(context.Background(), subject, "inbox", []byte(`{"id": "1"}`)))
+		require.NoError(t, broker.Publish(context.Background(), subject, []byte(`{"id": "2"}`)))
+
+		attempts := 0
+		handled := consumeN(t, broker, 1, func(_ context.Context, msg Message) error {
+			if string(msg.Data) == `{"id":"1"}` {
+				attempts++
+
+				return errors.New("poison")
+			}
+
+			return nil
+		})
+
+		require.Equal(t, maxAttempts, attempts)
+		require.JSONEq(t, `{"id": "2"}`, string(handled[0].Data))
+
+		dead, err := os.ReadFile(broker.messagesPath(deadLetterSubject(subject)))
+		require.NoError(t, err, "read dead letters")
+		require.JSONEq(t, `{"reply": "inbox", "data": {"id": "1"}}`, string(dead))
+	})
+
+	t.Run("refuses fewer than one attempt", func(t *testing.T) {
+		t.Parallel()
+
+		logger := log.New(os.Stdout, "TestFileBroker ", log.LstdFlags)
+
+		_, err := NewFileBroker(logger, t.TempDir(), time.Millisecond, 0)
+		require.Error(t, err)
+	})
+
+	t.Run("skips malformed lines", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		broker := newBroker(t, dir)
+
+		require.NoError(t, os.WriteFile(broker.messagesPath(subject), []byte("not json\n{\"reply\": \"inbox\"}\n"), 0o644))
+		require.NoError(t, broker.Publish(context.Background(), subject, []byte(`{"id": "1"}`)))
+
+		handled := consumeN(t, broker, 1, succeed)
+		require.JSONEq(t, `{"id": "1"}`, string(handled[0].Data))
+	})
+
+	t.Run("refuses messages that aren't JSON", func(t *testing.T) {
+		t.Parallel()
+
+		broker := newBroker(t, t.TempDir())
+
+		require.Error(t, broker.Publish(context.Background(), subject, []byte("hello")))
+	})
+
+	t.Run("refuses subjects that aren't file names", func(t *testing.T) {
+		t.Parallel()
+
+		broker := newBroker(t, t.TempDir())
+
+		require.Error(t, broker.Publish(context.Background(), "../escape", []byte(`{}`)))
+	})
+}
@@ -0,0 +1,67 @@
+package queue
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/nats-io/nats.go"
+)
+
+// NATSBroker is a Broker backed by a connection to a NATS server.
+//
+// Subscribers join a queue group, so that each message is handled by only one
+// of the processes subscribed to its subject. Core NATS doesn't redeliver
+// messages, so a message whose handler fails is logged and goes unanswered.
+// Publishers that need at-least-once delivery should send commands as requests
+// and retry those that time out.
+type NATSBroker struct {
+	logger     *log.Logger
+	conn       *nats.Conn
+	queueGroup string
+}
+
+var _ Broker = (*NATSBroker)(nil)
+
+// NewNATSBroker returns a NATSBroker that communicates over conn and whose
+// subscribers join queueGroup.
+func NewNATSBroker(logger *log.Logger, conn *nats.Conn, queueGroup string) *NATSBroker {
+	return &NATSBroker{logger: logger, conn: conn, queueGroup: queueGroup}
+}
+
+// Subscribe passes each message published to subject to handle until ctx is
+// done or the connection is closed.
+func (nb *NATSBroker) Subscribe(ctx context.Context, subject string, handle Handler) error {
+	sub, err := nb.conn.QueueSubscribeSync(subject, nb.queueGroup)
+	if err != nil {
+		return fmt.Errorf("subscribe to %s: %w", subject, err)
+	}
+
+	defer func() { _ = sub.Unsubscribe() }()
+
+	for {
+		msg, err := sub.NextMsgWithContext(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("receive from %s: %w", subject, err)
+		}
+
+		// A failed message can't be redelivered, so the publisher must retry
+		// if it needs an answer.
+		if err := handle(ctx, Message{Subject: msg.Subject, Reply: msg.Reply, Data: msg.Data}); err != nil {
+			nb.logger.Printf("Handling %s message failed, dropping it: %v", subject, err)
+		}
+	}
+}
+
+// Publish sends data to the subscribers of subject.
+func (nb *NATSBroker) Publish(_ context.Context, subject string, data []byte) error {
+	if err := nb.conn.Publish(subject, data); err != nil {
+		return fmt.Errorf("publish to %s: %w", subject, err)
+	}
+
+	return nil
+}
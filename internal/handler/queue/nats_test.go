@@ -0,0 +1,359 @@
+//go:build unit
+
+package queue
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/angusgmorrison/hexagonal/internal/service/classservice"
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNATSBroker(t *testing.T) {
+	t.Parallel()
+
+	t.Run("consumer answers requests", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger       = log.New(os.Stdout, "TestNATSBroker ", log.LstdFlags)
+			classService = classservice.NewMockInterface(t)
+			url          = startNATSServer(t)
+		)
+
+		consumerConn, err := nats.Connect(url)
+		require.NoError(t, err, "connect consumer")
+		t.Cleanup(consumerConn.Close)
+
+		clientConn, err := nats.Connect(url)
+		require.NoError(t, err, "connect client")
+		t.Cleanup(clientConn.Close)
+
+		classService.On("Enroll", mock.Anything, expectedEnrollmentRequest(t)).Return(nil).Once()
+
+		consumer := NewConsumer(
+			logger,
+			classService,
+			NewNATSBroker(logger, consumerConn, "hexagonal"),
+			NewMemoryResultStore(),
+			"enrollments.commands",
+		)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error)
+
+		go func() { done <- consumer.Run(ctx) }()
+
+		// The subscription is registered asynchronously, so early requests may
+		// go unanswered, as they would if the consumer were restarting.
+		var reply *nats.Msg
+
+		require.Eventually(t, func() bool {
+			reply, err = clientConn.Request("enrollments.commands", []byte(enrollCommandJSON), 100*time.Millisecond)
+
+			return err == nil
+		}, 5*time.Second, 10*time.Millisecond, "no reply")
+
+		require.JSONEq(t, `{"id": "cmd-1", "status": "accepted"}`, string(reply.Data))
+
+		// Retrying the command is answered without enrolling again.
+		reply, err = clientConn.Request("enrollments.commands", []byte(enrollCommandJSON), time.Second)
+		require.NoError(t, err, "retry request")
+		require.JSONEq(t, `{"id": "cmd-1", "status": "accepted"}`, string(reply.Data))
+
+		cancel()
+		require.NoError(t, <-done, "run consumer")
+	})
+
+	t.Run("logs and drops messages whose handler fails", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logs   bytes.Buffer
+			logger = log.New(&logs, "", 0)
+			url    = startNATSServer(t)
+		)
+
+		conn, err := nats.Connect(url)
+		require.NoError(t, err, "connect")
+		t.Cleanup(conn.Close)
+
+		broker := NewNATSBroker(logger, conn, "hexagonal")
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error)
+
+		var handled []string
+
+		go func() {
+			done <- broker.Subscribe(ctx, "enrollments.commands", func(_ context.Context, msg Message) error {
+				handled = append(handled, string(msg.Data))
+				if len(handled) == 1 {
+					return errors.New("connection reset")
+				}
+
+				cancel()
+
+				return nil
+			})
+		}()
+
+		// The subscription is registered asynchronously, so publish until the
+		// second message is handled.
+		timeout := time.After(5 * time.Second)
+
+	publish:
+		for {
+			require.NoError(t, broker.Publish(context.Background(), "enrollments.commands", []byte(`{"id": "1"}`)))
+
+			select {
+			case err := <-done:
+				require.NoError(t, err, "subscribe")
+
+				break publish
+			case <-timeout:
+				cancel()
+				t.Fatal("message not handled twice")
+			case <-time.After(10 * time.Millisecond):
+			}
+		}
+
+		require.Len(t, handled, 2, "failed message was redelivered or not dropped")
+		require.Contains(t, logs.String(), "connection reset")
+	})
+
+	t.Run("publishes to subscribers", func(t *testing.T) {
+		t.Parallel()
+
+		url := startNATSServer(t)
+
+		conn, err := nats.Connect(url)
+		require.NoError(t, err, "connect")
+		t.Cleanup(conn.Close)
+
+		sub, err := conn.SubscribeSync("enrollments.results")
+		require.NoError(t, err, "subscribe")
+		require.NoError(t, conn.Flush(), "flush")
+
+		broker := NewNATSBroker(log.New(os.Stdout, "TestNATSBroker ", log.LstdFlags), conn, "hexagonal")
+		require.NoError(t, broker.Publish(context.Background(), "enrollments.results", []byte(`{"id": "1"}`)))
+
+		msg, err := sub.NextMsg(5 * time.Second)
+		require.NoError(t, err, "receive")
+		require.Equal(t, `{"id": "1"}`, string(msg.Data))
+	})
+}
+
+// natsTestServer implements as much of the NATS client protocol as the broker
+// needs: publishing, plain and queue subscriptions, and wildcard subjects. Each
+// queue group receives a message once, by whichever member subscribed first.
+type natsTestServer struct {
+	mu      sync.Mutex
+	nextID  int
+	clients map[int]*natsTestClient
+}
+
+type natsTestClient struct {
+	id   int
+	conn net.Conn
+
+	// mu guards writes to conn and subs.
+	mu   sync.Mutex
+	subs map[string]natsTestSub
+}
+
+type natsTestSub struct {
+	sid     string
+	subject string
+	queue   string
+}
+
+// startNATSServer starts a natsTestServer that runs until the test completes,
+// and returns its URL.
+func startNATSServer(t *testing.T) string {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err, "listen")
+
+	srv := &natsTestServer{clients: make(map[int]*natsTestClient)}
+
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+
+			go srv.serve(conn)
+		}
+	}()
+
+	t.Cleanup(func() {
+		_ = lis.Close()
+
+		srv.mu.Lock()
+		defer srv.mu.Unlock()
+
+		for _, client := range srv.clients {
+			_ = client.conn.Close()
+		}
+	})
+
+	return "nats://" + lis.Addr().String()
+}
+
+func (srv *natsTestServer) serve(conn net.Conn) {
+	srv.mu.Lock()
+	srv.nextID++
+	client := &natsTestClient{id: srv.nextID, conn: conn, subs: make(map[string]natsTestSub)}
+	srv.clients[client.id] = client
+	srv.mu.Unlock()
+
+	defer func() {
+		srv.mu.Lock()
+		delete(srv.clients, client.id)
+		srv.mu.Unlock()
+
+		_ = conn.Close()
+	}()
+
+	client.write(`INFO {"server_id":"test","version":"2.10.0","proto":1,"max_payload":1048576,"headers":false}` + "\r\n")
+
+	reader := bufio.NewReader(conn)
+
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch strings.ToUpper(fields[0]) {
+		case "PING":
+			client.write("PONG\r\n")
+		case "SUB":
+			sub := natsTestSub{subject: fields[1], sid: fields[len(fields)-1]}
+			if len(fields) == 4 {
+				sub.queue = fields[2]
+			}
+
+			client.mu.Lock()
+			client.subs[sub.sid] = sub
+			client.mu.Unlock()
+		case "UNSUB":
+			client.mu.Lock()
+			delete(client.subs, fields[1])
+			client.mu.Unlock()
+		case "PUB":
+			var reply string
+			if len(fields) == 4 {
+				reply = fields[2]
+			}
+
+			size, err := strconv.Atoi(fields[len(fields)-1])
+			if err != nil {
+				return
+			}
+
+			payload := make([]byte, size+2)
+			if _, err := io.ReadFull(reader, payload); err != nil {
+				return
+			}
+
+			srv.route(fields[1], reply, payload[:size])
+		}
+	}
+}
+
+// route delivers a message to every matching plain subscription and to one
+// member of each matching queue group.
+func (srv *natsTestServer) route(subject, reply string, payload []byte) {
+	srv.mu.Lock()
+	clients := make([]*natsTestClient, 0, len(srv.clients))
+
+	for id := 1; id <= srv.nextID; id++ {
+		if client, ok := srv.clients[id]; ok {
+			clients = append(clients, client)
+		}
+	}
+	srv.mu.Unlock()
+
+	groups := make(map[string]bool)
+
+	for _, client := range clients {
+		client.mu.Lock()
+		subs := make([]natsTestSub, 0, len(client.subs))
+
+		for _, sub := range client.subs {
+			subs = append(subs, sub)
+		}
+		client.mu.Unlock()
+
+		for _, sub := range subs {
+			if !subjectMatches(sub.subject, subject) {
+				continue
+			}
+
+			if sub.queue != "" {
+				if groups[sub.queue] {
+					continue
+				}
+
+				groups[sub.queue] = true
+			}
+
+			header := fmt.Sprintf("MSG %s %s %d\r\n", subject, sub.sid, len(payload))
+			if reply != "" {
+				header = fmt.Sprintf("MSG %s %s %s %d\r\n", subject, sub.sid, reply, len(payload))
+			}
+
+			client.write(header + string(payload) + "\r\n")
+		}
+	}
+}
+
+func (client *natsTestClient) write(s string) {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+
+	_, _ = io.WriteString(client.conn, s)
+}
+
+// subjectMatches reports whether subject matches pattern, in which "*" matches
+// any one token and a final ">" matches one or more.
+func subjectMatches(pattern, subject string) bool {
+	patternTokens := strings.Split(pattern, ".")
+	subjectTokens := strings.Split(subject, ".")
+
+	for i, token := range patternTokens {
+		if token == ">" {
+			return len(subjectTokens) > i
+		}
+
+		if i >= len(subjectTokens) || (token != "*" && token != subjectTokens[i]) {
+			return false
+		}
+	}
+
+	return len(patternTokens) == len(subjectTokens)
+}
@@ -0,0 +1,76 @@
+package queue
+
+import (
+	"context"
+	"sync"
+)
+
+// ResultStatus describes the outcome of an enrollment command.
+type ResultStatus string
+
+const (
+	// ResultAccepted commands enrolled their students, although enrollments
+	// may await approval or payment.
+	ResultAccepted ResultStatus = "accepted"
+
+	// ResultRejected commands were refused by the class service, and won't
+	// succeed if retried unchanged.
+	ResultRejected ResultStatus = "rejected"
+)
+
+// Result is the outcome of an enrollment command, as published to the reply
+// subject. Error explains why a rejected command was refused.
+type Result struct {
+	CommandID string       `json:"id"`
+	Status    ResultStatus `json:"status"`
+	Error     string       `json:"error,omitempty"`
+}
+
+// ResultStore records the results of enrollment commands by command ID, so
+// that redelivered commands aren't processed twice.
+type ResultStore interface {
+	// LoadResult returns the result of the command with the given ID, and
+	// false if no result has been recorded.
+	LoadResult(ctx context.Context, commandID string) (Result, bool, error)
+
+	// SaveResult records the result of a command. If a result has already
+	// been recorded for the command, it's kept.
+	SaveResult(ctx context.Context, result Result) error
+}
+
+// MemoryResultStore is a ResultStore that holds results in memory. Results are
+// lost when the process stops, so redeliveries after a restart are processed
+// again.
+type MemoryResultStore struct {
+	mu      sync.Mutex
+	results map[string]Result
+}
+
+var _ ResultStore = (*MemoryResultStore)(nil)
+
+// NewMemoryResultStore returns an empty MemoryResultStore.
+func NewMemoryResultStore() *MemoryResultStore {
+	return &MemoryResultStore{results: make(map[string]Result)}
+}
+
+// LoadResult returns the result of the command with the given ID.
+func (mrs *MemoryResultStore) LoadResult(_ context.Context, commandID string) (Result, bool, error) {
+	mrs.mu.Lock()
+	defer mrs.mu.Unlock()
+
+	result, ok := mrs.results[commandID]
+
+	return result, ok, nil
+}
+
+// SaveResult records the result of a command, unless one is already recorded.
+func (mrs *MemoryResultStore) SaveResult(_ context.Context, result Result) error {
+	mrs.mu.Lock()
+	defer mrs.mu.Unlock()
+
+	if _, ok := mrs.results[result.CommandID]; !ok {
+		mrs.results[result.CommandID] = result
+	}
+
+	return nil
+}
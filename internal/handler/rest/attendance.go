@@ -0,0 +1,231 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/angusgmorrison/hexagonal/internal/primitive"
+	"github.com/angusgmorrison/hexagonal/internal/service/classservice"
+	"github.com/gin-gonic/gin"
+)
+
+// sessionsRequest creates sessions either at each of the times listed in
+// StartsAt or according to Schedule. Exactly one must be given.
+type sessionsRequest struct {
+	StartsAt []time.Time      `json:"starts_at"`
+	Schedule *scheduleRequest `json:"schedule"`
+}
+
+type scheduleRequest struct {
+	First    time.Time `json:"first"`
+	Until    time.Time `json:"until"`
+	Weekdays []weekday `json:"weekdays"`
+}
+
+func (sr scheduleRequest) toDomain() classservice.Schedule {
+	schedule := classservice.Schedule{
+		First:    sr.First,
+		Until:    sr.Until,
+		Weekdays: make([]time.Weekday, 0, len(sr.Weekdays)),
+	}
+
+	for _, day := range sr.Weekdays {
+		schedule.Weekdays = append(schedule.Weekdays, time.Weekday(day))
+	}
+
+	return schedule
+}
+
+// weekday is a time.Weekday that is parsed from its case-insensitive English
+// name, e.g. "monday".
+type weekday time.Weekday
+
+func (w *weekday) UnmarshalJSON(b []byte) error {
+	var name string
+	if err := json.Unmarshal(b, &name); err != nil {
+		return fmt.Errorf("unmarshal weekday: %w", err)
+	}
+
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if strings.EqualFold(name, day.String()) {
+			*w = weekday(day)
+
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unmarshal weekday: %q is not a day of the week", name)
+}
+
+type sessionResponse struct {
+	ID       int64     `json:"id"`
+	StartsAt time.Time `json:"starts_at"`
+}
+
+func newSessionsResponse(sessions classservice.Sessions) []sessionResponse {
+	resp := make([]sessionResponse, 0, len(sessions))
+
+	for _, session := range sessions {
+		resp = append(resp, sessionResponse{ID: session.ID, StartsAt: session.StartsAt})
+	}
+
+	return resp
+}
+
+// attendanceRequest records the attendance of students at one or more
+// sessions. SessionID is taken from the path when recording the attendance of
+// a single session.
+type attendanceRequest struct {
+	Attendance []attendanceRecord `json:"attendance"`
+}
+
+type attendanceRecord struct {
+	SessionID int64                  `json:"session_id"`
+	Email     primitive.EmailAddress `json:"email"`
+	Present   bool                   `json:"present"`
+}
+
+func (ar attendanceRequest) toDomain() []classservice.AttendanceRecord {
+	records := make([]classservice.AttendanceRecord, 0, len(ar.Attendance))
+
+	for _, record := range ar.Attendance {
+		records = append(records, classservice.AttendanceRecord{
+			SessionID: record.SessionID,
+			Student:   classservice.Student{Email: record.Email},
+			Present:   record.Present,
+		})
+	}
+
+	return records
+}
+
+// handleCreateSessions adds the sessions described by the request body to the
+// course identified by the code path parameter, and responds with the sessions
+// created.
+func (s *Server) handleCreateSessions() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var sReq sessionsRequest
+
+		err := c.ShouldBind(&sReq)
+		if err == nil && (len(sReq.StartsAt) == 0) == (sReq.Schedule == nil) {
+			err = errors.New("exactly one of starts_at and schedule is required")
+		}
+
+		if err != nil {
+			s.logger.Printf("Failed to parse sessions request: %s", err)
+			c.AbortWithStatus(http.StatusBadRequest)
+
+			return
+		}
+
+		courseCode := c.Param("code")
+
+		var sessions classservice.Sessions
+		if sReq.Schedule != nil {
+			sessions, err = s.classService.ScheduleSessions(c, courseCode, sReq.Schedule.toDomain())
+		} else {
+			sessions, err = s.classService.CreateSessions(c, courseCode, sReq.StartsAt)
+		}
+
+		if err != nil {
+			s.logger.Printf("Creating sessions failed: %s", err)
+			c.AbortWithStatus(changeFailureStatus(err))
+
+			return
+		}
+
+		c.JSON(http.StatusCreated, newSessionsResponse(sessions))
+	}
+}
+
+// handleListSessions responds with the sessions of the course identified by
+// the code path parameter.
+func (s *Server) handleListSessions() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		class, err := s.classService.GetClass(c, c.Param("code"))
+		if err != nil {
+			s.logger.Printf("Listing sessions failed: %s", err)
+			c.AbortWithStatus(lookupFailureStatus(err))
+
+			return
+		}
+
+		c.JSON(http.StatusOK, newSessionsResponse(class.Sessions))
+	}
+}
+
+// handleRecordSessionAttendance records the attendance of the students in the
+// request body at the session identified by the id path parameter.
+func (s *Server) handleRecordSessionAttendance() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := s.sessionID(c)
+		if !ok {
+			return
+		}
+
+		var aReq attendanceRequest
+		if err := c.ShouldBind(&aReq); err != nil {
+			s.logger.Printf("Failed to parse attendance request: %s", err)
+			c.AbortWithStatus(http.StatusBadRequest)
+
+			return
+		}
+
+		for i := range aReq.Attendance {
+			aReq.Attendance[i].SessionID = id
+		}
+
+		s.recordAttendance(c, aReq)
+	}
+}
+
+// handleRecordAttendance records the attendance of students at any number of
+// sessions of the course identified by the code path parameter.
+func (s *Server) handleRecordAttendance() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var aReq attendanceRequest
+		if err := c.ShouldBind(&aReq); err != nil {
+			s.logger.Printf("Failed to parse attendance request: %s", err)
+			c.AbortWithStatus(http.StatusBadRequest)
+
+			return
+		}
+
+		s.recordAttendance(c, aReq)
+	}
+}
+
+// sessionID parses the id path parameter. If the parameter isn't a positive
+// integer, the request is aborted with 400 Bad Request and sessionID returns
+// false.
+func (s *Server) sessionID(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err == nil && id <= 0 {
+		err = fmt.Errorf("session ID %d is not positive", id)
+	}
+
+	if err != nil {
+		s.logger.Printf("Failed to parse session ID: %s", err)
+		c.AbortWithStatus(http.StatusBadRequest)
+
+		return 0, false
+	}
+
+	return id, true
+}
+
+func (s *Server) recordAttendance(c *gin.Context, aReq attendanceRequest) {
+	if err := s.classService.RecordAttendance(c, c.Param("code"), aReq.toDomain()); err != nil {
+		s.logger.Printf("Recording attendance failed: %s", err)
+		c.AbortWithStatus(changeFailureStatus(err))
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
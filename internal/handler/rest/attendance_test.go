@@ -0,0 +1,227 @@
+//go:build unit
+
+package rest
+
+import (
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/angusgmorrison/hexagonal/internal/service/classservice"
+	"github.com/angusgmorrison/hexagonal/internal/service/instructorservice"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleCreateSessions(t *testing.T) {
+	t.Parallel()
+
+	const endpoint = "/courses/SICP/sessions"
+
+	var (
+		first = time.Date(2022, time.September, 5, 10, 0, 0, 0, time.UTC)
+		until = time.Date(2022, time.December, 16, 0, 0, 0, 0, time.UTC)
+	)
+
+	t.Run("creates sessions at explicit times", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger       = log.New(os.Stdout, "TestHandleCreateSessions ", log.LstdFlags)
+			classService = classservice.NewMockInterface(t)
+			server       = NewServer(logger, defaultConfig(), classService, instructorservice.NewMockInterface(t))
+			body         = `{"starts_at": ["2022-09-05T10:00:00Z"]}`
+			r            = httptest.NewRequest(http.MethodPost, endpoint, strings.NewReader(body))
+			w            = httptest.NewRecorder()
+		)
+
+		r.Header.Set("content-type", string(applicationJSON))
+
+		classService.On(
+			"CreateSessions",
+			mock.AnythingOfType("*gin.Context"),
+			"SICP",
+			[]time.Time{first},
+		).Return(classservice.Sessions{{ID: 1, StartsAt: first}}, nil)
+
+		server.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusCreated, w.Code, "unexpected status code")
+		require.JSONEq(t, `[{"id": 1, "starts_at": "2022-09-05T10:00:00Z"}]`, w.Body.String(), "unexpected body")
+	})
+
+	t.Run("creates sessions from a schedule", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger       = log.New(os.Stdout, "TestHandleCreateSessions ", log.LstdFlags)
+			classService = classservice.NewMockInterface(t)
+			server       = NewServer(logger, defaultConfig(), classService, instructorservice.NewMockInterface(t))
+			body         = `{"schedule": {
+				"first": "2022-09-05T10:00:00Z",
+				"until": "2022-12-16T00:00:00Z",
+				"weekdays": ["monday", "Wednesday"]
+			}}`
+			r = httptest.NewRequest(http.MethodPost, endpoint, strings.NewReader(body))
+			w = httptest.NewRecorder()
+		)
+
+		r.Header.Set("content-type", string(applicationJSON))
+
+		classService.On(
+			"ScheduleSessions",
+			mock.AnythingOfType("*gin.Context"),
+			"SICP",
+			classservice.Schedule{
+				First:    first,
+				Until:    until,
+				Weekdays: []time.Weekday{time.Monday, time.Wednesday},
+			},
+		).Return(classservice.Sessions{{ID: 1, StartsAt: first}}, nil)
+
+		server.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusCreated, w.Code, "unexpected status code")
+	})
+
+	t.Run("rejects invalid requests", func(t *testing.T) {
+		t.Parallel()
+
+		testCases := []struct {
+			name string
+			body string
+		}{
+			{name: "neither times nor schedule", body: `{}`},
+			{
+				name: "both times and schedule",
+				body: `{"starts_at": ["2022-09-05T10:00:00Z"], "schedule": {"weekdays": ["monday"]}}`,
+			},
+			{name: "unknown weekday", body: `{"schedule": {"weekdays": ["funday"]}}`},
+		}
+
+		for _, tc := range testCases {
+			tc := tc
+
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+
+				var (
+					logger       = log.New(os.Stdout, "TestHandleCreateSessions ", log.LstdFlags)
+					classService = classservice.NewMockInterface(t)
+					server       = NewServer(logger, defaultConfig(), classService, instructorservice.NewMockInterface(t))
+					r            = httptest.NewRequest(http.MethodPost, endpoint, strings.NewReader(tc.body))
+					w            = httptest.NewRecorder()
+				)
+
+				r.Header.Set("content-type", string(applicationJSON))
+
+				server.ServeHTTP(w, r)
+
+				require.Equal(t, http.StatusBadRequest, w.Code, "unexpected status code")
+			})
+		}
+	})
+}
+
+func TestHandleRecordAttendance(t *testing.T) {
+	t.Parallel()
+
+	records := []classservice.AttendanceRecord{
+		{SessionID: 3, Student: classservice.Student{Email: "r.tifft@gmail.com"}, Present: true},
+		{SessionID: 3, Student: classservice.Student{Email: "berthe@archibaldindustries.com"}},
+	}
+
+	testCases := []struct {
+		name       string
+		method     string
+		endpoint   string
+		body       string
+		serviceErr error
+		wantStatus int
+	}{
+		{
+			name:     "single session",
+			method:   http.MethodPut,
+			endpoint: "/courses/SICP/sessions/3/attendance",
+			body: `{"attendance": [
+				{"email": "r.tifft@gmail.com", "present": true},
+				{"email": "berthe@archibaldindustries.com", "present": false}
+			]}`,
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:     "multiple sessions",
+			method:   http.MethodPost,
+			endpoint: "/courses/SICP/attendance",
+			body: `{"attendance": [
+				{"session_id": 3, "email": "r.tifft@gmail.com", "present": true},
+				{"session_id": 3, "email": "berthe@archibaldindustries.com", "present": false}
+			]}`,
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:     "session not found",
+			method:   http.MethodPut,
+			endpoint: "/courses/SICP/sessions/3/attendance",
+			body: `{"attendance": [
+				{"email": "r.tifft@gmail.com", "present": true},
+				{"email": "berthe@archibaldindustries.com", "present": false}
+			]}`,
+			serviceErr: classservice.SessionNotFoundError{CourseCode: "SICP", SessionID: 3},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:     "student not enrolled",
+			method:   http.MethodPut,
+			endpoint: "/courses/SICP/sessions/3/attendance",
+			body: `{"attendance": [
+				{"email": "r.tifft@gmail.com", "present": true},
+				{"email": "berthe@archibaldindustries.com", "present": false}
+			]}`,
+			serviceErr: classservice.NotEnrolledError{CourseCode: "SICP"},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "invalid session ID",
+			method:     http.MethodPut,
+			endpoint:   "/courses/SICP/sessions/zero/attendance",
+			body:       `{"attendance": []}`,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				logger       = log.New(os.Stdout, "TestHandleRecordAttendance ", log.LstdFlags)
+				classService = classservice.NewMockInterface(t)
+				server       = NewServer(logger, defaultConfig(), classService, instructorservice.NewMockInterface(t))
+				r            = httptest.NewRequest(tc.method, tc.endpoint, strings.NewReader(tc.body))
+				w            = httptest.NewRecorder()
+			)
+
+			r.Header.Set("content-type", string(applicationJSON))
+
+			if tc.wantStatus != http.StatusBadRequest {
+				classService.On(
+					"RecordAttendance",
+					mock.AnythingOfType("*gin.Context"),
+					"SICP",
+					records,
+				).Return(tc.serviceErr)
+			}
+
+			server.ServeHTTP(w, r)
+
+			require.Equal(t, tc.wantStatus, w.Code, "unexpected status code")
+		})
+	}
+}
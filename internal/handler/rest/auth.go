@@ -0,0 +1,75 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/angusgmorrison/hexagonal/internal/service/authservice"
+	"github.com/gin-gonic/gin"
+)
+
+// apiKeyHeader is the header in which clients present API keys. Tokens are
+// presented in the Authorization header using the Bearer scheme.
+const apiKeyHeader = "X-API-Key"
+
+// errNoCredentials is returned when a request presents neither a bearer token
+// nor an API key.
+var errNoCredentials = authservice.InvalidCredentialsError{Reason: "no credentials presented"}
+
+// authenticated returns router unchanged if authentication isn't configured.
+// Otherwise, it returns a group of router whose routes require
+// authentication.
+func (s *Server) authenticated(router gin.IRouter) gin.IRoutes {
+	if s.authService == nil {
+		return router
+	}
+
+	return router.Group("/", s.authenticate())
+}
+
+// authenticate requires requests to present a bearer token or an API key, and
+// puts the authservice.Principal they identify into the request's context,
+// where the services can read it using authservice.FromContext. Requests
+// without valid credentials receive 401 Unauthorized.
+func (s *Server) authenticate() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, err := s.authenticatePrincipal(c)
+		if err != nil {
+			s.logger.Printf("Authentication failed: %s", err)
+
+			var credentialsErr authservice.InvalidCredentialsError
+			if !errors.As(err, &credentialsErr) {
+				c.AbortWithStatus(http.StatusInternalServerError)
+
+				return
+			}
+
+			c.Header("WWW-Authenticate", `Bearer realm="`+s.config.App.Name+`"`)
+			c.AbortWithStatus(http.StatusUnauthorized)
+
+			return
+		}
+
+		// Handlers pass the *gin.Context to the services, while handlers
+		// wrapping an http.Handler pass on the request's context, so the
+		// principal is put in both.
+		c.Set(authservice.ContextKey, principal)
+		c.Request = c.Request.WithContext(authservice.NewContext(c.Request.Context(), principal))
+
+		c.Next()
+	}
+}
+
+func (s *Server) authenticatePrincipal(c *gin.Context) (authservice.Principal, error) {
+	if key := c.GetHeader(apiKeyHeader); key != "" {
+		return s.authService.AuthenticateAPIKey(c, key)
+	}
+
+	scheme, token, ok := strings.Cut(c.GetHeader("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return authservice.Principal{}, errNoCredentials
+	}
+
+	return s.authService.AuthenticateToken(c, token)
+}
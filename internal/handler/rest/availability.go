@@ -0,0 +1,101 @@
+package rest
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/angusgmorrison/hexagonal/internal/service/classservice"
+	"github.com/gin-gonic/gin"
+)
+
+// availabilityHeartbeatInterval is how often a comment is sent to idle
+// availability streams, so that proxies don't close them.
+const availabilityHeartbeatInterval = 15 * time.Second
+
+// AvailabilitySubscriber provides updates to the places remaining in courses.
+// The channel returned by SubscribeAvailability receives the updates for a
+// course until the returned function is called.
+type AvailabilitySubscriber interface {
+	SubscribeAvailability(courseCode string) (<-chan classservice.Availability, func())
+}
+
+type availabilityResponse struct {
+	CourseCode      string `json:"course_code"`
+	AvailableSpaces uint32 `json:"available_spaces"`
+}
+
+// handleStreamAvailability streams the places remaining in the course matching
+// the code path parameter as Server-Sent Events. The first event reports the
+// current availability, and another is sent whenever it changes. The stream
+// lasts until the client disconnects or the server shuts down.
+func (s *Server) handleStreamAvailability() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		courseCode := c.Param("code")
+
+		// Subscribe before reading the course, so that no change is missed
+		// between the two.
+		updates, unsubscribe := s.availabilitySubscriber.SubscribeAvailability(courseCode)
+		defer unsubscribe()
+
+		class, err := s.classService.GetClass(c, courseCode)
+		if err != nil {
+			s.logger.Printf("Getting availability failed: %s", err)
+			c.AbortWithStatus(lookupFailureStatus(err))
+
+			return
+		}
+
+		c.Header("Cache-Control", "no-cache")
+		c.Header("X-Accel-Buffering", "no")
+
+		sendAvailability(c, classservice.Availability{
+			CourseCode:      class.Course.Code,
+			AvailableSpaces: class.AvailableSpaces(),
+		})
+
+		heartbeat := time.NewTicker(availabilityHeartbeatInterval)
+		defer heartbeat.Stop()
+
+		for {
+			select {
+			case <-c.Request.Context().Done():
+				return
+			case <-s.shuttingDown:
+				return
+			case update, ok := <-updates:
+				if !ok {
+					return
+				}
+
+				sendAvailability(c, update)
+			case <-heartbeat.C:
+				_, _ = io.WriteString(c.Writer, ": heartbeat\n\n")
+				c.Writer.Flush()
+			}
+		}
+	}
+}
+
+func sendAvailability(c *gin.Context, a classservice.Availability) {
+	c.SSEvent("availability", availabilityResponse{
+		CourseCode:      a.CourseCode,
+		AvailableSpaces: a.AvailableSpaces,
+	})
+	c.Writer.Flush()
+}
+
+// withoutStreamDeadlines exempts event streams from the server's write
+// timeout, which would otherwise end each stream after SERVER_WRITE_TIMEOUT.
+// Gin's ResponseWriter doesn't expose the connection's deadlines, so they're
+// cleared before the request reaches the router.
+func withoutStreamDeadlines(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/stream") {
+			_ = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,142 @@
+//go:build unit
+
+package rest
+
+import (
+	"bufio"
+	"context"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/angusgmorrison/hexagonal/internal/eventbus"
+	"github.com/angusgmorrison/hexagonal/internal/service/classservice"
+	"github.com/angusgmorrison/hexagonal/internal/service/instructorservice"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleStreamAvailability(t *testing.T) {
+	t.Parallel()
+
+	const endpoint = "/courses/SICP/availability/stream"
+
+	t.Run("streams the current availability and each change", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger       = log.New(os.Stdout, "TestHandleStreamAvailability ", log.LstdFlags)
+			classService = classservice.NewMockInterface(t)
+			bus          = eventbus.New()
+			server       = newAvailabilityServer(t, logger, classService, bus)
+			class        = classservice.Class{Course: classservice.Course{Code: "SICP", Capacity: 3}}
+		)
+
+		class.Students = classservice.Students{{ID: 1}}
+
+		classService.On("GetClass", mock.AnythingOfType("*gin.Context"), "SICP").Return(class, nil)
+
+		httpServer := httptest.NewServer(server)
+		t.Cleanup(httpServer.Close)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		r, err := http.NewRequestWithContext(ctx, http.MethodGet, httpServer.URL+endpoint, nil)
+		require.NoError(t, err, "create request")
+
+		resp, err := http.DefaultClient.Do(r)
+		require.NoError(t, err, "send request")
+
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusOK, resp.StatusCode, "unexpected status code")
+		require.Equal(t, "text/event-stream", resp.Header.Get("content-type"))
+
+		events := bufio.NewReader(resp.Body)
+
+		require.Equal(t, "event:availability\ndata:{\"course_code\":\"SICP\",\"available_spaces\":2}\n\n", readEvent(t, events))
+
+		bus.PublishAvailability(ctx, classservice.Availability{CourseCode: "SICP", AvailableSpaces: 1})
+
+		require.Equal(t, "event:availability\ndata:{\"course_code\":\"SICP\",\"available_spaces\":1}\n\n", readEvent(t, events))
+	})
+
+	t.Run("responds 404 Not Found if the course doesn't exist", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger       = log.New(os.Stdout, "TestHandleStreamAvailability ", log.LstdFlags)
+			classService = classservice.NewMockInterface(t)
+			server       = newAvailabilityServer(t, logger, classService, eventbus.New())
+			r            = httptest.NewRequest(http.MethodGet, endpoint, nil)
+			w            = httptest.NewRecorder()
+		)
+
+		classService.On("GetClass", mock.AnythingOfType("*gin.Context"), "SICP").
+			Return(classservice.Class{}, classservice.CourseNotFoundError{CourseCode: "SICP"})
+
+		server.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusNotFound, w.Code, "unexpected status code")
+	})
+
+	t.Run("isn't served unless a subscriber is configured", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger = log.New(os.Stdout, "TestHandleStreamAvailability ", log.LstdFlags)
+			server = NewServer(
+				logger,
+				defaultConfig(),
+				classservice.NewMockInterface(t),
+				instructorservice.NewMockInterface(t),
+			)
+			r = httptest.NewRequest(http.MethodGet, endpoint, nil)
+			w = httptest.NewRecorder()
+		)
+
+		server.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusNotFound, w.Code, "unexpected status code")
+	})
+}
+
+// readEvent reads lines from an event stream up to and including the blank
+// line that ends the next event.
+func readEvent(t *testing.T, r *bufio.Reader) string {
+	t.Helper()
+
+	var event strings.Builder
+
+	for {
+		line, err := r.ReadString('\n')
+		require.NoError(t, err, "read event")
+
+		event.WriteString(line)
+
+		if line == "\n" {
+			return event.String()
+		}
+	}
+}
+
+func newAvailabilityServer(
+	t *testing.T,
+	logger *log.Logger,
+	classService classservice.Interface,
+	subscriber AvailabilitySubscriber,
+) *Server {
+	t.Helper()
+
+	return NewServer(
+		logger,
+		defaultConfig(),
+		classService,
+		instructorservice.NewMockInterface(t),
+		WithAvailabilityStream(subscriber),
+	)
+}
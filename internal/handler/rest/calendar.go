@@ -0,0 +1,79 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/angusgmorrison/hexagonal/internal/primitive"
+	"github.com/angusgmorrison/hexagonal/internal/service/classservice"
+	ics "github.com/arran4/golang-ical"
+	"github.com/gin-gonic/gin"
+)
+
+// handleGetCalendar responds with an iCalendar feed of the sessions of every
+// course in which the student identified by the email path parameter is
+// enrolled.
+func (s *Server) handleGetCalendar() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		email := primitive.EmailAddress(c.Param("email"))
+
+		classes, err := s.classService.GetStudentClasses(c, email)
+		if err != nil {
+			s.logger.Printf("Getting calendar failed: %s", err)
+			c.AbortWithStatus(lookupFailureStatus(err))
+
+			return
+		}
+
+		cal := s.newCalendar(email, classes, time.Now())
+
+		c.Data(http.StatusOK, string(textCalendar)+"; charset=utf-8", []byte(cal.Serialize()))
+	}
+}
+
+// newCalendar returns a calendar with an event for each session of the
+// classes. Events are stamped with the time now, and last for the session
+// duration configured for the server, if any.
+func (s *Server) newCalendar(email primitive.EmailAddress, classes []classservice.Class, now time.Time) *ics.Calendar {
+	cal := ics.NewCalendarFor(s.config.App.Name)
+	cal.SetMethod(ics.MethodPublish)
+	cal.SetName(fmt.Sprintf("Classes of %s", email))
+
+	for _, class := range classes {
+		summary := class.Code
+		for _, entry := range class.Roster() {
+			if entry.Email == email && entry.SectionCode != "" {
+				summary = fmt.Sprintf("%s (section %s)", class.Code, entry.SectionCode)
+			}
+		}
+
+		var description string
+		if len(class.Instructors) > 0 {
+			names := make([]string, 0, len(class.Instructors))
+			for _, instructor := range class.Instructors {
+				names = append(names, instructor.Name)
+			}
+
+			description = "Taught by " + strings.Join(names, ", ")
+		}
+
+		for _, session := range class.Sessions {
+			event := cal.AddEvent(fmt.Sprintf("session-%d@%s", session.ID, s.config.App.Name))
+			event.SetDtStampTime(now)
+			event.SetStartAt(session.StartsAt)
+			event.SetSummary(summary)
+
+			if duration := s.config.Calendar.SessionDuration; duration > 0 {
+				event.SetEndAt(session.StartsAt.Add(duration))
+			}
+
+			if description != "" {
+				event.SetDescription(description)
+			}
+		}
+	}
+
+	return cal
+}
@@ -0,0 +1,100 @@
+//go:build unit
+
+package rest
+
+import (
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/angusgmorrison/hexagonal/internal/service/classservice"
+	"github.com/angusgmorrison/hexagonal/internal/service/instructorservice"
+	ics "github.com/arran4/golang-ical"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleGetCalendar(t *testing.T) {
+	t.Parallel()
+
+	const endpoint = "/students/berthe@archibaldindustries.com/calendar.ics"
+
+	t.Run("responds with an event for each session", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger       = log.New(os.Stdout, "TestHandleGetCalendar ", log.LstdFlags)
+			classService = classservice.NewMockInterface(t)
+			config       = defaultConfig()
+			r            = httptest.NewRequest(http.MethodGet, endpoint, nil)
+			w            = httptest.NewRecorder()
+			first        = time.Date(2022, time.September, 5, 9, 0, 0, 0, time.UTC)
+			second       = first.AddDate(0, 0, 7)
+		)
+
+		config.Calendar.SessionDuration = 90 * time.Minute
+		server := NewServer(logger, config, classService, instructorservice.NewMockInterface(t))
+
+		class := rosterClass(t)
+		class.Sessions = classservice.Sessions{{ID: 1, StartsAt: first}, {ID: 2, StartsAt: second}}
+
+		classService.On(
+			"GetStudentClasses",
+			mock.AnythingOfType("*gin.Context"),
+			class.Students[0].Email,
+		).Return([]classservice.Class{class}, nil)
+
+		server.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusOK, w.Code, "unexpected status code")
+		require.Equal(t, "text/calendar; charset=utf-8", w.Header().Get("content-type"))
+
+		cal, err := ics.ParseCalendar(w.Body)
+		require.NoError(t, err, "parse calendar")
+
+		events := cal.Events()
+		require.Len(t, events, 2)
+
+		for i, start := range []time.Time{first, second} {
+			event := events[i]
+
+			startAt, err := event.GetStartAt()
+			require.NoError(t, err, "get start")
+			require.True(t, start.Equal(startAt), "unexpected start")
+
+			endAt, err := event.GetEndAt()
+			require.NoError(t, err, "get end")
+			require.True(t, start.Add(90*time.Minute).Equal(endAt), "unexpected end")
+
+			require.Equal(t, "TAOCP (section A)", event.GetProperty(ics.ComponentPropertySummary).Value)
+			require.Equal(t, "Taught by Donald Knuth", event.GetProperty(ics.ComponentPropertyDescription).Value)
+		}
+
+		require.Equal(t, "session-1@hexagonal", events[0].Id())
+	})
+
+	t.Run("responds 404 Not Found to unregistered students", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger       = log.New(os.Stdout, "TestHandleGetCalendar ", log.LstdFlags)
+			classService = classservice.NewMockInterface(t)
+			server       = NewServer(logger, defaultConfig(), classService, instructorservice.NewMockInterface(t))
+			r            = httptest.NewRequest(http.MethodGet, endpoint, nil)
+			w            = httptest.NewRecorder()
+		)
+
+		classService.On(
+			"GetStudentClasses",
+			mock.AnythingOfType("*gin.Context"),
+			mock.Anything,
+		).Return(nil, classservice.UnregisteredStudentsError{})
+
+		server.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusNotFound, w.Code, "unexpected status code")
+	})
+}
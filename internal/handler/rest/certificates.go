@@ -0,0 +1,123 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/angusgmorrison/hexagonal/internal/primitive"
+	"github.com/angusgmorrison/hexagonal/internal/service/classservice"
+	"github.com/angusgmorrison/hexagonal/pkg/pdf"
+	"github.com/gin-gonic/gin"
+)
+
+// certificateResponse describes a verified certificate. It omits the student's
+// email address, since anyone holding the verification code may request it.
+type certificateResponse struct {
+	Code        string    `json:"code"`
+	StudentName string    `json:"student_name"`
+	CourseCode  string    `json:"course_code"`
+	CourseTitle string    `json:"course_title"`
+	CompletedAt time.Time `json:"completed_at"`
+	IssuedAt    time.Time `json:"issued_at"`
+}
+
+func newCertificateResponse(certificate classservice.Certificate) certificateResponse {
+	return certificateResponse{
+		Code:        certificate.Code,
+		StudentName: certificate.Student.Name,
+		CourseCode:  certificate.CourseCode,
+		CourseTitle: certificate.CourseTitle,
+		CompletedAt: certificate.CompletedAt,
+		IssuedAt:    certificate.IssuedAt,
+	}
+}
+
+// handleGetCertificate responds with a PDF certificate of completion for the
+// student identified by the email path parameter in the course identified by
+// the code path parameter.
+func (s *Server) handleGetCertificate() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		email := primitive.EmailAddress(c.Param("email"))
+		courseCode := c.Param("code")
+
+		certificate, err := s.classService.GetCertificate(c, email, courseCode)
+		if err != nil {
+			s.logger.Printf("Getting certificate failed: %s", err)
+			c.AbortWithStatus(lookupFailureStatus(err))
+
+			return
+		}
+
+		filename := strings.ToLower(fmt.Sprintf("certificate-%s-%s.pdf", certificate.CourseCode, certificate.Code))
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+		c.Data(http.StatusOK, string(applicationPDF), renderCertificate(certificate, verificationURL(c.Request, certificate)))
+	}
+}
+
+// handleVerifyCertificate responds with the details of the certificate
+// identified by the code path parameter, so that anyone presented with a
+// certificate can confirm that it was genuinely issued.
+func (s *Server) handleVerifyCertificate() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		certificate, err := s.classService.VerifyCertificate(c, c.Param("code"))
+		if err != nil {
+			s.logger.Printf("Verifying certificate failed: %s", err)
+			c.AbortWithStatus(lookupFailureStatus(err))
+
+			return
+		}
+
+		c.JSON(http.StatusOK, newCertificateResponse(certificate))
+	}
+}
+
+// verificationURL returns the absolute URL at which the certificate can be
+// verified, as reached by the client that requested it.
+func verificationURL(r *http.Request, certificate classservice.Certificate) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+
+	u := url.URL{
+		Scheme: scheme,
+		Host:   r.Host,
+		Path:   fmt.Sprintf("/certificates/%s/verify", certificate.Code),
+	}
+
+	return u.String()
+}
+
+// renderCertificate lays out a certificate on a landscape A4 page.
+func renderCertificate(certificate classservice.Certificate, verifyURL string) []byte {
+	var (
+		navy  = pdf.Color{R: 0.11, G: 0.2, B: 0.36}
+		grey  = pdf.Color{R: 0.4, G: 0.4, B: 0.4}
+		doc   = pdf.New(pdf.A4Height, pdf.A4Width)
+		w, h  = doc.Width(), doc.Height()
+		title = fmt.Sprintf("Certificate of Completion: %s", certificate.CourseTitle)
+	)
+
+	doc.SetTitle(title)
+
+	doc.Rect(3, navy, 24, 24, w-48, h-48)
+	doc.Rect(1, navy, 32, 32, w-64, h-64)
+
+	doc.CenteredText(pdf.HelveticaBold, 30, navy, h-120, "CERTIFICATE OF COMPLETION")
+	doc.CenteredText(pdf.TimesItalic, 16, pdf.Black, h-180, "This is to certify that")
+	doc.CenteredText(pdf.TimesRoman, 36, pdf.Black, h-235, certificate.Student.Name)
+	doc.Line(0.75, grey, w/2-200, h-248, w/2+200, h-248)
+	doc.CenteredText(pdf.TimesItalic, 16, pdf.Black, h-285, "has successfully completed the course")
+	doc.CenteredText(pdf.HelveticaBold, 24, navy, h-330, certificate.CourseTitle)
+	doc.CenteredText(pdf.Helvetica, 14, grey, h-355, certificate.CourseCode)
+	doc.CenteredText(pdf.TimesRoman, 16, pdf.Black, h-400,
+		fmt.Sprintf("on %s", certificate.CompletedAt.Format("2 January 2006")))
+
+	doc.CenteredText(pdf.Helvetica, 11, pdf.Black, 80, fmt.Sprintf("Verification code: %s", certificate.Code))
+	doc.CenteredText(pdf.Helvetica, 9, grey, 62, fmt.Sprintf("Verify this certificate at %s", verifyURL))
+
+	return doc.Bytes()
+}
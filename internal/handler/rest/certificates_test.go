@@ -0,0 +1,186 @@
+//go:build unit
+
+package rest
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/angusgmorrison/hexagonal/internal/primitive"
+	"github.com/angusgmorrison/hexagonal/internal/service/classservice"
+	"github.com/angusgmorrison/hexagonal/internal/service/instructorservice"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testCertificate = classservice.Certificate{
+	Code: "ABCD-EFGH-IJKL-MNOP",
+	Student: classservice.Student{
+		ID:    1,
+		Name:  "Angus Morrison",
+		Email: "angus@example.com",
+	},
+	CourseCode:  "SICP",
+	CourseTitle: "Structure and Interpretation of Computer Programs",
+	CompletedAt: time.Date(2022, time.June, 30, 12, 0, 0, 0, time.UTC),
+	IssuedAt:    time.Date(2022, time.July, 1, 9, 0, 0, 0, time.UTC),
+}
+
+func TestHandleGetCertificate(t *testing.T) {
+	t.Parallel()
+
+	const endpoint = "/students/angus@example.com/courses/SICP/certificate"
+
+	t.Run("responds 200 OK with a PDF certificate", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger       = log.New(os.Stdout, "TestHandleGetCertificate ", log.LstdFlags)
+			classService = classservice.NewMockInterface(t)
+			server       = NewServer(logger, defaultConfig(), classService, instructorservice.NewMockInterface(t))
+			r            = httptest.NewRequest(http.MethodGet, endpoint, nil)
+			w            = httptest.NewRecorder()
+		)
+
+		classService.On(
+			"GetCertificate",
+			mock.AnythingOfType("*gin.Context"),
+			primitive.EmailAddress("angus@example.com"),
+			"SICP",
+		).Return(testCertificate, nil)
+
+		server.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusOK, w.Code, "unexpected status code")
+		require.Equal(t, string(applicationPDF), w.Header().Get("content-type"))
+		require.Equal(t,
+			`attachment; filename="certificate-sicp-abcd-efgh-ijkl-mnop.pdf"`,
+			w.Header().Get("content-disposition"))
+
+		body := w.Body.Bytes()
+		require.True(t, bytes.HasPrefix(body, []byte("%PDF-")), "response is not a PDF")
+
+		for _, want := range []string{
+			"Angus Morrison",
+			"Structure and Interpretation of Computer Programs",
+			"on 30 June 2022",
+			"Verification code: ABCD-EFGH-IJKL-MNOP",
+			"http://example.com/certificates/ABCD-EFGH-IJKL-MNOP/verify",
+		} {
+			require.Contains(t, string(body), want)
+		}
+	})
+
+	testCases := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{
+			name:       "responds 404 Not Found if the student hasn't passed the course",
+			err:        classservice.CourseNotPassedError{CourseCode: "SICP", Email: "angus@example.com"},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "responds 404 Not Found if the student is unregistered",
+			err:        classservice.UnregisteredStudentsError{Students: classservice.Students{{Email: "angus@example.com"}}},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "responds 500 Internal Server Error on other errors",
+			err:        errors.New("boom"),
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				logger       = log.New(os.Stdout, "TestHandleGetCertificate ", log.LstdFlags)
+				classService = classservice.NewMockInterface(t)
+				server       = NewServer(logger, defaultConfig(), classService, instructorservice.NewMockInterface(t))
+				r            = httptest.NewRequest(http.MethodGet, endpoint, nil)
+				w            = httptest.NewRecorder()
+			)
+
+			classService.On(
+				"GetCertificate",
+				mock.AnythingOfType("*gin.Context"),
+				primitive.EmailAddress("angus@example.com"),
+				"SICP",
+			).Return(classservice.Certificate{}, tc.err)
+
+			server.ServeHTTP(w, r)
+
+			require.Equal(t, tc.wantStatus, w.Code, "unexpected status code")
+		})
+	}
+}
+
+func TestHandleVerifyCertificate(t *testing.T) {
+	t.Parallel()
+
+	const endpoint = "/certificates/abcd-efgh-ijkl-mnop/verify"
+
+	t.Run("responds 200 OK with the certificate", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger       = log.New(os.Stdout, "TestHandleVerifyCertificate ", log.LstdFlags)
+			classService = classservice.NewMockInterface(t)
+			server       = NewServer(logger, defaultConfig(), classService, instructorservice.NewMockInterface(t))
+			r            = httptest.NewRequest(http.MethodGet, endpoint, nil)
+			w            = httptest.NewRecorder()
+		)
+
+		classService.On(
+			"VerifyCertificate",
+			mock.AnythingOfType("*gin.Context"),
+			"abcd-efgh-ijkl-mnop",
+		).Return(testCertificate, nil)
+
+		server.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusOK, w.Code, "unexpected status code")
+		require.JSONEq(t, `{
+			"code": "ABCD-EFGH-IJKL-MNOP",
+			"student_name": "Angus Morrison",
+			"course_code": "SICP",
+			"course_title": "Structure and Interpretation of Computer Programs",
+			"completed_at": "2022-06-30T12:00:00Z",
+			"issued_at": "2022-07-01T09:00:00Z"
+		}`, w.Body.String())
+	})
+
+	t.Run("responds 404 Not Found if no certificate has the code", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger       = log.New(os.Stdout, "TestHandleVerifyCertificate ", log.LstdFlags)
+			classService = classservice.NewMockInterface(t)
+			server       = NewServer(logger, defaultConfig(), classService, instructorservice.NewMockInterface(t))
+			r            = httptest.NewRequest(http.MethodGet, endpoint, nil)
+			w            = httptest.NewRecorder()
+		)
+
+		classService.On(
+			"VerifyCertificate",
+			mock.AnythingOfType("*gin.Context"),
+			"abcd-efgh-ijkl-mnop",
+		).Return(classservice.Certificate{}, classservice.CertificateNotFoundError{Code: "ABCD-EFGH-IJKL-MNOP"})
+
+		server.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusNotFound, w.Code, "unexpected status code")
+	})
+}
@@ -11,13 +11,17 @@ import (
 type enrollmentRequest struct {
 	CourseTitle string   `json:"course_title"`
 	CourseCode  string   `json:"course_code"`
+	SectionCode string   `json:"section_code"`
+	VoucherCode string   `json:"voucher_code"`
 	Students    students `json:"students"`
 }
 
 func (er enrollmentRequest) toDomain() classservice.EnrollmentRequest {
 	return classservice.EnrollmentRequest{
-		CourseCode: er.CourseCode,
-		Students:   er.Students.toDomain(),
+		CourseCode:  er.CourseCode,
+		SectionCode: er.SectionCode,
+		VoucherCode: er.VoucherCode,
+		Students:    er.Students.toDomain(),
 	}
 }
 
@@ -69,3 +73,111 @@ func (s *Server) handleCreateEnrollments() gin.HandlerFunc {
 		c.Status(http.StatusCreated)
 	}
 }
+
+// enrollmentRequestV2 is the body of a v2 enrollment request, which names the
+// course in its path. Registered students are identified by email address, so
+// unlike v1, v2 doesn't ask for their other details.
+type enrollmentRequestV2 struct {
+	SectionCode   string        `json:"section_code"`
+	VoucherCode   string        `json:"voucher_code"`
+	StudentEmails studentEmails `json:"student_emails"`
+}
+
+func (er enrollmentRequestV2) toDomain(courseCode string) classservice.EnrollmentRequest {
+	return classservice.EnrollmentRequest{
+		CourseCode:  courseCode,
+		SectionCode: er.SectionCode,
+		VoucherCode: er.VoucherCode,
+		Students:    er.StudentEmails.toDomain(),
+	}
+}
+
+type studentEmails []primitive.EmailAddress
+
+func (se studentEmails) toDomain() classservice.Students {
+	domainStudents := make(classservice.Students, 0, len(se))
+
+	for _, email := range se {
+		domainStudents = append(domainStudents, classservice.Student{Email: email})
+	}
+
+	return domainStudents
+}
+
+// handleCreateEnrollmentsV2 enrolls students in the course identified by the
+// code path parameter.
+func (s *Server) handleCreateEnrollmentsV2() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var enReq enrollmentRequestV2
+		if err := c.ShouldBind(&enReq); err != nil {
+			s.logger.Printf("Failed to parse enrollment request: %s", err)
+			c.AbortWithStatus(http.StatusBadRequest)
+
+			return
+		}
+
+		if err := s.classService.Enroll(c, enReq.toDomain(c.Param("code"))); err != nil {
+			s.logger.Printf("Enrollment failed: %s", err)
+			c.AbortWithStatus(http.StatusUnprocessableEntity)
+
+			return
+		}
+
+		c.Status(http.StatusCreated)
+	}
+}
+
+// handleApproveEnrollment approves the pending enrollment of the student
+// identified by the email path parameter in the course identified by the code
+// path parameter.
+func (s *Server) handleApproveEnrollment() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		courseCode := c.Param("code")
+		email := primitive.EmailAddress(c.Param("email"))
+
+		if err := s.classService.ApproveEnrollment(c, courseCode, email); err != nil {
+			s.logger.Printf("Approval failed: %s", err)
+			c.AbortWithStatus(http.StatusUnprocessableEntity)
+
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// handleRejectEnrollment rejects the pending enrollment of the student
+// identified by the email path parameter in the course identified by the code
+// path parameter.
+func (s *Server) handleRejectEnrollment() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		courseCode := c.Param("code")
+		email := primitive.EmailAddress(c.Param("email"))
+
+		if err := s.classService.RejectEnrollment(c, courseCode, email); err != nil {
+			s.logger.Printf("Rejection failed: %s", err)
+			c.AbortWithStatus(http.StatusUnprocessableEntity)
+
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// handleCancelCourse cancels the course identified by the code path parameter,
+// ending the enrollment of all its students.
+func (s *Server) handleCancelCourse() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		courseCode := c.Param("code")
+
+		if err := s.classService.CancelCourse(c, courseCode); err != nil {
+			s.logger.Printf("Cancellation failed: %s", err)
+			c.AbortWithStatus(http.StatusUnprocessableEntity)
+
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
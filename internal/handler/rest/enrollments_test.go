@@ -15,6 +15,7 @@ import (
 	"github.com/angusgmorrison/hexagonal/internal/envconfig"
 	"github.com/angusgmorrison/hexagonal/internal/primitive"
 	"github.com/angusgmorrison/hexagonal/internal/service/classservice"
+	"github.com/angusgmorrison/hexagonal/internal/service/instructorservice"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -44,7 +45,7 @@ func TestHandleCreateEnrollments(t *testing.T) {
 		var (
 			logger       = log.New(os.Stdout, "TestHandleCreateEnrollments ", log.LstdFlags)
 			classService = classservice.NewMockInterface(t)
-			server       = NewServer(logger, defaultConfig(), classService)
+			server       = NewServer(logger, defaultConfig(), classService, instructorservice.NewMockInterface(t))
 			r            = httptest.NewRequest(http.MethodPost, endpoint, nil)
 			w            = httptest.NewRecorder()
 		)
@@ -82,6 +83,16 @@ func TestHandleCreateEnrollments(t *testing.T) {
 				serviceErr: classservice.AlreadyEnrolledError{},
 				wantStatus: http.StatusUnprocessableEntity,
 			},
+			{
+				name:       "section not found",
+				serviceErr: classservice.SectionNotFoundError{},
+				wantStatus: http.StatusUnprocessableEntity,
+			},
+			{
+				name:       "course load exceeded",
+				serviceErr: classservice.CourseLoadExceededError{},
+				wantStatus: http.StatusUnprocessableEntity,
+			},
 		}
 
 		for _, tc := range testCases {
@@ -97,7 +108,7 @@ func TestHandleCreateEnrollments(t *testing.T) {
 				var (
 					logger       = log.New(os.Stdout, "TestHandleCreateEnrollments ", log.LstdFlags)
 					classService = classservice.NewMockInterface(t)
-					server       = NewServer(logger, defaultConfig(), classService)
+					server       = NewServer(logger, defaultConfig(), classService, instructorservice.NewMockInterface(t))
 					r            = httptest.NewRequest(http.MethodPost, endpoint, bytes.NewReader(fixtureBytes))
 					w            = httptest.NewRecorder()
 				)
@@ -108,7 +119,8 @@ func TestHandleCreateEnrollments(t *testing.T) {
 				require.NoError(err)
 
 				expectedEnrollmentRequest := classservice.EnrollmentRequest{
-					CourseCode: "SICP",
+					CourseCode:  "SICP",
+					VoucherCode: "welcome10",
 					Students: classservice.Students{
 						{
 							Name:      "Ramdas Tifft",
@@ -132,6 +144,136 @@ func TestHandleCreateEnrollments(t *testing.T) {
 	})
 }
 
+func TestHandleEnrollmentDecisions(t *testing.T) {
+	t.Parallel()
+
+	const (
+		courseCode = "ADV101"
+		email      = primitive.EmailAddress("r.tifft@gmail.com")
+	)
+
+	testCases := []struct {
+		name          string
+		decision      string
+		serviceMethod string
+		serviceErr    error
+		wantStatus    int
+	}{
+		{
+			name:          "approved",
+			decision:      "approve",
+			serviceMethod: "ApproveEnrollment",
+			serviceErr:    nil,
+			wantStatus:    http.StatusNoContent,
+		},
+		{
+			name:          "approval oversubscribes class",
+			decision:      "approve",
+			serviceMethod: "ApproveEnrollment",
+			serviceErr:    classservice.OversubscribedError{},
+			wantStatus:    http.StatusUnprocessableEntity,
+		},
+		{
+			name:          "approved enrollment not pending",
+			decision:      "approve",
+			serviceMethod: "ApproveEnrollment",
+			serviceErr:    classservice.EnrollmentNotPendingError{},
+			wantStatus:    http.StatusUnprocessableEntity,
+		},
+		{
+			name:          "rejected",
+			decision:      "reject",
+			serviceMethod: "RejectEnrollment",
+			serviceErr:    nil,
+			wantStatus:    http.StatusNoContent,
+		},
+		{
+			name:          "rejected enrollment not pending",
+			decision:      "reject",
+			serviceMethod: "RejectEnrollment",
+			serviceErr:    classservice.EnrollmentNotPendingError{},
+			wantStatus:    http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				logger       = log.New(os.Stdout, "TestHandleEnrollmentDecisions ", log.LstdFlags)
+				classService = classservice.NewMockInterface(t)
+				server       = NewServer(logger, defaultConfig(), classService, instructorservice.NewMockInterface(t))
+				endpoint     = "/courses/" + courseCode + "/enrollments/" + string(email) + "/" + tc.decision
+				r            = httptest.NewRequest(http.MethodPost, endpoint, nil)
+				w            = httptest.NewRecorder()
+			)
+
+			classService.On(
+				tc.serviceMethod,
+				mock.AnythingOfType("*gin.Context"),
+				courseCode,
+				email,
+			).Return(tc.serviceErr)
+
+			server.ServeHTTP(w, r)
+
+			require.Equal(t, tc.wantStatus, w.Code, "unexpected status code")
+		})
+	}
+}
+
+func TestHandleCancelCourse(t *testing.T) {
+	t.Parallel()
+
+	const endpoint = "/courses/SICP/cancel"
+
+	testCases := []struct {
+		name       string
+		serviceErr error
+		wantStatus int
+	}{
+		{
+			name:       "cancelled",
+			serviceErr: nil,
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "already cancelled",
+			serviceErr: classservice.CourseCancelledError{},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				logger       = log.New(os.Stdout, "TestHandleCancelCourse ", log.LstdFlags)
+				classService = classservice.NewMockInterface(t)
+				server       = NewServer(logger, defaultConfig(), classService, instructorservice.NewMockInterface(t))
+				r            = httptest.NewRequest(http.MethodPost, endpoint, nil)
+				w            = httptest.NewRecorder()
+			)
+
+			classService.On(
+				"CancelCourse",
+				mock.AnythingOfType("*gin.Context"),
+				"SICP",
+			).Return(tc.serviceErr)
+
+			server.ServeHTTP(w, r)
+
+			require.Equal(t, tc.wantStatus, w.Code, "unexpected status code")
+		})
+	}
+}
+
 func defaultConfig() envconfig.EnvConfig {
 	return envconfig.EnvConfig{
 		App: envconfig.App{
@@ -142,3 +284,71 @@ func defaultConfig() envconfig.EnvConfig {
 		},
 	}
 }
+
+func TestHandleCreateEnrollmentsV2(t *testing.T) {
+	t.Parallel()
+
+	const endpoint = "/v2/courses/SICP/enrollments"
+
+	t.Run("responds 415 Unsupported Media Type to non-JSON requests", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger = log.New(os.Stdout, "TestHandleCreateEnrollmentsV2 ", log.LstdFlags)
+			server = NewServer(logger, defaultConfig(), classservice.NewMockInterface(t), instructorservice.NewMockInterface(t))
+			r      = httptest.NewRequest(http.MethodPost, endpoint, nil)
+			w      = httptest.NewRecorder()
+		)
+
+		server.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusUnsupportedMediaType, w.Code, "unexpected status code")
+	})
+
+	testCases := []struct {
+		name       string
+		serviceErr error
+		wantStatus int
+	}{
+		{
+			name:       "created",
+			serviceErr: nil,
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:       "unregistered students",
+			serviceErr: classservice.UnregisteredStudentsError{},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				logger       = log.New(os.Stdout, "TestHandleCreateEnrollmentsV2 ", log.LstdFlags)
+				classService = classservice.NewMockInterface(t)
+				server       = NewServer(logger, defaultConfig(), classService, instructorservice.NewMockInterface(t))
+				body         = `{"section_code":"A","voucher_code":"welcome10","student_emails":["r.tifft@gmail.com"]}`
+				r            = httptest.NewRequest(http.MethodPost, endpoint, bytes.NewBufferString(body))
+				w            = httptest.NewRecorder()
+			)
+
+			r.Header.Set("content-type", string(applicationJSON))
+
+			classService.On("Enroll", mock.AnythingOfType("*gin.Context"), classservice.EnrollmentRequest{
+				CourseCode:  "SICP",
+				SectionCode: "A",
+				VoucherCode: "welcome10",
+				Students:    classservice.Students{{Email: "r.tifft@gmail.com"}},
+			}).Return(tc.serviceErr)
+
+			server.ServeHTTP(w, r)
+
+			require.Equal(t, tc.wantStatus, w.Code, "unexpected status code")
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package rest
+
+import (
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/angusgmorrison/hexagonal/internal/primitive"
+	"github.com/angusgmorrison/hexagonal/internal/service/classservice"
+	"github.com/gin-gonic/gin"
+)
+
+type gradeRequest struct {
+	Grade string `json:"grade"`
+}
+
+type transcriptResponse struct {
+	Name  string                 `json:"name"`
+	Email primitive.EmailAddress `json:"email"`
+
+	// GPA is rounded to two decimal places, and is null if the student has no
+	// grades that count toward it.
+	GPA     *float64          `json:"gpa"`
+	Courses []transcriptEntry `json:"courses"`
+}
+
+type transcriptEntry struct {
+	CourseCode  string    `json:"course_code"`
+	Grade       string    `json:"grade"`
+	Passed      bool      `json:"passed"`
+	CompletedAt time.Time `json:"completed_at"`
+}
+
+func newTranscriptResponse(transcript classservice.Transcript) transcriptResponse {
+	resp := transcriptResponse{
+		Name:    transcript.Student.Name,
+		Email:   transcript.Student.Email,
+		Courses: make([]transcriptEntry, 0, len(transcript.Entries)),
+	}
+
+	if gpa, ok := transcript.GPA(); ok {
+		rounded := math.Round(gpa*100) / 100
+		resp.GPA = &rounded
+	}
+
+	for _, entry := range transcript.Entries {
+		resp.Courses = append(resp.Courses, transcriptEntry{
+			CourseCode:  entry.CourseCode,
+			Grade:       entry.Grade.Letter,
+			Passed:      entry.Grade.Passing,
+			CompletedAt: entry.CompletedAt,
+		})
+	}
+
+	return resp
+}
+
+// handleRecordGrade awards the grade in the request body to the student
+// identified by the email path parameter for the course identified by the code
+// path parameter.
+func (s *Server) handleRecordGrade() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var gReq gradeRequest
+		if err := c.ShouldBind(&gReq); err != nil {
+			s.logger.Printf("Failed to parse grade request: %s", err)
+			c.AbortWithStatus(http.StatusBadRequest)
+
+			return
+		}
+
+		courseCode := c.Param("code")
+		email := primitive.EmailAddress(c.Param("email"))
+
+		if err := s.classService.RecordGrade(c, courseCode, email, gReq.Grade); err != nil {
+			s.logger.Printf("Recording grade failed: %s", err)
+			c.AbortWithStatus(changeFailureStatus(err))
+
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// handleGetTranscript responds with the transcript of the student identified
+// by the email path parameter.
+func (s *Server) handleGetTranscript() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		email := primitive.EmailAddress(c.Param("email"))
+
+		transcript, err := s.classService.GetTranscript(c, email)
+		if err != nil {
+			s.logger.Printf("Getting transcript failed: %s", err)
+			c.AbortWithStatus(lookupFailureStatus(err))
+
+			return
+		}
+
+		c.JSON(http.StatusOK, newTranscriptResponse(transcript))
+	}
+}
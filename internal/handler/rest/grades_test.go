@@ -0,0 +1,216 @@
+//go:build unit
+
+package rest
+
+import (
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/angusgmorrison/hexagonal/internal/primitive"
+	"github.com/angusgmorrison/hexagonal/internal/service/classservice"
+	"github.com/angusgmorrison/hexagonal/internal/service/instructorservice"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleRecordGrade(t *testing.T) {
+	t.Parallel()
+
+	const endpoint = "/courses/SICP/enrollments/r.tifft@gmail.com/grade"
+
+	testCases := []struct {
+		name       string
+		body       string
+		serviceErr error
+		wantCalled bool
+		wantStatus int
+	}{
+		{
+			name:       "recorded",
+			body:       `{"grade": "A"}`,
+			wantCalled: true,
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "malformed body",
+			body:       `{"grade": 4}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "course not found",
+			body:       `{"grade": "A"}`,
+			serviceErr: classservice.CourseNotFoundError{CourseCode: "SICP"},
+			wantCalled: true,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "unknown grade",
+			body:       `{"grade": "A"}`,
+			serviceErr: classservice.UnknownGradeError{Grade: "A"},
+			wantCalled: true,
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "not enrolled",
+			body:       `{"grade": "A"}`,
+			serviceErr: classservice.NotEnrolledError{CourseCode: "SICP"},
+			wantCalled: true,
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				logger       = log.New(os.Stdout, "TestHandleRecordGrade ", log.LstdFlags)
+				classService = classservice.NewMockInterface(t)
+				server       = NewServer(logger, defaultConfig(), classService, instructorservice.NewMockInterface(t))
+				r            = httptest.NewRequest(http.MethodPut, endpoint, strings.NewReader(tc.body))
+				w            = httptest.NewRecorder()
+			)
+
+			r.Header.Set("content-type", string(applicationJSON))
+
+			if tc.wantCalled {
+				classService.On(
+					"RecordGrade",
+					mock.AnythingOfType("*gin.Context"),
+					"SICP",
+					primitive.EmailAddress("r.tifft@gmail.com"),
+					"A",
+				).Return(tc.serviceErr)
+			}
+
+			server.ServeHTTP(w, r)
+
+			require.Equal(t, tc.wantStatus, w.Code, "unexpected status code")
+		})
+	}
+}
+
+func TestHandleGetTranscript(t *testing.T) {
+	t.Parallel()
+
+	const endpoint = "/students/r.tifft@gmail.com/transcript"
+
+	completedAt := time.Date(2022, time.December, 16, 12, 0, 0, 0, time.UTC)
+	student := classservice.Student{ID: 1, Name: "Ramdas Tifft", Email: "r.tifft@gmail.com"}
+
+	t.Run("responds with the transcript", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger       = log.New(os.Stdout, "TestHandleGetTranscript ", log.LstdFlags)
+			classService = classservice.NewMockInterface(t)
+			server       = NewServer(logger, defaultConfig(), classService, instructorservice.NewMockInterface(t))
+			r            = httptest.NewRequest(http.MethodGet, endpoint, nil)
+			w            = httptest.NewRecorder()
+		)
+
+		classService.On(
+			"GetTranscript",
+			mock.AnythingOfType("*gin.Context"),
+			primitive.EmailAddress("r.tifft@gmail.com"),
+		).Return(classservice.Transcript{
+			Student: student,
+			Entries: []classservice.TranscriptEntry{
+				{
+					CourseCode:  "SICP",
+					Grade:       classservice.Grade{Letter: "A", Points: 4, Passing: true},
+					CompletedAt: completedAt,
+				},
+				{
+					CourseCode:  "TAOCP",
+					Grade:       classservice.Grade{Letter: "B-", Points: 2.7, Passing: true},
+					CompletedAt: completedAt,
+				},
+				{
+					CourseCode:  "CLRS",
+					Grade:       classservice.Grade{Letter: "B+", Points: 3.3, Passing: true},
+					CompletedAt: completedAt,
+				},
+				{
+					CourseCode:  "HTDP",
+					Grade:       classservice.Grade{Letter: "NP", PassFail: true},
+					CompletedAt: completedAt,
+				},
+			},
+		}, nil)
+
+		server.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusOK, w.Code, "unexpected status code")
+		require.JSONEq(t, `{
+			"name": "Ramdas Tifft",
+			"email": "r.tifft@gmail.com",
+			"gpa": 3.33,
+			"courses": [
+				{"course_code": "SICP", "grade": "A", "passed": true, "completed_at": "2022-12-16T12:00:00Z"},
+				{"course_code": "TAOCP", "grade": "B-", "passed": true, "completed_at": "2022-12-16T12:00:00Z"},
+				{"course_code": "CLRS", "grade": "B+", "passed": true, "completed_at": "2022-12-16T12:00:00Z"},
+				{"course_code": "HTDP", "grade": "NP", "passed": false, "completed_at": "2022-12-16T12:00:00Z"}
+			]
+		}`, w.Body.String(), "unexpected body")
+	})
+
+	t.Run("responds with null GPA to students without graded courses", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger       = log.New(os.Stdout, "TestHandleGetTranscript ", log.LstdFlags)
+			classService = classservice.NewMockInterface(t)
+			server       = NewServer(logger, defaultConfig(), classService, instructorservice.NewMockInterface(t))
+			r            = httptest.NewRequest(http.MethodGet, endpoint, nil)
+			w            = httptest.NewRecorder()
+		)
+
+		classService.On(
+			"GetTranscript",
+			mock.AnythingOfType("*gin.Context"),
+			primitive.EmailAddress("r.tifft@gmail.com"),
+		).Return(classservice.Transcript{Student: student}, nil)
+
+		server.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusOK, w.Code, "unexpected status code")
+		require.JSONEq(t, `{
+			"name": "Ramdas Tifft",
+			"email": "r.tifft@gmail.com",
+			"gpa": null,
+			"courses": []
+		}`, w.Body.String(), "unexpected body")
+	})
+
+	t.Run("responds 404 Not Found to unregistered students", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger       = log.New(os.Stdout, "TestHandleGetTranscript ", log.LstdFlags)
+			classService = classservice.NewMockInterface(t)
+			server       = NewServer(logger, defaultConfig(), classService, instructorservice.NewMockInterface(t))
+			r            = httptest.NewRequest(http.MethodGet, endpoint, nil)
+			w            = httptest.NewRecorder()
+		)
+
+		classService.On(
+			"GetTranscript",
+			mock.AnythingOfType("*gin.Context"),
+			primitive.EmailAddress("r.tifft@gmail.com"),
+		).Return(classservice.Transcript{}, classservice.UnregisteredStudentsError{
+			Students: classservice.Students{{Email: "r.tifft@gmail.com"}},
+		})
+
+		server.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusNotFound, w.Code, "unexpected status code")
+	})
+}
@@ -0,0 +1,217 @@
+package rest
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/angusgmorrison/hexagonal/internal/primitive"
+	"github.com/angusgmorrison/hexagonal/internal/service/importservice"
+	"github.com/gin-gonic/gin"
+)
+
+// maxImportRequestBytes is the largest import CSV accepted. It leaves room for
+// the default maximum number of rows with long names and emails.
+const maxImportRequestBytes = 4 << 20
+
+// importColumns are the columns required of an import CSV, which may appear in
+// any order.
+var importColumns = []string{"course_code", "name", "birthdate", "email"}
+
+type importResponse struct {
+	ID            int64                 `json:"id"`
+	Status        string                `json:"status"`
+	TotalRows     uint32                `json:"total_rows"`
+	ProcessedRows uint32                `json:"processed_rows"`
+	FailedRows    uint32                `json:"failed_rows"`
+	CreatedAt     time.Time             `json:"created_at"`
+	CompletedAt   *time.Time            `json:"completed_at"`
+	Errors        []importErrorResponse `json:"errors"`
+}
+
+type importErrorResponse struct {
+	Line       int                    `json:"line"`
+	CourseCode string                 `json:"course_code"`
+	Email      primitive.EmailAddress `json:"email"`
+	Message    string                 `json:"message"`
+}
+
+func newImportResponse(imp importservice.Import) importResponse {
+	resp := importResponse{
+		ID:            imp.ID,
+		Status:        string(imp.Status),
+		TotalRows:     imp.TotalRows,
+		ProcessedRows: imp.ProcessedRows,
+		FailedRows:    imp.FailedRows,
+		CreatedAt:     imp.CreatedAt,
+		Errors:        make([]importErrorResponse, 0, len(imp.Errors)),
+	}
+
+	if !imp.CompletedAt.IsZero() {
+		resp.CompletedAt = &imp.CompletedAt
+	}
+
+	for _, rowErr := range imp.Errors {
+		resp.Errors = append(resp.Errors, importErrorResponse{
+			Line:       rowErr.Line,
+			CourseCode: rowErr.CourseCode,
+			Email:      rowErr.Email,
+			Message:    rowErr.Message,
+		})
+	}
+
+	return resp
+}
+
+// handleCreateImport starts an import of the enrollments listed in the CSV
+// request body. The rows are enrolled in the background, so the response
+// describes the pending import and links to its progress.
+func (s *Server) handleCreateImport() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportRequestBytes)
+
+		rows, err := readImportRows(c.Request.Body)
+		if err != nil {
+			s.logger.Printf("Failed to parse import CSV: %s", err)
+
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				c.AbortWithStatus(http.StatusRequestEntityTooLarge)
+
+				return
+			}
+
+			c.AbortWithStatus(http.StatusBadRequest)
+
+			return
+		}
+
+		// The import outlives the request, and the *gin.Context is reused once
+		// the handler returns, so the service is given a detached copy of the
+		// request's context.
+		imp, err := s.importService.StartImport(context.WithoutCancel(c.Request.Context()), rows)
+		if err != nil {
+			s.logger.Printf("Starting import failed: %s", err)
+			c.AbortWithStatus(startImportFailureStatus(err))
+
+			return
+		}
+
+		// Link the import under the request's path so that the client stays on
+		// the same version of the API.
+		c.Header("Location", fmt.Sprintf("%s/%d", c.Request.URL.Path, imp.ID))
+		c.JSON(http.StatusAccepted, newImportResponse(imp))
+	}
+}
+
+// handleGetImport responds with the progress of the import identified by the
+// id path parameter and the errors recorded against its rows so far.
+func (s *Server) handleGetImport() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err == nil && id <= 0 {
+			err = fmt.Errorf("import ID %d is not positive", id)
+		}
+
+		if err != nil {
+			s.logger.Printf("Failed to parse import ID: %s", err)
+			c.AbortWithStatus(http.StatusBadRequest)
+
+			return
+		}
+
+		imp, err := s.importService.GetImport(c, id)
+		if err != nil {
+			s.logger.Printf("Getting import failed: %s", err)
+			c.AbortWithStatus(lookupFailureStatus(err))
+
+			return
+		}
+
+		c.JSON(http.StatusOK, newImportResponse(imp))
+	}
+}
+
+// startImportFailureStatus returns the status code with which to respond to a
+// request that failed to start an import.
+func startImportFailureStatus(err error) int {
+	var (
+		tooManyRowsErr    importservice.TooManyRowsError
+		tooManyImportsErr importservice.TooManyImportsError
+	)
+
+	switch {
+	case errors.As(err, &tooManyRowsErr):
+		return http.StatusRequestEntityTooLarge
+	case errors.As(err, &tooManyImportsErr):
+		return http.StatusTooManyRequests
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// readImportRows reads the rows of an import CSV. The header must name every
+// column in importColumns; other columns are ignored. Rows are numbered by the
+// line on which they start, so that errors can be traced back to the file.
+func readImportRows(r io.Reader) (importservice.Rows, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("import CSV is empty")
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("read import CSV header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	for _, name := range importColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("import CSV has no %q column", name)
+		}
+	}
+
+	var rows importservice.Rows
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("read import CSV: %w", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		field := func(name string) string {
+			return strings.TrimSpace(record[columns[name]])
+		}
+
+		rows = append(rows, importservice.Row{
+			Line:       line,
+			CourseCode: field("course_code"),
+			Name:       field("name"),
+			Birthdate:  field("birthdate"),
+			Email:      primitive.EmailAddress(field("email")),
+		})
+	}
+
+	if len(rows) == 0 {
+		return nil, errors.New("import CSV lists no rows")
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,304 @@
+//go:build unit
+
+package rest
+
+import (
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/angusgmorrison/hexagonal/internal/service/classservice"
+	"github.com/angusgmorrison/hexagonal/internal/service/importservice"
+	"github.com/angusgmorrison/hexagonal/internal/service/instructorservice"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleCreateImport(t *testing.T) {
+	t.Parallel()
+
+	const endpoint = "/v1/imports"
+
+	createdAt := time.Date(2022, time.September, 1, 9, 0, 0, 0, time.UTC)
+
+	t.Run("responds 202 Accepted with the pending import", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger        = log.New(os.Stdout, "TestHandleCreateImport ", log.LstdFlags)
+			importService = importservice.NewMockInterface(t)
+			server        = newImportServer(t, logger, importService)
+			body          = "Email,Course_Code,Name,Birthdate\n" +
+				"angus@example.com,SICP,Angus Morrison,1990-03-04\n" +
+				"r.tifft@gmail.com,SICP,Ramdas Tifft,\n"
+			r = httptest.NewRequest(http.MethodPost, endpoint, strings.NewReader(body))
+			w = httptest.NewRecorder()
+		)
+
+		r.Header.Set("content-type", string(textCSV))
+
+		importService.On("StartImport", mock.Anything, importservice.Rows{
+			{Line: 2, CourseCode: "SICP", Name: "Angus Morrison", Birthdate: "1990-03-04", Email: "angus@example.com"},
+			{Line: 3, CourseCode: "SICP", Name: "Ramdas Tifft", Email: "r.tifft@gmail.com"},
+		}).Return(importservice.Import{
+			ID:        7,
+			Status:    importservice.StatusPending,
+			TotalRows: 2,
+			CreatedAt: createdAt,
+		}, nil)
+
+		server.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusAccepted, w.Code, "unexpected status code")
+		require.Equal(t, "/v1/imports/7", w.Header().Get("Location"))
+		require.JSONEq(t, `{
+			"id": 7,
+			"status": "pending",
+			"total_rows": 2,
+			"processed_rows": 0,
+			"failed_rows": 0,
+			"created_at": "2022-09-01T09:00:00Z",
+			"completed_at": null,
+			"errors": []
+		}`, w.Body.String())
+	})
+
+	t.Run("responds 415 Unsupported Media Type if the body isn't CSV", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger = log.New(os.Stdout, "TestHandleCreateImport ", log.LstdFlags)
+			server = newImportServer(t, logger, importservice.NewMockInterface(t))
+			r      = httptest.NewRequest(http.MethodPost, endpoint, strings.NewReader("[]"))
+			w      = httptest.NewRecorder()
+		)
+
+		r.Header.Set("content-type", string(applicationJSON))
+
+		server.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusUnsupportedMediaType, w.Code, "unexpected status code")
+	})
+
+	t.Run("responds 400 Bad Request if the CSV is malformed", func(t *testing.T) {
+		t.Parallel()
+
+		testCases := []struct {
+			name string
+			body string
+		}{
+			{name: "empty", body: ""},
+			{name: "no rows", body: "course_code,name,birthdate,email\n"},
+			{name: "missing column", body: "course_code,name,email\nSICP,Angus Morrison,angus@example.com\n"},
+			{name: "ragged row", body: "course_code,name,birthdate,email\nSICP,Angus Morrison\n"},
+		}
+
+		for _, tc := range testCases {
+			tc := tc
+
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+
+				var (
+					logger = log.New(os.Stdout, "TestHandleCreateImport ", log.LstdFlags)
+					server = newImportServer(t, logger, importservice.NewMockInterface(t))
+					r      = httptest.NewRequest(http.MethodPost, endpoint, strings.NewReader(tc.body))
+					w      = httptest.NewRecorder()
+				)
+
+				r.Header.Set("content-type", string(textCSV))
+
+				server.ServeHTTP(w, r)
+
+				require.Equal(t, http.StatusBadRequest, w.Code, "unexpected status code")
+			})
+		}
+	})
+
+	t.Run("responds 413 Request Entity Too Large if the body is too large", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger = log.New(os.Stdout, "TestHandleCreateImport ", log.LstdFlags)
+			server = newImportServer(t, logger, importservice.NewMockInterface(t))
+			row    = "SICP,Angus Morrison,1990-03-04,angus@example.com\n"
+			body   = "course_code,name,birthdate,email\n" + strings.Repeat(row, maxImportRequestBytes/len(row)+1)
+			r      = httptest.NewRequest(http.MethodPost, endpoint, strings.NewReader(body))
+			w      = httptest.NewRecorder()
+		)
+
+		r.Header.Set("content-type", string(textCSV))
+
+		server.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusRequestEntityTooLarge, w.Code, "unexpected status code")
+	})
+
+	t.Run("responds according to why the import was refused", func(t *testing.T) {
+		t.Parallel()
+
+		testCases := []struct {
+			name       string
+			serviceErr error
+			wantStatus int
+		}{
+			{
+				name:       "too many rows",
+				serviceErr: importservice.TooManyRowsError{Rows: 2, MaxRows: 1},
+				wantStatus: http.StatusRequestEntityTooLarge,
+			},
+			{
+				name:       "too many imports",
+				serviceErr: importservice.TooManyImportsError{MaxConcurrent: 1},
+				wantStatus: http.StatusTooManyRequests,
+			},
+		}
+
+		for _, tc := range testCases {
+			tc := tc
+
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+
+				var (
+					logger        = log.New(os.Stdout, "TestHandleCreateImport ", log.LstdFlags)
+					importService = importservice.NewMockInterface(t)
+					server        = newImportServer(t, logger, importService)
+					body          = "course_code,name,birthdate,email\nSICP,Angus Morrison,1990-03-04,angus@example.com\n"
+					r             = httptest.NewRequest(http.MethodPost, endpoint, strings.NewReader(body))
+					w             = httptest.NewRecorder()
+				)
+
+				r.Header.Set("content-type", string(textCSV))
+
+				importService.On("StartImport", mock.Anything, mock.Anything).Return(importservice.Import{}, tc.serviceErr)
+
+				server.ServeHTTP(w, r)
+
+				require.Equal(t, tc.wantStatus, w.Code, "unexpected status code")
+			})
+		}
+	})
+
+	t.Run("isn't served unless imports are configured", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger = log.New(os.Stdout, "TestHandleCreateImport ", log.LstdFlags)
+			server = NewServer(
+				logger,
+				defaultConfig(),
+				classservice.NewMockInterface(t),
+				instructorservice.NewMockInterface(t),
+			)
+			r = httptest.NewRequest(http.MethodPost, endpoint, strings.NewReader("course_code\n"))
+			w = httptest.NewRecorder()
+		)
+
+		r.Header.Set("content-type", string(textCSV))
+
+		server.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusNotFound, w.Code, "unexpected status code")
+	})
+}
+
+func TestHandleGetImport(t *testing.T) {
+	t.Parallel()
+
+	var (
+		createdAt   = time.Date(2022, time.September, 1, 9, 0, 0, 0, time.UTC)
+		completedAt = createdAt.Add(time.Minute)
+	)
+
+	t.Run("responds 200 OK with the import's progress and errors", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger        = log.New(os.Stdout, "TestHandleGetImport ", log.LstdFlags)
+			importService = importservice.NewMockInterface(t)
+			server        = newImportServer(t, logger, importService)
+			r             = httptest.NewRequest(http.MethodGet, "/imports/7", nil)
+			w             = httptest.NewRecorder()
+		)
+
+		importService.On("GetImport", mock.AnythingOfType("*gin.Context"), int64(7)).Return(importservice.Import{
+			ID:            7,
+			Status:        importservice.StatusCompleted,
+			TotalRows:     2,
+			ProcessedRows: 2,
+			FailedRows:    1,
+			Errors: importservice.RowErrors{
+				{Line: 3, CourseCode: "SICP", Email: "r.tifft@gmail.com", Message: "birthdate is required"},
+			},
+			CreatedAt:   createdAt,
+			CompletedAt: completedAt,
+		}, nil)
+
+		server.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusOK, w.Code, "unexpected status code")
+		require.JSONEq(t, `{
+			"id": 7,
+			"status": "completed",
+			"total_rows": 2,
+			"processed_rows": 2,
+			"failed_rows": 1,
+			"created_at": "2022-09-01T09:00:00Z",
+			"completed_at": "2022-09-01T09:01:00Z",
+			"errors": [
+				{"line": 3, "course_code": "SICP", "email": "r.tifft@gmail.com", "message": "birthdate is required"}
+			]
+		}`, w.Body.String())
+	})
+
+	t.Run("responds 404 Not Found if the import doesn't exist", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger        = log.New(os.Stdout, "TestHandleGetImport ", log.LstdFlags)
+			importService = importservice.NewMockInterface(t)
+			server        = newImportServer(t, logger, importService)
+			r             = httptest.NewRequest(http.MethodGet, "/imports/8", nil)
+			w             = httptest.NewRecorder()
+		)
+
+		importService.On("GetImport", mock.AnythingOfType("*gin.Context"), int64(8)).
+			Return(importservice.Import{}, importservice.ImportNotFoundError{ID: 8})
+
+		server.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusNotFound, w.Code, "unexpected status code")
+	})
+
+	t.Run("responds 400 Bad Request if the ID is invalid", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger = log.New(os.Stdout, "TestHandleGetImport ", log.LstdFlags)
+			server = newImportServer(t, logger, importservice.NewMockInterface(t))
+			r      = httptest.NewRequest(http.MethodGet, "/imports/latest", nil)
+			w      = httptest.NewRecorder()
+		)
+
+		server.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusBadRequest, w.Code, "unexpected status code")
+	})
+}
+
+func newImportServer(t *testing.T, logger *log.Logger, importService importservice.Interface) *Server {
+	t.Helper()
+
+	return NewServer(
+		logger,
+		defaultConfig(),
+		classservice.NewMockInterface(t),
+		instructorservice.NewMockInterface(t),
+		WithImports(importService),
+	)
+}
@@ -0,0 +1,262 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/angusgmorrison/hexagonal/internal/primitive"
+	"github.com/angusgmorrison/hexagonal/internal/service/classservice"
+	"github.com/angusgmorrison/hexagonal/internal/service/importservice"
+	"github.com/angusgmorrison/hexagonal/internal/service/instructorservice"
+	"github.com/gin-gonic/gin"
+)
+
+type instructorRequest struct {
+	Name  string                 `json:"name"`
+	Email primitive.EmailAddress `json:"email"`
+}
+
+func (ir instructorRequest) toDomain(id int64) instructorservice.Instructor {
+	return instructorservice.Instructor{
+		ID:    id,
+		Name:  ir.Name,
+		Email: ir.Email,
+	}
+}
+
+type instructorResponse struct {
+	ID    int64                  `json:"id"`
+	Name  string                 `json:"name"`
+	Email primitive.EmailAddress `json:"email"`
+}
+
+func newInstructorResponse(i instructorservice.Instructor) instructorResponse {
+	return instructorResponse{
+		ID:    i.ID,
+		Name:  i.Name,
+		Email: i.Email,
+	}
+}
+
+// handleListInstructors responds with every instructor.
+func (s *Server) handleListInstructors() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		instructors, err := s.instructorService.ListInstructors(c)
+		if err != nil {
+			s.logger.Printf("Listing instructors failed: %s", err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+
+			return
+		}
+
+		resp := make([]instructorResponse, 0, len(instructors))
+		for _, instructor := range instructors {
+			resp = append(resp, newInstructorResponse(instructor))
+		}
+
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+// handleCreateInstructor registers the instructor described by the request
+// body.
+func (s *Server) handleCreateInstructor() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var iReq instructorRequest
+		if err := c.ShouldBind(&iReq); err != nil {
+			s.logger.Printf("Failed to parse instructor request: %s", err)
+			c.AbortWithStatus(http.StatusBadRequest)
+
+			return
+		}
+
+		instructor, err := s.instructorService.CreateInstructor(c, iReq.toDomain(0))
+		if err != nil {
+			s.logger.Printf("Instructor creation failed: %s", err)
+			c.AbortWithStatus(http.StatusUnprocessableEntity)
+
+			return
+		}
+
+		c.JSON(http.StatusCreated, newInstructorResponse(instructor))
+	}
+}
+
+// handleGetInstructor responds with the instructor identified by the id path
+// parameter.
+func (s *Server) handleGetInstructor() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := s.instructorID(c)
+		if !ok {
+			return
+		}
+
+		instructor, err := s.instructorService.GetInstructor(c, id)
+		if err != nil {
+			s.logger.Printf("Getting instructor failed: %s", err)
+			c.AbortWithStatus(lookupFailureStatus(err))
+
+			return
+		}
+
+		c.JSON(http.StatusOK, newInstructorResponse(instructor))
+	}
+}
+
+// handleUpdateInstructor replaces the details of the instructor identified by
+// the id path parameter with those in the request body.
+func (s *Server) handleUpdateInstructor() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := s.instructorID(c)
+		if !ok {
+			return
+		}
+
+		var iReq instructorRequest
+		if err := c.ShouldBind(&iReq); err != nil {
+			s.logger.Printf("Failed to parse instructor request: %s", err)
+			c.AbortWithStatus(http.StatusBadRequest)
+
+			return
+		}
+
+		instructor, err := s.instructorService.UpdateInstructor(c, iReq.toDomain(id))
+		if err != nil {
+			s.logger.Printf("Instructor update failed: %s", err)
+			c.AbortWithStatus(changeFailureStatus(err))
+
+			return
+		}
+
+		c.JSON(http.StatusOK, newInstructorResponse(instructor))
+	}
+}
+
+// handleDeleteInstructor deletes the instructor identified by the id path
+// parameter.
+func (s *Server) handleDeleteInstructor() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := s.instructorID(c)
+		if !ok {
+			return
+		}
+
+		if err := s.instructorService.DeleteInstructor(c, id); err != nil {
+			s.logger.Printf("Instructor deletion failed: %s", err)
+			c.AbortWithStatus(changeFailureStatus(err))
+
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// handleAssignInstructor assigns the instructor identified by the id path
+// parameter to teach the course identified by the code path parameter.
+func (s *Server) handleAssignInstructor() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := s.instructorID(c)
+		if !ok {
+			return
+		}
+
+		if err := s.classService.AssignInstructor(c, c.Param("code"), id); err != nil {
+			s.logger.Printf("Instructor assignment failed: %s", err)
+			c.AbortWithStatus(changeFailureStatus(err))
+
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// handleUnassignInstructor removes the instructor identified by the id path
+// parameter from the course identified by the code path parameter.
+func (s *Server) handleUnassignInstructor() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := s.instructorID(c)
+		if !ok {
+			return
+		}
+
+		if err := s.classService.UnassignInstructor(c, c.Param("code"), id); err != nil {
+			s.logger.Printf("Instructor unassignment failed: %s", err)
+			c.AbortWithStatus(changeFailureStatus(err))
+
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// instructorID parses the id path parameter. If the parameter isn't a positive
+// integer, the request is aborted with 400 Bad Request and instructorID
+// returns false.
+func (s *Server) instructorID(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err == nil && id <= 0 {
+		err = fmt.Errorf("instructor ID %d is not positive", id)
+	}
+
+	if err != nil {
+		s.logger.Printf("Failed to parse instructor ID: %s", err)
+		c.AbortWithStatus(http.StatusBadRequest)
+
+		return 0, false
+	}
+
+	return id, true
+}
+
+// isNotFound reports whether err means that a course, session, student,
+// instructor or import named in the request doesn't exist.
+func isNotFound(err error) bool {
+	var (
+		courseErr          classservice.CourseNotFoundError
+		sessionErr         classservice.SessionNotFoundError
+		invoiceErr         classservice.InvoiceNotFoundError
+		voucherErr         classservice.VoucherNotFoundError
+		certificateErr     classservice.CertificateNotFoundError
+		notPassedErr       classservice.CourseNotPassedError
+		studentErr         classservice.UnregisteredStudentsError
+		classInstructorErr classservice.InstructorNotFoundError
+		instructorErr      instructorservice.InstructorNotFoundError
+		importErr          importservice.ImportNotFoundError
+	)
+
+	return errors.As(err, &courseErr) ||
+		errors.As(err, &sessionErr) ||
+		errors.As(err, &invoiceErr) ||
+		errors.As(err, &voucherErr) ||
+		errors.As(err, &certificateErr) ||
+		errors.As(err, &notPassedErr) ||
+		errors.As(err, &studentErr) ||
+		errors.As(err, &classInstructorErr) ||
+		errors.As(err, &instructorErr) ||
+		errors.As(err, &importErr)
+}
+
+// lookupFailureStatus returns the status code with which to respond to a
+// request that failed to read a resource.
+func lookupFailureStatus(err error) int {
+	if isNotFound(err) {
+		return http.StatusNotFound
+	}
+
+	return http.StatusInternalServerError
+}
+
+// changeFailureStatus returns the status code with which to respond to a
+// request that failed to change a resource.
+func changeFailureStatus(err error) int {
+	if isNotFound(err) {
+		return http.StatusNotFound
+	}
+
+	return http.StatusUnprocessableEntity
+}
@@ -0,0 +1,270 @@
+//go:build unit
+
+package rest
+
+import (
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/angusgmorrison/hexagonal/internal/service/classservice"
+	"github.com/angusgmorrison/hexagonal/internal/service/instructorservice"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleCreateInstructor(t *testing.T) {
+	t.Parallel()
+
+	const endpoint = "/instructors"
+
+	instructor := instructorservice.Instructor{Name: "Gerald Sussman", Email: "gjs@mit.edu"}
+
+	t.Run("responds 400 Bad Request to malformed requests", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger            = log.New(os.Stdout, "TestHandleCreateInstructor ", log.LstdFlags)
+			instructorService = instructorservice.NewMockInterface(t)
+			server            = NewServer(logger, defaultConfig(), classservice.NewMockInterface(t), instructorService)
+			r                 = httptest.NewRequest(http.MethodPost, endpoint, strings.NewReader(`{"name": 1}`))
+			w                 = httptest.NewRecorder()
+		)
+
+		r.Header.Set("content-type", string(applicationJSON))
+
+		server.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusBadRequest, w.Code, "unexpected status code")
+	})
+
+	testCases := []struct {
+		name       string
+		serviceErr error
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "created",
+			serviceErr: nil,
+			wantStatus: http.StatusCreated,
+			wantBody:   `{"id":1,"name":"Gerald Sussman","email":"gjs@mit.edu"}`,
+		},
+		{
+			name:       "email taken",
+			serviceErr: instructorservice.EmailTakenError{},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				logger            = log.New(os.Stdout, "TestHandleCreateInstructor ", log.LstdFlags)
+				instructorService = instructorservice.NewMockInterface(t)
+				server            = NewServer(logger, defaultConfig(), classservice.NewMockInterface(t), instructorService)
+				body              = `{"name": "Gerald Sussman", "email": "gjs@mit.edu"}`
+				r                 = httptest.NewRequest(http.MethodPost, endpoint, strings.NewReader(body))
+				w                 = httptest.NewRecorder()
+				created           = instructor
+			)
+
+			r.Header.Set("content-type", string(applicationJSON))
+
+			created.ID = 1
+
+			instructorService.On(
+				"CreateInstructor",
+				mock.AnythingOfType("*gin.Context"),
+				instructor,
+			).Return(created, tc.serviceErr)
+
+			server.ServeHTTP(w, r)
+
+			require.Equal(t, tc.wantStatus, w.Code, "unexpected status code")
+
+			if tc.wantBody != "" {
+				require.JSONEq(t, tc.wantBody, w.Body.String(), "unexpected body")
+			}
+		})
+	}
+}
+
+func TestHandleGetInstructor(t *testing.T) {
+	t.Parallel()
+
+	t.Run("responds 400 Bad Request to invalid IDs", func(t *testing.T) {
+		t.Parallel()
+
+		for _, endpoint := range []string{"/instructors/abc", "/instructors/0"} {
+			var (
+				logger = log.New(os.Stdout, "TestHandleGetInstructor ", log.LstdFlags)
+				server = NewServer(
+					logger, defaultConfig(), classservice.NewMockInterface(t), instructorservice.NewMockInterface(t))
+				r = httptest.NewRequest(http.MethodGet, endpoint, nil)
+				w = httptest.NewRecorder()
+			)
+
+			server.ServeHTTP(w, r)
+
+			require.Equal(t, http.StatusBadRequest, w.Code, "unexpected status code for %s", endpoint)
+		}
+	})
+
+	testCases := []struct {
+		name       string
+		serviceErr error
+		wantStatus int
+	}{
+		{
+			name:       "found",
+			serviceErr: nil,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "not found",
+			serviceErr: instructorservice.InstructorNotFoundError{ID: 1},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				logger            = log.New(os.Stdout, "TestHandleGetInstructor ", log.LstdFlags)
+				instructorService = instructorservice.NewMockInterface(t)
+				server            = NewServer(logger, defaultConfig(), classservice.NewMockInterface(t), instructorService)
+				r                 = httptest.NewRequest(http.MethodGet, "/instructors/1", nil)
+				w                 = httptest.NewRecorder()
+			)
+
+			instructorService.On(
+				"GetInstructor",
+				mock.AnythingOfType("*gin.Context"),
+				int64(1),
+			).Return(instructorservice.Instructor{ID: 1}, tc.serviceErr)
+
+			server.ServeHTTP(w, r)
+
+			require.Equal(t, tc.wantStatus, w.Code, "unexpected status code")
+		})
+	}
+}
+
+func TestHandleDeleteInstructor(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name       string
+		serviceErr error
+		wantStatus int
+	}{
+		{
+			name:       "deleted",
+			serviceErr: nil,
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "not found",
+			serviceErr: instructorservice.InstructorNotFoundError{ID: 1},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				logger            = log.New(os.Stdout, "TestHandleDeleteInstructor ", log.LstdFlags)
+				instructorService = instructorservice.NewMockInterface(t)
+				server            = NewServer(logger, defaultConfig(), classservice.NewMockInterface(t), instructorService)
+				r                 = httptest.NewRequest(http.MethodDelete, "/instructors/1", nil)
+				w                 = httptest.NewRecorder()
+			)
+
+			instructorService.On(
+				"DeleteInstructor",
+				mock.AnythingOfType("*gin.Context"),
+				int64(1),
+			).Return(tc.serviceErr)
+
+			server.ServeHTTP(w, r)
+
+			require.Equal(t, tc.wantStatus, w.Code, "unexpected status code")
+		})
+	}
+}
+
+func TestHandleAssignInstructor(t *testing.T) {
+	t.Parallel()
+
+	const endpoint = "/courses/SICP/instructors/1"
+
+	testCases := []struct {
+		name       string
+		serviceErr error
+		wantStatus int
+	}{
+		{
+			name:       "assigned",
+			serviceErr: nil,
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "course not found",
+			serviceErr: classservice.CourseNotFoundError{CourseCode: "SICP"},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "instructor not found",
+			serviceErr: classservice.InstructorNotFoundError{ID: 1},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "already assigned",
+			serviceErr: classservice.AlreadyAssignedError{},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				logger       = log.New(os.Stdout, "TestHandleAssignInstructor ", log.LstdFlags)
+				classService = classservice.NewMockInterface(t)
+				server       = NewServer(logger, defaultConfig(), classService, instructorservice.NewMockInterface(t))
+				r            = httptest.NewRequest(http.MethodPut, endpoint, nil)
+				w            = httptest.NewRecorder()
+			)
+
+			classService.On(
+				"AssignInstructor",
+				mock.AnythingOfType("*gin.Context"),
+				"SICP",
+				int64(1),
+			).Return(tc.serviceErr)
+
+			server.ServeHTTP(w, r)
+
+			require.Equal(t, tc.wantStatus, w.Code, "unexpected status code")
+		})
+	}
+}
@@ -1,7 +1,9 @@
 package rest
 
 import (
+	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/angusgmorrison/hexagonal/pkg/slice"
 	"github.com/gin-gonic/gin"
@@ -18,7 +20,6 @@ func globalServerMiddleware() serverMiddleware {
 	return serverMiddleware{
 		gin.Logger(),
 		gin.Recovery(),
-		contentTypes(applicationJSON),
 	}
 }
 
@@ -26,8 +27,15 @@ type contentType string
 
 const (
 	applicationJSON contentType = "application/json"
+	applicationPDF  contentType = "application/pdf"
+	applicationXLSX contentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	textCalendar    contentType = "text/calendar"
+	textCSV         contentType = "text/csv"
+	textEventStream contentType = "text/event-stream"
 )
 
+// contentTypes rejects requests whose content type is not one of those given.
+// It should be applied to each route that accepts a request body.
 func contentTypes(contentTypes ...contentType) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		if !slice.Includes(contentTypes, contentType(c.ContentType())) {
@@ -39,3 +47,22 @@ func contentTypes(contentTypes ...contentType) gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// deprecated marks the responses of routes that are due to be removed with the
+// headers defined by RFCs 9745 and 8594: Deprecation, giving the time at which
+// the route was deprecated, and Sunset, giving the time after which it will be
+// removed, if known. The Link header names the route's successor, found by
+// prefixing the request path with successorPrefix.
+func deprecated(deprecatedAt, sunset time.Time, successorPrefix string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Deprecation", fmt.Sprintf("@%d", deprecatedAt.Unix()))
+
+		if !sunset.IsZero() {
+			c.Header("Sunset", sunset.UTC().Format(http.TimeFormat))
+		}
+
+		c.Header("Link", fmt.Sprintf(`<%s%s>; rel="successor-version"`, successorPrefix, c.Request.URL.Path))
+
+		c.Next()
+	}
+}
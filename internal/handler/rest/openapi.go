@@ -0,0 +1,384 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/angusgmorrison/hexagonal/internal/primitive"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3gen"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/gin-gonic/gin"
+)
+
+// openAPIVersion is the latest version of the API described by the OpenAPI
+// document.
+const openAPIVersion = "2.0.0"
+
+// operation describes a route for the OpenAPI document. Bodies are described
+// by values of the Go types that handlers bind and render, from which JSON
+// schemas are generated, so the document can't drift from the handlers'
+// types.
+type operation struct {
+	method  string
+	path    string // In Gin's syntax, e.g. /courses/:code.
+	summary string
+
+	// headers are the optional request headers the handler reads.
+	headers []string
+
+	// request is nil if the operation takes no body.
+	request *requestBody
+
+	responses []response
+
+	// deprecated operations are due to be removed, and their responses carry
+	// the headers set by the deprecated middleware.
+	deprecated bool
+
+	// public operations don't require authentication.
+	public bool
+}
+
+// requestBody describes a required request body. Body is nil for content that
+// isn't JSON.
+type requestBody struct {
+	contentType contentType
+	description string
+	body        any
+}
+
+// response describes a response with the given status. Body describes the
+// JSON content, if any. Other content types are listed without a schema.
+type response struct {
+	status       int
+	description  string
+	contentTypes []contentType
+	body         any
+	headers      []string
+}
+
+func jsonRequest(body any) *requestBody {
+	return &requestBody{contentType: applicationJSON, body: body}
+}
+
+func jsonResponse(status int, description string, body any) response {
+	return response{
+		status:       status,
+		description:  description,
+		contentTypes: []contentType{applicationJSON},
+		body:         body,
+	}
+}
+
+func emptyResponse(status int, description string) response {
+	return response{status: status, description: description}
+}
+
+var (
+	badRequest           = emptyResponse(http.StatusBadRequest, "The request is malformed.")
+	unauthorized         = emptyResponse(http.StatusUnauthorized, "The request isn't authenticated.")
+	notFound             = emptyResponse(http.StatusNotFound, "A resource named by the request doesn't exist.")
+	notAcceptable        = emptyResponse(http.StatusNotAcceptable, "None of the accepted content types can be produced.")
+	requestTooLarge      = emptyResponse(http.StatusRequestEntityTooLarge, "The request body is too large.")
+	unsupportedMediaType = emptyResponse(http.StatusUnsupportedMediaType, "The request body has the wrong content type.")
+	unprocessable        = emptyResponse(http.StatusUnprocessableEntity, "The request was refused.")
+	tooManyRequests      = emptyResponse(http.StatusTooManyRequests, "The server is too busy to accept the request; retry later.")
+	internalError        = emptyResponse(http.StatusInternalServerError, "The request failed unexpectedly.")
+)
+
+// The names of the security schemes by which clients authenticate.
+const (
+	bearerAuthScheme = "bearerAuth"
+	apiKeyAuthScheme = "apiKeyAuth"
+)
+
+// newOpenAPIDocument describes each of the given routes using its operation
+// from operationsByRoute, returning an error if any route is undocumented. If
+// authenticated is true, operations that aren't public require a bearer token
+// or API key.
+func newOpenAPIDocument(title string, routes gin.RoutesInfo, authenticated bool) (*openapi3.T, error) {
+	doc := &openapi3.T{
+		OpenAPI: "3.0.3",
+		Info: &openapi3.Info{
+			Title:   title,
+			Version: openAPIVersion,
+		},
+		Paths: openapi3.NewPaths(),
+	}
+
+	if authenticated {
+		doc.Components = &openapi3.Components{
+			SecuritySchemes: openapi3.SecuritySchemes{
+				bearerAuthScheme: &openapi3.SecuritySchemeRef{
+					Value: openapi3.NewJWTSecurityScheme(),
+				},
+				apiKeyAuthScheme: &openapi3.SecuritySchemeRef{
+					Value: openapi3.NewSecurityScheme().WithType("apiKey").WithIn("header").WithName(apiKeyHeader),
+				},
+			},
+		}
+	}
+
+	operations := operationsByRoute()
+	generator := openapi3gen.NewGenerator(openapi3gen.SchemaCustomizer(customizeSchema))
+
+	for _, route := range routes {
+		op, ok := operations[routeKey(route.Method, route.Path)]
+		if !ok {
+			return nil, fmt.Errorf("%s %s has no operation", route.Method, route.Path)
+		}
+
+		requiresAuth := authenticated && !op.public
+		if requiresAuth {
+			op.responses = append(slices.Clip(op.responses), unauthorized)
+		}
+
+		openAPIOp, err := op.toOpenAPI(generator)
+		if err != nil {
+			return nil, fmt.Errorf("describe %s %s: %w", route.Method, route.Path, err)
+		}
+
+		if requiresAuth {
+			openAPIOp.Security = &openapi3.SecurityRequirements{
+				openapi3.NewSecurityRequirement().Authenticate(bearerAuthScheme),
+				openapi3.NewSecurityRequirement().Authenticate(apiKeyAuthScheme),
+			}
+		}
+
+		path := openAPIPath(route.Path)
+
+		pathItem := doc.Paths.Value(path)
+		if pathItem == nil {
+			pathItem = &openapi3.PathItem{}
+			doc.Paths.Set(path, pathItem)
+		}
+
+		pathItem.SetOperation(route.Method, openAPIOp)
+	}
+
+	if err := doc.Validate(context.Background()); err != nil {
+		return nil, fmt.Errorf("validate OpenAPI document: %w", err)
+	}
+
+	return doc, nil
+}
+
+// mustOpenAPIDocument is like newOpenAPIDocument but panics if the document
+// can't be built. The document is derived entirely from code, so failures are
+// programming errors, which the tests catch.
+func mustOpenAPIDocument(title string, routes gin.RoutesInfo, authenticated bool) *openapi3.T {
+	doc, err := newOpenAPIDocument(title, routes, authenticated)
+	if err != nil {
+		panic(err)
+	}
+
+	return doc
+}
+
+func (op operation) toOpenAPI(generator *openapi3gen.Generator) (*openapi3.Operation, error) {
+	openAPIOp := openapi3.NewOperation()
+	openAPIOp.Summary = op.summary
+	openAPIOp.Deprecated = op.deprecated
+
+	for _, segment := range strings.Split(op.path, "/") {
+		if name, ok := strings.CutPrefix(segment, ":"); ok {
+			openAPIOp.AddParameter(pathParameter(name))
+		}
+	}
+
+	for _, name := range op.headers {
+		openAPIOp.AddParameter(openapi3.NewHeaderParameter(name).WithSchema(openapi3.NewStringSchema()))
+	}
+
+	if op.request != nil {
+		schema, err := generateSchema(generator, op.request.body)
+		if err != nil {
+			return nil, err
+		}
+
+		mediaType := openapi3.NewMediaType()
+		if schema != nil {
+			mediaType.Schema = schema
+		}
+
+		body := openapi3.NewRequestBody().WithRequired(true).WithDescription(op.request.description)
+		body.Content = openapi3.Content{string(op.request.contentType): mediaType}
+
+		openAPIOp.RequestBody = &openapi3.RequestBodyRef{Value: body}
+	}
+
+	openAPIOp.Responses = openapi3.NewResponses()
+	openAPIOp.Responses.Delete("default")
+
+	for _, resp := range op.responses {
+		openAPIResp := openapi3.NewResponse().WithDescription(resp.description)
+
+		for _, ct := range resp.contentTypes {
+			mediaType := openapi3.NewMediaType()
+
+			if ct == applicationJSON {
+				schema, err := generateSchema(generator, resp.body)
+				if err != nil {
+					return nil, err
+				}
+
+				mediaType.Schema = schema
+			}
+
+			if openAPIResp.Content == nil {
+				openAPIResp.Content = openapi3.Content{}
+			}
+
+			openAPIResp.Content[string(ct)] = mediaType
+		}
+
+		headers := resp.headers
+		if op.deprecated {
+			headers = append(slices.Clip(headers), "Deprecation", "Sunset", "Link")
+		}
+
+		for _, name := range headers {
+			if openAPIResp.Headers == nil {
+				openAPIResp.Headers = openapi3.Headers{}
+			}
+
+			openAPIResp.Headers[name] = &openapi3.HeaderRef{Value: &openapi3.Header{
+				Parameter: openapi3.Parameter{Schema: openapi3.NewStringSchema().NewRef()},
+			}}
+		}
+
+		openAPIOp.AddResponse(resp.status, openAPIResp)
+	}
+
+	return openAPIOp, nil
+}
+
+// pathParameter describes a path parameter. Parameters named "id" identify
+// resources by positive integer; the rest are strings.
+func pathParameter(name string) *openapi3.Parameter {
+	schema := openapi3.NewStringSchema()
+	if name == "id" {
+		schema = openapi3.NewInt64Schema().WithMin(1)
+	}
+
+	return openapi3.NewPathParameter(name).WithSchema(schema)
+}
+
+func generateSchema(generator *openapi3gen.Generator, body any) (*openapi3.SchemaRef, error) {
+	if body == nil {
+		return nil, nil
+	}
+
+	schema, err := generator.GenerateSchemaRef(reflect.TypeOf(body))
+	if err != nil {
+		return nil, fmt.Errorf("generate schema for %T: %w", body, err)
+	}
+
+	return schema, nil
+}
+
+// customizeSchema corrects the generated schemas of types that marshal
+// themselves, and allows nil slices, which are marshalled as null.
+func customizeSchema(_ string, t reflect.Type, _ reflect.StructTag, schema *openapi3.Schema) error {
+	switch t {
+	case reflect.TypeOf(primitive.Birthdate{}):
+		schema.Type = &openapi3.Types{openapi3.TypeString}
+		schema.Format = "date"
+	case reflect.TypeOf(weekday(0)):
+		schema.Type = &openapi3.Types{openapi3.TypeString}
+		schema.Description = "The English name of a day of the week, in any case."
+		schema.Min = nil
+	}
+
+	if t.Kind() == reflect.Slice {
+		schema.Nullable = true
+	}
+
+	return nil
+}
+
+// openAPIPath converts a path in Gin's syntax to OpenAPI's.
+func openAPIPath(ginPath string) string {
+	segments := strings.Split(ginPath, "/")
+	for i, segment := range segments {
+		if name, ok := strings.CutPrefix(segment, ":"); ok {
+			segments[i] = "{" + name + "}"
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
+
+func routeKey(method, path string) string {
+	return method + " " + path
+}
+
+// operationsByRoute returns the operation of every route that setupRoutes can
+// register, keyed by method and Gin path. Versioned operations are served
+// under the prefix of their version, and v1 operations are also served without
+// a prefix, deprecated.
+func operationsByRoute() map[string]operation {
+	byRoute := make(map[string]operation)
+
+	add := func(prefix string, deprecated bool, operations ...[]operation) {
+		for _, ops := range operations {
+			for _, op := range ops {
+				op.path = prefix + op.path
+				op.deprecated = deprecated
+				byRoute[routeKey(op.method, op.path)] = op
+			}
+		}
+	}
+
+	add("", false, unversionedOperations)
+	add("", true, v1Operations, commonOperations)
+	add(v1Prefix, false, v1Operations, commonOperations)
+	add(v2Prefix, false, v2Operations, commonOperations)
+
+	return byRoute
+}
+
+// openAPIRoutes returns the route of each operation in doc, keyed by the
+// method and Gin path of the route that serves it.
+func openAPIRoutes(doc *openapi3.T) map[string]*routers.Route {
+	routes := make(map[string]*routers.Route)
+
+	for _, op := range operationsByRoute() {
+		pathItem := doc.Paths.Value(openAPIPath(op.path))
+		if pathItem == nil || pathItem.GetOperation(op.method) == nil {
+			continue
+		}
+
+		routes[routeKey(op.method, op.path)] = &routers.Route{
+			Spec:      doc,
+			Path:      openAPIPath(op.path),
+			PathItem:  pathItem,
+			Method:    op.method,
+			Operation: pathItem.GetOperation(op.method),
+		}
+	}
+
+	return routes
+}
+
+// handleGetOpenAPI responds with the OpenAPI document describing the server's
+// routes.
+func (s *Server) handleGetOpenAPI() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		doc, err := json.Marshal(s.openAPI)
+		if err != nil {
+			s.logger.Printf("Marshalling OpenAPI document failed: %s", err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+
+			return
+		}
+
+		c.Data(http.StatusOK, string(applicationJSON)+"; charset=utf-8", doc)
+	}
+}
@@ -0,0 +1,394 @@
+package rest
+
+import "net/http"
+
+// graphQLRequest is the body of a GraphQL request, which the GraphQL handler
+// decodes itself.
+type graphQLRequest struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+// v1Operations describes the routes registered by setupV1Routes, apart from
+// the common routes.
+var v1Operations = []operation{
+	{
+		method:    http.MethodPost,
+		path:      "/enroll",
+		summary:   "Enroll students in a course",
+		request:   jsonRequest(enrollmentRequest{}),
+		responses: []response{emptyResponse(http.StatusCreated, "The students were enrolled."), badRequest, unsupportedMediaType, unprocessable},
+	},
+	{
+		method:    http.MethodPost,
+		path:      "/transfers",
+		summary:   "Transfer students between courses",
+		request:   jsonRequest(transferRequest{}),
+		responses: []response{emptyResponse(http.StatusNoContent, "The students were transferred."), badRequest, unsupportedMediaType, unprocessable},
+	},
+}
+
+// v2Operations describes the routes registered by setupV2Routes, apart from
+// the common routes.
+var v2Operations = []operation{
+	{
+		method:    http.MethodPost,
+		path:      "/courses/:code/enrollments",
+		summary:   "Enroll students in a course",
+		request:   jsonRequest(enrollmentRequestV2{}),
+		responses: []response{emptyResponse(http.StatusCreated, "The students were enrolled."), badRequest, unsupportedMediaType, unprocessable},
+	},
+	{
+		method:    http.MethodPost,
+		path:      "/transfers",
+		summary:   "Transfer students between courses",
+		request:   jsonRequest(transferRequestV2{}),
+		responses: []response{emptyResponse(http.StatusNoContent, "The students were transferred."), badRequest, unsupportedMediaType, unprocessable},
+	},
+}
+
+// commonOperations describes the routes registered by setupCommonRoutes.
+var commonOperations = []operation{
+	{
+		method:  http.MethodPost,
+		path:    "/rules/validate",
+		summary: "Validate an enrollment rule",
+		request: jsonRequest(ruleValidationRequest{}),
+		responses: []response{
+			jsonResponse(http.StatusOK, "The rule is valid.", ruleValidationResponse{}),
+			badRequest,
+			requestTooLarge,
+			unsupportedMediaType,
+			jsonResponse(http.StatusUnprocessableEntity, "The rule is invalid.", ruleValidationResponse{}),
+			internalError,
+		},
+	},
+	{
+		method:    http.MethodPost,
+		path:      "/courses/:code/cancel",
+		summary:   "Cancel a course",
+		responses: []response{emptyResponse(http.StatusNoContent, "The course was cancelled."), unprocessable},
+	},
+	{
+		method:  http.MethodPost,
+		path:    "/courses/:code/reservations",
+		summary: "Reserve a place in a course",
+		request: jsonRequest(reservationRequest{}),
+		responses: []response{
+			jsonResponse(http.StatusCreated, "The place was reserved.", reservationResponse{}),
+			badRequest,
+			unsupportedMediaType,
+			unprocessable,
+		},
+	},
+	{
+		method:    http.MethodPost,
+		path:      "/courses/:code/enrollments/:email/approve",
+		summary:   "Approve a pending enrollment",
+		responses: []response{emptyResponse(http.StatusNoContent, "The enrollment was approved."), unprocessable},
+	},
+	{
+		method:    http.MethodPost,
+		path:      "/courses/:code/enrollments/:email/reject",
+		summary:   "Reject a pending enrollment",
+		responses: []response{emptyResponse(http.StatusNoContent, "The enrollment was rejected."), unprocessable},
+	},
+	{
+		method:  http.MethodPut,
+		path:    "/courses/:code/enrollments/:email/grade",
+		summary: "Record a student's grade",
+		request: jsonRequest(gradeRequest{}),
+		responses: []response{
+			emptyResponse(http.StatusNoContent, "The grade was recorded."),
+			badRequest,
+			notFound,
+			unsupportedMediaType,
+			unprocessable,
+		},
+	},
+	{
+		method:  http.MethodGet,
+		path:    "/courses/:code/roster",
+		summary: "Get a class roster as JSON, CSV or XLSX",
+		responses: []response{
+			{
+				status:       http.StatusOK,
+				description:  "The roster, in the format named by the Accept header.",
+				contentTypes: []contentType{applicationJSON, textCSV, applicationXLSX},
+				body:         rosterResponse{},
+			},
+			notFound,
+			notAcceptable,
+			internalError,
+		},
+	},
+	{
+		method:    http.MethodGet,
+		path:      "/courses/:code/sessions",
+		summary:   "List a course's sessions",
+		responses: []response{jsonResponse(http.StatusOK, "The sessions.", []sessionResponse{}), notFound, internalError},
+	},
+	{
+		method:  http.MethodPost,
+		path:    "/courses/:code/sessions",
+		summary: "Schedule a course's sessions",
+		request: jsonRequest(sessionsRequest{}),
+		responses: []response{
+			jsonResponse(http.StatusCreated, "The sessions created.", []sessionResponse{}),
+			badRequest,
+			notFound,
+			unsupportedMediaType,
+			unprocessable,
+		},
+	},
+	{
+		method:  http.MethodPut,
+		path:    "/courses/:code/sessions/:id/attendance",
+		summary: "Record attendance at a session",
+		request: jsonRequest(attendanceRequest{}),
+		responses: []response{
+			emptyResponse(http.StatusNoContent, "The attendance was recorded."),
+			badRequest,
+			notFound,
+			unsupportedMediaType,
+			unprocessable,
+		},
+	},
+	{
+		method:  http.MethodPost,
+		path:    "/courses/:code/attendance",
+		summary: "Record attendance at several sessions",
+		request: jsonRequest(attendanceRequest{}),
+		responses: []response{
+			emptyResponse(http.StatusNoContent, "The attendance was recorded."),
+			badRequest,
+			notFound,
+			unsupportedMediaType,
+			unprocessable,
+		},
+	},
+	{
+		method:  http.MethodPut,
+		path:    "/courses/:code/instructors/:id",
+		summary: "Assign an instructor to a course",
+		responses: []response{
+			emptyResponse(http.StatusNoContent, "The instructor was assigned."),
+			badRequest,
+			notFound,
+			unprocessable,
+		},
+	},
+	{
+		method:  http.MethodDelete,
+		path:    "/courses/:code/instructors/:id",
+		summary: "Unassign an instructor from a course",
+		responses: []response{
+			emptyResponse(http.StatusNoContent, "The instructor was unassigned."),
+			badRequest,
+			notFound,
+			unprocessable,
+		},
+	},
+	{
+		method:  http.MethodGet,
+		path:    "/courses/:code/availability/stream",
+		summary: "Stream the places remaining in a course as Server-Sent Events",
+		responses: []response{
+			{
+				status:       http.StatusOK,
+				description:  `"availability" events, whose data is {"course_code", "available_spaces"}.`,
+				contentTypes: []contentType{textEventStream},
+			},
+			notFound,
+			internalError,
+		},
+	},
+	{
+		method:    http.MethodGet,
+		path:      "/instructors",
+		summary:   "List instructors",
+		responses: []response{jsonResponse(http.StatusOK, "The instructors.", []instructorResponse{}), internalError},
+	},
+	{
+		method:  http.MethodPost,
+		path:    "/instructors",
+		summary: "Create an instructor",
+		request: jsonRequest(instructorRequest{}),
+		responses: []response{
+			jsonResponse(http.StatusCreated, "The instructor created.", instructorResponse{}),
+			badRequest,
+			unsupportedMediaType,
+			unprocessable,
+		},
+	},
+	{
+		method:  http.MethodGet,
+		path:    "/instructors/:id",
+		summary: "Get an instructor",
+		responses: []response{
+			jsonResponse(http.StatusOK, "The instructor.", instructorResponse{}),
+			badRequest,
+			notFound,
+			internalError,
+		},
+	},
+	{
+		method:  http.MethodPut,
+		path:    "/instructors/:id",
+		summary: "Update an instructor",
+		request: jsonRequest(instructorRequest{}),
+		responses: []response{
+			jsonResponse(http.StatusOK, "The updated instructor.", instructorResponse{}),
+			badRequest,
+			notFound,
+			unsupportedMediaType,
+			unprocessable,
+		},
+	},
+	{
+		method:  http.MethodDelete,
+		path:    "/instructors/:id",
+		summary: "Delete an instructor",
+		responses: []response{
+			emptyResponse(http.StatusNoContent, "The instructor was deleted."),
+			badRequest,
+			notFound,
+			unprocessable,
+		},
+	},
+	{
+		method:  http.MethodGet,
+		path:    "/instructors/:id/classes",
+		summary: "Get the rosters of an instructor's classes",
+		responses: []response{
+			jsonResponse(http.StatusOK, "The rosters.", []rosterResponse{}),
+			badRequest,
+			notFound,
+			internalError,
+		},
+	},
+	{
+		method:    http.MethodGet,
+		path:      "/students/:email/transcript",
+		summary:   "Get a student's transcript",
+		responses: []response{jsonResponse(http.StatusOK, "The transcript.", transcriptResponse{}), notFound, internalError},
+	},
+	{
+		method:  http.MethodGet,
+		path:    "/students/:email/calendar.ics",
+		summary: "Get a student's class sessions as an iCalendar feed",
+		responses: []response{
+			{status: http.StatusOK, description: "The calendar.", contentTypes: []contentType{textCalendar}},
+			notFound,
+			internalError,
+		},
+	},
+	{
+		method:  http.MethodGet,
+		path:    "/students/:email/courses/:code/certificate",
+		summary: "Download a student's certificate of completion",
+		responses: []response{
+			{status: http.StatusOK, description: "The certificate.", contentTypes: []contentType{applicationPDF}},
+			notFound,
+			internalError,
+		},
+	},
+	{
+		method:    http.MethodGet,
+		path:      "/certificates/:code/verify",
+		public:    true,
+		summary:   "Verify a certificate",
+		responses: []response{jsonResponse(http.StatusOK, "The certificate.", certificateResponse{}), notFound, internalError},
+	},
+	{
+		method:  http.MethodPost,
+		path:    "/payments/callback",
+		public:  true,
+		summary: "Confirm payment of an invoice",
+		headers: []string{callbackTokenHeader},
+		request: jsonRequest(paymentCallbackRequest{}),
+		responses: []response{
+			emptyResponse(http.StatusNoContent, "The payment was confirmed."),
+			badRequest,
+			unauthorized,
+			notFound,
+			unsupportedMediaType,
+			unprocessable,
+		},
+	},
+	{
+		method:  http.MethodPost,
+		path:    "/vouchers",
+		summary: "Create a voucher",
+		request: jsonRequest(voucherRequest{}),
+		responses: []response{
+			jsonResponse(http.StatusCreated, "The voucher created.", voucherResponse{}),
+			badRequest,
+			unsupportedMediaType,
+			unprocessable,
+		},
+	},
+	{
+		method:    http.MethodGet,
+		path:      "/vouchers/:code",
+		summary:   "Get a voucher",
+		responses: []response{jsonResponse(http.StatusOK, "The voucher.", voucherResponse{}), notFound, internalError},
+	},
+	{
+		method:  http.MethodPost,
+		path:    "/imports",
+		summary: "Import enrollments from a CSV file",
+		request: &requestBody{
+			contentType: textCSV,
+			description: "A header naming the columns course_code, name, birthdate and email, in any order, and a row for each enrollment. Files over 4 MiB or listing more rows than the server accepts are refused with 413.",
+		},
+		responses: []response{
+			{
+				status:       http.StatusAccepted,
+				description:  "The pending import, whose progress is linked by the Location header.",
+				contentTypes: []contentType{applicationJSON},
+				body:         importResponse{},
+				headers:      []string{"Location"},
+			},
+			badRequest,
+			requestTooLarge,
+			unsupportedMediaType,
+			tooManyRequests,
+			internalError,
+		},
+	},
+	{
+		method:  http.MethodGet,
+		path:    "/imports/:id",
+		summary: "Get the progress of an import",
+		responses: []response{
+			jsonResponse(http.StatusOK, "The import.", importResponse{}),
+			badRequest,
+			notFound,
+			internalError,
+		},
+	},
+}
+
+// unversionedOperations describes the routes outside the versioned API.
+var unversionedOperations = []operation{
+	{
+		method:  http.MethodPost,
+		path:    "/graphql",
+		summary: "Query the GraphQL API",
+		request: jsonRequest(graphQLRequest{}),
+		responses: []response{
+			jsonResponse(http.StatusOK, "The result of the query, whose shape depends on the query.", map[string]any{}),
+			badRequest,
+			unsupportedMediaType,
+		},
+	},
+	{
+		method:    http.MethodGet,
+		path:      "/openapi.json",
+		public:    true,
+		summary:   "Get this OpenAPI document",
+		responses: []response{jsonResponse(http.StatusOK, "The OpenAPI document.", map[string]any{})},
+	},
+}
@@ -0,0 +1,161 @@
+//go:build unit
+
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/angusgmorrison/hexagonal/internal/envconfig"
+	"github.com/angusgmorrison/hexagonal/internal/eventbus"
+	"github.com/angusgmorrison/hexagonal/internal/service/classservice"
+	"github.com/angusgmorrison/hexagonal/internal/service/importservice"
+	"github.com/angusgmorrison/hexagonal/internal/service/instructorservice"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAPIDocument(t *testing.T) {
+	t.Parallel()
+
+	t.Run("describes every route and nothing else", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger = log.New(os.Stdout, "TestOpenAPIDocument ", log.LstdFlags)
+			server = newOpenAPIServer(t, logger, defaultConfig(), classservice.NewMockInterface(t))
+		)
+
+		// Building the document fails for routes without an operation, so it
+		// remains to check that every operation is served.
+		operations := operationsByRoute()
+		for key := range operations {
+			require.Contains(t, server.openAPIRoutes, key, "operation has no route")
+		}
+
+		require.Len(t, server.openAPIRoutes, len(operations), "routes and operations differ")
+	})
+
+	t.Run("is served as JSON", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger = log.New(os.Stdout, "TestOpenAPIDocument ", log.LstdFlags)
+			server = newOpenAPIServer(t, logger, defaultConfig(), classservice.NewMockInterface(t))
+			r      = httptest.NewRequest(http.MethodGet, "/openapi.json", nil)
+			w      = httptest.NewRecorder()
+		)
+
+		server.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusOK, w.Code, "unexpected status code")
+
+		var doc struct {
+			OpenAPI string                    `json:"openapi"`
+			Paths   map[string]map[string]any `json:"paths"`
+		}
+
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &doc), "decode document")
+		require.Equal(t, "3.0.3", doc.OpenAPI)
+		require.Contains(t, doc.Paths, "/courses/{code}/roster")
+		require.Contains(t, doc.Paths["/enroll"], "post")
+	})
+}
+
+func TestValidateOpenAPI(t *testing.T) {
+	t.Parallel()
+
+	const endpoint = "/enroll"
+
+	t.Run("accepts requests and responses matching the document", func(t *testing.T) {
+		t.Parallel()
+
+		fixtureBytes, err := ioutil.ReadFile(filepath.Join("testdata", "enrollment_request.json"))
+		require.NoError(t, err)
+
+		var (
+			logger       = log.New(os.Stdout, "TestValidateOpenAPI ", log.LstdFlags)
+			classService = classservice.NewMockInterface(t)
+			server       = newOpenAPIServer(t, logger, debugConfig(), classService)
+			r            = httptest.NewRequest(http.MethodPost, endpoint, bytes.NewReader(fixtureBytes))
+			w            = httptest.NewRecorder()
+		)
+
+		r.Header.Set("content-type", string(applicationJSON))
+
+		classService.On("Enroll", mock.AnythingOfType("*gin.Context"), mock.Anything).Return(nil)
+
+		server.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusCreated, w.Code, "unexpected status code")
+	})
+
+	t.Run("responds 400 Bad Request to requests that don't match the document", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger = log.New(os.Stdout, "TestValidateOpenAPI ", log.LstdFlags)
+			server = newOpenAPIServer(t, logger, debugConfig(), classservice.NewMockInterface(t))
+			body   = `{"course_code":"SICP","students":"Ramdas Tifft"}`
+			r      = httptest.NewRequest(http.MethodPost, endpoint, bytes.NewBufferString(body))
+			w      = httptest.NewRecorder()
+		)
+
+		r.Header.Set("content-type", string(applicationJSON))
+
+		server.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusBadRequest, w.Code, "unexpected status code")
+	})
+
+	t.Run("leaves unsupported content types to the route", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger = log.New(os.Stdout, "TestValidateOpenAPI ", log.LstdFlags)
+			server = newOpenAPIServer(t, logger, debugConfig(), classservice.NewMockInterface(t))
+			r      = httptest.NewRequest(http.MethodPost, endpoint, bytes.NewBufferString("SICP"))
+			w      = httptest.NewRecorder()
+		)
+
+		r.Header.Set("content-type", "text/plain")
+
+		server.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusUnsupportedMediaType, w.Code, "unexpected status code")
+	})
+}
+
+func newOpenAPIServer(
+	t *testing.T,
+	logger *log.Logger,
+	config envconfig.EnvConfig,
+	classService classservice.Interface,
+) *Server {
+	t.Helper()
+
+	return NewServer(
+		logger,
+		config,
+		classService,
+		instructorservice.NewMockInterface(t),
+		WithImports(importservice.NewMockInterface(t)),
+		WithGraphQL(http.NotFoundHandler()),
+		WithAvailabilityStream(eventbus.New()),
+	)
+}
+
+func debugConfig() envconfig.EnvConfig {
+	config := defaultConfig()
+	config.App.GinMode = gin.DebugMode
+
+	return config
+}
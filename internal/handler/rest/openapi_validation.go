@@ -0,0 +1,114 @@
+package rest
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/gin-gonic/gin"
+)
+
+// bufferedResponseWriter holds back the body of a response so that it can be
+// validated before it's sent.
+type bufferedResponseWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *bufferedResponseWriter) Write(b []byte) (int, error) {
+	return w.body.Write(b)
+}
+
+func (w *bufferedResponseWriter) WriteString(s string) (int, error) {
+	return w.body.WriteString(s)
+}
+
+// validateOpenAPI rejects requests and responses that don't match the
+// server's OpenAPI document. Invalid requests are aborted with 400 Bad Request
+// and invalid responses are replaced by 500 Internal Server Error, so that
+// drift between the handlers and the document is noticed in development.
+//
+// Requests whose content type the operation doesn't accept are passed on, so
+// that the route's contentTypes middleware can reject them. Streamed responses
+// aren't validated, since they can't be held back.
+func (s *Server) validateOpenAPI() gin.HandlerFunc {
+	options := &openapi3filter.Options{AuthenticationFunc: openapi3filter.NoopAuthenticationFunc}
+
+	return func(c *gin.Context) {
+		route, ok := s.openAPIRoutes[routeKey(c.Request.Method, c.FullPath())]
+		if !ok {
+			c.Next()
+
+			return
+		}
+
+		if body := route.Operation.RequestBody; body != nil && body.Value.Content.Get(c.ContentType()) == nil {
+			c.Next()
+
+			return
+		}
+
+		pathParams := make(map[string]string, len(c.Params))
+		for _, param := range c.Params {
+			pathParams[param.Key] = param.Value
+		}
+
+		requestInput := &openapi3filter.RequestValidationInput{
+			Request:    c.Request,
+			PathParams: pathParams,
+			Route:      route,
+			Options:    options,
+		}
+
+		if err := openapi3filter.ValidateRequest(c, requestInput); err != nil {
+			s.logger.Printf("Request doesn't match OpenAPI document: %s", err)
+			c.AbortWithStatus(http.StatusBadRequest)
+
+			return
+		}
+
+		if isStreamed(route.Operation.Responses.Status(http.StatusOK)) {
+			c.Next()
+
+			return
+		}
+
+		original := c.Writer
+		buffered := &bufferedResponseWriter{ResponseWriter: original}
+		c.Writer = buffered
+
+		c.Next()
+
+		c.Writer = original
+
+		responseInput := &openapi3filter.ResponseValidationInput{
+			RequestValidationInput: requestInput,
+			Status:                 original.Status(),
+			Header:                 original.Header(),
+			Body:                   io.NopCloser(bytes.NewReader(buffered.body.Bytes())),
+			Options:                options,
+		}
+
+		if err := openapi3filter.ValidateResponse(c, responseInput); err != nil {
+			s.logger.Printf("Response doesn't match OpenAPI document: %s", err)
+
+			if !original.Written() {
+				original.Header().Del("Content-Type")
+				original.Header().Del("Content-Disposition")
+				original.WriteHeader(http.StatusInternalServerError)
+				original.WriteHeaderNow()
+
+				return
+			}
+		}
+
+		_, _ = original.Write(buffered.body.Bytes())
+	}
+}
+
+// isStreamed reports whether resp is a stream of Server-Sent Events.
+func isStreamed(resp *openapi3.ResponseRef) bool {
+	return resp != nil && resp.Value.Content.Get(string(textEventStream)) != nil
+}
@@ -0,0 +1,59 @@
+package rest
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// callbackTokenHeader is the header in which the payment provider presents the
+// secret configured by BILLING_CALLBACK_TOKEN.
+const callbackTokenHeader = "X-Callback-Token"
+
+type paymentCallbackRequest struct {
+	InvoiceID string `json:"invoice_id"`
+}
+
+// handlePaymentCallback confirms payment of the invoice identified in the
+// request body. It is called by the payment provider, which may report the
+// same payment more than once.
+//
+// The route is public, so callbacks are refused unless BILLING_CALLBACK_TOKEN
+// is set and presented.
+func (s *Server) handlePaymentCallback() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := s.config.Billing.CallbackToken
+		if token == "" {
+			s.logger.Printf("Rejected payment callback: BILLING_CALLBACK_TOKEN isn't set")
+			c.AbortWithStatus(http.StatusUnauthorized)
+
+			return
+		}
+
+		presented := c.GetHeader(callbackTokenHeader)
+		if subtle.ConstantTimeCompare([]byte(presented), []byte(token)) != 1 {
+			s.logger.Printf("Rejected payment callback with invalid %s", callbackTokenHeader)
+			c.AbortWithStatus(http.StatusUnauthorized)
+
+			return
+		}
+
+		var pReq paymentCallbackRequest
+		if err := c.ShouldBind(&pReq); err != nil {
+			s.logger.Printf("Failed to parse payment callback: %s", err)
+			c.AbortWithStatus(http.StatusBadRequest)
+
+			return
+		}
+
+		if err := s.classService.ConfirmPayment(c, pReq.InvoiceID); err != nil {
+			s.logger.Printf("Confirming payment failed: %s", err)
+			c.AbortWithStatus(changeFailureStatus(err))
+
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,126 @@
+//go:build unit
+
+package rest
+
+import (
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/angusgmorrison/hexagonal/internal/service/classservice"
+	"github.com/angusgmorrison/hexagonal/internal/service/instructorservice"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlePaymentCallback(t *testing.T) {
+	t.Parallel()
+
+	const (
+		endpoint = "/payments/callback"
+		body     = `{"invoice_id": "inv_000001"}`
+		secret   = "s3cret"
+	)
+
+	testCases := []struct {
+		name          string
+		callbackToken string
+		header        string
+		body          string
+		serviceErr    error
+		wantCalled    bool
+		wantStatus    int
+	}{
+		{
+			name:          "confirmed",
+			callbackToken: secret,
+			header:        secret,
+			body:          body,
+			wantCalled:    true,
+			wantStatus:    http.StatusNoContent,
+		},
+		{
+			name:       "no token configured",
+			body:       body,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:          "invalid token",
+			callbackToken: secret,
+			header:        "guess",
+			body:          body,
+			wantStatus:    http.StatusUnauthorized,
+		},
+		{
+			name:          "missing token",
+			callbackToken: secret,
+			body:          body,
+			wantStatus:    http.StatusUnauthorized,
+		},
+		{
+			name:          "malformed body",
+			callbackToken: secret,
+			header:        secret,
+			body:          `{"invoice_id": 1}`,
+			wantStatus:    http.StatusBadRequest,
+		},
+		{
+			name:          "invoice not found",
+			callbackToken: secret,
+			header:        secret,
+			body:          body,
+			serviceErr:    classservice.InvoiceNotFoundError{InvoiceID: "inv_000001"},
+			wantCalled:    true,
+			wantStatus:    http.StatusNotFound,
+		},
+		{
+			name:          "not awaiting payment",
+			callbackToken: secret,
+			header:        secret,
+			body:          body,
+			serviceErr:    classservice.NotAwaitingPaymentError{InvoiceID: "inv_000001", CourseCode: "LISP"},
+			wantCalled:    true,
+			wantStatus:    http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			config := defaultConfig()
+			config.Billing.CallbackToken = tc.callbackToken
+
+			var (
+				logger       = log.New(os.Stdout, "TestHandlePaymentCallback ", log.LstdFlags)
+				classService = classservice.NewMockInterface(t)
+				server       = NewServer(logger, config, classService, instructorservice.NewMockInterface(t))
+				r            = httptest.NewRequest(http.MethodPost, endpoint, strings.NewReader(tc.body))
+				w            = httptest.NewRecorder()
+			)
+
+			r.Header.Set("content-type", string(applicationJSON))
+
+			if tc.header != "" {
+				r.Header.Set(callbackTokenHeader, tc.header)
+			}
+
+			if tc.wantCalled {
+				classService.On(
+					"ConfirmPayment",
+					mock.AnythingOfType("*gin.Context"),
+					"inv_000001",
+				).Return(tc.serviceErr)
+			}
+
+			server.ServeHTTP(w, r)
+
+			require.Equal(t, tc.wantStatus, w.Code, "unexpected status code")
+		})
+	}
+}
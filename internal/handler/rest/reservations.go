@@ -0,0 +1,49 @@
+package rest
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/angusgmorrison/hexagonal/internal/primitive"
+	"github.com/gin-gonic/gin"
+)
+
+type reservationRequest struct {
+	Email primitive.EmailAddress `json:"email"`
+}
+
+type reservationResponse struct {
+	CourseCode string                 `json:"course_code"`
+	Email      primitive.EmailAddress `json:"email"`
+	ExpiresAt  time.Time              `json:"expires_at"`
+}
+
+// handleCreateReservation holds a place in the course identified by the code
+// path parameter for the student named in the request body.
+func (s *Server) handleCreateReservation() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var resReq reservationRequest
+		if err := c.ShouldBind(&resReq); err != nil {
+			s.logger.Printf("Failed to parse reservation request: %s", err)
+			c.AbortWithStatus(http.StatusBadRequest)
+
+			return
+		}
+
+		courseCode := c.Param("code")
+
+		reservation, err := s.classService.Reserve(c, courseCode, resReq.Email)
+		if err != nil {
+			s.logger.Printf("Reservation failed: %s", err)
+			c.AbortWithStatus(http.StatusUnprocessableEntity)
+
+			return
+		}
+
+		c.JSON(http.StatusCreated, reservationResponse{
+			CourseCode: courseCode,
+			Email:      resReq.Email,
+			ExpiresAt:  reservation.ExpiresAt,
+		})
+	}
+}
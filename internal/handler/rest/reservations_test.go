@@ -0,0 +1,90 @@
+//go:build unit
+
+package rest
+
+import (
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/angusgmorrison/hexagonal/internal/primitive"
+	"github.com/angusgmorrison/hexagonal/internal/service/classservice"
+	"github.com/angusgmorrison/hexagonal/internal/service/instructorservice"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleCreateReservation(t *testing.T) {
+	t.Parallel()
+
+	const (
+		endpoint = "/courses/SICP/reservations"
+		email    = primitive.EmailAddress("r.tifft@gmail.com")
+	)
+
+	expiresAt := time.Date(2022, time.September, 1, 12, 10, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name        string
+		reservation classservice.Reservation
+		serviceErr  error
+		wantStatus  int
+		wantBody    string
+	}{
+		{
+			name:        "reserved",
+			reservation: classservice.Reservation{ID: 1, StudentID: 1, ExpiresAt: expiresAt},
+			serviceErr:  nil,
+			wantStatus:  http.StatusCreated,
+			wantBody:    `{"course_code":"SICP","email":"r.tifft@gmail.com","expires_at":"2022-09-01T12:10:00Z"}`,
+		},
+		{
+			name:       "class oversubscribed",
+			serviceErr: classservice.OversubscribedError{},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "already reserved",
+			serviceErr: classservice.AlreadyReservedError{},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				logger       = log.New(os.Stdout, "TestHandleCreateReservation ", log.LstdFlags)
+				classService = classservice.NewMockInterface(t)
+				server       = NewServer(logger, defaultConfig(), classService, instructorservice.NewMockInterface(t))
+				body         = strings.NewReader(`{"email": "r.tifft@gmail.com"}`)
+				r            = httptest.NewRequest(http.MethodPost, endpoint, body)
+				w            = httptest.NewRecorder()
+			)
+
+			r.Header.Set("content-type", string(applicationJSON))
+
+			classService.On(
+				"Reserve",
+				mock.AnythingOfType("*gin.Context"),
+				"SICP",
+				email,
+			).Return(tc.reservation, tc.serviceErr)
+
+			server.ServeHTTP(w, r)
+
+			require.Equal(t, tc.wantStatus, w.Code, "unexpected status code")
+
+			if tc.wantBody != "" {
+				require.JSONEq(t, tc.wantBody, w.Body.String(), "unexpected response body")
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"math"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/angusgmorrison/hexagonal/internal/primitive"
+	"github.com/angusgmorrison/hexagonal/internal/service/classservice"
+	"github.com/angusgmorrison/hexagonal/pkg/csvformula"
+	"github.com/gin-gonic/gin"
+	"github.com/xuri/excelize/v2"
+)
+
+// rosterSheet names the worksheet of roster spreadsheets.
+const rosterSheet = "Roster"
+
+// rosterColumns head the columns of exported rosters.
+var rosterColumns = []string{"email", "name", "birthdate", "status", "section", "attendance_percentage"}
+
+// rosterRow returns the cells of an exported roster that describe the entry.
+// The attendance percentage is rounded to one decimal place, and is nil if no
+// attendance has been recorded for the student.
+func rosterRow(entry classservice.RosterEntry) []any {
+	var attendance any
+	if percentage, ok := entry.Attendance.Percentage(); ok {
+		attendance = math.Round(percentage*10) / 10
+	}
+
+	return []any{
+		string(entry.Email),
+		entry.Name,
+		time.Time(entry.Birthdate).Format(primitive.BirthdateLayout),
+		string(entry.Status),
+		entry.SectionCode,
+		attendance,
+	}
+}
+
+// writeRosterCSV responds with the class roster as a CSV file. Text cells are
+// escaped by csvformula.Escape, since names and emails are chosen by students.
+func (s *Server) writeRosterCSV(c *gin.Context, class classservice.Class) {
+	var buf bytes.Buffer
+
+	w := csv.NewWriter(&buf)
+
+	_ = w.Write(rosterColumns)
+
+	for _, entry := range class.Roster() {
+		row := rosterRow(entry)
+		record := make([]string, len(row))
+
+		for i, cell := range row {
+			switch v := cell.(type) {
+			case nil:
+			case float64:
+				record[i] = strconv.FormatFloat(v, 'f', -1, 64)
+			case string:
+				record[i] = csvformula.Escape(v)
+			default:
+				record[i] = fmt.Sprint(v)
+			}
+		}
+
+		_ = w.Write(record)
+	}
+
+	w.Flush()
+
+	if err := w.Error(); err != nil {
+		s.logger.Printf("Writing roster CSV failed: %s", err)
+		c.AbortWithStatus(http.StatusInternalServerError)
+
+		return
+	}
+
+	attachRoster(c, class, "csv")
+	c.Data(http.StatusOK, string(textCSV), buf.Bytes())
+}
+
+// writeRosterXLSX responds with the class roster as an XLSX spreadsheet.
+func (s *Server) writeRosterXLSX(c *gin.Context, class classservice.Class) {
+	buf, err := rosterSpreadsheet(class)
+	if err != nil {
+		s.logger.Printf("Writing roster spreadsheet failed: %s", err)
+		c.AbortWithStatus(http.StatusInternalServerError)
+
+		return
+	}
+
+	attachRoster(c, class, "xlsx")
+	c.Data(http.StatusOK, string(applicationXLSX), buf.Bytes())
+}
+
+func rosterSpreadsheet(class classservice.Class) (*bytes.Buffer, error) {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	if err := f.SetSheetName(f.GetSheetName(0), rosterSheet); err != nil {
+		return nil, fmt.Errorf("name sheet: %w", err)
+	}
+
+	header := make([]any, 0, len(rosterColumns))
+	for _, column := range rosterColumns {
+		header = append(header, column)
+	}
+
+	rows := [][]any{header}
+	for _, entry := range class.Roster() {
+		rows = append(rows, rosterRow(entry))
+	}
+
+	for i, row := range rows {
+		cell, err := excelize.CoordinatesToCellName(1, i+1)
+		if err != nil {
+			return nil, fmt.Errorf("name cell: %w", err)
+		}
+
+		if err := f.SetSheetRow(rosterSheet, cell, &row); err != nil {
+			return nil, fmt.Errorf("write row %d: %w", i+1, err)
+		}
+	}
+
+	buf, err := f.WriteToBuffer()
+	if err != nil {
+		return nil, fmt.Errorf("write spreadsheet: %w", err)
+	}
+
+	return buf, nil
+}
+
+// attachRoster names the file in which clients should save the roster.
+func attachRoster(c *gin.Context, class classservice.Class, extension string) {
+	filename := fmt.Sprintf("%s-roster.%s", class.Code, extension)
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+}
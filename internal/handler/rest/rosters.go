@@ -0,0 +1,154 @@
+package rest
+
+import (
+	"math"
+	"net/http"
+
+	"github.com/angusgmorrison/hexagonal/internal/primitive"
+	"github.com/angusgmorrison/hexagonal/internal/service/classservice"
+	"github.com/gin-gonic/gin"
+)
+
+// rosterResponse represents a class roster. Fee is omitted if the course is
+// free.
+type rosterResponse struct {
+	CourseCode      string               `json:"course_code"`
+	Capacity        uint32               `json:"capacity"`
+	Cancelled       bool                 `json:"cancelled"`
+	Fee             *primitive.Money     `json:"fee,omitempty"`
+	Instructors     []instructorResponse `json:"instructors"`
+	Students        []rosterStudent      `json:"students"`
+	Pending         []rosterStudent      `json:"pending"`
+	AwaitingPayment []rosterStudent      `json:"awaiting_payment"`
+}
+
+// rosterStudent represents a student on a class roster. SectionCode is empty
+// if the course has no sections, or if a pending student named no section.
+// AttendancePercentage is rounded to one decimal place, and is omitted if no
+// attendance has been recorded for the student.
+type rosterStudent struct {
+	Name                 string                 `json:"name"`
+	Birthdate            primitive.Birthdate    `json:"birthdate"`
+	Email                primitive.EmailAddress `json:"email"`
+	SectionCode          string                 `json:"section_code,omitempty"`
+	AttendancePercentage *float64               `json:"attendance_percentage,omitempty"`
+}
+
+func newRosterStudent(entry classservice.RosterEntry) rosterStudent {
+	rs := rosterStudent{
+		Name:        entry.Name,
+		Birthdate:   entry.Birthdate,
+		Email:       entry.Email,
+		SectionCode: entry.SectionCode,
+	}
+
+	if percentage, ok := entry.Attendance.Percentage(); ok {
+		rounded := math.Round(percentage*10) / 10
+		rs.AttendancePercentage = &rounded
+	}
+
+	return rs
+}
+
+func newRosterResponse(class classservice.Class) rosterResponse {
+	var (
+		students        = make([]rosterStudent, 0, len(class.Students))
+		pending         = make([]rosterStudent, 0, len(class.Pending))
+		awaitingPayment = make([]rosterStudent, 0, len(class.AwaitingPayment))
+	)
+
+	for _, entry := range class.Roster() {
+		switch entry.Status {
+		case classservice.RosterEnrolled:
+			students = append(students, newRosterStudent(entry))
+		case classservice.RosterPending:
+			pending = append(pending, newRosterStudent(entry))
+		case classservice.RosterAwaitingPayment:
+			awaitingPayment = append(awaitingPayment, newRosterStudent(entry))
+		}
+	}
+
+	instructors := make([]instructorResponse, 0, len(class.Instructors))
+
+	for _, instructor := range class.Instructors {
+		instructors = append(instructors, instructorResponse{
+			ID:    instructor.ID,
+			Name:  instructor.Name,
+			Email: instructor.Email,
+		})
+	}
+
+	resp := rosterResponse{
+		CourseCode:      class.Code,
+		Capacity:        class.Capacity,
+		Cancelled:       class.Cancelled,
+		Instructors:     instructors,
+		Students:        students,
+		Pending:         pending,
+		AwaitingPayment: awaitingPayment,
+	}
+
+	if !class.Fee.IsZero() {
+		fee := class.Fee
+		resp.Fee = &fee
+	}
+
+	return resp
+}
+
+// handleGetRoster responds with the roster of the course identified by the
+// code path parameter. The roster is JSON by default, and a CSV file or XLSX
+// spreadsheet listing one student per row if the Accept header asks for one.
+func (s *Server) handleGetRoster() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		format := contentType(c.NegotiateFormat(string(applicationJSON), string(textCSV), string(applicationXLSX)))
+		if format == "" {
+			c.AbortWithStatus(http.StatusNotAcceptable)
+
+			return
+		}
+
+		class, err := s.classService.GetClass(c, c.Param("code"))
+		if err != nil {
+			s.logger.Printf("Getting roster failed: %s", err)
+			c.AbortWithStatus(lookupFailureStatus(err))
+
+			return
+		}
+
+		switch format {
+		case textCSV:
+			s.writeRosterCSV(c, class)
+		case applicationXLSX:
+			s.writeRosterXLSX(c, class)
+		default:
+			c.JSON(http.StatusOK, newRosterResponse(class))
+		}
+	}
+}
+
+// handleGetInstructorClasses responds with the rosters of the classes taught by
+// the instructor identified by the id path parameter.
+func (s *Server) handleGetInstructorClasses() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := s.instructorID(c)
+		if !ok {
+			return
+		}
+
+		classes, err := s.classService.GetClassesTaughtBy(c, id)
+		if err != nil {
+			s.logger.Printf("Getting instructor's classes failed: %s", err)
+			c.AbortWithStatus(lookupFailureStatus(err))
+
+			return
+		}
+
+		rosters := make([]rosterResponse, 0, len(classes))
+		for _, class := range classes {
+			rosters = append(rosters, newRosterResponse(class))
+		}
+
+		c.JSON(http.StatusOK, rosters)
+	}
+}
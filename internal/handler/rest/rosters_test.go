@@ -0,0 +1,319 @@
+//go:build unit
+
+package rest
+
+import (
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/angusgmorrison/hexagonal/internal/primitive"
+	"github.com/angusgmorrison/hexagonal/internal/service/classservice"
+	"github.com/angusgmorrison/hexagonal/internal/service/instructorservice"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+)
+
+func TestHandleGetRoster(t *testing.T) {
+	t.Parallel()
+
+	const endpoint = "/courses/TAOCP/roster"
+
+	t.Run("responds with the roster", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger       = log.New(os.Stdout, "TestHandleGetRoster ", log.LstdFlags)
+			classService = classservice.NewMockInterface(t)
+			server       = NewServer(logger, defaultConfig(), classService, instructorservice.NewMockInterface(t))
+			r            = httptest.NewRequest(http.MethodGet, endpoint, nil)
+			w            = httptest.NewRecorder()
+		)
+
+		classService.On(
+			"GetClass",
+			mock.AnythingOfType("*gin.Context"),
+			"TAOCP",
+		).Return(rosterClass(t), nil)
+
+		server.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusOK, w.Code, "unexpected status code")
+		require.JSONEq(t, `{
+			"course_code": "TAOCP",
+			"capacity": 6,
+			"cancelled": false,
+			"fee": {"amount": 12500, "currency": "GBP"},
+			"instructors": [{"id": 1, "name": "Donald Knuth", "email": "knuth@stanford.edu"}],
+			"students": [
+				{"name": "Berthe Archibald", "birthdate": "1987-09-03", "email": "berthe@archibaldindustries.com", "section_code": "A", "attendance_percentage": 66.7}
+			],
+			"pending": [
+				{"name": "Ramdas Tifft", "birthdate": "1991-10-03", "email": "r.tifft@gmail.com"}
+			],
+			"awaiting_payment": [
+				{"name": "Bert Rainey", "birthdate": "1984-02-29", "email": "bert@rainey.org", "section_code": "B"}
+			]
+		}`, w.Body.String(), "unexpected body")
+	})
+
+	t.Run("responds 404 Not Found to unknown courses", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger       = log.New(os.Stdout, "TestHandleGetRoster ", log.LstdFlags)
+			classService = classservice.NewMockInterface(t)
+			server       = NewServer(logger, defaultConfig(), classService, instructorservice.NewMockInterface(t))
+			r            = httptest.NewRequest(http.MethodGet, endpoint, nil)
+			w            = httptest.NewRecorder()
+		)
+
+		classService.On(
+			"GetClass",
+			mock.AnythingOfType("*gin.Context"),
+			"TAOCP",
+		).Return(classservice.Class{}, classservice.CourseNotFoundError{CourseCode: "TAOCP"})
+
+		server.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusNotFound, w.Code, "unexpected status code")
+	})
+
+	t.Run("responds with a CSV roster", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger       = log.New(os.Stdout, "TestHandleGetRoster ", log.LstdFlags)
+			classService = classservice.NewMockInterface(t)
+			server       = NewServer(logger, defaultConfig(), classService, instructorservice.NewMockInterface(t))
+			r            = httptest.NewRequest(http.MethodGet, endpoint, nil)
+			w            = httptest.NewRecorder()
+		)
+
+		r.Header.Set("accept", string(textCSV))
+
+		classService.On(
+			"GetClass",
+			mock.AnythingOfType("*gin.Context"),
+			"TAOCP",
+		).Return(rosterClass(t), nil)
+
+		server.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusOK, w.Code, "unexpected status code")
+		require.Equal(t, string(textCSV), w.Header().Get("content-type"))
+		require.Equal(t, `attachment; filename=TAOCP-roster.csv`, w.Header().Get("content-disposition"))
+		require.Equal(t,
+			"email,name,birthdate,status,section,attendance_percentage\n"+
+				"berthe@archibaldindustries.com,Berthe Archibald,1987-09-03,enrolled,A,66.7\n"+
+				"r.tifft@gmail.com,Ramdas Tifft,1991-10-03,pending,,\n"+
+				"bert@rainey.org,Bert Rainey,1984-02-29,awaiting_payment,B,\n",
+			w.Body.String(), "unexpected body")
+	})
+
+	t.Run("escapes formulas in CSV rosters", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger       = log.New(os.Stdout, "TestHandleGetRoster ", log.LstdFlags)
+			classService = classservice.NewMockInterface(t)
+			server       = NewServer(logger, defaultConfig(), classService, instructorservice.NewMockInterface(t))
+			r            = httptest.NewRequest(http.MethodGet, endpoint, nil)
+			w            = httptest.NewRecorder()
+			class        = rosterClass(t)
+		)
+
+		class.Students[0].Name = `=HYPERLINK("http://evil.example","Berthe")`
+		class.Pending[0].Name = "@SUM(A1:A2)"
+		class.AwaitingPayment[0].Name = "-Bert"
+
+		r.Header.Set("accept", string(textCSV))
+
+		classService.On(
+			"GetClass",
+			mock.AnythingOfType("*gin.Context"),
+			"TAOCP",
+		).Return(class, nil)
+
+		server.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusOK, w.Code, "unexpected status code")
+		require.Equal(t,
+			"email,name,birthdate,status,section,attendance_percentage\n"+
+				`berthe@archibaldindustries.com,"'=HYPERLINK(""http://evil.example"",""Berthe"")",1987-09-03,enrolled,A,66.7`+"\n"+
+				"r.tifft@gmail.com,'@SUM(A1:A2),1991-10-03,pending,,\n"+
+				"bert@rainey.org,'-Bert,1984-02-29,awaiting_payment,B,\n",
+			w.Body.String(), "unexpected body")
+	})
+
+	t.Run("responds with an XLSX roster", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger       = log.New(os.Stdout, "TestHandleGetRoster ", log.LstdFlags)
+			classService = classservice.NewMockInterface(t)
+			server       = NewServer(logger, defaultConfig(), classService, instructorservice.NewMockInterface(t))
+			r            = httptest.NewRequest(http.MethodGet, endpoint, nil)
+			w            = httptest.NewRecorder()
+		)
+
+		r.Header.Set("accept", string(applicationXLSX))
+
+		classService.On(
+			"GetClass",
+			mock.AnythingOfType("*gin.Context"),
+			"TAOCP",
+		).Return(rosterClass(t), nil)
+
+		server.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusOK, w.Code, "unexpected status code")
+		require.Equal(t, string(applicationXLSX), w.Header().Get("content-type"))
+
+		f, err := excelize.OpenReader(w.Body)
+		require.NoError(t, err, "open spreadsheet")
+
+		defer f.Close()
+
+		rows, err := f.GetRows(rosterSheet)
+		require.NoError(t, err, "read spreadsheet")
+		require.Equal(t, [][]string{
+			{"email", "name", "birthdate", "status", "section", "attendance_percentage"},
+			{"berthe@archibaldindustries.com", "Berthe Archibald", "1987-09-03", "enrolled", "A", "66.7"},
+			{"r.tifft@gmail.com", "Ramdas Tifft", "1991-10-03", "pending"},
+			{"bert@rainey.org", "Bert Rainey", "1984-02-29", "awaiting_payment", "B"},
+		}, rows)
+	})
+
+	t.Run("responds 406 Not Acceptable to unsupported formats", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger = log.New(os.Stdout, "TestHandleGetRoster ", log.LstdFlags)
+			server = NewServer(logger, defaultConfig(), classservice.NewMockInterface(t), instructorservice.NewMockInterface(t))
+			r      = httptest.NewRequest(http.MethodGet, endpoint, nil)
+			w      = httptest.NewRecorder()
+		)
+
+		r.Header.Set("accept", "text/html")
+
+		server.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusNotAcceptable, w.Code, "unexpected status code")
+	})
+}
+
+func TestHandleGetInstructorClasses(t *testing.T) {
+	t.Parallel()
+
+	const endpoint = "/instructors/1/classes"
+
+	testCases := []struct {
+		name       string
+		classes    []classservice.Class
+		serviceErr error
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "no classes",
+			classes:    nil,
+			serviceErr: nil,
+			wantStatus: http.StatusOK,
+			wantBody:   `[]`,
+		},
+		{
+			name:       "instructor not found",
+			serviceErr: classservice.InstructorNotFoundError{ID: 1},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				logger       = log.New(os.Stdout, "TestHandleGetInstructorClasses ", log.LstdFlags)
+				classService = classservice.NewMockInterface(t)
+				server       = NewServer(logger, defaultConfig(), classService, instructorservice.NewMockInterface(t))
+				r            = httptest.NewRequest(http.MethodGet, endpoint, nil)
+				w            = httptest.NewRecorder()
+			)
+
+			classService.On(
+				"GetClassesTaughtBy",
+				mock.AnythingOfType("*gin.Context"),
+				int64(1),
+			).Return(tc.classes, tc.serviceErr)
+
+			server.ServeHTTP(w, r)
+
+			require.Equal(t, tc.wantStatus, w.Code, "unexpected status code")
+
+			if tc.wantBody != "" {
+				require.JSONEq(t, tc.wantBody, w.Body.String(), "unexpected body")
+			}
+		})
+	}
+}
+
+// rosterClass returns a sectioned class with a fee, one enrolled student, one
+// pending student who named no section, one student awaiting payment and one
+// instructor.
+func rosterClass(t *testing.T) classservice.Class {
+	t.Helper()
+
+	enrolledBirthdate, err := primitive.ParseBirthdate("1987-09-03")
+	require.NoError(t, err, "parse birthdate")
+
+	pendingBirthdate, err := primitive.ParseBirthdate("1991-10-03")
+	require.NoError(t, err, "parse birthdate")
+
+	awaitingBirthdate, err := primitive.ParseBirthdate("1984-02-29")
+	require.NoError(t, err, "parse birthdate")
+
+	fee, err := primitive.NewMoney(12500, "GBP")
+	require.NoError(t, err, "create fee")
+
+	enrolled := classservice.Student{
+		ID:        1,
+		Name:      "Berthe Archibald",
+		Birthdate: enrolledBirthdate,
+		Email:     "berthe@archibaldindustries.com",
+	}
+	pending := classservice.Student{
+		ID:        2,
+		Name:      "Ramdas Tifft",
+		Birthdate: pendingBirthdate,
+		Email:     "r.tifft@gmail.com",
+	}
+	awaiting := classservice.Student{
+		ID:        3,
+		Name:      "Bert Rainey",
+		Birthdate: awaitingBirthdate,
+		Email:     "bert@rainey.org",
+	}
+
+	return classservice.Class{
+		Course: classservice.Course{
+			Code:     "TAOCP",
+			Capacity: 6,
+			Fee:      fee,
+			Sections: classservice.Sections{
+				{Code: "A", Capacity: 3, Students: classservice.Students{enrolled}},
+				{Code: "B", Capacity: 3, AwaitingPayment: classservice.Students{awaiting}},
+			},
+		},
+		Students:        classservice.Students{enrolled},
+		Pending:         classservice.Students{pending},
+		AwaitingPayment: classservice.Students{awaiting},
+		Instructors:     classservice.Instructors{{ID: 1, Name: "Donald Knuth", Email: "knuth@stanford.edu"}},
+		Attendance:      classservice.StudentAttendance{enrolled.ID: {Attended: 2, Recorded: 3}},
+	}
+}
@@ -1,15 +1,118 @@
 package rest
 
 import (
+	"time"
+
 	"github.com/gin-gonic/gin"
 )
 
+// Each version of the REST API is served under its own path prefix.
+const (
+	v1Prefix = "/v1"
+	v2Prefix = "/v2"
+)
+
+// legacyRoutesDeprecatedAt is when versioning was introduced, deprecating the
+// unversioned routes that preceded it.
+var legacyRoutesDeprecatedAt = time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC)
+
 func (s *Server) setupRoutes() {
 	router := gin.New()
 
 	router.Use(globalServerMiddleware()...)
 
-	router.POST("/enroll", s.handleCreateEnrollments())
+	if s.config.App.GinMode == gin.DebugMode {
+		router.Use(s.validateOpenAPI())
+	}
+
+	// The unversioned routes serve v1 until their sunset, so that clients
+	// written before versioning continue to work in the meantime.
+	s.setupV1Routes(router.Group("/", deprecated(legacyRoutesDeprecatedAt, s.config.HTTP.LegacyRoutesSunset, v1Prefix)))
+	s.setupV1Routes(router.Group(v1Prefix))
+	s.setupV2Routes(router.Group(v2Prefix))
+
+	// GraphQL evolves its schema in place, so it isn't versioned.
+	if s.graphQLHandler != nil {
+		s.authenticated(router).POST("/graphql", contentTypes(applicationJSON), gin.WrapH(s.graphQLHandler))
+	}
+
+	router.GET("/openapi.json", s.handleGetOpenAPI())
+
+	s.openAPI = mustOpenAPIDocument(s.config.App.Name, router.Routes(), s.authService != nil)
+	s.openAPIRoutes = openAPIRoutes(s.openAPI)
+	s.server.Handler = withoutStreamDeadlines(router)
+}
+
+// setupV1Routes registers the routes of v1 of the API, whose enrollment and
+// transfer requests describe each student in full.
+func (s *Server) setupV1Routes(router gin.IRouter) {
+	var (
+		acceptJSON    = contentTypes(applicationJSON)
+		authenticated = s.authenticated(router)
+	)
+
+	authenticated.POST("/enroll", acceptJSON, s.handleCreateEnrollments())
+	authenticated.POST("/transfers", acceptJSON, s.handleCreateTransfer())
+
+	s.setupCommonRoutes(router, authenticated)
+}
+
+// setupV2Routes registers the routes of v2 of the API, whose enrollment and
+// transfer requests identify students by email address alone.
+func (s *Server) setupV2Routes(router gin.IRouter) {
+	var (
+		acceptJSON    = contentTypes(applicationJSON)
+		authenticated = s.authenticated(router)
+	)
+
+	authenticated.POST("/courses/:code/enrollments", acceptJSON, s.handleCreateEnrollmentsV2())
+	authenticated.POST("/transfers", acceptJSON, s.handleCreateTransferV2())
+
+	s.setupCommonRoutes(router, authenticated)
+}
+
+// setupCommonRoutes registers the routes that are the same in every version
+// of the API, either on public or, if they require authentication, on
+// authenticated.
+func (s *Server) setupCommonRoutes(public, authenticated gin.IRoutes) {
+	acceptJSON := contentTypes(applicationJSON)
+
+	// Anyone presented with a certificate may verify it, and the payment
+	// provider authenticates using BILLING_CALLBACK_TOKEN instead.
+	public.GET("/certificates/:code/verify", s.handleVerifyCertificate())
+	public.POST("/payments/callback", acceptJSON, s.handlePaymentCallback())
+
+	authenticated.POST("/rules/validate", acceptJSON, s.handleValidateRule())
+	authenticated.POST("/courses/:code/cancel", s.handleCancelCourse())
+	authenticated.POST("/courses/:code/reservations", acceptJSON, s.handleCreateReservation())
+	authenticated.POST("/courses/:code/enrollments/:email/approve", s.handleApproveEnrollment())
+	authenticated.POST("/courses/:code/enrollments/:email/reject", s.handleRejectEnrollment())
+	authenticated.PUT("/courses/:code/enrollments/:email/grade", acceptJSON, s.handleRecordGrade())
+	authenticated.GET("/courses/:code/roster", s.handleGetRoster())
+	authenticated.GET("/courses/:code/sessions", s.handleListSessions())
+	authenticated.POST("/courses/:code/sessions", acceptJSON, s.handleCreateSessions())
+	authenticated.PUT("/courses/:code/sessions/:id/attendance", acceptJSON, s.handleRecordSessionAttendance())
+	authenticated.POST("/courses/:code/attendance", acceptJSON, s.handleRecordAttendance())
+	authenticated.PUT("/courses/:code/instructors/:id", s.handleAssignInstructor())
+	authenticated.DELETE("/courses/:code/instructors/:id", s.handleUnassignInstructor())
+	authenticated.GET("/instructors", s.handleListInstructors())
+	authenticated.POST("/instructors", acceptJSON, s.handleCreateInstructor())
+	authenticated.GET("/instructors/:id", s.handleGetInstructor())
+	authenticated.PUT("/instructors/:id", acceptJSON, s.handleUpdateInstructor())
+	authenticated.DELETE("/instructors/:id", s.handleDeleteInstructor())
+	authenticated.GET("/instructors/:id/classes", s.handleGetInstructorClasses())
+	authenticated.GET("/students/:email/transcript", s.handleGetTranscript())
+	authenticated.GET("/students/:email/calendar.ics", s.handleGetCalendar())
+	authenticated.GET("/students/:email/courses/:code/certificate", s.handleGetCertificate())
+	authenticated.POST("/vouchers", acceptJSON, s.handleCreateVoucher())
+	authenticated.GET("/vouchers/:code", s.handleGetVoucher())
+
+	if s.importService != nil {
+		authenticated.POST("/imports", contentTypes(textCSV), s.handleCreateImport())
+		authenticated.GET("/imports/:id", s.handleGetImport())
+	}
 
-	s.server.Handler = router
+	if s.availabilitySubscriber != nil {
+		authenticated.GET("/courses/:code/availability/stream", s.handleStreamAvailability())
+	}
 }
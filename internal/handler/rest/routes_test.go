@@ -0,0 +1,81 @@
+//go:build unit
+
+package rest
+
+import (
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/angusgmorrison/hexagonal/internal/service/classservice"
+	"github.com/angusgmorrison/hexagonal/internal/service/instructorservice"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersionedRoutes(t *testing.T) {
+	t.Parallel()
+
+	newServer := func(t *testing.T) *Server {
+		t.Helper()
+
+		config := defaultConfig()
+		config.HTTP.LegacyRoutesSunset = time.Date(2027, time.April, 18, 0, 0, 0, 0, time.UTC)
+
+		return NewServer(
+			log.New(os.Stdout, "TestVersionedRoutes ", log.LstdFlags),
+			config,
+			classservice.NewMockInterface(t),
+			instructorservice.NewMockInterface(t),
+		)
+	}
+
+	t.Run("unversioned routes serve v1 with deprecation headers", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			server = newServer(t)
+			r      = httptest.NewRequest(http.MethodPost, "/enroll", nil)
+			w      = httptest.NewRecorder()
+		)
+
+		server.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusUnsupportedMediaType, w.Code, "unexpected status code")
+		require.Equal(t, "@1792281600", w.Header().Get("Deprecation"))
+		require.Equal(t, "Sun, 18 Apr 2027 00:00:00 GMT", w.Header().Get("Sunset"))
+		require.Equal(t, `</v1/enroll>; rel="successor-version"`, w.Header().Get("Link"))
+	})
+
+	t.Run("versioned routes aren't deprecated", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			server = newServer(t)
+			r      = httptest.NewRequest(http.MethodPost, "/v1/enroll", nil)
+			w      = httptest.NewRecorder()
+		)
+
+		server.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusUnsupportedMediaType, w.Code, "unexpected status code")
+		require.Empty(t, w.Header().Get("Deprecation"))
+		require.Empty(t, w.Header().Get("Sunset"))
+	})
+
+	t.Run("routes replaced in v2 aren't served by it", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			server = newServer(t)
+			r      = httptest.NewRequest(http.MethodPost, "/v2/enroll", nil)
+			w      = httptest.NewRecorder()
+		)
+
+		server.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusNotFound, w.Code, "unexpected status code")
+	})
+}
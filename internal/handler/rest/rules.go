@@ -0,0 +1,82 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/angusgmorrison/hexagonal/internal/service/classservice"
+	"github.com/angusgmorrison/hexagonal/pkg/rule"
+	"github.com/gin-gonic/gin"
+)
+
+// maxRuleRequestBytes is the largest rule validation request accepted. It
+// leaves room for a rule of rule.MaxSourceLength bytes escaped as JSON.
+const maxRuleRequestBytes = 8 * rule.MaxSourceLength
+
+type ruleValidationRequest struct {
+	Rule string `json:"rule"`
+}
+
+type ruleValidationResponse struct {
+	Valid  bool          `json:"valid"`
+	Errors []ruleProblem `json:"errors,omitempty"`
+}
+
+type ruleProblem struct {
+	Offset  int    `json:"offset"`
+	Message string `json:"message"`
+}
+
+func ruleProblemsFromDomain(problems []classservice.RuleProblem) []ruleProblem {
+	ruleProblems := make([]ruleProblem, 0, len(problems))
+
+	for _, p := range problems {
+		ruleProblems = append(ruleProblems, ruleProblem{Offset: p.Offset, Message: p.Message})
+	}
+
+	return ruleProblems
+}
+
+// handleValidateRule compiles an enrollment rule received over HTTP and
+// reports any errors found.
+func (s *Server) handleValidateRule() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxRuleRequestBytes)
+
+		var ruleReq ruleValidationRequest
+		if err := c.ShouldBind(&ruleReq); err != nil {
+			s.logger.Printf("Failed to parse rule validation request: %s", err)
+
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				c.AbortWithStatus(http.StatusRequestEntityTooLarge)
+
+				return
+			}
+
+			c.AbortWithStatus(http.StatusBadRequest)
+
+			return
+		}
+
+		err := s.classService.ValidateEnrollmentRule(c, ruleReq.Rule)
+		if err == nil {
+			c.JSON(http.StatusOK, ruleValidationResponse{Valid: true})
+
+			return
+		}
+
+		var invalidRuleErr classservice.InvalidRuleError
+		if !errors.As(err, &invalidRuleErr) {
+			s.logger.Printf("Rule validation failed: %s", err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+
+			return
+		}
+
+		c.JSON(http.StatusUnprocessableEntity, ruleValidationResponse{
+			Valid:  false,
+			Errors: ruleProblemsFromDomain(invalidRuleErr.Problems),
+		})
+	}
+}
@@ -0,0 +1,108 @@
+//go:build unit
+
+package rest
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/angusgmorrison/hexagonal/internal/service/classservice"
+	"github.com/angusgmorrison/hexagonal/internal/service/instructorservice"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleValidateRule(t *testing.T) {
+	t.Parallel()
+
+	const (
+		endpoint = "/rules/validate"
+		rule     = `student.age >= "16"`
+	)
+
+	testCases := []struct {
+		name       string
+		serviceErr error
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "valid rule",
+			serviceErr: nil,
+			wantStatus: http.StatusOK,
+			wantBody:   `{"valid":true}`,
+		},
+		{
+			name: "invalid rule",
+			serviceErr: classservice.InvalidRuleError{
+				Rule: rule,
+				Problems: []classservice.RuleProblem{
+					{Offset: 12, Message: "operator >= cannot be applied to int and string"},
+				},
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody:   `{"valid":false,"errors":[{"offset":12,"message":"operator >= cannot be applied to int and string"}]}`,
+		},
+		{
+			name:       "unexpected error",
+			serviceErr: errors.New("boom"),
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				logger       = log.New(os.Stdout, "TestHandleValidateRule ", log.LstdFlags)
+				classService = classservice.NewMockInterface(t)
+				server       = NewServer(logger, defaultConfig(), classService, instructorservice.NewMockInterface(t))
+				body         = strings.NewReader(`{"rule": "student.age >= \"16\""}`)
+				r            = httptest.NewRequest(http.MethodPost, endpoint, body)
+				w            = httptest.NewRecorder()
+			)
+
+			r.Header.Set("content-type", string(applicationJSON))
+
+			classService.On(
+				"ValidateEnrollmentRule",
+				mock.AnythingOfType("*gin.Context"),
+				rule,
+			).Return(tc.serviceErr)
+
+			server.ServeHTTP(w, r)
+
+			require.Equal(t, tc.wantStatus, w.Code, "unexpected status code")
+
+			if tc.wantBody != "" {
+				require.JSONEq(t, tc.wantBody, w.Body.String(), "unexpected response body")
+			}
+		})
+	}
+
+	t.Run("responds 413 Request Entity Too Large to oversized requests", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger = log.New(os.Stdout, "TestHandleValidateRule ", log.LstdFlags)
+			server = NewServer(logger, defaultConfig(), classservice.NewMockInterface(t), instructorservice.NewMockInterface(t))
+			body   = strings.NewReader(`{"rule": "` + strings.Repeat("!", maxRuleRequestBytes) + `true"}`)
+			r      = httptest.NewRequest(http.MethodPost, endpoint, body)
+			w      = httptest.NewRecorder()
+		)
+
+		r.Header.Set("content-type", string(applicationJSON))
+
+		server.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusRequestEntityTooLarge, w.Code, "unexpected status code")
+	})
+}
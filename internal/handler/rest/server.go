@@ -11,7 +11,12 @@ import (
 	"syscall"
 
 	"github.com/angusgmorrison/hexagonal/internal/envconfig"
+	"github.com/angusgmorrison/hexagonal/internal/service/authservice"
 	"github.com/angusgmorrison/hexagonal/internal/service/classservice"
+	"github.com/angusgmorrison/hexagonal/internal/service/importservice"
+	"github.com/angusgmorrison/hexagonal/internal/service/instructorservice"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/routers"
 )
 
 // Server provides HTTP routing and handler dependencies.
@@ -30,7 +35,67 @@ type Server struct {
 
 	// Services are the interfaces by which handlers communicate requests to
 	// business logic.
-	classService classservice.Interface
+	classService      classservice.Interface
+	instructorService instructorservice.Interface
+
+	// importService runs bulk enrollment imports, if configured.
+	importService importservice.Interface
+
+	// authService authenticates clients, if configured. Otherwise, every
+	// route is public.
+	authService authservice.Interface
+
+	// graphQLHandler serves the GraphQL API, if configured.
+	graphQLHandler http.Handler
+
+	// availabilitySubscriber feeds streams of course availability, if
+	// configured.
+	availabilitySubscriber AvailabilitySubscriber
+
+	// shuttingDown is closed when the server begins to shut down, ending any
+	// open streams so that their connections can close.
+	shuttingDown chan struct{}
+
+	// openAPI describes the server's routes, which are looked up in
+	// openAPIRoutes by method and path when validating requests.
+	openAPI       *openapi3.T
+	openAPIRoutes map[string]*routers.Route
+}
+
+// Option configures optional behaviour of the Server returned by NewServer.
+type Option func(*Server)
+
+// WithGraphQL serves the GraphQL API provided by handler at POST /graphql.
+func WithGraphQL(handler http.Handler) Option {
+	return func(s *Server) {
+		s.graphQLHandler = handler
+	}
+}
+
+// WithAvailabilityStream serves streams of the places remaining in each
+// course, fed by subscriber, at GET /courses/:code/availability/stream in each
+// version of the API.
+func WithAvailabilityStream(subscriber AvailabilitySubscriber) Option {
+	return func(s *Server) {
+		s.availabilitySubscriber = subscriber
+	}
+}
+
+// WithImports serves bulk enrollment imports, run by importService, at
+// /imports in each version of the API.
+func WithImports(importService importservice.Interface) Option {
+	return func(s *Server) {
+		s.importService = importService
+	}
+}
+
+// WithAuthentication requires clients to authenticate using authService,
+// except on routes that must remain public: the OpenAPI document, certificate
+// verification, and payment callbacks, which carry their own token.
+func WithAuthentication(authService authservice.Interface) Option {
+	return func(s *Server) {
+		s.authService = authService
+	}
 }
 
 // NewServer returns a new hexagonal server configured using the provided Config.
@@ -38,6 +103,8 @@ func NewServer(
 	logger *log.Logger,
 	envConfig envconfig.EnvConfig,
 	classService classservice.Interface,
+	instructorService instructorservice.Interface,
+	opts ...Option,
 ) *Server {
 	server := Server{
 		config: envConfig,
@@ -47,10 +114,17 @@ func NewServer(
 			ReadTimeout:  envConfig.HTTP.ReadTimeout,
 			WriteTimeout: envConfig.HTTP.WriteTimeout,
 		},
-		errorStream:  make(chan error, 1),
-		classService: classService,
+		errorStream:       make(chan error, 1),
+		shuttingDown:      make(chan struct{}),
+		classService:      classService,
+		instructorService: instructorService,
+	}
+
+	for _, opt := range opts {
+		opt(&server)
 	}
 
+	server.server.RegisterOnShutdown(func() { close(server.shuttingDown) })
 	server.setupRoutes()
 
 	return &server
@@ -0,0 +1,69 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type transferRequest struct {
+	FromCourseCode string   `json:"from_course_code"`
+	ToCourseCode   string   `json:"to_course_code"`
+	Students       students `json:"students"`
+}
+
+// transferRequestV2 is the body of a v2 transfer request, which identifies
+// students by email address alone.
+type transferRequestV2 struct {
+	FromCourseCode string        `json:"from_course_code"`
+	ToCourseCode   string        `json:"to_course_code"`
+	StudentEmails  studentEmails `json:"student_emails"`
+}
+
+// handleCreateTransfer receives requests to move students from one course to
+// another over HTTP and executes them.
+func (s *Server) handleCreateTransfer() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var tReq transferRequest
+		if err := c.ShouldBind(&tReq); err != nil {
+			s.logger.Printf("Failed to parse transfer request: %s", err)
+			c.AbortWithStatus(http.StatusBadRequest)
+
+			return
+		}
+
+		err := s.classService.Transfer(c, tReq.FromCourseCode, tReq.ToCourseCode, tReq.Students.toDomain())
+		if err != nil {
+			s.logger.Printf("Transfer failed: %s", err)
+			c.AbortWithStatus(http.StatusUnprocessableEntity)
+
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// handleCreateTransferV2 moves the students named by a v2 transfer request from
+// one course to another.
+func (s *Server) handleCreateTransferV2() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var tReq transferRequestV2
+		if err := c.ShouldBind(&tReq); err != nil {
+			s.logger.Printf("Failed to parse transfer request: %s", err)
+			c.AbortWithStatus(http.StatusBadRequest)
+
+			return
+		}
+
+		err := s.classService.Transfer(c, tReq.FromCourseCode, tReq.ToCourseCode, tReq.StudentEmails.toDomain())
+		if err != nil {
+			s.logger.Printf("Transfer failed: %s", err)
+			c.AbortWithStatus(http.StatusUnprocessableEntity)
+
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
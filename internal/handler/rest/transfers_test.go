@@ -0,0 +1,138 @@
+//go:build unit
+
+package rest
+
+import (
+	"bytes"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/angusgmorrison/hexagonal/internal/primitive"
+	"github.com/angusgmorrison/hexagonal/internal/service/classservice"
+	"github.com/angusgmorrison/hexagonal/internal/service/instructorservice"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleCreateTransfer(t *testing.T) {
+	t.Parallel()
+
+	const endpoint = "/transfers"
+
+	t.Run("responds 415 Unsupported Media Type to non-JSON requests", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger       = log.New(os.Stdout, "TestHandleCreateTransfer ", log.LstdFlags)
+			classService = classservice.NewMockInterface(t)
+			server       = NewServer(logger, defaultConfig(), classService, instructorservice.NewMockInterface(t))
+			r            = httptest.NewRequest(http.MethodPost, endpoint, nil)
+			w            = httptest.NewRecorder()
+		)
+
+		server.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusUnsupportedMediaType, w.Code, "unexpected status code")
+	})
+
+	testCases := []struct {
+		name       string
+		serviceErr error
+		wantStatus int
+	}{
+		{
+			name:       "transferred",
+			serviceErr: nil,
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "students not enrolled",
+			serviceErr: classservice.NotEnrolledError{},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "target class oversubscribed",
+			serviceErr: classservice.OversubscribedError{},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "students already enrolled in target class",
+			serviceErr: classservice.AlreadyEnrolledError{},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			fixtureBytes, err := ioutil.ReadFile(filepath.Join("testdata", "transfer_request.json"))
+			require.NoError(t, err)
+
+			var (
+				logger       = log.New(os.Stdout, "TestHandleCreateTransfer ", log.LstdFlags)
+				classService = classservice.NewMockInterface(t)
+				server       = NewServer(logger, defaultConfig(), classService, instructorservice.NewMockInterface(t))
+				r            = httptest.NewRequest(http.MethodPost, endpoint, bytes.NewReader(fixtureBytes))
+				w            = httptest.NewRecorder()
+			)
+
+			r.Header.Set("content-type", string(applicationJSON))
+
+			birthdate, err := primitive.ParseBirthdate("1991-10-03")
+			require.NoError(t, err)
+
+			classService.On(
+				"Transfer",
+				mock.AnythingOfType("*gin.Context"),
+				"SICP",
+				"TAOCP",
+				classservice.Students{
+					{
+						Name:      "Ramdas Tifft",
+						Birthdate: birthdate,
+						Email:     "r.tifft@gmail.com",
+					},
+				},
+			).Return(tc.serviceErr)
+
+			server.ServeHTTP(w, r)
+
+			require.Equal(t, tc.wantStatus, w.Code, "unexpected status code")
+		})
+	}
+}
+
+func TestHandleCreateTransferV2(t *testing.T) {
+	t.Parallel()
+
+	var (
+		logger       = log.New(os.Stdout, "TestHandleCreateTransferV2 ", log.LstdFlags)
+		classService = classservice.NewMockInterface(t)
+		server       = NewServer(logger, defaultConfig(), classService, instructorservice.NewMockInterface(t))
+		body         = `{"from_course_code":"SICP","to_course_code":"HTDP","student_emails":["r.tifft@gmail.com"]}`
+		r            = httptest.NewRequest(http.MethodPost, "/v2/transfers", bytes.NewBufferString(body))
+		w            = httptest.NewRecorder()
+	)
+
+	r.Header.Set("content-type", string(applicationJSON))
+
+	classService.On(
+		"Transfer",
+		mock.AnythingOfType("*gin.Context"),
+		"SICP",
+		"HTDP",
+		classservice.Students{{Email: "r.tifft@gmail.com"}},
+	).Return(nil)
+
+	server.ServeHTTP(w, r)
+
+	require.Equal(t, http.StatusNoContent, w.Code, "unexpected status code")
+}
@@ -0,0 +1,139 @@
+package rest
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/angusgmorrison/hexagonal/internal/primitive"
+	"github.com/angusgmorrison/hexagonal/internal/service/classservice"
+	"github.com/gin-gonic/gin"
+)
+
+// voucherRequest defines a voucher. Percent is required for percentage
+// vouchers and Amount for fixed vouchers. The remaining fields are optional:
+// MaxUses is zero for unlimited use, the validity period is open at either end
+// for which no time is given, and an empty CourseCodes applies to every
+// course.
+type voucherRequest struct {
+	Code        string           `json:"code"`
+	Kind        string           `json:"kind"`
+	Percent     uint32           `json:"percent"`
+	Amount      *primitive.Money `json:"amount"`
+	MaxUses     uint32           `json:"max_uses"`
+	ValidFrom   *time.Time       `json:"valid_from"`
+	ValidUntil  *time.Time       `json:"valid_until"`
+	CourseCodes []string         `json:"course_codes"`
+}
+
+func (vr voucherRequest) toDomain() classservice.Voucher {
+	voucher := classservice.Voucher{
+		Code:        vr.Code,
+		Kind:        classservice.DiscountKind(vr.Kind),
+		Percent:     vr.Percent,
+		MaxUses:     vr.MaxUses,
+		CourseCodes: vr.CourseCodes,
+	}
+
+	if vr.Amount != nil {
+		voucher.Amount = *vr.Amount
+	}
+
+	if vr.ValidFrom != nil {
+		voucher.ValidFrom = *vr.ValidFrom
+	}
+
+	if vr.ValidUntil != nil {
+		voucher.ValidUntil = *vr.ValidUntil
+	}
+
+	return voucher
+}
+
+// voucherResponse represents a voucher and its use. Fields that don't apply to
+// the voucher are null, and Remaining is null if its use is unlimited.
+type voucherResponse struct {
+	Code        string           `json:"code"`
+	Kind        string           `json:"kind"`
+	Percent     *uint32          `json:"percent"`
+	Amount      *primitive.Money `json:"amount"`
+	MaxUses     *uint32          `json:"max_uses"`
+	Uses        uint32           `json:"uses"`
+	Remaining   *uint32          `json:"remaining"`
+	ValidFrom   *time.Time       `json:"valid_from"`
+	ValidUntil  *time.Time       `json:"valid_until"`
+	CourseCodes []string         `json:"course_codes"`
+}
+
+func newVoucherResponse(voucher classservice.Voucher) voucherResponse {
+	resp := voucherResponse{
+		Code:        voucher.Code,
+		Kind:        string(voucher.Kind),
+		Uses:        voucher.Uses,
+		CourseCodes: voucher.CourseCodes,
+	}
+
+	if resp.CourseCodes == nil {
+		resp.CourseCodes = []string{}
+	}
+
+	switch voucher.Kind {
+	case classservice.DiscountPercentage:
+		resp.Percent = &voucher.Percent
+	case classservice.DiscountFixed:
+		resp.Amount = &voucher.Amount
+	}
+
+	if remaining, limited := voucher.Remaining(); limited {
+		resp.MaxUses = &voucher.MaxUses
+		resp.Remaining = &remaining
+	}
+
+	if !voucher.ValidFrom.IsZero() {
+		resp.ValidFrom = &voucher.ValidFrom
+	}
+
+	if !voucher.ValidUntil.IsZero() {
+		resp.ValidUntil = &voucher.ValidUntil
+	}
+
+	return resp
+}
+
+// handleCreateVoucher defines the voucher described by the request body.
+func (s *Server) handleCreateVoucher() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var vReq voucherRequest
+		if err := c.ShouldBind(&vReq); err != nil {
+			s.logger.Printf("Failed to parse voucher request: %s", err)
+			c.AbortWithStatus(http.StatusBadRequest)
+
+			return
+		}
+
+		voucher, err := s.classService.CreateVoucher(c, vReq.toDomain())
+		if err != nil {
+			s.logger.Printf("Voucher creation failed: %s", err)
+			c.AbortWithStatus(http.StatusUnprocessableEntity)
+
+			return
+		}
+
+		c.JSON(http.StatusCreated, newVoucherResponse(voucher))
+	}
+}
+
+// handleGetVoucher responds with the voucher identified by the code path
+// parameter.
+func (s *Server) handleGetVoucher() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		voucher, err := s.classService.GetVoucher(c, c.Param("code"))
+		if err != nil {
+			s.logger.Printf("Getting voucher failed: %s", err)
+			c.AbortWithStatus(lookupFailureStatus(err))
+
+			return
+		}
+
+		c.JSON(http.StatusOK, newVoucherResponse(voucher))
+	}
+}
@@ -0,0 +1,211 @@
+//go:build unit
+
+package rest
+
+import (
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/angusgmorrison/hexagonal/internal/primitive"
+	"github.com/angusgmorrison/hexagonal/internal/service/classservice"
+	"github.com/angusgmorrison/hexagonal/internal/service/instructorservice"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleCreateVoucher(t *testing.T) {
+	t.Parallel()
+
+	const endpoint = "/vouchers"
+
+	validUntil := time.Date(2022, time.December, 31, 0, 0, 0, 0, time.UTC)
+
+	t.Run("responds 201 Created with the voucher", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger       = log.New(os.Stdout, "TestHandleCreateVoucher ", log.LstdFlags)
+			classService = classservice.NewMockInterface(t)
+			server       = NewServer(logger, defaultConfig(), classService, instructorservice.NewMockInterface(t))
+			body         = `{
+				"code": "lisp25",
+				"kind": "fixed",
+				"amount": {"amount": 2500, "currency": "GBP"},
+				"max_uses": 10,
+				"valid_until": "2022-12-31T00:00:00Z",
+				"course_codes": ["LISP"]
+			}`
+			r = httptest.NewRequest(http.MethodPost, endpoint, strings.NewReader(body))
+			w = httptest.NewRecorder()
+		)
+
+		r.Header.Set("content-type", string(applicationJSON))
+
+		voucher := classservice.Voucher{
+			Code:        "lisp25",
+			Kind:        classservice.DiscountFixed,
+			Amount:      primitive.Money{Amount: 2500, Currency: "GBP"},
+			MaxUses:     10,
+			ValidUntil:  validUntil,
+			CourseCodes: []string{"LISP"},
+		}
+		created := voucher
+		created.ID = 1
+		created.Code = "LISP25"
+
+		classService.On(
+			"CreateVoucher",
+			mock.AnythingOfType("*gin.Context"),
+			voucher,
+		).Return(created, nil)
+
+		server.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusCreated, w.Code, "unexpected status code")
+		require.JSONEq(t, `{
+			"code": "LISP25",
+			"kind": "fixed",
+			"percent": null,
+			"amount": {"amount": 2500, "currency": "GBP"},
+			"max_uses": 10,
+			"uses": 0,
+			"remaining": 10,
+			"valid_from": null,
+			"valid_until": "2022-12-31T00:00:00Z",
+			"course_codes": ["LISP"]
+		}`, w.Body.String(), "unexpected body")
+	})
+
+	testCases := []struct {
+		name       string
+		body       string
+		serviceErr error
+		wantStatus int
+	}{
+		{
+			name:       "malformed body",
+			body:       `{"code": 25}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "code taken",
+			body:       `{"code": "WELCOME", "kind": "percentage", "percent": 10}`,
+			serviceErr: classservice.VoucherCodeTakenError{Code: "WELCOME"},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "invalid voucher",
+			body:       `{"code": "WELCOME", "kind": "percentage", "percent": 10}`,
+			serviceErr: classservice.InvalidVoucherError{Code: "WELCOME"},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				logger       = log.New(os.Stdout, "TestHandleCreateVoucher ", log.LstdFlags)
+				classService = classservice.NewMockInterface(t)
+				server       = NewServer(logger, defaultConfig(), classService, instructorservice.NewMockInterface(t))
+				r            = httptest.NewRequest(http.MethodPost, endpoint, strings.NewReader(tc.body))
+				w            = httptest.NewRecorder()
+			)
+
+			r.Header.Set("content-type", string(applicationJSON))
+
+			if tc.serviceErr != nil {
+				classService.On(
+					"CreateVoucher",
+					mock.AnythingOfType("*gin.Context"),
+					classservice.Voucher{Code: "WELCOME", Kind: classservice.DiscountPercentage, Percent: 10},
+				).Return(classservice.Voucher{}, tc.serviceErr)
+			}
+
+			server.ServeHTTP(w, r)
+
+			require.Equal(t, tc.wantStatus, w.Code, "unexpected status code")
+		})
+	}
+}
+
+func TestHandleGetVoucher(t *testing.T) {
+	t.Parallel()
+
+	const endpoint = "/vouchers/WELCOME"
+
+	testCases := []struct {
+		name       string
+		voucher    classservice.Voucher
+		serviceErr error
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name: "found",
+			voucher: classservice.Voucher{
+				ID:      1,
+				Code:    "WELCOME",
+				Kind:    classservice.DiscountPercentage,
+				Percent: 10,
+				Uses:    3,
+			},
+			wantStatus: http.StatusOK,
+			wantBody: `{
+				"code": "WELCOME",
+				"kind": "percentage",
+				"percent": 10,
+				"amount": null,
+				"max_uses": null,
+				"uses": 3,
+				"remaining": null,
+				"valid_from": null,
+				"valid_until": null,
+				"course_codes": []
+			}`,
+		},
+		{
+			name:       "not found",
+			serviceErr: classservice.VoucherNotFoundError{Code: "WELCOME"},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				logger       = log.New(os.Stdout, "TestHandleGetVoucher ", log.LstdFlags)
+				classService = classservice.NewMockInterface(t)
+				server       = NewServer(logger, defaultConfig(), classService, instructorservice.NewMockInterface(t))
+				r            = httptest.NewRequest(http.MethodGet, endpoint, nil)
+				w            = httptest.NewRecorder()
+			)
+
+			classService.On(
+				"GetVoucher",
+				mock.AnythingOfType("*gin.Context"),
+				"WELCOME",
+			).Return(tc.voucher, tc.serviceErr)
+
+			server.ServeHTTP(w, r)
+
+			require.Equal(t, tc.wantStatus, w.Code, "unexpected status code")
+
+			if tc.wantBody != "" {
+				require.JSONEq(t, tc.wantBody, w.Body.String(), "unexpected body")
+			}
+		})
+	}
+}
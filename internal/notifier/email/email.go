@@ -0,0 +1,223 @@
+// Package email provides an implementation of classservice.Notifier that
+// renders notifications as email messages and hands them to a Sender for
+// delivery.
+//
+// Each kind of notification has a plain-text template, kind.txt.tmpl, which
+// must also define the message's subject as the template "subject", and an HTML
+// template, kind.html.tmpl. Both are executed with the classservice.Notification
+// as their data.
+package email
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"io/fs"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"path"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/angusgmorrison/hexagonal/internal/service/classservice"
+)
+
+//go:embed templates
+var _templates embed.FS
+
+// Sender delivers email messages.
+type Sender interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// Message is an email message with alternative plain-text and HTML bodies.
+type Message struct {
+	From    mail.Address
+	To      mail.Address
+	Subject string
+	Date    time.Time
+	Text    string
+	HTML    string
+}
+
+// Bytes encodes the message in the Internet Message Format of RFC 5322, as a
+// MIME multipart/alternative message.
+func (m Message) Bytes() ([]byte, error) {
+	var body bytes.Buffer
+
+	mw := multipart.NewWriter(&body)
+
+	parts := []struct {
+		contentType string
+		content     string
+	}{
+		{contentType: "text/plain; charset=utf-8", content: m.Text},
+		{contentType: "text/html; charset=utf-8", content: m.HTML},
+	}
+
+	for _, p := range parts {
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Type", p.contentType)
+		header.Set("Content-Transfer-Encoding", "quoted-printable")
+
+		pw, err := mw.CreatePart(header)
+		if err != nil {
+			return nil, fmt.Errorf("create %s part: %w", p.contentType, err)
+		}
+
+		if err := writeQuotedPrintable(pw, p.content); err != nil {
+			return nil, fmt.Errorf("write %s part: %w", p.contentType, err)
+		}
+	}
+
+	if err := mw.Close(); err != nil {
+		return nil, fmt.Errorf("close multipart writer: %w", err)
+	}
+
+	var msg bytes.Buffer
+
+	fmt.Fprintf(&msg, "From: %s\r\n", m.From.String())
+	fmt.Fprintf(&msg, "To: %s\r\n", m.To.String())
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", m.Subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", m.Date.Format(time.RFC1123Z))
+	fmt.Fprintf(&msg, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&msg, "Content-Type: multipart/alternative; boundary=%q\r\n", mw.Boundary())
+	fmt.Fprintf(&msg, "\r\n")
+	msg.Write(body.Bytes())
+
+	return msg.Bytes(), nil
+}
+
+func writeQuotedPrintable(w io.Writer, content string) error {
+	qpw := quotedprintable.NewWriter(w)
+
+	if _, err := io.WriteString(qpw, content); err != nil {
+		return err
+	}
+
+	return qpw.Close()
+}
+
+// Notifier satisfies classservice.Notifier.
+type Notifier struct {
+	from      mail.Address
+	sender    Sender
+	templates map[classservice.NotificationKind]templatePair
+
+	// now returns the current time.
+	now func() time.Time
+}
+
+var _ classservice.Notifier = (*Notifier)(nil)
+
+type templatePair struct {
+	text *texttemplate.Template
+	html *htmltemplate.Template
+}
+
+// New returns a Notifier that sends messages from the given address using
+// sender. It returns an error if the message templates can't be parsed.
+func New(from mail.Address, sender Sender) (*Notifier, error) {
+	templates, err := parseTemplates(_templates)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Notifier{
+		from:      from,
+		sender:    sender,
+		templates: templates,
+		now:       time.Now,
+	}, nil
+}
+
+// parseTemplates parses the templates for each kind of notification found in
+// the templates directory of fsys.
+func parseTemplates(fsys fs.FS) (map[classservice.NotificationKind]templatePair, error) {
+	textPaths, err := fs.Glob(fsys, "templates/*.txt.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("find templates: %w", err)
+	}
+
+	templates := make(map[classservice.NotificationKind]templatePair, len(textPaths))
+
+	for _, textPath := range textPaths {
+		kind := strings.TrimSuffix(path.Base(textPath), ".txt.tmpl")
+		htmlPath := path.Join("templates", kind+".html.tmpl")
+
+		text, err := texttemplate.ParseFS(fsys, textPath)
+		if err != nil {
+			return nil, fmt.Errorf("parse %s: %w", textPath, err)
+		}
+
+		if text.Lookup("subject") == nil {
+			return nil, fmt.Errorf("%s does not define a subject", textPath)
+		}
+
+		html, err := htmltemplate.ParseFS(fsys, htmlPath)
+		if err != nil {
+			return nil, fmt.Errorf("parse %s: %w", htmlPath, err)
+		}
+
+		templates[classservice.NotificationKind(kind)] = templatePair{text: text, html: html}
+	}
+
+	return templates, nil
+}
+
+// Notify renders the notification as an email message addressed to the
+// student concerned and sends it.
+func (n *Notifier) Notify(ctx context.Context, notification classservice.Notification) error {
+	msg, err := n.Compose(notification)
+	if err != nil {
+		return fmt.Errorf("Notify: %w", err)
+	}
+
+	if err := n.sender.Send(ctx, msg); err != nil {
+		return fmt.Errorf("Notify: send to %s: %w", msg.To.Address, err)
+	}
+
+	return nil
+}
+
+// Compose renders the notification as an email message addressed to the
+// student concerned.
+func (n *Notifier) Compose(notification classservice.Notification) (Message, error) {
+	tmpl, ok := n.templates[notification.Kind]
+	if !ok {
+		return Message{}, fmt.Errorf("no template for notification kind %q", notification.Kind)
+	}
+
+	var subject, text, html strings.Builder
+
+	if err := tmpl.text.ExecuteTemplate(&subject, "subject", notification); err != nil {
+		return Message{}, fmt.Errorf("render %s subject: %w", notification.Kind, err)
+	}
+
+	if err := tmpl.text.Execute(&text, notification); err != nil {
+		return Message{}, fmt.Errorf("render %s text: %w", notification.Kind, err)
+	}
+
+	if err := tmpl.html.Execute(&html, notification); err != nil {
+		return Message{}, fmt.Errorf("render %s HTML: %w", notification.Kind, err)
+	}
+
+	return Message{
+		From: n.from,
+		To: mail.Address{
+			Name:    notification.Student.Name,
+			Address: string(notification.Student.Email),
+		},
+		Subject: strings.TrimSpace(subject.String()),
+		Date:    n.now(),
+		Text:    text.String(),
+		HTML:    html.String(),
+	}, nil
+}
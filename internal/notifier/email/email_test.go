@@ -0,0 +1,191 @@
+//go:build unit
+
+package email
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"testing"
+	"time"
+
+	"github.com/angusgmorrison/hexagonal/internal/service/classservice"
+	"github.com/stretchr/testify/require"
+)
+
+var testFrom = mail.Address{Name: "Registrar", Address: "registrar@hexagonal.test"}
+
+func testNotification(kind classservice.NotificationKind) classservice.Notification {
+	return classservice.Notification{
+		Kind:        kind,
+		CourseCode:  "TAOCP",
+		SectionCode: "B",
+		Student: classservice.Student{
+			Name:  "Ramdas <Tifft>",
+			Email: "r.tifft@gmail.com",
+		},
+	}
+}
+
+func TestCompose(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2022, time.September, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		kind        classservice.NotificationKind
+		wantSubject string
+	}{
+		{kind: classservice.NotificationEnrolled, wantSubject: "You're enrolled in TAOCP"},
+		{kind: classservice.NotificationUnenrolled, wantSubject: "You're no longer enrolled in TAOCP"},
+		{kind: classservice.NotificationCourseCancelled, wantSubject: "TAOCP has been cancelled"},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(string(tc.kind), func(t *testing.T) {
+			t.Parallel()
+
+			notifier, err := New(testFrom, nil)
+			require.NoError(t, err)
+
+			notifier.now = func() time.Time { return now }
+
+			msg, err := notifier.Compose(testNotification(tc.kind))
+			require.NoError(t, err)
+
+			require.Equal(t, testFrom, msg.From)
+			require.Equal(t, mail.Address{Name: "Ramdas <Tifft>", Address: "r.tifft@gmail.com"}, msg.To)
+			require.Equal(t, tc.wantSubject, msg.Subject)
+			require.Equal(t, now, msg.Date)
+			require.Contains(t, msg.Text, "Hi Ramdas <Tifft>,")
+			require.Contains(t, msg.HTML, "Hi Ramdas &lt;Tifft&gt;,", "HTML body should be escaped")
+		})
+	}
+
+	t.Run("includes section of enrollment", func(t *testing.T) {
+		t.Parallel()
+
+		notifier, err := New(testFrom, nil)
+		require.NoError(t, err)
+
+		msg, err := notifier.Compose(testNotification(classservice.NotificationEnrolled))
+		require.NoError(t, err)
+		require.Contains(t, msg.Text, "TAOCP, section B.")
+	})
+
+	t.Run("unknown notification kind", func(t *testing.T) {
+		t.Parallel()
+
+		notifier, err := New(testFrom, nil)
+		require.NoError(t, err)
+
+		_, err = notifier.Compose(testNotification("graduated"))
+		require.Error(t, err)
+	})
+}
+
+func TestNotify(t *testing.T) {
+	t.Parallel()
+
+	t.Run("sends composed message", func(t *testing.T) {
+		t.Parallel()
+
+		sender := &recordingSender{}
+
+		notifier, err := New(testFrom, sender)
+		require.NoError(t, err)
+
+		err = notifier.Notify(context.Background(), testNotification(classservice.NotificationEnrolled))
+		require.NoError(t, err)
+		require.Len(t, sender.sent, 1)
+		require.Equal(t, "r.tifft@gmail.com", sender.sent[0].To.Address)
+	})
+
+	t.Run("reports send failure", func(t *testing.T) {
+		t.Parallel()
+
+		sendErr := errors.New("connection refused")
+		sender := &recordingSender{err: sendErr}
+
+		notifier, err := New(testFrom, sender)
+		require.NoError(t, err)
+
+		err = notifier.Notify(context.Background(), testNotification(classservice.NotificationEnrolled))
+		require.ErrorIs(t, err, sendErr)
+	})
+}
+
+func TestMessageBytes(t *testing.T) {
+	t.Parallel()
+
+	msg := Message{
+		From:    testFrom,
+		To:      mail.Address{Name: "Ramdas Tifft", Address: "r.tifft@gmail.com"},
+		Subject: "Café society",
+		Date:    time.Date(2022, time.September, 1, 12, 0, 0, 0, time.UTC),
+		Text:    "Plain text body",
+		HTML:    "<p>HTML body</p>",
+	}
+
+	data, err := msg.Bytes()
+	require.NoError(t, err)
+
+	parsed, err := mail.ReadMessage(bytes.NewReader(data))
+	require.NoError(t, err)
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	require.NoError(t, err)
+	require.Equal(t, msg.Subject, subject)
+
+	to, err := parsed.Header.AddressList("To")
+	require.NoError(t, err)
+	require.Equal(t, []*mail.Address{&msg.To}, to)
+
+	date, err := parsed.Header.Date()
+	require.NoError(t, err)
+	require.True(t, msg.Date.Equal(date), "unexpected date %s", date)
+
+	mediaType, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	require.Equal(t, "multipart/alternative", mediaType)
+
+	reader := multipart.NewReader(parsed.Body, params["boundary"])
+
+	for _, want := range []struct{ contentType, body string }{
+		{contentType: "text/plain; charset=utf-8", body: msg.Text},
+		{contentType: "text/html; charset=utf-8", body: msg.HTML},
+	} {
+		part, err := reader.NextPart()
+		require.NoError(t, err)
+		require.Equal(t, want.contentType, part.Header.Get("Content-Type"))
+
+		// multipart.Reader decodes quoted-printable parts transparently.
+		body, err := io.ReadAll(part)
+		require.NoError(t, err)
+		require.Equal(t, want.body, string(body))
+	}
+
+	_, err = reader.NextPart()
+	require.ErrorIs(t, err, io.EOF)
+}
+
+type recordingSender struct {
+	sent []Message
+	err  error
+}
+
+func (rs *recordingSender) Send(_ context.Context, msg Message) error {
+	if rs.err != nil {
+		return rs.err
+	}
+
+	rs.sent = append(rs.sent, msg)
+
+	return nil
+}
@@ -0,0 +1,80 @@
+// Package emlfile provides an implementation of email.Sender that writes each
+// message to a .eml file in a directory instead of delivering it. The directory
+// serves as a local mailbox for development and tests, and its files can be
+// opened by most mail clients.
+package emlfile
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/angusgmorrison/hexagonal/internal/notifier/email"
+)
+
+// Sender satisfies email.Sender.
+type Sender struct {
+	dir string
+
+	mu  sync.Mutex
+	seq uint64
+}
+
+var _ email.Sender = (*Sender)(nil)
+
+// New returns a Sender that writes messages to dir, creating it if necessary.
+func New(dir string) (*Sender, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create mailbox %q: %w", dir, err)
+	}
+
+	return &Sender{dir: dir}, nil
+}
+
+// Send writes msg to a new file in the Sender's directory. Files are named
+// after the message's date and recipient, so that listing the directory shows
+// messages in the order they were sent.
+func (s *Sender) Send(_ context.Context, msg email.Message) error {
+	data, err := msg.Bytes()
+	if err != nil {
+		return fmt.Errorf("Send: %w", err)
+	}
+
+	s.mu.Lock()
+	s.seq++
+	seq := s.seq
+	s.mu.Unlock()
+
+	name := fmt.Sprintf("%s-%06d-%s.eml",
+		msg.Date.UTC().Format("20060102T150405Z"), seq, sanitize(msg.To.Address))
+	path := filepath.Join(s.dir, name)
+
+	// Write to a temporary file first, so that readers of the mailbox never
+	// see a partial message.
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("Send: %w", err)
+	}
+
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("Send: %w", err)
+	}
+
+	return nil
+}
+
+// sanitize replaces characters that are unsafe in file names.
+func sanitize(address string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9',
+			r == '@', r == '.', r == '-', r == '_', r == '+':
+			return r
+		default:
+			return '_'
+		}
+	}, address)
+}
@@ -0,0 +1,51 @@
+//go:build unit
+
+package emlfile
+
+import (
+	"context"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/angusgmorrison/hexagonal/internal/notifier/email"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSend(t *testing.T) {
+	t.Parallel()
+
+	dir := filepath.Join(t.TempDir(), "mail")
+
+	sender, err := New(dir)
+	require.NoError(t, err)
+
+	msg := email.Message{
+		From:    mail.Address{Name: "Registrar", Address: "registrar@hexagonal.test"},
+		To:      mail.Address{Name: "Ramdas Tifft", Address: "r.tifft@gmail.com"},
+		Subject: "You're enrolled in SICP",
+		Date:    time.Date(2022, time.September, 1, 12, 0, 0, 0, time.UTC),
+		Text:    "Hi Ramdas Tifft",
+		HTML:    "<p>Hi Ramdas Tifft</p>",
+	}
+
+	for i := 0; i < 2; i++ {
+		require.NoError(t, sender.Send(context.Background(), msg))
+	}
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 2, "expected one file per message")
+	require.Equal(t, "20220901T120000Z-000001-r.tifft@gmail.com.eml", entries[0].Name())
+
+	f, err := os.Open(filepath.Join(dir, entries[0].Name()))
+	require.NoError(t, err)
+
+	defer f.Close()
+
+	parsed, err := mail.ReadMessage(f)
+	require.NoError(t, err)
+	require.Equal(t, "You're enrolled in SICP", parsed.Header.Get("Subject"))
+}
@@ -0,0 +1,54 @@
+// Package smtpmail provides an implementation of email.Sender that delivers
+// messages through an SMTP server.
+package smtpmail
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+
+	"github.com/angusgmorrison/hexagonal/internal/notifier/email"
+)
+
+// Sender satisfies email.Sender.
+type Sender struct {
+	addr string
+	auth smtp.Auth
+}
+
+var _ email.Sender = (*Sender)(nil)
+
+// New returns a Sender that delivers messages through the SMTP server at host
+// and port. If username is non-empty, the Sender authenticates using PLAIN
+// authentication, which requires the server to support TLS unless it is
+// running on localhost.
+func New(host string, port int, username, password string) *Sender {
+	sender := Sender{addr: net.JoinHostPort(host, strconv.Itoa(port))}
+
+	if username != "" {
+		sender.auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return &sender
+}
+
+// Send delivers msg to its recipient. net/smtp doesn't support cancellation, so
+// ctx is only checked before sending.
+func (s *Sender) Send(ctx context.Context, msg email.Message) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("Send: %w", err)
+	}
+
+	data, err := msg.Bytes()
+	if err != nil {
+		return fmt.Errorf("Send: %w", err)
+	}
+
+	if err := smtp.SendMail(s.addr, s.auth, msg.From.Address, []string{msg.To.Address}, data); err != nil {
+		return fmt.Errorf("Send: %w", err)
+	}
+
+	return nil
+}
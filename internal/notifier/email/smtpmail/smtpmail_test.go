@@ -0,0 +1,143 @@
+//go:build unit
+
+package smtpmail
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/angusgmorrison/hexagonal/internal/notifier/email"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSend(t *testing.T) {
+	t.Parallel()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	defer listener.Close()
+
+	received := make(chan receivedMail, 1)
+
+	go serveOneSMTPSession(t, listener, received)
+
+	addr := listener.Addr().(*net.TCPAddr)
+	sender := New("127.0.0.1", addr.Port, "", "")
+
+	msg := email.Message{
+		From:    mail.Address{Name: "Registrar", Address: "registrar@hexagonal.test"},
+		To:      mail.Address{Name: "Ramdas Tifft", Address: "r.tifft@gmail.com"},
+		Subject: "You're enrolled in SICP",
+		Date:    time.Date(2022, time.September, 1, 12, 0, 0, 0, time.UTC),
+		Text:    "Hi Ramdas Tifft",
+		HTML:    "<p>Hi Ramdas Tifft</p>",
+	}
+
+	err = sender.Send(context.Background(), msg)
+	require.NoError(t, err)
+
+	got := <-received
+	require.Equal(t, "<registrar@hexagonal.test>", got.from)
+	require.Equal(t, []string{"<r.tifft@gmail.com>"}, got.to)
+	require.Contains(t, got.data, "Subject: You're enrolled in SICP")
+}
+
+func TestSendCancelled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := New("127.0.0.1", 1, "", "").Send(ctx, email.Message{})
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+type receivedMail struct {
+	from string
+	to   []string
+	data string
+}
+
+// serveOneSMTPSession accepts a single connection on listener and plays the
+// part of an SMTP server for the minimal dialogue used by net/smtp.SendMail.
+func serveOneSMTPSession(t *testing.T, listener net.Listener, received chan<- receivedMail) {
+	t.Helper()
+
+	conn, err := listener.Accept()
+	if err != nil {
+		return
+	}
+
+	defer conn.Close()
+
+	tp := textproto.NewConn(conn)
+
+	var got receivedMail
+
+	reply := func(format string, args ...any) {
+		_ = tp.PrintfLine(format, args...)
+	}
+
+	reply("220 localhost ESMTP")
+
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+
+		verb, arg, _ := strings.Cut(line, " ")
+
+		switch strings.ToUpper(verb) {
+		case "EHLO", "HELO":
+			reply("250 localhost")
+		case "MAIL":
+			got.from = strings.TrimPrefix(arg, "FROM:")
+			reply("250 OK")
+		case "RCPT":
+			got.to = append(got.to, strings.TrimPrefix(arg, "TO:"))
+			reply("250 OK")
+		case "DATA":
+			reply("354 Go ahead")
+
+			data, err := readDotLines(tp.Reader.R)
+			if err != nil {
+				return
+			}
+
+			got.data = data
+			reply("250 OK")
+		case "QUIT":
+			reply("221 Bye")
+			received <- got
+
+			return
+		default:
+			reply("502 Command not implemented")
+		}
+	}
+}
+
+func readDotLines(r *bufio.Reader) (string, error) {
+	var b strings.Builder
+
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return "", err
+		}
+
+		if line == ".\r\n" {
+			return b.String(), nil
+		}
+
+		b.WriteString(line)
+	}
+}
@@ -0,0 +1,34 @@
+// Package lognotifier provides an implementation of classservice.Notifier that
+// writes notifications to a log instead of delivering them. It is useful in
+// development, where no mail server is available.
+package lognotifier
+
+import (
+	"context"
+
+	"github.com/angusgmorrison/hexagonal/internal/service/classservice"
+)
+
+type logger interface {
+	Printf(format string, args ...any)
+}
+
+// Notifier satisfies classservice.Notifier.
+type Notifier struct {
+	logger logger
+}
+
+var _ classservice.Notifier = (*Notifier)(nil)
+
+// New returns a Notifier that writes to logger.
+func New(logger logger) *Notifier {
+	return &Notifier{logger: logger}
+}
+
+// Notify logs the notification. It never fails.
+func (n *Notifier) Notify(_ context.Context, notification classservice.Notification) error {
+	n.logger.Printf("Notify %s <%s>: %s (course %q)",
+		notification.Student.Name, notification.Student.Email, notification.Kind, notification.CourseCode)
+
+	return nil
+}
@@ -38,3 +38,21 @@ func (bd *Birthdate) UnmarshalJSON(b []byte) error {
 
 	return nil
 }
+
+// MarshalJSON encodes Birthdates in the same format from which they're parsed.
+func (bd Birthdate) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Time(bd).Format(BirthdateLayout))
+}
+
+// AgeAt returns the age in whole years of a person with this Birthdate at the
+// time t.
+func (bd Birthdate) AgeAt(t time.Time) int {
+	born := time.Time(bd)
+	age := t.Year() - born.Year()
+
+	if t.Month() < born.Month() || (t.Month() == born.Month() && t.Day() < born.Day()) {
+		age--
+	}
+
+	return age
+}
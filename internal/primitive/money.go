@@ -0,0 +1,78 @@
+package primitive
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Currency is an ISO 4217 currency code, e.g. "GBP".
+type Currency string
+
+// ParseCurrency parses a Currency from a three-letter code, which is
+// normalized to upper case.
+func ParseCurrency(code string) (Currency, error) {
+	upper := strings.ToUpper(code)
+	if len(upper) != 3 || strings.IndexFunc(upper, func(r rune) bool { return r < 'A' || r > 'Z' }) >= 0 {
+		return "", fmt.Errorf("parse Currency: %q is not a three-letter currency code", code)
+	}
+
+	return Currency(upper), nil
+}
+
+// MinorUnits returns the number of decimal places between the currency's major
+// and minor units, e.g. 2 for GBP (pounds and pence) and 0 for JPY.
+func (c Currency) MinorUnits() int {
+	switch c {
+	case "BIF", "CLP", "DJF", "GNF", "ISK", "JPY", "KMF", "KRW", "PYG", "RWF", "UGX", "VND", "VUV", "XAF", "XOF", "XPF":
+		return 0
+	case "BHD", "IQD", "JOD", "KWD", "LYD", "OMR", "TND":
+		return 3
+	default:
+		return 2
+	}
+}
+
+// Money is an amount of a currency, counted in the currency's minor units to
+// avoid the rounding errors of floating-point arithmetic. The zero Money
+// represents no money at all.
+type Money struct {
+	Amount   int64    `json:"amount"`
+	Currency Currency `json:"currency"`
+}
+
+// NewMoney returns the given number of minor units of the currency with the
+// given code.
+func NewMoney(amount int64, currencyCode string) (Money, error) {
+	currency, err := ParseCurrency(currencyCode)
+	if err != nil {
+		return Money{}, fmt.Errorf("NewMoney: %w", err)
+	}
+
+	return Money{Amount: amount, Currency: currency}, nil
+}
+
+// IsZero reports whether m is an amount of nothing.
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+// String formats m in major units followed by its currency code, e.g.
+// "125.00 GBP".
+func (m Money) String() string {
+	units := m.Currency.MinorUnits()
+	if units == 0 {
+		return fmt.Sprintf("%d %s", m.Amount, m.Currency)
+	}
+
+	sign, amount := "", m.Amount
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+
+	scale := int64(1)
+	for i := 0; i < units; i++ {
+		scale *= 10
+	}
+
+	return fmt.Sprintf("%s%d.%0*d %s", sign, amount/scale, units, amount%scale, m.Currency)
+}
@@ -0,0 +1,143 @@
+package authservice
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// apiKeyPrefix begins every API key, making keys easy to recognise, e.g. by
+// secret scanners.
+const apiKeyPrefix = "hxk_"
+
+// apiKeyBytes is the number of random bytes in an API key.
+const apiKeyBytes = 32
+
+// AuthenticateAPIKey returns the Principal named by the API key, or
+// InvalidCredentialsError if the key is unknown or has been revoked.
+func (svc *authService) AuthenticateAPIKey(ctx context.Context, key string) (Principal, error) {
+	if !strings.HasPrefix(key, apiKeyPrefix) {
+		return Principal{}, fmt.Errorf("AuthenticateAPIKey: %w", InvalidCredentialsError{Reason: "malformed API key"})
+	}
+
+	var apiKey APIKey
+
+	authenticate := func(ctx context.Context, repo Repository) error {
+		var err error
+
+		apiKey, err = repo.GetAPIKeyByHash(ctx, hashAPIKey(key))
+
+		return err
+	}
+
+	if err := svc.repo.Execute(ctx, authenticate); err != nil {
+		return Principal{}, fmt.Errorf("AuthenticateAPIKey: %w", err)
+	}
+
+	if apiKey.Revoked() {
+		return Principal{}, fmt.Errorf("AuthenticateAPIKey: %w", InvalidCredentialsError{
+			Reason: fmt.Sprintf("API key %d was revoked", apiKey.ID),
+		})
+	}
+
+	return Principal{Kind: PrincipalKindAPIKey, Subject: apiKey.Name}, nil
+}
+
+// CreateAPIKey issues a new API key with the given name, returning the key
+// itself alongside its stored details. The key can't be recovered later.
+//
+// Names identify the principals that present each key, so they must be
+// unique, or APIKeyNameTakenError is returned.
+func (svc *authService) CreateAPIKey(ctx context.Context, name string) (APIKey, string, error) {
+	key, err := generateAPIKey()
+	if err != nil {
+		return APIKey{}, "", fmt.Errorf("CreateAPIKey: %w", err)
+	}
+
+	apiKey := APIKey{
+		Name:      name,
+		Hash:      hashAPIKey(key),
+		CreatedAt: svc.now().UTC(),
+	}
+
+	if err := svc.validate.Struct(apiKey); err != nil {
+		return APIKey{}, "", fmt.Errorf("CreateAPIKey: %w", err)
+	}
+
+	create := func(ctx context.Context, repo Repository) error {
+		existing, err := repo.GetAPIKeysByName(ctx, name)
+		if err != nil {
+			return err
+		}
+
+		if len(existing) > 0 {
+			return APIKeyNameTakenError{Name: name}
+		}
+
+		apiKey, err = repo.CreateAPIKey(ctx, apiKey)
+
+		return err
+	}
+
+	if err := svc.repo.Execute(ctx, create); err != nil {
+		return APIKey{}, "", fmt.Errorf("CreateAPIKey: %w", err)
+	}
+
+	return apiKey, key, nil
+}
+
+// ListAPIKeys returns every API key, including those that were revoked.
+func (svc *authService) ListAPIKeys(ctx context.Context) (APIKeys, error) {
+	var keys APIKeys
+
+	list := func(ctx context.Context, repo Repository) error {
+		var err error
+
+		keys, err = repo.ListAPIKeys(ctx)
+
+		return err
+	}
+
+	if err := svc.repo.Execute(ctx, list); err != nil {
+		return nil, fmt.Errorf("ListAPIKeys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// RevokeAPIKey revokes the API key with the given ID, so that it can no longer
+// be used to authenticate. Revoking a key that was already revoked has no
+// effect. If there's no such key, APIKeyNotFoundError is returned.
+func (svc *authService) RevokeAPIKey(ctx context.Context, id int64) error {
+	revoke := func(ctx context.Context, repo Repository) error {
+		return repo.RevokeAPIKey(ctx, id, svc.now().UTC())
+	}
+
+	if err := svc.repo.Execute(ctx, revoke); err != nil {
+		return fmt.Errorf("RevokeAPIKey: %w", err)
+	}
+
+	return nil
+}
+
+// generateAPIKey returns a new random API key.
+func generateAPIKey() (string, error) {
+	b := make([]byte, apiKeyBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate API key: %w", err)
+	}
+
+	return apiKeyPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashAPIKey returns the SHA-256 hash of key. API keys are random and long,
+// so unlike passwords they don't need a slow, salted hash, and hashing them
+// deterministically lets keys be looked up by their hash.
+func hashAPIKey(key string) []byte {
+	sum := sha256.Sum256([]byte(key))
+
+	return sum[:]
+}
@@ -0,0 +1,208 @@
+//go:build unit
+
+package authservice
+
+import (
+	"context"
+	"log"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthenticateAPIKey(t *testing.T) {
+	t.Parallel()
+
+	const key = "hxk_c2VjcmV0"
+
+	testCases := []struct {
+		name          string
+		stored        APIKey
+		repoErr       error
+		wantPrincipal Principal
+		wantErr       bool
+	}{
+		{
+			name:          "known key",
+			stored:        APIKey{ID: 1, Name: "registrar"},
+			wantPrincipal: Principal{Kind: PrincipalKindAPIKey, Subject: "registrar"},
+		},
+		{
+			name:    "revoked key",
+			stored:  APIKey{ID: 1, Name: "registrar", RevokedAt: time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)},
+			wantErr: true,
+		},
+		{
+			name:    "unknown key",
+			repoErr: InvalidCredentialsError{Reason: "unknown API key"},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				logger     = log.New(os.Stdout, "TestAuthenticateAPIKey ", log.LstdFlags)
+				atomicRepo = NewMockAtomicRepository(t)
+				repo       = NewMockRepository(t)
+				service    = New(logger, validator.New(), atomicRepo)
+				ctx        = context.Background()
+			)
+
+			atomicRepo.On(
+				"Execute",
+				ctx,
+				mock.AnythingOfType("AtomicOperation"),
+			).Return(func(ctx context.Context, op AtomicOperation) error {
+				return op(ctx, repo)
+			})
+
+			repo.On("GetAPIKeyByHash", ctx, hashAPIKey(key)).Return(tc.stored, tc.repoErr)
+
+			principal, err := service.AuthenticateAPIKey(ctx, key)
+			if tc.wantErr {
+				var credentialsErr InvalidCredentialsError
+				require.ErrorAs(t, err, &credentialsErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.wantPrincipal, principal)
+		})
+	}
+
+	t.Run("rejects keys without the prefix without looking them up", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger  = log.New(os.Stdout, "TestAuthenticateAPIKey ", log.LstdFlags)
+			service = New(logger, validator.New(), NewMockAtomicRepository(t))
+		)
+
+		_, err := service.AuthenticateAPIKey(context.Background(), "c2VjcmV0")
+
+		var credentialsErr InvalidCredentialsError
+		require.ErrorAs(t, err, &credentialsErr)
+	})
+}
+
+func TestCreateAPIKey(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, time.October, 18, 12, 0, 0, 0, time.UTC)
+
+	t.Run("stores the hash of a new key", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "TestCreateAPIKey ", log.LstdFlags)
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			service    = New(logger, validator.New(), atomicRepo, WithClock(func() time.Time { return now }))
+			ctx        = context.Background()
+		)
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On("GetAPIKeysByName", ctx, "registrar").Return(APIKeys{}, nil)
+		repo.On("CreateAPIKey", ctx, mock.AnythingOfType("APIKey")).Return(func(_ context.Context, k APIKey) APIKey {
+			k.ID = 3
+
+			return k
+		}, nil)
+
+		apiKey, key, err := service.CreateAPIKey(ctx, "registrar")
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(key, apiKeyPrefix), "key lacks prefix")
+		require.Equal(t, APIKey{ID: 3, Name: "registrar", Hash: hashAPIKey(key), CreatedAt: now}, apiKey)
+
+		_, otherKey, err := service.CreateAPIKey(ctx, "registrar")
+		require.NoError(t, err)
+		require.NotEqual(t, key, otherKey, "keys aren't random")
+	})
+
+	t.Run("rejects names that are taken", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "TestCreateAPIKey ", log.LstdFlags)
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			service    = New(logger, validator.New(), atomicRepo)
+			ctx        = context.Background()
+		)
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On("GetAPIKeysByName", ctx, "registrar").Return(APIKeys{{ID: 1, Name: "registrar"}}, nil)
+
+		_, _, err := service.CreateAPIKey(ctx, "registrar")
+
+		var takenErr APIKeyNameTakenError
+		require.ErrorAs(t, err, &takenErr)
+	})
+
+	t.Run("requires a name", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger  = log.New(os.Stdout, "TestCreateAPIKey ", log.LstdFlags)
+			service = New(logger, validator.New(), NewMockAtomicRepository(t))
+		)
+
+		_, _, err := service.CreateAPIKey(context.Background(), "")
+
+		var validationErrs validator.ValidationErrors
+		require.ErrorAs(t, err, &validationErrs)
+	})
+}
+
+func TestRevokeAPIKey(t *testing.T) {
+	t.Parallel()
+
+	var (
+		logger     = log.New(os.Stdout, "TestRevokeAPIKey ", log.LstdFlags)
+		atomicRepo = NewMockAtomicRepository(t)
+		repo       = NewMockRepository(t)
+		now        = time.Date(2026, time.October, 18, 12, 0, 0, 0, time.UTC)
+		service    = New(logger, validator.New(), atomicRepo, WithClock(func() time.Time { return now }))
+		ctx        = context.Background()
+	)
+
+	atomicRepo.On(
+		"Execute",
+		ctx,
+		mock.AnythingOfType("AtomicOperation"),
+	).Return(func(ctx context.Context, op AtomicOperation) error {
+		return op(ctx, repo)
+	})
+
+	repo.On("RevokeAPIKey", ctx, int64(4), now).Return(APIKeyNotFoundError{ID: 4})
+
+	err := service.RevokeAPIKey(ctx, 4)
+
+	var notFoundErr APIKeyNotFoundError
+	require.ErrorAs(t, err, &notFoundErr)
+}
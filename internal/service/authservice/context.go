@@ -0,0 +1,22 @@
+package authservice
+
+import "context"
+
+// ContextKey is the key under which a context carries the Principal
+// responsible for it. It's a string, rather than an unexported type, so that a
+// *gin.Context, which resolves string keys from its own store, can carry the
+// Principal too.
+const ContextKey = "authservice.principal"
+
+// NewContext returns a copy of ctx carrying p.
+func NewContext(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, ContextKey, p) //nolint:staticcheck // Must be a string; see ContextKey.
+}
+
+// FromContext returns the Principal carried by ctx, and false if ctx carries
+// none, e.g. because authentication isn't configured.
+func FromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(ContextKey).(Principal)
+
+	return p, ok
+}
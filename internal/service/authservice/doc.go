@@ -0,0 +1,3 @@
+// Package authservice holds the business logic and data structures associated
+// with authenticating the clients of the application's APIs.
+package authservice
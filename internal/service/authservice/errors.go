@@ -0,0 +1,32 @@
+package authservice
+
+import "fmt"
+
+// InvalidCredentialsError is returned when a token or API key can't be used
+// to authenticate, e.g. because it's malformed, expired, revoked or unknown.
+type InvalidCredentialsError struct {
+	Reason string
+}
+
+func (ice InvalidCredentialsError) Error() string {
+	return fmt.Sprintf("invalid credentials: %s", ice.Reason)
+}
+
+// APIKeyNotFoundError is returned when no API key has the ID provided.
+type APIKeyNotFoundError struct {
+	ID int64
+}
+
+func (aknfe APIKeyNotFoundError) Error() string {
+	return fmt.Sprintf("no API key with ID %d", aknfe.ID)
+}
+
+// APIKeyNameTakenError is returned when attempting to create an API key with
+// the name of an existing key.
+type APIKeyNameTakenError struct {
+	Name string
+}
+
+func (akte APIKeyNameTakenError) Error() string {
+	return fmt.Sprintf("an API key named %q already exists", akte.Name)
+}
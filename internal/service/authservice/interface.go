@@ -0,0 +1,83 @@
+package authservice
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Interface specifies the business operations of the service.
+type Interface interface {
+	AuthenticateToken(ctx context.Context, token string) (Principal, error)
+	AuthenticateAPIKey(ctx context.Context, key string) (Principal, error)
+	CreateAPIKey(ctx context.Context, name string) (APIKey, string, error)
+	ListAPIKeys(ctx context.Context) (APIKeys, error)
+	RevokeAPIKey(ctx context.Context, id int64) error
+}
+
+// New configures and returns an Interface implementation. Tokens can't be
+// authenticated unless verification keys are provided using
+// WithVerificationKeys.
+func New(logger logger, validate *validator.Validate, repo AtomicRepository, opts ...Option) Interface {
+	svc := authService{
+		logger:   logger,
+		validate: validate,
+		repo:     repo,
+		now:      time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(&svc)
+	}
+
+	return &svc
+}
+
+// authService implements authservice.Interface.
+type authService struct {
+	logger   logger
+	validate *validator.Validate
+	repo     AtomicRepository
+
+	// keys verify the signatures of tokens.
+	keys []VerificationKey
+
+	// issuer and audience, if set, must match the "iss" and "aud" claims of
+	// tokens.
+	issuer   string
+	audience string
+
+	// now returns the current time.
+	now func() time.Time
+}
+
+type AtomicOperation func(context.Context, Repository) error
+
+type AtomicRepository interface {
+	Execute(context.Context, AtomicOperation) error
+}
+
+type Repository interface {
+	// GetAPIKeyByHash loads the API key with the given hash, returning
+	// InvalidCredentialsError if there's none.
+	GetAPIKeyByHash(ctx context.Context, hash []byte) (APIKey, error)
+
+	// GetAPIKeysByName loads the API keys with the given name.
+	GetAPIKeysByName(ctx context.Context, name string) (APIKeys, error)
+
+	// ListAPIKeys loads every API key, including those that were revoked.
+	ListAPIKeys(ctx context.Context) (APIKeys, error)
+
+	// CreateAPIKey writes a new API key to the repository and returns it with
+	// its ID populated.
+	CreateAPIKey(ctx context.Context, key APIKey) (APIKey, error)
+
+	// RevokeAPIKey records the time at which the API key with the given ID
+	// was revoked, returning APIKeyNotFoundError if there's no such key.
+	RevokeAPIKey(ctx context.Context, id int64, at time.Time) error
+}
+
+type logger interface {
+	Printf(format string, args ...any)
+}
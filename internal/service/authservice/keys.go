@@ -0,0 +1,149 @@
+package authservice
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// The algorithms with which tokens may be signed.
+const (
+	HS256 = "HS256"
+	RS256 = "RS256"
+)
+
+// minRSAKeyBits is the smallest RSA modulus accepted for verifying tokens.
+const minRSAKeyBits = 2048
+
+// VerificationKey verifies the signatures of tokens signed using its
+// Algorithm. Key is a shared secret for HS256, or an *rsa.PublicKey for RS256.
+// If ID is set, the key only verifies tokens whose "kid" header matches it.
+type VerificationKey struct {
+	ID        string
+	Algorithm string
+	Key       any
+}
+
+// HS256Key returns a key that verifies tokens signed with the shared secret.
+func HS256Key(id string, secret []byte) VerificationKey {
+	return VerificationKey{ID: id, Algorithm: HS256, Key: secret}
+}
+
+// RS256KeyFromPEM returns a key that verifies tokens signed by the private
+// counterpart of the PEM-encoded RSA public key.
+func RS256KeyFromPEM(id string, pem []byte) (VerificationKey, error) {
+	key, err := jwt.ParseRSAPublicKeyFromPEM(pem)
+	if err != nil {
+		return VerificationKey{}, fmt.Errorf("RS256KeyFromPEM: %w", err)
+	}
+
+	if key.N.BitLen() < minRSAKeyBits {
+		return VerificationKey{}, fmt.Errorf("RS256KeyFromPEM: key is shorter than %d bits", minRSAKeyBits)
+	}
+
+	return VerificationKey{ID: id, Algorithm: RS256, Key: key}, nil
+}
+
+// jwk is a JSON Web Key, as defined by RFC 7517, of type RSA or oct.
+type jwk struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Algorithm string `json:"alg"`
+	Use       string `json:"use"`
+
+	// N and E are the modulus and exponent of an RSA key.
+	N string `json:"n"`
+	E string `json:"e"`
+
+	// K is the value of a symmetric (oct) key.
+	K string `json:"k"`
+}
+
+// ParseJWKS returns the keys of a JSON Web Key Set that can verify tokens: RSA
+// keys for RS256 and symmetric keys for HS256. Other keys, and those intended
+// for encryption, are skipped. An error is returned if no keys remain.
+func ParseJWKS(data []byte) ([]VerificationKey, error) {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("ParseJWKS: %w", err)
+	}
+
+	keys := make([]VerificationKey, 0, len(set.Keys))
+
+	for i, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+
+		var (
+			key VerificationKey
+			err error
+		)
+
+		switch {
+		case k.KeyType == "RSA" && (k.Algorithm == "" || k.Algorithm == RS256):
+			key, err = k.rs256Key()
+		case k.KeyType == "oct" && (k.Algorithm == "" || k.Algorithm == HS256):
+			key, err = k.hs256Key()
+		default:
+			continue
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("ParseJWKS: key %d: %w", i, err)
+		}
+
+		keys = append(keys, key)
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.New("ParseJWKS: no RS256 or HS256 signing keys")
+	}
+
+	return keys, nil
+}
+
+func (k jwk) rs256Key() (VerificationKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(k.N)
+	if err != nil {
+		return VerificationKey{}, fmt.Errorf("decode modulus: %w", err)
+	}
+
+	e, err := base64.RawURLEncoding.DecodeString(k.E)
+	if err != nil {
+		return VerificationKey{}, fmt.Errorf("decode exponent: %w", err)
+	}
+
+	exponent := new(big.Int).SetBytes(e)
+	if !exponent.IsInt64() || exponent.Int64() < 3 || exponent.Int64() > 1<<31-1 {
+		return VerificationKey{}, errors.New("invalid exponent")
+	}
+
+	key := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}
+	if key.N.BitLen() < minRSAKeyBits {
+		return VerificationKey{}, fmt.Errorf("key is shorter than %d bits", minRSAKeyBits)
+	}
+
+	return VerificationKey{ID: k.KeyID, Algorithm: RS256, Key: key}, nil
+}
+
+func (k jwk) hs256Key() (VerificationKey, error) {
+	secret, err := base64.RawURLEncoding.DecodeString(k.K)
+	if err != nil {
+		return VerificationKey{}, fmt.Errorf("decode key: %w", err)
+	}
+
+	if len(secret) == 0 {
+		return VerificationKey{}, errors.New("empty key")
+	}
+
+	return HS256Key(k.KeyID, secret), nil
+}
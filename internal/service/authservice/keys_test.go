@@ -0,0 +1,81 @@
+//go:build unit
+
+package authservice
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseJWKS(t *testing.T) {
+	t.Parallel()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	var (
+		n = base64.RawURLEncoding.EncodeToString(rsaKey.N.Bytes())
+		e = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(rsaKey.E)).Bytes())
+		k = base64.RawURLEncoding.EncodeToString([]byte("correct horse battery staple"))
+	)
+
+	t.Run("returns RS256 and HS256 signing keys", func(t *testing.T) {
+		t.Parallel()
+
+		jwks := fmt.Sprintf(`{"keys": [
+			{"kty": "RSA", "kid": "rsa-1", "use": "sig", "n": %q, "e": %q},
+			{"kty": "oct", "kid": "oct-1", "alg": "HS256", "k": %q},
+			{"kty": "RSA", "kid": "rsa-enc", "use": "enc", "n": %q, "e": %q},
+			{"kty": "EC", "kid": "ec-1", "crv": "P-256", "x": "", "y": ""}
+		]}`, n, e, k, n, e)
+
+		keys, err := ParseJWKS([]byte(jwks))
+		require.NoError(t, err)
+		require.Equal(t, []VerificationKey{
+			{ID: "rsa-1", Algorithm: RS256, Key: &rsaKey.PublicKey},
+			{ID: "oct-1", Algorithm: HS256, Key: []byte("correct horse battery staple")},
+		}, keys)
+	})
+
+	t.Run("rejects sets without signing keys", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ParseJWKS([]byte(`{"keys": [{"kty": "EC", "crv": "P-256"}]}`))
+		require.Error(t, err)
+	})
+
+	t.Run("rejects short RSA keys", func(t *testing.T) {
+		t.Parallel()
+
+		shortKey, err := rsa.GenerateKey(rand.Reader, 1024)
+		require.NoError(t, err)
+
+		jwks := fmt.Sprintf(`{"keys": [{"kty": "RSA", "n": %q, "e": %q}]}`,
+			base64.RawURLEncoding.EncodeToString(shortKey.N.Bytes()), e)
+
+		_, err = ParseJWKS([]byte(jwks))
+		require.Error(t, err)
+	})
+}
+
+func TestRS256KeyFromPEM(t *testing.T) {
+	t.Parallel()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	der, err := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+	require.NoError(t, err)
+
+	key, err := RS256KeyFromPEM("rsa-1", pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	require.NoError(t, err)
+	require.Equal(t, VerificationKey{ID: "rsa-1", Algorithm: RS256, Key: &rsaKey.PublicKey}, key)
+}
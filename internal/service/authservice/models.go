@@ -0,0 +1,43 @@
+package authservice
+
+import "time"
+
+// PrincipalKind identifies the credentials with which a Principal was
+// authenticated.
+type PrincipalKind string
+
+const (
+	// PrincipalKindToken principals presented a signed JWT.
+	PrincipalKindToken PrincipalKind = "token"
+
+	// PrincipalKindAPIKey principals presented an API key.
+	PrincipalKindAPIKey PrincipalKind = "api_key"
+)
+
+// Principal represents an authenticated client. Subject is the "sub" claim of
+// a token, or the name of an API key.
+type Principal struct {
+	Kind    PrincipalKind
+	Subject string
+}
+
+// APIKey represents a long-lived credential issued to a client. Only the
+// SHA-256 hash of the key is kept, so the key itself is known only to the
+// client it was issued to.
+type APIKey struct {
+	ID        int64
+	Name      string `validate:"required,max=255"`
+	Hash      []byte
+	CreatedAt time.Time
+
+	// RevokedAt is zero unless the key has been revoked.
+	RevokedAt time.Time
+}
+
+// Revoked reports whether the key has been revoked.
+func (k APIKey) Revoked() bool {
+	return !k.RevokedAt.IsZero()
+}
+
+// APIKeys is a convenience wrapper.
+type APIKeys []APIKey
@@ -0,0 +1,34 @@
+package authservice
+
+import "time"
+
+// Option configures optional behaviour of the service returned by New.
+type Option func(*authService)
+
+// WithVerificationKeys sets the keys that verify the signatures of tokens.
+func WithVerificationKeys(keys ...VerificationKey) Option {
+	return func(svc *authService) {
+		svc.keys = append(svc.keys, keys...)
+	}
+}
+
+// WithIssuer requires the "iss" claim of tokens to equal issuer.
+func WithIssuer(issuer string) Option {
+	return func(svc *authService) {
+		svc.issuer = issuer
+	}
+}
+
+// WithAudience requires the "aud" claim of tokens to include audience.
+func WithAudience(audience string) Option {
+	return func(svc *authService) {
+		svc.audience = audience
+	}
+}
+
+// WithClock replaces the function used by the service to tell the time.
+func WithClock(now func() time.Time) Option {
+	return func(svc *authService) {
+		svc.now = now
+	}
+}
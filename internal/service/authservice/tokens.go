@@ -0,0 +1,67 @@
+package authservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// AuthenticateToken verifies a JWT signed using HS256 or RS256 and returns
+// the Principal named by its "sub" claim.
+//
+// The token must be signed by one of the service's verification keys using
+// that key's algorithm, and carry a subject and an expiry time that hasn't
+// passed. If the service was configured with an issuer or audience, the
+// token's claims must match them. Otherwise, InvalidCredentialsError is
+// returned.
+func (svc *authService) AuthenticateToken(_ context.Context, token string) (Principal, error) {
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{HS256, RS256}),
+		jwt.WithExpirationRequired(),
+		jwt.WithTimeFunc(svc.now),
+	}
+
+	if svc.issuer != "" {
+		opts = append(opts, jwt.WithIssuer(svc.issuer))
+	}
+
+	if svc.audience != "" {
+		opts = append(opts, jwt.WithAudience(svc.audience))
+	}
+
+	var claims jwt.RegisteredClaims
+
+	if _, err := jwt.ParseWithClaims(token, &claims, svc.verificationKeys, opts...); err != nil {
+		return Principal{}, fmt.Errorf("AuthenticateToken: %w", InvalidCredentialsError{Reason: err.Error()})
+	}
+
+	if claims.Subject == "" {
+		return Principal{}, fmt.Errorf("AuthenticateToken: %w", InvalidCredentialsError{Reason: "token has no subject"})
+	}
+
+	return Principal{Kind: PrincipalKindToken, Subject: claims.Subject}, nil
+}
+
+// verificationKeys returns the keys that may have signed token: those for its
+// algorithm whose ID matches its "kid" header, if it has one.
+func (svc *authService) verificationKeys(token *jwt.Token) (any, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	var set jwt.VerificationKeySet
+
+	for _, key := range svc.keys {
+		if key.Algorithm != token.Method.Alg() || (kid != "" && key.ID != "" && key.ID != kid) {
+			continue
+		}
+
+		set.Keys = append(set.Keys, key.Key)
+	}
+
+	if len(set.Keys) == 0 {
+		return nil, errors.New("no key can verify the token")
+	}
+
+	return set, nil
+}
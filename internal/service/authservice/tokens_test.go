@@ -0,0 +1,146 @@
+//go:build unit
+
+package authservice
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthenticateToken(t *testing.T) {
+	t.Parallel()
+
+	var (
+		now       = time.Date(2026, time.October, 18, 12, 0, 0, 0, time.UTC)
+		secret    = []byte("correct horse battery staple")
+		claims    = jwt.RegisteredClaims{Subject: "registrar", ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour))}
+		rsaKey, _ = rsa.GenerateKey(rand.Reader, 2048)
+	)
+
+	sign := func(t *testing.T, method jwt.SigningMethod, key any, kid string, claims jwt.Claims) string {
+		t.Helper()
+
+		token := jwt.NewWithClaims(method, claims)
+		if kid != "" {
+			token.Header["kid"] = kid
+		}
+
+		signed, err := token.SignedString(key)
+		require.NoError(t, err)
+
+		return signed
+	}
+
+	newService := func(t *testing.T, opts ...Option) Interface {
+		t.Helper()
+
+		opts = append([]Option{
+			WithVerificationKeys(HS256Key("", secret), VerificationKey{ID: "rsa-1", Algorithm: RS256, Key: &rsaKey.PublicKey}),
+			WithClock(func() time.Time { return now }),
+		}, opts...)
+
+		return New(log.New(os.Stdout, "TestAuthenticateToken ", log.LstdFlags), validator.New(), NewMockAtomicRepository(t), opts...)
+	}
+
+	t.Run("accepts valid tokens", func(t *testing.T) {
+		t.Parallel()
+
+		testCases := []struct {
+			name  string
+			token string
+		}{
+			{name: "HS256", token: sign(t, jwt.SigningMethodHS256, secret, "", claims)},
+			{name: "RS256 with key ID", token: sign(t, jwt.SigningMethodRS256, rsaKey, "rsa-1", claims)},
+			{name: "RS256 without key ID", token: sign(t, jwt.SigningMethodRS256, rsaKey, "", claims)},
+		}
+
+		for _, tc := range testCases {
+			tc := tc
+
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+
+				principal, err := newService(t).AuthenticateToken(context.Background(), tc.token)
+				require.NoError(t, err)
+				require.Equal(t, Principal{Kind: PrincipalKindToken, Subject: "registrar"}, principal)
+			})
+		}
+	})
+
+	t.Run("rejects invalid tokens", func(t *testing.T) {
+		t.Parallel()
+
+		otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+
+		expired := claims
+		expired.ExpiresAt = jwt.NewNumericDate(now.Add(-time.Minute))
+
+		noExpiry := claims
+		noExpiry.ExpiresAt = nil
+
+		noSubject := claims
+		noSubject.Subject = ""
+
+		testCases := []struct {
+			name  string
+			token string
+			opts  []Option
+		}{
+			{name: "malformed", token: "not.a.token"},
+			{name: "wrong secret", token: sign(t, jwt.SigningMethodHS256, []byte("wrong"), "", claims)},
+			{name: "wrong RSA key", token: sign(t, jwt.SigningMethodRS256, otherKey, "", claims)},
+			{name: "unknown key ID", token: sign(t, jwt.SigningMethodRS256, rsaKey, "rsa-2", claims)},
+			{name: "unsupported algorithm", token: sign(t, jwt.SigningMethodHS512, secret, "", claims)},
+			{name: "unsigned", token: sign(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, "", claims)},
+			{name: "expired", token: sign(t, jwt.SigningMethodHS256, secret, "", expired)},
+			{name: "no expiry", token: sign(t, jwt.SigningMethodHS256, secret, "", noExpiry)},
+			{name: "no subject", token: sign(t, jwt.SigningMethodHS256, secret, "", noSubject)},
+			{
+				name:  "wrong issuer",
+				token: sign(t, jwt.SigningMethodHS256, secret, "", claims),
+				opts:  []Option{WithIssuer("https://idp.hexagonal.test")},
+			},
+			{
+				name:  "wrong audience",
+				token: sign(t, jwt.SigningMethodHS256, secret, "", claims),
+				opts:  []Option{WithAudience("hexagonal")},
+			},
+		}
+
+		for _, tc := range testCases {
+			tc := tc
+
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+
+				_, err := newService(t, tc.opts...).AuthenticateToken(context.Background(), tc.token)
+
+				var credentialsErr InvalidCredentialsError
+				require.ErrorAs(t, err, &credentialsErr)
+			})
+		}
+	})
+
+	t.Run("checks the issuer and audience if configured", func(t *testing.T) {
+		t.Parallel()
+
+		withIssuer := claims
+		withIssuer.Issuer = "https://idp.hexagonal.test"
+		withIssuer.Audience = jwt.ClaimStrings{"hexagonal"}
+
+		service := newService(t, WithIssuer("https://idp.hexagonal.test"), WithAudience("hexagonal"))
+
+		_, err := service.AuthenticateToken(context.Background(), sign(t, jwt.SigningMethodHS256, secret, "", withIssuer))
+		require.NoError(t, err)
+	})
+}
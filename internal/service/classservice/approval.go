@@ -0,0 +1,168 @@
+package classservice
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/angusgmorrison/hexagonal/internal/primitive"
+)
+
+// ApproveEnrollment enrolls the student with the given email address, whose
+// enrollment in the course is pending approval.
+//
+// The capacity of the class is checked again at the time of approval, since
+// pending students don't hold a place. If the course is divided into
+// sections, the student is placed in the section they asked to join or, if
+// they named none, the least-full section. If the course charges a fee, the
+// student is invoiced and their enrollment awaits payment.
+func (svc *classService) ApproveEnrollment(
+	ctx context.Context,
+	courseCode string,
+	email primitive.EmailAddress,
+) error {
+	if err := svc.validateCourseAndEmail(courseCode, email); err != nil {
+		return fmt.Errorf("ApproveEnrollment: %w", err)
+	}
+
+	var pending notifications
+
+	approve := func(ctx context.Context, repo Repository) error {
+		class, student, err := svc.getPendingEnrollment(ctx, repo, courseCode, email)
+		if err != nil {
+			return err
+		}
+
+		students := Students{student}
+
+		if err := verifyCourseNotCancelled(ctx, repo, class, students); err != nil {
+			return err
+		}
+
+		if err := verifyClassHasCapacity(ctx, repo, class, students); err != nil {
+			return err
+		}
+
+		var section Section
+
+		if len(class.Sections) > 0 {
+			var sectionCode string
+			if requested, ok := class.Sections.pendingFor(student); ok {
+				sectionCode = requested.Code
+			}
+
+			assignments, err := class.assignSections(sectionCode, students)
+			if err != nil {
+				return err
+			}
+
+			section = assignments[0]
+		}
+
+		if !class.Fee.IsZero() {
+			return svc.awaitPayment(ctx, repo, class, section, student)
+		}
+
+		if _, err := repo.ApproveEnrollment(ctx, class.Course, section, student); err != nil {
+			return fmt.Errorf("ApproveEnrollment: %w", err)
+		}
+
+		if err := convertReservations(ctx, repo, class, students); err != nil {
+			return fmt.Errorf("ApproveEnrollment: %w", err)
+		}
+
+		pending.add(NotificationEnrolled, class.Code, section.Code, students)
+
+		return nil
+	}
+
+	if err := svc.repo.Execute(ctx, approve); err != nil {
+		return err
+	}
+
+	svc.deliver(ctx, pending)
+
+	svc.publishAvailability(ctx, courseCode)
+
+	return nil
+}
+
+// awaitPayment invoices an approved student for the fee of the class, leaving
+// their enrollment awaiting payment in the given section.
+func (svc *classService) awaitPayment(
+	ctx context.Context,
+	repo Repository,
+	class Class,
+	section Section,
+	student Student,
+) error {
+	if _, err := repo.AwaitPayment(ctx, class.Course, section, student); err != nil {
+		return fmt.Errorf("ApproveEnrollment: %w", err)
+	}
+
+	if err := convertReservations(ctx, repo, class, Students{student}); err != nil {
+		return fmt.Errorf("ApproveEnrollment: %w", err)
+	}
+
+	if err := svc.invoiceAll(ctx, repo, class, Voucher{}, Students{student}); err != nil {
+		return fmt.Errorf("ApproveEnrollment: %w", err)
+	}
+
+	return nil
+}
+
+// RejectEnrollment refuses the pending enrollment of the student with the
+// given email address.
+func (svc *classService) RejectEnrollment(
+	ctx context.Context,
+	courseCode string,
+	email primitive.EmailAddress,
+) error {
+	if err := svc.validateCourseAndEmail(courseCode, email); err != nil {
+		return fmt.Errorf("RejectEnrollment: %w", err)
+	}
+
+	reject := func(ctx context.Context, repo Repository) error {
+		class, student, err := svc.getPendingEnrollment(ctx, repo, courseCode, email)
+		if err != nil {
+			return err
+		}
+
+		if _, err := repo.RejectEnrollment(ctx, class.Course, student); err != nil {
+			return fmt.Errorf("RejectEnrollment: %w", err)
+		}
+
+		return nil
+	}
+
+	return svc.repo.Execute(ctx, reject)
+}
+
+func (svc *classService) validateCourseAndEmail(courseCode string, email primitive.EmailAddress) error {
+	if err := svc.validate.Var(courseCode, "required"); err != nil {
+		return err
+	}
+
+	return svc.validate.Var(email, "required")
+}
+
+// getPendingEnrollment loads a class and the student with the given email
+// address whose enrollment in it is pending. The class is locked, since
+// approval claims one of its places.
+func (svc *classService) getPendingEnrollment(
+	ctx context.Context,
+	repo Repository,
+	courseCode string,
+	email primitive.EmailAddress,
+) (Class, Student, error) {
+	class, err := svc.getClassForUpdate(ctx, repo, courseCode)
+	if err != nil {
+		return Class{}, Student{}, fmt.Errorf("get pending enrollment: %w", err)
+	}
+
+	student, ok := class.Pending.ByEmail(email)
+	if !ok {
+		return Class{}, Student{}, EnrollmentNotPendingError{CourseCode: courseCode, Email: email}
+	}
+
+	return class, student, nil
+}
@@ -0,0 +1,371 @@
+//go:build unit
+
+package classservice
+
+import (
+	"context"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnrollRequiringApproval(t *testing.T) {
+	t.Parallel()
+
+	t.Run("leaves enrollment pending", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "leaves enrollment pending ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			service    = New(logger, validate, atomicRepo)
+			ctx        = context.Background()
+			req        = defaultEnrollmentRequest(t)
+			class      = approvalClass()
+			students   = registeredStudents(t, req.Students)
+		)
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On(
+			"GetClassForUpdate",
+			ctx,
+			req.CourseCode,
+		).Return(class, nil)
+
+		repo.On(
+			"GetStudentsByEmail",
+			ctx,
+			req.Students.EmailAddresses(),
+		).Return(students, nil)
+
+		repo.On(
+			"RequestEnrollment",
+			ctx,
+			class.Course,
+			Section{},
+			students,
+		).Return(class, nil)
+
+		err := service.Enroll(ctx, req)
+		require.NoError(t, err)
+	})
+
+	t.Run("validates that students aren't already pending", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "validates that students aren't already pending ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			service    = New(logger, validate, atomicRepo)
+			ctx        = context.Background()
+			req        = defaultEnrollmentRequest(t)
+			class      = approvalClass()
+			students   = registeredStudents(t, req.Students)
+		)
+
+		class.Pending = students
+		wantErr := AlreadyEnrolledError{Students: students}
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On(
+			"GetClassForUpdate",
+			ctx,
+			req.CourseCode,
+		).Return(class, nil)
+
+		repo.On(
+			"GetStudentsByEmail",
+			ctx,
+			req.Students.EmailAddresses(),
+		).Return(students, nil)
+
+		err := service.Enroll(ctx, req)
+
+		var gotErr AlreadyEnrolledError
+		require.ErrorAs(t, err, &gotErr)
+		require.Equal(t, wantErr, gotErr, "unequal AlreadyEnrolledErrors")
+	})
+}
+
+func TestApproveEnrollment(t *testing.T) {
+	t.Parallel()
+
+	t.Run("validates enrollment is pending", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "validates enrollment is pending ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			service    = New(logger, validate, atomicRepo)
+			ctx        = context.Background()
+			class      = approvalClass()
+			student    = defaultStudent(t)
+			wantErr    = EnrollmentNotPendingError{CourseCode: class.Code, Email: student.Email}
+		)
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On(
+			"GetClassForUpdate",
+			ctx,
+			class.Code,
+		).Return(class, nil)
+
+		err := service.ApproveEnrollment(ctx, class.Code, student.Email)
+
+		var gotErr EnrollmentNotPendingError
+		require.ErrorAs(t, err, &gotErr)
+		require.Equal(t, wantErr, gotErr, "unequal EnrollmentNotPendingErrors")
+	})
+
+	t.Run("rechecks capacity", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "rechecks capacity ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			service    = New(logger, validate, atomicRepo)
+			ctx        = context.Background()
+			class      = approvalClass()
+			student    = defaultStudent(t)
+		)
+
+		student.ID = 1
+		class.Pending = Students{student}
+		class.Students = Students{{ID: 2}, {ID: 3}}
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On(
+			"GetClassForUpdate",
+			ctx,
+			class.Code,
+		).Return(class, nil)
+
+		err := service.ApproveEnrollment(ctx, class.Code, student.Email)
+
+		var gotErr OversubscribedError
+		require.ErrorAs(t, err, &gotErr)
+	})
+
+	t.Run("approves enrollment", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "approves enrollment ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			service    = New(logger, validate, atomicRepo)
+			ctx        = context.Background()
+			class      = approvalClass()
+			student    = defaultStudent(t)
+		)
+
+		student.ID = 1
+		class.Pending = Students{student}
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On(
+			"GetClassForUpdate",
+			ctx,
+			class.Code,
+		).Return(class, nil)
+
+		repo.On(
+			"ApproveEnrollment",
+			ctx,
+			class.Course,
+			Section{},
+			student,
+		).Return(class, nil)
+
+		err := service.ApproveEnrollment(ctx, class.Code, student.Email)
+		require.NoError(t, err)
+	})
+
+	t.Run("places student in requested section", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "places student in requested section ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			service    = New(logger, validate, atomicRepo)
+			ctx        = context.Background()
+			class      = sectionedClass(t)
+			student    = defaultStudent(t)
+		)
+
+		student.ID = 1
+		class.RequiresApproval = true
+		class.Pending = Students{student}
+		class.Sections[0].Capacity = 3
+		class.Sections[1].Pending = Students{student}
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On(
+			"GetClassForUpdate",
+			ctx,
+			class.Code,
+		).Return(class, nil)
+
+		// Section A is less full, but the student asked for section B.
+		repo.On(
+			"ApproveEnrollment",
+			ctx,
+			class.Course,
+			mock.MatchedBy(func(sec Section) bool { return sec.Code == "B" }),
+			student,
+		).Return(class, nil)
+
+		err := service.ApproveEnrollment(ctx, class.Code, student.Email)
+		require.NoError(t, err)
+	})
+}
+
+func TestRejectEnrollment(t *testing.T) {
+	t.Parallel()
+
+	t.Run("validates enrollment is pending", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "validates enrollment is pending ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			service    = New(logger, validate, atomicRepo)
+			ctx        = context.Background()
+			class      = approvalClass()
+			student    = defaultStudent(t)
+		)
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On(
+			"GetClassForUpdate",
+			ctx,
+			class.Code,
+		).Return(class, nil)
+
+		err := service.RejectEnrollment(ctx, class.Code, student.Email)
+
+		var gotErr EnrollmentNotPendingError
+		require.ErrorAs(t, err, &gotErr)
+	})
+
+	t.Run("rejects enrollment", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "rejects enrollment ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			service    = New(logger, validate, atomicRepo)
+			ctx        = context.Background()
+			class      = approvalClass()
+			student    = defaultStudent(t)
+		)
+
+		student.ID = 1
+		class.Pending = Students{student}
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On(
+			"GetClassForUpdate",
+			ctx,
+			class.Code,
+		).Return(class, nil)
+
+		repo.On(
+			"RejectEnrollment",
+			ctx,
+			class.Course,
+			student,
+		).Return(class, nil)
+
+		err := service.RejectEnrollment(ctx, class.Code, student.Email)
+		require.NoError(t, err)
+	})
+}
+
+// approvalClass returns an empty class with space for two students, which
+// requires enrollments to be approved.
+func approvalClass() Class {
+	return Class{
+		Course: Course{
+			ID:               1,
+			Code:             "SICP",
+			Capacity:         2,
+			RequiresApproval: true,
+		},
+	}
+}
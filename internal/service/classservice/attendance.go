@@ -0,0 +1,157 @@
+package classservice
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/angusgmorrison/hexagonal/internal/primitive"
+)
+
+// CreateSessions adds sessions starting at each of the given times to the
+// course matching courseCode, and returns the sessions created. Times at which
+// the course already has a session are ignored. At most MaxSessions times may
+// be given. The course must not have been cancelled.
+func (svc *classService) CreateSessions(
+	ctx context.Context,
+	courseCode string,
+	startTimes []time.Time,
+) (Sessions, error) {
+	if err := svc.validate.Var(courseCode, "required"); err != nil {
+		return nil, fmt.Errorf("CreateSessions: %w", err)
+	}
+
+	if err := svc.validate.Var(startTimes, fmt.Sprintf("min=1,max=%d,dive,required", MaxSessions)); err != nil {
+		return nil, fmt.Errorf("CreateSessions: %w", err)
+	}
+
+	var sessions Sessions
+
+	create := func(ctx context.Context, repo Repository) error {
+		class, err := svc.getClass(ctx, repo, courseCode)
+		if err != nil {
+			return fmt.Errorf("CreateSessions: %w", err)
+		}
+
+		if err := verifyCourseNotCancelled(ctx, repo, class, nil); err != nil {
+			return err
+		}
+
+		sessions, err = repo.CreateSessions(ctx, class.Course, startTimes)
+		if err != nil {
+			return fmt.Errorf("CreateSessions: %w", err)
+		}
+
+		return nil
+	}
+
+	if err := svc.repo.Execute(ctx, create); err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+// ScheduleSessions adds the sessions described by the schedule to the course
+// matching courseCode, as CreateSessions. Schedules spanning more than
+// MaxScheduleSpan are refused with ScheduleTooLongError.
+func (svc *classService) ScheduleSessions(
+	ctx context.Context,
+	courseCode string,
+	schedule Schedule,
+) (Sessions, error) {
+	if err := svc.validate.Struct(schedule); err != nil {
+		return nil, fmt.Errorf("ScheduleSessions: %w", err)
+	}
+
+	if schedule.Until.Sub(schedule.First) > MaxScheduleSpan {
+		return nil, ScheduleTooLongError{CourseCode: courseCode, MaxSpan: MaxScheduleSpan}
+	}
+
+	startTimes := schedule.StartTimes()
+	if len(startTimes) == 0 {
+		return nil, EmptyScheduleError{CourseCode: courseCode}
+	}
+
+	return svc.CreateSessions(ctx, courseCode, startTimes)
+}
+
+// RecordAttendance records whether each student was present at a session of
+// the course matching courseCode, replacing any attendance previously recorded
+// for the same student and session. Each session must belong to the course,
+// and each student, identified by email address, must be actively enrolled in
+// it.
+func (svc *classService) RecordAttendance(
+	ctx context.Context,
+	courseCode string,
+	records []AttendanceRecord,
+) error {
+	if err := svc.validate.Var(courseCode, "required"); err != nil {
+		return fmt.Errorf("RecordAttendance: %w", err)
+	}
+
+	if err := svc.validate.Var(records, "min=1,dive"); err != nil {
+		return fmt.Errorf("RecordAttendance: %w", err)
+	}
+
+	record := func(ctx context.Context, repo Repository) error {
+		class, err := svc.getClass(ctx, repo, courseCode)
+		if err != nil {
+			return fmt.Errorf("RecordAttendance: %w", err)
+		}
+
+		resolved, err := resolveAttendance(class, records)
+		if err != nil {
+			return err
+		}
+
+		if _, err := repo.RecordAttendance(ctx, class.Course, resolved, svc.now()); err != nil {
+			return fmt.Errorf("RecordAttendance: %w", err)
+		}
+
+		return nil
+	}
+
+	return svc.repo.Execute(ctx, record)
+}
+
+// resolveAttendance returns the records with each student replaced by the
+// matching student enrolled in the class. It fails if any record names a
+// session that doesn't belong to the class or a student who isn't enrolled in
+// it.
+func resolveAttendance(class Class, records []AttendanceRecord) ([]AttendanceRecord, error) {
+	resolved := make([]AttendanceRecord, 0, len(records))
+
+	var (
+		notEnrolled Students
+		seen        = make(map[primitive.EmailAddress]bool)
+	)
+
+	for _, record := range records {
+		if _, ok := class.Sessions.ByID(record.SessionID); !ok {
+			return nil, SessionNotFoundError{CourseCode: class.Code, SessionID: record.SessionID}
+		}
+
+		student, ok := class.Students.ByEmail(record.Student.Email)
+		if !ok {
+			if !seen[record.Student.Email] {
+				notEnrolled = append(notEnrolled, record.Student)
+				seen[record.Student.Email] = true
+			}
+
+			continue
+		}
+
+		record.Student = student
+		resolved = append(resolved, record)
+	}
+
+	if len(notEnrolled) > 0 {
+		return nil, NotEnrolledError{
+			CourseCode: class.Code,
+			Students:   notEnrolled,
+		}
+	}
+
+	return resolved, nil
+}
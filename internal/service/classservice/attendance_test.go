@@ -0,0 +1,316 @@
+//go:build unit
+
+package classservice
+
+import (
+	"context"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/angusgmorrison/hexagonal/internal/primitive"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduleStartTimes(t *testing.T) {
+	t.Parallel()
+
+	schedule := Schedule{
+		First:    time.Date(2022, time.September, 5, 10, 30, 0, 0, time.UTC), // Monday
+		Until:    time.Date(2022, time.September, 14, 0, 0, 0, 0, time.UTC),
+		Weekdays: []time.Weekday{time.Monday, time.Wednesday},
+	}
+
+	want := []time.Time{
+		time.Date(2022, time.September, 5, 10, 30, 0, 0, time.UTC),
+		time.Date(2022, time.September, 7, 10, 30, 0, 0, time.UTC),
+		time.Date(2022, time.September, 12, 10, 30, 0, 0, time.UTC),
+	}
+
+	require.Equal(t, want, schedule.StartTimes())
+}
+
+func TestScheduleSessions(t *testing.T) {
+	t.Parallel()
+
+	first := time.Date(2022, time.September, 5, 10, 0, 0, 0, time.UTC)
+
+	t.Run("validates schedule", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "validates schedule ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			service    = New(logger, validate, atomicRepo)
+		)
+
+		testCases := []struct {
+			name     string
+			schedule Schedule
+		}{
+			{
+				name:     "missing first",
+				schedule: Schedule{Until: first, Weekdays: []time.Weekday{time.Monday}},
+			},
+			{
+				name:     "until before first",
+				schedule: Schedule{First: first, Until: first.Add(-time.Hour), Weekdays: []time.Weekday{time.Monday}},
+			},
+			{
+				name:     "no weekdays",
+				schedule: Schedule{First: first, Until: first.AddDate(0, 1, 0)},
+			},
+		}
+
+		for _, tc := range testCases {
+			tc := tc
+
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+
+				_, err := service.ScheduleSessions(context.Background(), "SICP", tc.schedule)
+
+				var validationErrs validator.ValidationErrors
+				require.ErrorAs(t, err, &validationErrs)
+			})
+		}
+	})
+
+	t.Run("rejects empty schedule", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "rejects empty schedule ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			service    = New(logger, validate, atomicRepo)
+			schedule   = Schedule{First: first, Until: first.AddDate(0, 0, 2), Weekdays: []time.Weekday{time.Friday}}
+		)
+
+		_, err := service.ScheduleSessions(context.Background(), "SICP", schedule)
+		require.Equal(t, EmptyScheduleError{CourseCode: "SICP"}, err)
+	})
+
+	t.Run("rejects schedule spanning too long", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "rejects schedule spanning too long ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			service    = New(logger, validate, atomicRepo)
+			schedule   = Schedule{
+				First:    first,
+				Until:    first.Add(MaxScheduleSpan + time.Hour),
+				Weekdays: []time.Weekday{time.Monday},
+			}
+		)
+
+		_, err := service.ScheduleSessions(context.Background(), "SICP", schedule)
+		require.Equal(t, ScheduleTooLongError{CourseCode: "SICP", MaxSpan: MaxScheduleSpan}, err)
+	})
+
+	t.Run("creates scheduled sessions", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "creates scheduled sessions ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			service    = New(logger, validate, atomicRepo)
+			ctx        = context.Background()
+			class      = defaultClass(t)
+			schedule   = Schedule{First: first, Until: first.AddDate(0, 0, 7), Weekdays: []time.Weekday{time.Monday}}
+			startTimes = []time.Time{first, first.AddDate(0, 0, 7)}
+			sessions   = Sessions{{ID: 1, StartsAt: startTimes[0]}, {ID: 2, StartsAt: startTimes[1]}}
+		)
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On("GetClassByCourseCode", ctx, class.Code).Return(class, nil)
+		repo.On("CreateSessions", ctx, class.Course, startTimes).Return(sessions, nil)
+
+		got, err := service.ScheduleSessions(ctx, class.Code, schedule)
+		require.NoError(t, err)
+		require.Equal(t, sessions, got)
+	})
+
+	t.Run("rejects cancelled course", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "rejects cancelled course ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			service    = New(logger, validate, atomicRepo)
+			ctx        = context.Background()
+			class      = cancelledClass()
+		)
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On("GetClassByCourseCode", ctx, class.Code).Return(class, nil)
+
+		_, err := service.CreateSessions(ctx, class.Code, []time.Time{first})
+		require.Equal(t, CourseCancelledError{CourseCode: class.Code}, err)
+	})
+}
+
+func TestRecordAttendance(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2022, time.September, 5, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	attendanceClass := func(t *testing.T) Class {
+		t.Helper()
+
+		class := defaultClass(t)
+		class.Students = registeredStudents(t, Students{defaultStudent(t)})
+		class.Sessions = Sessions{{ID: 1, StartsAt: now.Add(-2 * time.Hour)}}
+
+		return class
+	}
+
+	testCases := []struct {
+		name    string
+		records func(class Class) []AttendanceRecord
+		wantErr func(class Class) error
+	}{
+		{
+			name: "session not found",
+			records: func(class Class) []AttendanceRecord {
+				return []AttendanceRecord{{SessionID: 2, Student: Student{Email: class.Students[0].Email}}}
+			},
+			wantErr: func(class Class) error {
+				return SessionNotFoundError{CourseCode: class.Code, SessionID: 2}
+			},
+		},
+		{
+			name: "student not enrolled",
+			records: func(class Class) []AttendanceRecord {
+				return []AttendanceRecord{
+					{SessionID: 1, Student: Student{Email: "berthe@archibaldindustries.com"}},
+					{SessionID: 1, Student: Student{Email: class.Students[0].Email}, Present: true},
+				}
+			},
+			wantErr: func(class Class) error {
+				return NotEnrolledError{
+					CourseCode: class.Code,
+					Students:   Students{{Email: "berthe@archibaldindustries.com"}},
+				}
+			},
+		},
+		{
+			name: "recorded",
+			records: func(class Class) []AttendanceRecord {
+				return []AttendanceRecord{{SessionID: 1, Student: Student{Email: class.Students[0].Email}, Present: true}}
+			},
+			wantErr: func(Class) error { return nil },
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				logger     = log.New(os.Stdout, "TestRecordAttendance ", log.LstdFlags)
+				validate   = validator.New()
+				atomicRepo = NewMockAtomicRepository(t)
+				repo       = NewMockRepository(t)
+				service    = New(logger, validate, atomicRepo, WithClock(clock))
+				ctx        = context.Background()
+				class      = attendanceClass(t)
+				wantErr    = tc.wantErr(class)
+			)
+
+			atomicRepo.On(
+				"Execute",
+				ctx,
+				mock.AnythingOfType("AtomicOperation"),
+			).Return(func(ctx context.Context, op AtomicOperation) error {
+				return op(ctx, repo)
+			})
+
+			repo.On("GetClassByCourseCode", ctx, class.Code).Return(class, nil)
+
+			if wantErr == nil {
+				repo.On(
+					"RecordAttendance",
+					ctx,
+					class.Course,
+					[]AttendanceRecord{{SessionID: 1, Student: class.Students[0], Present: true}},
+					now,
+				).Return(class, nil)
+			}
+
+			err := service.RecordAttendance(ctx, class.Code, tc.records(class))
+			require.Equal(t, wantErr, err)
+		})
+	}
+
+	t.Run("validates records", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "validates records ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			service    = New(logger, validate, atomicRepo)
+			email      = primitive.EmailAddress("r.tifft@gmail.com")
+		)
+
+		testCases := []struct {
+			name    string
+			records []AttendanceRecord
+		}{
+			{name: "no records", records: nil},
+			{name: "missing session ID", records: []AttendanceRecord{{Student: Student{Email: email}}}},
+		}
+
+		for _, tc := range testCases {
+			tc := tc
+
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+
+				err := service.RecordAttendance(context.Background(), "SICP", tc.records)
+
+				var validationErrs validator.ValidationErrors
+				require.ErrorAs(t, err, &validationErrs)
+			})
+		}
+	})
+}
+
+func TestAttendancePercentage(t *testing.T) {
+	t.Parallel()
+
+	_, ok := Attendance{}.Percentage()
+	require.False(t, ok)
+
+	percentage, ok := Attendance{Attended: 3, Recorded: 4}.Percentage()
+	require.True(t, ok)
+	require.InDelta(t, 75.0, percentage, 1e-9)
+}
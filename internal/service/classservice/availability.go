@@ -0,0 +1,51 @@
+package classservice
+
+import "context"
+
+// Availability reports the number of places remaining in a course, as given by
+// Class.AvailableSpaces.
+type Availability struct {
+	CourseCode      string
+	AvailableSpaces uint32
+}
+
+// AvailabilityPublisher is informed of the places remaining in a course
+// whenever its enrollments or reservations change. Publishers are called after
+// the change has been committed, so they can't abort it, and should return
+// promptly.
+type AvailabilityPublisher interface {
+	PublishAvailability(ctx context.Context, a Availability)
+}
+
+// publishAvailability publishes the availability of each of the courses, if
+// the service has an AvailabilityPublisher. Failures to read a course are
+// logged, since the change that prompted the publication has already
+// succeeded.
+func (svc *classService) publishAvailability(ctx context.Context, courseCodes ...string) {
+	if svc.availabilityPublisher == nil {
+		return
+	}
+
+	for _, courseCode := range courseCodes {
+		var class Class
+
+		read := func(ctx context.Context, repo Repository) error {
+			var err error
+
+			class, err = svc.getClass(ctx, repo, courseCode)
+
+			return err
+		}
+
+		if err := svc.repo.Execute(ctx, read); err != nil {
+			svc.logger.Printf("Failed to publish availability of %s: %v", courseCode, err)
+
+			continue
+		}
+
+		svc.availabilityPublisher.PublishAvailability(ctx, Availability{
+			CourseCode:      class.Course.Code,
+			AvailableSpaces: class.AvailableSpaces(),
+		})
+	}
+}
@@ -0,0 +1,201 @@
+//go:build unit
+
+package classservice
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublishAvailability(t *testing.T) {
+	t.Parallel()
+
+	t.Run("publishes the places remaining once a change is committed", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "publishes availability ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			publisher  = NewMockAvailabilityPublisher(t)
+			service    = New(logger, validate, atomicRepo, WithAvailabilityPublisher(publisher))
+			ctx        = context.Background()
+			student    = defaultStudent(t)
+		)
+
+		student.ID = 1
+		class := transferClass("SICP", 2, student)
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On("GetClassByCourseCode", ctx, "SICP").Return(class, nil).Once()
+		repo.On("UnenrollStudents", ctx, class.Course, Students{student}).Return(transferClass("SICP", 2), nil)
+		repo.On("GetClassByCourseCode", ctx, "SICP").Return(transferClass("SICP", 2), nil).Once()
+		publisher.On("PublishAvailability", ctx, Availability{CourseCode: "SICP", AvailableSpaces: 2}).Return()
+
+		err := service.Unenroll(ctx, "SICP", Students{{Email: student.Email}})
+		require.NoError(t, err)
+	})
+
+	t.Run("publishes no places in a cancelled course", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "publishes no places in a cancelled course ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			publisher  = NewMockAvailabilityPublisher(t)
+			service    = New(logger, validate, atomicRepo, WithAvailabilityPublisher(publisher))
+			ctx        = context.Background()
+			class      = transferClass("SICP", 2)
+			cancelled  = transferClass("SICP", 2)
+		)
+
+		cancelled.Cancelled = true
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On("GetClassByCourseCode", ctx, "SICP").Return(class, nil).Once()
+		repo.On("CancelCourse", ctx, class.Course, mock.AnythingOfType("time.Time")).Return(cancelled, nil)
+		repo.On("GetClassByCourseCode", ctx, "SICP").Return(cancelled, nil).Once()
+		publisher.On("PublishAvailability", ctx, Availability{CourseCode: "SICP", AvailableSpaces: 0}).Return()
+
+		err := service.CancelCourse(ctx, "SICP")
+		require.NoError(t, err)
+	})
+
+	t.Run("publishes the places freed by expired reservations", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "publishes the places freed by expired reservations ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			publisher  = NewMockAvailabilityPublisher(t)
+			service    = New(logger, validate, atomicRepo, WithAvailabilityPublisher(publisher))
+			ctx        = context.Background()
+		)
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On("ReleaseExpiredReservations", ctx, mock.AnythingOfType("time.Time")).
+			Return(2, []string{"SICP", "TAOCP"}, nil)
+		repo.On("GetClassByCourseCode", ctx, "SICP").Return(transferClass("SICP", 2), nil)
+		repo.On("GetClassByCourseCode", ctx, "TAOCP").Return(transferClass("TAOCP", 3), nil)
+		publisher.On("PublishAvailability", ctx, Availability{CourseCode: "SICP", AvailableSpaces: 2}).Return().Once()
+		publisher.On("PublishAvailability", ctx, Availability{CourseCode: "TAOCP", AvailableSpaces: 3}).Return().Once()
+
+		released, err := service.ReleaseExpiredReservations(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 2, released)
+	})
+
+	t.Run("doesn't fail the change if the course can't be read", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = newMockLogger(t)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			publisher  = NewMockAvailabilityPublisher(t)
+			service    = New(logger, validate, atomicRepo, WithAvailabilityPublisher(publisher))
+			ctx        = context.Background()
+			student    = defaultStudent(t)
+			readErr    = errors.New("connection reset")
+		)
+
+		student.ID = 1
+		class := transferClass("SICP", 2, student)
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On("GetClassByCourseCode", ctx, "SICP").Return(class, nil).Once()
+		repo.On("UnenrollStudents", ctx, class.Course, Students{student}).Return(transferClass("SICP", 2), nil)
+		repo.On("GetClassByCourseCode", ctx, "SICP").Return(Class{}, readErr).Once()
+		logger.On("Printf", mock.AnythingOfType("string"), "SICP", mock.Anything).Return()
+
+		err := service.Unenroll(ctx, "SICP", Students{{Email: student.Email}})
+		require.NoError(t, err)
+	})
+}
+
+func TestClassAvailableSpaces(t *testing.T) {
+	t.Parallel()
+
+	students := Students{{ID: 1}, {ID: 2}, {ID: 3}}
+
+	testCases := []struct {
+		name  string
+		class Class
+		want  uint32
+	}{
+		{
+			name:  "has places",
+			class: transferClass("SICP", 5, students...),
+			want:  2,
+		},
+		{
+			name:  "is full",
+			class: transferClass("SICP", 3, students...),
+			want:  0,
+		},
+		{
+			name:  "is over capacity",
+			class: transferClass("SICP", 2, students...),
+			want:  0,
+		},
+		{
+			name: "has more reservations than places",
+			class: Class{
+				Course:       Course{Code: "SICP", Capacity: 2},
+				Reservations: Reservations{{StudentID: 1}, {StudentID: 2}, {StudentID: 3}},
+			},
+			want: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tc.want, tc.class.AvailableSpaces())
+		})
+	}
+}
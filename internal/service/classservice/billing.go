@@ -0,0 +1,139 @@
+package classservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/angusgmorrison/hexagonal/internal/primitive"
+)
+
+// Invoice bills a student for the fee of a course. ID is assigned by the
+// Billing provider, which uses it to identify the invoice when confirming
+// payment. Amount is the fee less the discount of the voucher with
+// VoucherCode, if the student redeemed one. PaidAt is zero until the invoice
+// has been paid.
+type Invoice struct {
+	ID          string
+	CourseCode  string
+	Student     Student
+	Amount      primitive.Money
+	VoucherCode string
+	IssuedAt    time.Time
+	PaidAt      time.Time
+}
+
+// Paid reports whether the invoice has been paid.
+func (i Invoice) Paid() bool {
+	return !i.PaidAt.IsZero()
+}
+
+// Billing issues invoices through a payment provider. Billing is called inside
+// the atomic operation that enrolls the student, so an error returned by
+// Issue aborts the enrollment.
+//
+// The provider reports payment of an invoice asynchronously, prompting a call
+// to ConfirmPayment.
+type Billing interface {
+	// Issue sends the invoice to the student, returning it with its ID
+	// populated.
+	Issue(ctx context.Context, inv Invoice) (Invoice, error)
+}
+
+// ErrNoBilling is returned when attempting to invoice a student while the
+// service has no Billing configured.
+var ErrNoBilling = errors.New("no billing provider configured")
+
+// unconfiguredBilling refuses to issue invoices. It is used when the service
+// has no Billing configured, so that students can't enroll in courses that
+// charge a fee without being billed.
+type unconfiguredBilling struct{}
+
+func (unconfiguredBilling) Issue(context.Context, Invoice) (Invoice, error) {
+	return Invoice{}, ErrNoBilling
+}
+
+// invoiceAll issues an invoice for the fee of the class, less the discount of
+// the voucher, to each of the students and records it in the repository. The
+// voucher is zero if the students redeemed none.
+func (svc *classService) invoiceAll(
+	ctx context.Context,
+	repo Repository,
+	class Class,
+	voucher Voucher,
+	students Students,
+) error {
+	for _, student := range students {
+		inv, err := svc.billing.Issue(ctx, Invoice{
+			CourseCode:  class.Code,
+			Student:     student,
+			Amount:      voucher.apply(class.Fee),
+			VoucherCode: voucher.Code,
+			IssuedAt:    svc.now(),
+		})
+		if err != nil {
+			return fmt.Errorf("invoice student %d for course %q: %w", student.ID, class.Code, err)
+		}
+
+		if err := repo.SaveInvoice(ctx, class.Course, inv); err != nil {
+			return fmt.Errorf("invoice student %d for course %q: %w", student.ID, class.Code, err)
+		}
+	}
+
+	return nil
+}
+
+// ConfirmPayment records that the invoice with the given ID has been paid,
+// completing the enrollment of the student it billed, who is then notified.
+// Confirming payment of an invoice that has already been paid has no effect,
+// since payment providers may report a payment more than once.
+func (svc *classService) ConfirmPayment(ctx context.Context, invoiceID string) error {
+	if err := svc.validate.Var(invoiceID, "required"); err != nil {
+		return fmt.Errorf("ConfirmPayment: %w", err)
+	}
+
+	var pending notifications
+
+	confirm := func(ctx context.Context, repo Repository) error {
+		inv, err := repo.GetInvoice(ctx, invoiceID)
+		if err != nil {
+			return fmt.Errorf("ConfirmPayment: %w", err)
+		}
+
+		if inv.Paid() {
+			return nil
+		}
+
+		class, err := svc.getClass(ctx, repo, inv.CourseCode)
+		if err != nil {
+			return fmt.Errorf("ConfirmPayment: %w", err)
+		}
+
+		student, ok := class.AwaitingPayment.ByEmail(inv.Student.Email)
+		if !ok {
+			return NotAwaitingPaymentError{InvoiceID: inv.ID, CourseCode: class.Code, Student: inv.Student}
+		}
+
+		if _, err := repo.MarkInvoicePaid(ctx, class.Course, inv, svc.now()); err != nil {
+			return fmt.Errorf("ConfirmPayment: %w", err)
+		}
+
+		var sectionCode string
+		if section, ok := class.Sections.awaitingPaymentFor(student); ok {
+			sectionCode = section.Code
+		}
+
+		pending.add(NotificationEnrolled, class.Code, sectionCode, Students{student})
+
+		return nil
+	}
+
+	if err := svc.repo.Execute(ctx, confirm); err != nil {
+		return err
+	}
+
+	svc.deliver(ctx, pending)
+
+	return nil
+}
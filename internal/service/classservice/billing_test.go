@@ -0,0 +1,329 @@
+//go:build unit
+
+package classservice
+
+import (
+	"context"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/angusgmorrison/hexagonal/internal/primitive"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnrollWithFee(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2022, time.September, 1, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	t.Run("invoices students and awaits payment", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "invoices students and awaits payment ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			billing    = NewMockBilling(t)
+			service    = New(logger, validate, atomicRepo, WithClock(clock), WithBilling(billing))
+			ctx        = context.Background()
+			req        = defaultEnrollmentRequest(t)
+			class      = feeClass(t)
+			students   = registeredStudents(t, req.Students)
+			unissued   = Invoice{CourseCode: class.Code, Student: students[0], Amount: class.Fee, IssuedAt: now}
+			issued     = unissued
+		)
+
+		issued.ID = "inv_000001"
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On("GetClassForUpdate", ctx, req.CourseCode).Return(class, nil)
+		repo.On("GetStudentsByEmail", ctx, req.Students.EmailAddresses()).Return(students, nil)
+		repo.On("EnrollStudentsAwaitingPayment", ctx, class.Course, Section{Students: students}, students).
+			Return(class, nil)
+		billing.On("Issue", ctx, unissued).Return(issued, nil)
+		repo.On("SaveInvoice", ctx, class.Course, issued).Return(nil)
+
+		// The student isn't notified until they've paid, so the default
+		// Notifier must not be called.
+		err := service.Enroll(ctx, req)
+		require.NoError(t, err)
+	})
+
+	t.Run("fails without a billing provider", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "fails without a billing provider ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			service    = New(logger, validate, atomicRepo, WithClock(clock))
+			ctx        = context.Background()
+			req        = defaultEnrollmentRequest(t)
+			class      = feeClass(t)
+			students   = registeredStudents(t, req.Students)
+		)
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On("GetClassForUpdate", ctx, req.CourseCode).Return(class, nil)
+		repo.On("GetStudentsByEmail", ctx, req.Students.EmailAddresses()).Return(students, nil)
+		repo.On("EnrollStudentsAwaitingPayment", ctx, class.Course, Section{Students: students}, students).
+			Return(class, nil)
+
+		err := service.Enroll(ctx, req)
+		require.ErrorIs(t, err, ErrNoBilling)
+	})
+
+	t.Run("students awaiting payment hold places", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "students awaiting payment hold places ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			service    = New(logger, validate, atomicRepo, WithClock(clock))
+			ctx        = context.Background()
+			req        = defaultEnrollmentRequest(t)
+			class      = feeClass(t)
+			students   = registeredStudents(t, req.Students)
+		)
+
+		class.Students = Students{{ID: 100}}
+		class.AwaitingPayment = Students{{ID: 101}}
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On("GetClassForUpdate", ctx, req.CourseCode).Return(class, nil)
+		repo.On("GetStudentsByEmail", ctx, req.Students.EmailAddresses()).Return(students, nil)
+
+		err := service.Enroll(ctx, req)
+
+		var gotErr OversubscribedError
+		require.ErrorAs(t, err, &gotErr)
+	})
+
+	t.Run("rejects students already awaiting payment", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "rejects students already awaiting payment ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			service    = New(logger, validate, atomicRepo, WithClock(clock))
+			ctx        = context.Background()
+			req        = defaultEnrollmentRequest(t)
+			class      = feeClass(t)
+			students   = registeredStudents(t, req.Students)
+		)
+
+		class.AwaitingPayment = students
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On("GetClassForUpdate", ctx, req.CourseCode).Return(class, nil)
+		repo.On("GetStudentsByEmail", ctx, req.Students.EmailAddresses()).Return(students, nil)
+
+		err := service.Enroll(ctx, req)
+
+		var gotErr AlreadyEnrolledError
+		require.ErrorAs(t, err, &gotErr)
+		require.Equal(t, AlreadyEnrolledError{Students: students}, gotErr, "unequal AlreadyEnrolledErrors")
+	})
+}
+
+func TestConfirmPayment(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2022, time.September, 2, 9, 30, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	student := Student{ID: 1, Name: "Ramdas Tifft", Email: "r.tifft@gmail.com"}
+
+	unpaidInvoice := func(class Class) Invoice {
+		return Invoice{
+			ID:         "inv_000001",
+			CourseCode: class.Code,
+			Student:    student,
+			Amount:     class.Fee,
+			IssuedAt:   now.Add(-time.Hour),
+		}
+	}
+
+	t.Run("completes enrollment and notifies the student", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "completes enrollment and notifies the student ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			notifier   = NewMockNotifier(t)
+			service    = New(logger, validate, atomicRepo, WithClock(clock), WithNotifier(notifier))
+			ctx        = context.Background()
+			class      = feeClass(t)
+			inv        = unpaidInvoice(class)
+		)
+
+		class.AwaitingPayment = Students{student}
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On("GetInvoice", ctx, inv.ID).Return(inv, nil)
+		repo.On("GetClassByCourseCode", ctx, class.Code).Return(class, nil)
+		repo.On("MarkInvoicePaid", ctx, class.Course, inv, now).Return(class, nil)
+		notifier.On("Notify", ctx, Notification{
+			Kind:       NotificationEnrolled,
+			CourseCode: class.Code,
+			Student:    student,
+		}).Return(nil)
+
+		err := service.ConfirmPayment(ctx, inv.ID)
+		require.NoError(t, err)
+	})
+
+	t.Run("ignores invoices that have already been paid", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "ignores invoices that have already been paid ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			service    = New(logger, validate, atomicRepo, WithClock(clock))
+			ctx        = context.Background()
+			inv        = unpaidInvoice(feeClass(t))
+		)
+
+		inv.PaidAt = now.Add(-time.Minute)
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On("GetInvoice", ctx, inv.ID).Return(inv, nil)
+
+		err := service.ConfirmPayment(ctx, inv.ID)
+		require.NoError(t, err)
+	})
+
+	t.Run("validates the student is awaiting payment", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "validates the student is awaiting payment ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			service    = New(logger, validate, atomicRepo, WithClock(clock))
+			ctx        = context.Background()
+			class      = feeClass(t)
+			inv        = unpaidInvoice(class)
+			wantErr    = NotAwaitingPaymentError{InvoiceID: inv.ID, CourseCode: class.Code, Student: student}
+		)
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On("GetInvoice", ctx, inv.ID).Return(inv, nil)
+		repo.On("GetClassByCourseCode", ctx, class.Code).Return(class, nil)
+
+		err := service.ConfirmPayment(ctx, inv.ID)
+
+		var gotErr NotAwaitingPaymentError
+		require.ErrorAs(t, err, &gotErr)
+		require.Equal(t, wantErr, gotErr, "unequal NotAwaitingPaymentErrors")
+	})
+
+	t.Run("fails for unknown invoices", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "fails for unknown invoices ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			service    = New(logger, validate, atomicRepo, WithClock(clock))
+			ctx        = context.Background()
+			wantErr    = InvoiceNotFoundError{InvoiceID: "inv_999999"}
+		)
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On("GetInvoice", ctx, wantErr.InvoiceID).Return(Invoice{}, wantErr)
+
+		err := service.ConfirmPayment(ctx, wantErr.InvoiceID)
+
+		var gotErr InvoiceNotFoundError
+		require.ErrorAs(t, err, &gotErr)
+		require.Equal(t, wantErr, gotErr, "unequal InvoiceNotFoundErrors")
+	})
+}
+
+// feeClass returns a class with space for two students that charges a fee.
+func feeClass(t *testing.T) Class {
+	t.Helper()
+
+	fee, err := primitive.NewMoney(12500, "GBP")
+	require.NoError(t, err, "create fee")
+
+	course := defaultCourse()
+	course.ID = 1
+	course.Fee = fee
+
+	return Class{Course: course}
+}
@@ -0,0 +1,53 @@
+package classservice
+
+import (
+	"context"
+	"fmt"
+)
+
+// CancelCourse cancels the course matching courseCode as a single atomic
+// operation. The course is archived, every active, pending and unpaid
+// enrollment in it is cancelled, any reservations are released, and each
+// student whose enrollment was cancelled is notified. The course's
+// availability is published as zero.
+//
+// Once a course is cancelled, students can't enroll, reserve places or be
+// transferred into or out of it, and pending enrollments can't be approved.
+func (svc *classService) CancelCourse(ctx context.Context, courseCode string) error {
+	if err := svc.validate.Var(courseCode, "required"); err != nil {
+		return fmt.Errorf("CancelCourse: %w", err)
+	}
+
+	var pending notifications
+
+	cancel := func(ctx context.Context, repo Repository) error {
+		class, err := svc.getClass(ctx, repo, courseCode)
+		if err != nil {
+			return fmt.Errorf("CancelCourse: %w", err)
+		}
+
+		if err := verifyCourseNotCancelled(ctx, repo, class, nil); err != nil {
+			return err
+		}
+
+		if _, err := repo.CancelCourse(ctx, class.Course, svc.now()); err != nil {
+			return fmt.Errorf("CancelCourse: %w", err)
+		}
+
+		affected := append(append(Students{}, class.Students...), class.Pending...)
+		affected = append(affected, class.AwaitingPayment...)
+
+		pending.add(NotificationCourseCancelled, class.Code, "", affected)
+
+		return nil
+	}
+
+	if err := svc.repo.Execute(ctx, cancel); err != nil {
+		return err
+	}
+
+	svc.deliver(ctx, pending)
+	svc.publishAvailability(ctx, courseCode)
+
+	return nil
+}
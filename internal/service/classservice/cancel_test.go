@@ -0,0 +1,201 @@
+//go:build unit
+
+package classservice
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCancelCourse(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2022, time.September, 1, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	t.Run("cancels enrollments and notifies students", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "cancels enrollments and notifies students ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			notifier   = NewMockNotifier(t)
+			service    = New(logger, validate, atomicRepo, WithClock(clock), WithNotifier(notifier))
+			ctx        = context.Background()
+			enrolled   = Student{ID: 1, Name: "Berthe Archibald", Email: "berthe@archibaldindustries.com"}
+			pending    = Student{ID: 2, Name: "Ramdas Tifft", Email: "r.tifft@gmail.com"}
+			class      = Class{Course: defaultCourse(), Students: Students{enrolled}, Pending: Students{pending}}
+		)
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On("GetClassByCourseCode", ctx, class.Code).Return(class, nil)
+		repo.On("CancelCourse", ctx, class.Course, now).Return(Class{Course: class.Course}, nil)
+
+		for _, student := range (Students{enrolled, pending}) {
+			notifier.On("Notify", ctx, Notification{
+				Kind:       NotificationCourseCancelled,
+				CourseCode: class.Code,
+				Student:    student,
+			}).Return(nil).Once()
+		}
+
+		err := service.CancelCourse(ctx, class.Code)
+		require.NoError(t, err)
+	})
+
+	t.Run("notifies every student even if a notification fails", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "notifies every student even if a notification fails ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			notifier   = NewMockNotifier(t)
+			service    = New(logger, validate, atomicRepo, WithClock(clock), WithNotifier(notifier))
+			ctx        = context.Background()
+			class      = Class{Course: defaultCourse(), Students: Students{{ID: 1}, {ID: 2}}}
+			notifyErr  = errors.New("mail server unavailable")
+		)
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On("GetClassByCourseCode", ctx, class.Code).Return(class, nil)
+		repo.On("CancelCourse", ctx, class.Course, now).Return(Class{Course: class.Course}, nil)
+		notifier.On("Notify", ctx, mock.AnythingOfType("Notification")).Return(notifyErr).Twice()
+
+		err := service.CancelCourse(ctx, class.Code)
+		require.NoError(t, err)
+	})
+
+	t.Run("doesn't notify students if the cancellation isn't committed", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "doesn't notify students if the cancellation isn't committed ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			service    = New(logger, validate, atomicRepo, WithClock(clock), WithNotifier(NewMockNotifier(t)))
+			ctx        = context.Background()
+			class      = Class{Course: defaultCourse(), Students: Students{{ID: 1}}}
+			commitErr  = errors.New("connection reset")
+		)
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			if err := op(ctx, repo); err != nil {
+				return err
+			}
+
+			return commitErr
+		})
+
+		repo.On("GetClassByCourseCode", ctx, class.Code).Return(class, nil)
+		repo.On("CancelCourse", ctx, class.Course, now).Return(Class{Course: class.Course}, nil)
+
+		err := service.CancelCourse(ctx, class.Code)
+		require.ErrorIs(t, err, commitErr)
+	})
+
+	t.Run("rejects cancelled course", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "rejects cancelled course ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			service    = New(logger, validate, atomicRepo)
+			ctx        = context.Background()
+			class      = cancelledClass()
+		)
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On("GetClassByCourseCode", ctx, class.Code).Return(class, nil)
+
+		err := service.CancelCourse(ctx, class.Code)
+		require.Equal(t, CourseCancelledError{CourseCode: class.Code}, err)
+	})
+}
+
+func TestCancelledCourseBlocksEnrollment(t *testing.T) {
+	t.Parallel()
+
+	var (
+		logger     = log.New(os.Stdout, "TestCancelledCourseBlocksEnrollment ", log.LstdFlags)
+		validate   = validator.New()
+		atomicRepo = NewMockAtomicRepository(t)
+		repo       = NewMockRepository(t)
+		service    = New(logger, validate, atomicRepo)
+		ctx        = context.Background()
+		req        = defaultEnrollmentRequest(t)
+		class      = cancelledClass()
+	)
+
+	atomicRepo.On(
+		"Execute",
+		ctx,
+		mock.AnythingOfType("AtomicOperation"),
+	).Return(func(ctx context.Context, op AtomicOperation) error {
+		return op(ctx, repo)
+	})
+
+	repo.On("GetClassForUpdate", ctx, req.CourseCode).Return(class, nil)
+	repo.On(
+		"GetStudentsByEmail",
+		ctx,
+		req.Students.EmailAddresses(),
+	).Return(registeredStudents(t, req.Students), nil)
+
+	t.Run("Enroll", func(t *testing.T) {
+		err := service.Enroll(ctx, req)
+		require.Equal(t, CourseCancelledError{CourseCode: class.Code}, err)
+	})
+
+	t.Run("Reserve", func(t *testing.T) {
+		_, err := service.Reserve(ctx, req.CourseCode, req.Students[0].Email)
+		require.Equal(t, CourseCancelledError{CourseCode: class.Code}, err)
+	})
+}
+
+// cancelledClass returns an empty class whose course has been cancelled.
+func cancelledClass() Class {
+	course := defaultCourse()
+	course.Cancelled = true
+
+	return Class{Course: course}
+}
@@ -0,0 +1,158 @@
+package classservice
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/angusgmorrison/hexagonal/internal/primitive"
+)
+
+// Certificate attests that a student passed a course. Anyone holding its Code
+// can verify it using VerifyCertificate.
+type Certificate struct {
+	Code        string
+	Student     Student
+	CourseCode  string
+	CourseTitle string
+	CompletedAt time.Time
+	IssuedAt    time.Time
+}
+
+// certificateCodeGroups is the number of four-character groups in a
+// certificate code.
+const certificateCodeGroups = 4
+
+// newCertificateCode returns a random certificate code of the form
+// XXXX-XXXX-XXXX-XXXX, using the characters of the base32 alphabet so that it
+// can be read aloud and typed without confusion.
+func newCertificateCode() (string, error) {
+	// Every 5 random bytes encode to 8 base32 characters without padding.
+	b := make([]byte, certificateCodeGroups*4*5/8)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate certificate code: %w", err)
+	}
+
+	encoded := base32.StdEncoding.EncodeToString(b)
+	groups := make([]string, 0, certificateCodeGroups)
+
+	for i := 0; i < len(encoded); i += 4 {
+		groups = append(groups, encoded[i:i+4])
+	}
+
+	return strings.Join(groups, "-"), nil
+}
+
+// normalizeCertificateCode returns the canonical form of a certificate code,
+// so that codes can be verified regardless of case.
+func normalizeCertificateCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// GetCertificate returns the certificate of completion of the student with the
+// given email address for the course matching courseCode. The student must
+// have been awarded a passing grade in the course, and the certificate attests
+// to their most recent pass. The certificate is issued the first time it is
+// requested, and the same certificate is returned thereafter.
+func (svc *classService) GetCertificate(
+	ctx context.Context,
+	email primitive.EmailAddress,
+	courseCode string,
+) (Certificate, error) {
+	if err := svc.validateCourseAndEmail(courseCode, email); err != nil {
+		return Certificate{}, fmt.Errorf("GetCertificate: %w", err)
+	}
+
+	var certificate Certificate
+
+	get := func(ctx context.Context, repo Repository) error {
+		registeredStudents, err := repo.GetStudentsByEmail(ctx, []primitive.EmailAddress{email})
+		if err != nil {
+			return fmt.Errorf("GetCertificate: %w", err)
+		}
+
+		students := Students{{Email: email}}.resolve(registeredStudents)
+
+		if err := verifyStudentsRegistered(ctx, repo, Class{}, students); err != nil {
+			return err
+		}
+
+		completions, err := repo.GetCompletions(ctx, students)
+		if err != nil {
+			return fmt.Errorf("GetCertificate: %w", err)
+		}
+
+		completion, ok := svc.latestPass(completions[students[0].ID], courseCode)
+		if !ok {
+			return CourseNotPassedError{CourseCode: courseCode, Email: email}
+		}
+
+		code, err := newCertificateCode()
+		if err != nil {
+			return fmt.Errorf("GetCertificate: %w", err)
+		}
+
+		certificate, err = repo.IssueCertificate(ctx, students[0], completion, code, svc.now())
+		if err != nil {
+			return fmt.Errorf("GetCertificate: %w", err)
+		}
+
+		return nil
+	}
+
+	if err := svc.repo.Execute(ctx, get); err != nil {
+		return Certificate{}, err
+	}
+
+	return certificate, nil
+}
+
+// latestPass returns the most recent of the completions, which are in order of
+// completion, that awarded a passing grade in the course matching courseCode.
+func (svc *classService) latestPass(completions Completions, courseCode string) (Completion, bool) {
+	for i := len(completions) - 1; i >= 0; i-- {
+		completion := completions[i]
+		if completion.CourseCode != courseCode {
+			continue
+		}
+
+		if grade, ok := svc.gradingScale.Grade(completion.Grade); ok && grade.Passing {
+			return completion, true
+		}
+	}
+
+	return Completion{}, false
+}
+
+// VerifyCertificate returns the certificate with the given code, or
+// CertificateNotFoundError if no such certificate was issued. Codes are
+// case-insensitive.
+func (svc *classService) VerifyCertificate(ctx context.Context, code string) (Certificate, error) {
+	code = normalizeCertificateCode(code)
+
+	if err := svc.validate.Var(code, "required"); err != nil {
+		return Certificate{}, fmt.Errorf("VerifyCertificate: %w", err)
+	}
+
+	var certificate Certificate
+
+	verify := func(ctx context.Context, repo Repository) error {
+		var err error
+
+		certificate, err = repo.GetCertificateByCode(ctx, code)
+		if err != nil {
+			return fmt.Errorf("VerifyCertificate: %w", err)
+		}
+
+		return nil
+	}
+
+	if err := svc.repo.Execute(ctx, verify); err != nil {
+		return Certificate{}, err
+	}
+
+	return certificate, nil
+}
@@ -0,0 +1,203 @@
+//go:build unit
+
+package classservice
+
+import (
+	"context"
+	"log"
+	"os"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetCertificate(t *testing.T) {
+	t.Parallel()
+
+	var (
+		now         = time.Date(2023, time.January, 9, 9, 0, 0, 0, time.UTC)
+		clock       = func() time.Time { return now }
+		failedAt    = time.Date(2022, time.June, 30, 12, 0, 0, 0, time.UTC)
+		passedAt    = time.Date(2022, time.December, 16, 12, 0, 0, 0, time.UTC)
+		codePattern = regexp.MustCompile(`^[A-Z2-7]{4}(-[A-Z2-7]{4}){3}$`)
+	)
+
+	t.Run("issues a certificate for the latest pass", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "issues a certificate for the latest pass ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			service    = New(logger, validate, atomicRepo, WithClock(clock))
+			ctx        = context.Background()
+			students   = registeredStudents(t, Students{defaultStudent(t)})
+			passed     = Completion{CourseCode: "SICP", Grade: "B", CompletedAt: passedAt}
+		)
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On("GetStudentsByEmail", ctx, students.EmailAddresses()).Return(students, nil)
+		repo.On("GetCompletions", ctx, students).Return(StudentCompletions{
+			students[0].ID: {
+				{CourseCode: "SICP", Grade: "F", CompletedAt: failedAt},
+				{CourseCode: "TAOCP", Grade: "A", CompletedAt: failedAt},
+				passed,
+			},
+		}, nil)
+		repo.On(
+			"IssueCertificate",
+			ctx,
+			students[0],
+			passed,
+			mock.MatchedBy(codePattern.MatchString),
+			now,
+		).Return(func(_ context.Context, s Student, c Completion, code string, t time.Time) Certificate {
+			return Certificate{
+				Code:        code,
+				Student:     s,
+				CourseCode:  c.CourseCode,
+				CourseTitle: "Structure and Interpretation of Computer Programs",
+				CompletedAt: c.CompletedAt,
+				IssuedAt:    t,
+			}
+		}, nil)
+
+		got, err := service.GetCertificate(ctx, students[0].Email, "SICP")
+		require.NoError(t, err)
+		require.Regexp(t, codePattern, got.Code)
+		require.Equal(t, students[0], got.Student)
+		require.Equal(t, passedAt, got.CompletedAt)
+		require.Equal(t, now, got.IssuedAt)
+	})
+
+	t.Run("rejects students who haven't passed the course", func(t *testing.T) {
+		t.Parallel()
+
+		testCases := []struct {
+			name        string
+			completions Completions
+		}{
+			{
+				name:        "not completed",
+				completions: Completions{{CourseCode: "TAOCP", Grade: "A", CompletedAt: passedAt}},
+			},
+			{
+				name:        "failed",
+				completions: Completions{{CourseCode: "SICP", Grade: "F", CompletedAt: failedAt}},
+			},
+			{
+				name:        "not passed",
+				completions: Completions{{CourseCode: "SICP", Grade: "NP", CompletedAt: failedAt}},
+			},
+		}
+
+		for _, tc := range testCases {
+			tc := tc
+
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+
+				var (
+					logger     = log.New(os.Stdout, tc.name+" ", log.LstdFlags)
+					validate   = validator.New()
+					atomicRepo = NewMockAtomicRepository(t)
+					repo       = NewMockRepository(t)
+					service    = New(logger, validate, atomicRepo, WithClock(clock))
+					ctx        = context.Background()
+					students   = registeredStudents(t, Students{defaultStudent(t)})
+				)
+
+				atomicRepo.On(
+					"Execute",
+					ctx,
+					mock.AnythingOfType("AtomicOperation"),
+				).Return(func(ctx context.Context, op AtomicOperation) error {
+					return op(ctx, repo)
+				})
+
+				repo.On("GetStudentsByEmail", ctx, students.EmailAddresses()).Return(students, nil)
+				repo.On("GetCompletions", ctx, students).Return(StudentCompletions{
+					students[0].ID: tc.completions,
+				}, nil)
+
+				_, err := service.GetCertificate(ctx, students[0].Email, "SICP")
+
+				var notPassedErr CourseNotPassedError
+				require.ErrorAs(t, err, &notPassedErr)
+				require.Equal(t, CourseNotPassedError{CourseCode: "SICP", Email: students[0].Email}, notPassedErr)
+			})
+		}
+	})
+}
+
+func TestVerifyCertificate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("normalizes the code", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger      = log.New(os.Stdout, "normalizes the code ", log.LstdFlags)
+			validate    = validator.New()
+			atomicRepo  = NewMockAtomicRepository(t)
+			repo        = NewMockRepository(t)
+			service     = New(logger, validate, atomicRepo)
+			ctx         = context.Background()
+			certificate = Certificate{Code: "ABCD-EFGH-IJKL-MNOP", CourseCode: "SICP"}
+		)
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On("GetCertificateByCode", ctx, "ABCD-EFGH-IJKL-MNOP").Return(certificate, nil)
+
+		got, err := service.VerifyCertificate(ctx, " abcd-efgh-ijkl-mnop ")
+		require.NoError(t, err)
+		require.Equal(t, certificate, got)
+	})
+
+	t.Run("rejects empty codes", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "rejects empty codes ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			service    = New(logger, validate, atomicRepo)
+		)
+
+		_, err := service.VerifyCertificate(context.Background(), "  ")
+
+		var validationErrs validator.ValidationErrors
+		require.ErrorAs(t, err, &validationErrs)
+	})
+}
+
+func TestNewCertificateCode(t *testing.T) {
+	t.Parallel()
+
+	first, err := newCertificateCode()
+	require.NoError(t, err)
+	require.Regexp(t, `^[A-Z2-7]{4}(-[A-Z2-7]{4}){3}$`, first)
+
+	second, err := newCertificateCode()
+	require.NoError(t, err)
+	require.NotEqual(t, first, second)
+}
@@ -4,22 +4,52 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/angusgmorrison/hexagonal/pkg/slice"
+	"github.com/angusgmorrison/hexagonal/internal/primitive"
 )
 
 // Enroll enrolls the students contained in the given EnrollmentRequest in the
 // course matching the request's CourseCode.
 //
-// If the course does not exist, any of the students do not exist, any of the
-// students are already enrolled in the course, or enrolling the students in the
-// course would cause the course to be oversubscribed, an error is returned.
+// If the course does not exist, or the enrollment violates any of the
+// service's EnrollmentPolicies, an error is returned. By default, this is the
+// case if any of the students do not exist, any of the students are already
+// enrolled in the course, any of the students haven't passed the course's
+// prerequisites, enrolling the students would exceed their maximum course
+// load, or enrolling the students in the course would cause the course
+// to be oversubscribed.
+//
+// Students enrolling in a course that is divided into sections are placed in
+// the section named by the request or, if none is named, distributed between
+// the least-full sections.
+//
+// If the course requires approval, the students' enrollment is left pending
+// until it is approved using ApproveEnrollment. If the course charges a fee,
+// each student is invoiced through the service's Billing and their enrollment
+// awaits payment until it is confirmed using ConfirmPayment. Otherwise, each
+// student is sent a confirmation through the service's Notifier.
+//
+// If the request names a voucher, it is redeemed once for each student and its
+// discount applied to the fee of the course. Vouchers can't be redeemed for
+// courses that are free or that require approval.
+//
+// If the request has an ID, it's recorded in the same atomic operation as the
+// enrollment, and a request whose ID was already recorded fails with
+// DuplicateRequestError.
 func (svc *classService) Enroll(ctx context.Context, req EnrollmentRequest) error {
 	if err := svc.validate.Struct(req); err != nil {
 		return fmt.Errorf("Enroll: %w", err)
 	}
 
+	var pending notifications
+
 	enroll := func(ctx context.Context, repo Repository) error {
-		class, err := repo.GetClassByCourseCode(ctx, req.CourseCode)
+		if req.RequestID != "" {
+			if err := repo.RecordRequest(ctx, req.RequestID, svc.now()); err != nil {
+				return fmt.Errorf("Enroll: %w", err)
+			}
+		}
+
+		class, err := svc.getClassForUpdate(ctx, repo, req.CourseCode)
 		if err != nil {
 			return fmt.Errorf("Enroll: %w", err)
 		}
@@ -29,26 +59,26 @@ func (svc *classService) Enroll(ctx context.Context, req EnrollmentRequest) erro
 			return fmt.Errorf("Enroll: %w", err)
 		}
 
-		if len(registeredStudents) < len(req.Students) {
-			return UnregisteredStudentsError{
-				Students: slice.Difference(registeredStudents, req.Students),
-			}
-		}
+		students := req.Students.resolve(registeredStudents)
 
-		if err := verifyStudentsNotAlreadyEnrolled(class, registeredStudents); err != nil {
+		if err := svc.evaluatePolicies(ctx, repo, class, students); err != nil {
 			return err
 		}
 
-		if !class.hasCapacityFor(registeredStudents) {
-			return OversubscribedError{
-				CourseCode:           class.Code,
-				AvailableSpaces:      class.availableSpaces(),
-				AttemptedEnrollments: uint32(len(registeredStudents)),
+		var voucher Voucher
+
+		if req.VoucherCode != "" {
+			voucher, err = svc.redeemVoucher(ctx, repo, class, req.VoucherCode, students)
+			if err != nil {
+				return err
 			}
 		}
 
-		class, err = repo.EnrollStudents(ctx, class.Course, registeredStudents)
-		if err != nil {
+		if class.RequiresApproval {
+			return requestEnrollment(ctx, repo, class, req.SectionCode, students)
+		}
+
+		if err := svc.enrollStudents(ctx, repo, class, req.SectionCode, voucher, students, &pending); err != nil {
 			return fmt.Errorf("Enroll: %w", err)
 		}
 
@@ -59,23 +89,106 @@ func (svc *classService) Enroll(ctx context.Context, req EnrollmentRequest) erro
 		return err
 	}
 
+	svc.deliver(ctx, pending)
+
+	svc.publishAvailability(ctx, req.CourseCode)
+
+	return nil
+}
+
+// enrollStudents writes the enrollment of students in a class, converting any
+// places they reserved. Students enrolling in a class that is divided into
+// sections are placed in the named section or, if sectionCode is empty,
+// distributed between the least-full sections.
+//
+// If the course charges a fee that isn't waived entirely by the voucher the
+// students redeemed, each student is invoiced and their enrollment awaits
+// payment. Otherwise, a notification of their enrollment is added to pending
+// for each student. The voucher is zero if the students redeemed none.
+func (svc *classService) enrollStudents(
+	ctx context.Context,
+	repo Repository,
+	class Class,
+	sectionCode string,
+	voucher Voucher,
+	students Students,
+	pending *notifications,
+) error {
+	fee := voucher.apply(class.Fee)
+
+	assignments := Sections{{Students: students}}
+
+	if len(class.Sections) > 0 || sectionCode != "" {
+		var err error
+
+		assignments, err = class.assignSections(sectionCode, students)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, section := range assignments {
+		if err := writeEnrollment(ctx, repo, class.Course, fee, section); err != nil {
+			return err
+		}
+	}
+
+	if err := convertReservations(ctx, repo, class, students); err != nil {
+		return err
+	}
+
+	if !fee.IsZero() {
+		return svc.invoiceAll(ctx, repo, class, voucher, students)
+	}
+
+	for _, section := range assignments {
+		pending.add(NotificationEnrolled, class.Code, section.Code, section.Students)
+	}
+
 	return nil
 }
 
-func verifyStudentsNotAlreadyEnrolled(
+// writeEnrollment writes the enrollment of the students assigned to a section
+// of the course, who must pay the given fee. The section is zero if the course
+// has no sections.
+func writeEnrollment(ctx context.Context, repo Repository, course Course, fee primitive.Money, section Section) error {
+	var err error
+
+	switch {
+	case !fee.IsZero():
+		_, err = repo.EnrollStudentsAwaitingPayment(ctx, course, section, section.Students)
+	case section.Code == "":
+		_, err = repo.EnrollStudents(ctx, course, section.Students)
+	default:
+		_, err = repo.EnrollStudentsInSection(ctx, course, section, section.Students)
+	}
+
+	return err
+}
+
+// requestEnrollment leaves the students' enrollment pending approval. If the
+// students named a section, it must exist and have space for them, but they
+// aren't placed in it until they're approved.
+func requestEnrollment(
+	ctx context.Context,
+	repo Repository,
 	class Class,
+	sectionCode string,
 	students Students,
 ) error {
-	alreadyEnrolledEmails := slice.Intersection(
-		class.Students.EmailAddresses(), students.EmailAddresses())
+	var section Section
 
-	if len(alreadyEnrolledEmails) > 0 {
-		alreadyEnrolledEmailSet := slice.ToSet(alreadyEnrolledEmails)
-		alreadyEnrolledStudents := slice.Filter(students, func(student Student) bool {
-			return alreadyEnrolledEmailSet[student.Email]
-		})
+	if sectionCode != "" {
+		assignments, err := class.assignSections(sectionCode, students)
+		if err != nil {
+			return err
+		}
 
-		return AlreadyEnrolledError{Students: alreadyEnrolledStudents}
+		section = assignments[0]
+	}
+
+	if _, err := repo.RequestEnrollment(ctx, class.Course, section, students); err != nil {
+		return fmt.Errorf("Enroll: %w", err)
 	}
 
 	return nil
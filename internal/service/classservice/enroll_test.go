@@ -8,6 +8,7 @@ import (
 	"log"
 	"os"
 	testing "testing"
+	"time"
 
 	"github.com/angusgmorrison/hexagonal/internal/primitive"
 	"github.com/go-playground/validator/v10"
@@ -88,7 +89,7 @@ func TestEnroll(t *testing.T) {
 		})
 
 		repo.On(
-			"GetClassByCourseCode",
+			"GetClassForUpdate",
 			ctx,
 			req.CourseCode,
 		).Return(Class{}, wantErr)
@@ -120,7 +121,7 @@ func TestEnroll(t *testing.T) {
 		})
 
 		repo.On(
-			"GetClassByCourseCode",
+			"GetClassForUpdate",
 			ctx,
 			req.CourseCode,
 		).Return(defaultClass(t), nil)
@@ -160,7 +161,7 @@ func TestEnroll(t *testing.T) {
 		})
 
 		repo.On(
-			"GetClassByCourseCode",
+			"GetClassForUpdate",
 			ctx,
 			req.CourseCode,
 		).Return(defaultClass(t), nil)
@@ -205,7 +206,7 @@ func TestEnroll(t *testing.T) {
 
 		wantErr := OversubscribedError{
 			CourseCode:           class.Code,
-			AvailableSpaces:      class.availableSpaces(),
+			AvailableSpaces:      class.AvailableSpaces(),
 			AttemptedEnrollments: uint32(len(req.Students)),
 		}
 
@@ -218,7 +219,7 @@ func TestEnroll(t *testing.T) {
 		})
 
 		repo.On(
-			"GetClassByCourseCode",
+			"GetClassForUpdate",
 			ctx,
 			req.CourseCode,
 		).Return(class, nil)
@@ -269,7 +270,7 @@ func TestEnroll(t *testing.T) {
 		})
 
 		repo.On(
-			"GetClassByCourseCode",
+			"GetClassForUpdate",
 			ctx,
 			req.CourseCode,
 		).Return(class, nil)
@@ -294,6 +295,626 @@ func TestEnroll(t *testing.T) {
 		err := service.Enroll(ctx, req)
 		require.NoError(t, err)
 	})
+
+	t.Run("records the request ID with the enrollment", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "records the request ID with the enrollment ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			now        = time.Date(2022, time.March, 1, 9, 0, 0, 0, time.UTC)
+			service    = New(logger, validate, atomicRepo, WithClock(func() time.Time { return now }))
+			ctx        = context.Background()
+			req        = defaultEnrollmentRequest(t)
+			class      = Class{Course: Course{Code: "SICP", Capacity: 1}}
+		)
+
+		req.RequestID = "cmd-1"
+
+		registeredStudent := defaultStudent(t)
+		registeredStudent.ID = 1
+		registeredStudents := Students{registeredStudent}
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On("RecordRequest", ctx, "cmd-1", now).Return(nil).Once()
+		repo.On("GetClassForUpdate", ctx, req.CourseCode).Return(class, nil)
+		repo.On("GetStudentsByEmail", ctx, req.Students.EmailAddresses()).Return(registeredStudents, nil)
+		repo.On("EnrollStudents", ctx, class.Course, registeredStudents).Return(class, nil)
+
+		err := service.Enroll(ctx, req)
+		require.NoError(t, err)
+	})
+
+	t.Run("refuses requests that were already carried out", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "refuses requests that were already carried out ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			service    = New(logger, validate, atomicRepo)
+			ctx        = context.Background()
+			req        = defaultEnrollmentRequest(t)
+			wantErr    = DuplicateRequestError{RequestID: "cmd-1"}
+		)
+
+		req.RequestID = "cmd-1"
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On("RecordRequest", ctx, "cmd-1", mock.AnythingOfType("time.Time")).Return(wantErr)
+
+		err := service.Enroll(ctx, req)
+
+		var gotErr DuplicateRequestError
+		require.ErrorAs(t, err, &gotErr)
+		require.Equal(t, wantErr, gotErr)
+	})
+
+	t.Run("notifies enrolled students", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "notifies enrolled students ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			notifier   = NewMockNotifier(t)
+			service    = New(logger, validate, atomicRepo, WithNotifier(notifier))
+			ctx        = context.Background()
+			req        = defaultEnrollmentRequest(t)
+			class      = sectionedClass(t)
+			registered = registeredStudents(t, req.Students)
+		)
+
+		req.SectionCode = "B"
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On("GetClassForUpdate", ctx, req.CourseCode).Return(class, nil)
+		repo.On("GetStudentsByEmail", ctx, req.Students.EmailAddresses()).Return(registered, nil)
+		repo.On(
+			"EnrollStudentsInSection",
+			ctx,
+			class.Course,
+			mock.MatchedBy(func(sec Section) bool { return sec.Code == "B" }),
+			registered,
+		).Return(class, nil)
+
+		notifier.On("Notify", ctx, Notification{
+			Kind:        NotificationEnrolled,
+			CourseCode:  class.Code,
+			SectionCode: "B",
+			Student:     registered[0],
+		}).Return(nil).Once()
+
+		err := service.Enroll(ctx, req)
+		require.NoError(t, err)
+	})
+}
+
+func TestEnrollCourseLoad(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name             string
+		defaultMax       uint32
+		studentMax       uint32
+		currentLoad      uint32
+		wantLoadsFetched bool
+		wantLoadExceeded bool
+	}{
+		{
+			name:             "unlimited course load",
+			defaultMax:       0,
+			studentMax:       0,
+			wantLoadsFetched: false,
+		},
+		{
+			name:             "below default course load",
+			defaultMax:       2,
+			currentLoad:      1,
+			wantLoadsFetched: true,
+		},
+		{
+			name:             "at default course load",
+			defaultMax:       2,
+			currentLoad:      2,
+			wantLoadsFetched: true,
+			wantLoadExceeded: true,
+		},
+		{
+			name:             "student override above default course load",
+			defaultMax:       2,
+			studentMax:       3,
+			currentLoad:      2,
+			wantLoadsFetched: true,
+		},
+		{
+			name:             "student override with unlimited default",
+			defaultMax:       0,
+			studentMax:       1,
+			currentLoad:      1,
+			wantLoadsFetched: true,
+			wantLoadExceeded: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				logger     = log.New(os.Stdout, "TestEnrollCourseLoad ", log.LstdFlags)
+				validate   = validator.New()
+				atomicRepo = NewMockAtomicRepository(t)
+				repo       = NewMockRepository(t)
+				service    = New(logger, validate, atomicRepo, WithDefaultMaxCourseLoad(tc.defaultMax))
+				ctx        = context.Background()
+				req        = defaultEnrollmentRequest(t)
+				class      = Class{Course: Course{Code: "SICP", Capacity: 1}}
+			)
+
+			students := registeredStudents(t, req.Students)
+			students[0].MaxCourseLoad = tc.studentMax
+
+			atomicRepo.On(
+				"Execute",
+				ctx,
+				mock.AnythingOfType("AtomicOperation"),
+			).Return(func(ctx context.Context, op AtomicOperation) error {
+				return op(ctx, repo)
+			})
+
+			repo.On(
+				"GetClassForUpdate",
+				ctx,
+				req.CourseCode,
+			).Return(class, nil)
+
+			repo.On(
+				"GetStudentsByEmail",
+				ctx,
+				req.Students.EmailAddresses(),
+			).Return(students, nil)
+
+			if tc.wantLoadsFetched {
+				repo.On(
+					"GetCourseLoads",
+					ctx,
+					students,
+				).Return(CourseLoads{students[0].ID: tc.currentLoad}, nil)
+			}
+
+			if !tc.wantLoadExceeded {
+				repo.On(
+					"EnrollStudents",
+					ctx,
+					class.Course,
+					students,
+				).Return(class, nil)
+			}
+
+			err := service.Enroll(ctx, req)
+
+			if !tc.wantLoadExceeded {
+				require.NoError(t, err)
+
+				return
+			}
+
+			wantErr := CourseLoadExceededError{CourseCode: class.Code, Students: students}
+
+			var gotErr CourseLoadExceededError
+			require.ErrorAs(t, err, &gotErr)
+			require.Equal(t, wantErr, gotErr, "unequal CourseLoadExceededErrors")
+		})
+	}
+}
+
+func TestEnrollmentPolicies(t *testing.T) {
+	t.Parallel()
+
+	t.Run("custom policies are evaluated after built-in policies", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "custom policies are evaluated after built-in policies ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			ctx        = context.Background()
+			req        = defaultEnrollmentRequest(t)
+			class      = defaultClass(t)
+			calls      []string
+		)
+
+		recordingPolicy := func(name string) EnrollmentPolicy {
+			return EnrollmentPolicyFunc(func(context.Context, Repository, Class, Students) error {
+				calls = append(calls, name)
+
+				return nil
+			})
+		}
+
+		service := New(logger, validate, atomicRepo, WithPolicies(recordingPolicy("first"), recordingPolicy("second")))
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On(
+			"GetClassForUpdate",
+			ctx,
+			req.CourseCode,
+		).Return(class, nil)
+
+		// The student is already enrolled, so the built-in policies reject the
+		// enrollment before the custom policies are reached.
+		repo.On(
+			"GetStudentsByEmail",
+			ctx,
+			req.Students.EmailAddresses(),
+		).Return(registeredStudents(t, req.Students), nil)
+
+		err := service.Enroll(ctx, req)
+
+		var gotErr AlreadyEnrolledError
+		require.ErrorAs(t, err, &gotErr)
+		require.Empty(t, calls, "custom policies evaluated before built-in policies")
+	})
+
+	t.Run("custom policies are evaluated in order", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "custom policies are evaluated in order ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			ctx        = context.Background()
+			req        = defaultEnrollmentRequest(t)
+			class      = Class{Course: Course{Code: "SICP", Capacity: 1}}
+			wantErr    = errors.New("rejected by second policy")
+			calls      []string
+		)
+
+		students := registeredStudents(t, req.Students)
+
+		first := EnrollmentPolicyFunc(func(_ context.Context, _ Repository, gotClass Class, gotStudents Students) error {
+			calls = append(calls, "first")
+
+			require.Equal(t, class, gotClass, "unexpected class")
+			require.Equal(t, students, gotStudents, "unexpected students")
+
+			return nil
+		})
+
+		second := EnrollmentPolicyFunc(func(context.Context, Repository, Class, Students) error {
+			calls = append(calls, "second")
+
+			return wantErr
+		})
+
+		third := EnrollmentPolicyFunc(func(context.Context, Repository, Class, Students) error {
+			calls = append(calls, "third")
+
+			return nil
+		})
+
+		service := New(logger, validate, atomicRepo, WithPolicies(first, second), WithPolicies(third))
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On(
+			"GetClassForUpdate",
+			ctx,
+			req.CourseCode,
+		).Return(class, nil)
+
+		repo.On(
+			"GetStudentsByEmail",
+			ctx,
+			req.Students.EmailAddresses(),
+		).Return(students, nil)
+
+		err := service.Enroll(ctx, req)
+		require.ErrorIs(t, err, wantErr)
+		require.Equal(t, []string{"first", "second"}, calls, "unexpected policy evaluation order")
+	})
+}
+
+func TestEnrollInSections(t *testing.T) {
+	t.Parallel()
+
+	t.Run("validates requested section exists", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "validates requested section exists ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			service    = New(logger, validate, atomicRepo)
+			ctx        = context.Background()
+			req        = defaultEnrollmentRequest(t)
+			class      = sectionedClass(t)
+		)
+
+		req.SectionCode = "C"
+		wantErr := SectionNotFoundError{CourseCode: class.Code, SectionCode: req.SectionCode}
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On(
+			"GetClassForUpdate",
+			ctx,
+			req.CourseCode,
+		).Return(class, nil)
+
+		repo.On(
+			"GetStudentsByEmail",
+			ctx,
+			req.Students.EmailAddresses(),
+		).Return(registeredStudents(t, req.Students), nil)
+
+		err := service.Enroll(ctx, req)
+
+		var gotErr SectionNotFoundError
+		require.ErrorAs(t, err, &gotErr)
+		require.Equal(t, wantErr, gotErr, "unequal SectionNotFoundErrors")
+	})
+
+	t.Run("validates requested section has capacity", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "validates requested section has capacity ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			service    = New(logger, validate, atomicRepo)
+			ctx        = context.Background()
+			req        = defaultEnrollmentRequest(t)
+			class      = sectionedClass(t)
+		)
+
+		req.SectionCode = "A"
+		wantErr := OversubscribedError{
+			CourseCode:             class.Code,
+			SectionCode:            "A",
+			AvailableSpaces:        0,
+			AvailableSectionSpaces: map[string]uint32{"A": 0, "B": 2},
+			AttemptedEnrollments:   1,
+		}
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On(
+			"GetClassForUpdate",
+			ctx,
+			req.CourseCode,
+		).Return(class, nil)
+
+		repo.On(
+			"GetStudentsByEmail",
+			ctx,
+			req.Students.EmailAddresses(),
+		).Return(registeredStudents(t, req.Students), nil)
+
+		err := service.Enroll(ctx, req)
+
+		var gotErr OversubscribedError
+		require.ErrorAs(t, err, &gotErr)
+		require.Equal(t, wantErr, gotErr, "unequal OversubscribedErrors")
+	})
+
+	t.Run("reports capacity across all sections", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "reports capacity across all sections ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			service    = New(logger, validate, atomicRepo)
+			ctx        = context.Background()
+			req        = threeStudentEnrollmentRequest(t)
+			class      = sectionedClass(t)
+		)
+
+		wantErr := OversubscribedError{
+			CourseCode:             class.Code,
+			AvailableSpaces:        2,
+			AvailableSectionSpaces: map[string]uint32{"A": 0, "B": 2},
+			AttemptedEnrollments:   3,
+		}
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On(
+			"GetClassForUpdate",
+			ctx,
+			req.CourseCode,
+		).Return(class, nil)
+
+		repo.On(
+			"GetStudentsByEmail",
+			ctx,
+			req.Students.EmailAddresses(),
+		).Return(registeredStudents(t, req.Students), nil)
+
+		err := service.Enroll(ctx, req)
+
+		var gotErr OversubscribedError
+		require.ErrorAs(t, err, &gotErr)
+		require.Equal(t, wantErr, gotErr, "unequal OversubscribedErrors")
+	})
+
+	t.Run("assigns students to the least-full sections", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "assigns students to the least-full sections ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			service    = New(logger, validate, atomicRepo)
+			ctx        = context.Background()
+			req        = defaultEnrollmentRequest(t)
+			class      = sectionedClass(t)
+		)
+
+		// Open a space in section A, which is then the least-full section.
+		class.Sections[0].Capacity = 3
+		class.Sections[1].Students = append(class.Sections[1].Students, Student{ID: 99})
+
+		students := registeredStudents(t, req.Students)
+		wantSection := class.Sections[0]
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On(
+			"GetClassForUpdate",
+			ctx,
+			req.CourseCode,
+		).Return(class, nil)
+
+		repo.On(
+			"GetStudentsByEmail",
+			ctx,
+			req.Students.EmailAddresses(),
+		).Return(students, nil)
+
+		repo.On(
+			"EnrollStudentsInSection",
+			ctx,
+			class.Course,
+			mock.MatchedBy(func(sec Section) bool { return sec.ID == wantSection.ID }),
+			students,
+		).Return(class, nil)
+
+		err := service.Enroll(ctx, req)
+		require.NoError(t, err)
+	})
+
+	t.Run("balances students between sections", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "balances students between sections ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			service    = New(logger, validate, atomicRepo)
+			ctx        = context.Background()
+			req        = threeStudentEnrollmentRequest(t)
+			class      = sectionedClass(t)
+		)
+
+		class.Sections[0].Capacity = 4
+		class.Sections[1].Capacity = 4
+
+		students := registeredStudents(t, req.Students)
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On(
+			"GetClassForUpdate",
+			ctx,
+			req.CourseCode,
+		).Return(class, nil)
+
+		repo.On(
+			"GetStudentsByEmail",
+			ctx,
+			req.Students.EmailAddresses(),
+		).Return(students, nil)
+
+		// Section B starts empty, so it receives the first and third students.
+		repo.On(
+			"EnrollStudentsInSection",
+			ctx,
+			class.Course,
+			mock.MatchedBy(func(sec Section) bool { return sec.Code == "A" }),
+			Students{students[1]},
+		).Return(class, nil)
+
+		repo.On(
+			"EnrollStudentsInSection",
+			ctx,
+			class.Course,
+			mock.MatchedBy(func(sec Section) bool { return sec.Code == "B" }),
+			Students{students[0], students[2]},
+		).Return(class, nil)
+
+		err := service.Enroll(ctx, req)
+		require.NoError(t, err)
+	})
 }
 
 func defaultEnrollmentRequest(t *testing.T) EnrollmentRequest {
@@ -335,3 +956,55 @@ func defaultStudent(t *testing.T) Student {
 		Email:     "r.tifft@gmail.com",
 	}
 }
+
+// sectionedClass returns a class with a full section "A" and an empty section
+// "B" with space for two students.
+func sectionedClass(t *testing.T) Class {
+	t.Helper()
+
+	enrolled := Student{
+		ID:    100,
+		Name:  "Berthe Archibald",
+		Email: "berthe@archibaldindustries.com",
+	}
+
+	return Class{
+		Course: Course{
+			ID:       1,
+			Code:     "SICP",
+			Capacity: 3,
+			Sections: Sections{
+				{ID: 1, Code: "A", Capacity: 1, Students: Students{enrolled}},
+				{ID: 2, Code: "B", Capacity: 2},
+			},
+		},
+		Students: Students{enrolled},
+	}
+}
+
+func threeStudentEnrollmentRequest(t *testing.T) EnrollmentRequest {
+	t.Helper()
+
+	req := defaultEnrollmentRequest(t)
+	req.Students = append(req.Students,
+		Student{Name: "Matheo Travieso", Email: "mat@travieso.com"},
+		Student{Name: "Rhodri Murray", Email: "murrayboi98@hotmail.com"},
+	)
+
+	return req
+}
+
+// registeredStudents returns a copy of students with their IDs populated, as
+// though loaded from the repository.
+func registeredStudents(t *testing.T, students Students) Students {
+	t.Helper()
+
+	registered := make(Students, 0, len(students))
+
+	for i, student := range students {
+		student.ID = int64(i + 1)
+		registered = append(registered, student)
+	}
+
+	return registered
+}
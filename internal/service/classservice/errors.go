@@ -1,21 +1,52 @@
 package classservice
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/angusgmorrison/hexagonal/internal/primitive"
+)
 
 // OversubscribedError is returned when attempting to enroll more students than
 // a course has spaces available.
+//
+// If enrollment was requested in a specific section, SectionCode is populated
+// and AvailableSpaces refers to that section. Otherwise, AvailableSpaces is the
+// total across the whole course. For sectioned courses,
+// AvailableSectionSpaces reports the spaces remaining in every section, keyed
+// by section code.
 type OversubscribedError struct {
-	CourseCode           string
-	AvailableSpaces      uint32
-	AttemptedEnrollments uint32
+	CourseCode             string
+	SectionCode            string
+	AvailableSpaces        uint32
+	AvailableSectionSpaces map[string]uint32
+	AttemptedEnrollments   uint32
 }
 
 func (oe OversubscribedError) Error() string {
+	if oe.SectionCode != "" {
+		return fmt.Sprintf(
+			"attempted to enroll %d students, but section %q of course %q has only %d spaces",
+			oe.AttemptedEnrollments, oe.SectionCode, oe.CourseCode, oe.AvailableSpaces)
+	}
+
 	return fmt.Sprintf(
 		"attmepted to enroll %d students, but course %q has only %d spaces",
 		oe.AttemptedEnrollments, oe.CourseCode, oe.AvailableSpaces)
 }
 
+// SectionNotFoundError is returned when an enrollment request specifies a
+// section that doesn't belong to the course.
+type SectionNotFoundError struct {
+	CourseCode  string
+	SectionCode string
+}
+
+func (snfe SectionNotFoundError) Error() string {
+	return fmt.Sprintf("course %q has no section %q", snfe.CourseCode, snfe.SectionCode)
+}
+
 type UnregisteredStudentsError struct {
 	Students Students
 }
@@ -24,8 +55,18 @@ func (use UnregisteredStudentsError) Error() string {
 	return fmt.Sprintf("attempted to enroll unregistered students: %s", use.Students)
 }
 
+// DuplicateRequestError is returned when an enrollment request has the ID of a
+// request that was already carried out.
+type DuplicateRequestError struct {
+	RequestID string
+}
+
+func (dre DuplicateRequestError) Error() string {
+	return fmt.Sprintf("request %q has already been carried out", dre.RequestID)
+}
+
 // AlreadyEnrolledError is returned when attempting to enroll students who are
-// already enrolled in the class.
+// already enrolled in the class, or whose enrollment is awaiting approval.
 type AlreadyEnrolledError struct {
 	Students Students
 }
@@ -33,3 +74,314 @@ type AlreadyEnrolledError struct {
 func (are AlreadyEnrolledError) Error() string {
 	return fmt.Sprintf("students %s are already registered", are.Students)
 }
+
+// CourseLoadExceededError is returned when enrolling students in a course would
+// take them over their maximum course load.
+type CourseLoadExceededError struct {
+	CourseCode string
+	Students   Students
+}
+
+func (clee CourseLoadExceededError) Error() string {
+	return fmt.Sprintf(
+		"enrolling students %s in course %q would exceed their maximum course load",
+		clee.Students, clee.CourseCode)
+}
+
+// RuleViolationError is returned when students attempting to enroll in a
+// course don't satisfy the course's enrollment rule.
+type RuleViolationError struct {
+	CourseCode string
+	Rule       string
+	Students   Students
+}
+
+func (rve RuleViolationError) Error() string {
+	return fmt.Sprintf(
+		"students %s do not satisfy the enrollment rule of course %q: %s",
+		rve.Students, rve.CourseCode, rve.Rule)
+}
+
+// InvalidRuleError is returned when an enrollment rule can't be compiled.
+type InvalidRuleError struct {
+	Rule     string
+	Problems []RuleProblem
+}
+
+func (ire InvalidRuleError) Error() string {
+	problems := make([]string, 0, len(ire.Problems))
+
+	for _, p := range ire.Problems {
+		problems = append(problems, p.String())
+	}
+
+	return fmt.Sprintf("invalid rule %q: %s", ire.Rule, strings.Join(problems, "; "))
+}
+
+// RuleProblem describes an error found at a byte offset of an enrollment rule.
+type RuleProblem struct {
+	Offset  int
+	Message string
+}
+
+func (rp RuleProblem) String() string {
+	return fmt.Sprintf("offset %d: %s", rp.Offset, rp.Message)
+}
+
+// EnrollmentNotPendingError is returned when attempting to approve or reject an
+// enrollment that isn't awaiting approval.
+type EnrollmentNotPendingError struct {
+	CourseCode string
+	Email      primitive.EmailAddress
+}
+
+func (enpe EnrollmentNotPendingError) Error() string {
+	return fmt.Sprintf("%s has no pending enrollment in course %q", enpe.Email, enpe.CourseCode)
+}
+
+// NotEnrolledError is returned when attempting to move students out of a class
+// in which they aren't actively enrolled.
+type NotEnrolledError struct {
+	CourseCode string
+	Students   Students
+}
+
+func (nee NotEnrolledError) Error() string {
+	return fmt.Sprintf("students %s are not enrolled in course %q", nee.Students, nee.CourseCode)
+}
+
+// TransferRequiresApprovalError is returned when attempting to transfer
+// students into a course that requires enrollments to be approved. Students
+// must request enrollment in such courses instead.
+type TransferRequiresApprovalError struct {
+	CourseCode string
+}
+
+func (trae TransferRequiresApprovalError) Error() string {
+	return fmt.Sprintf("course %q requires approval and can't be transferred into", trae.CourseCode)
+}
+
+// AlreadyReservedError is returned when a student attempts to reserve a place
+// in a class where they already hold an unexpired reservation.
+type AlreadyReservedError struct {
+	CourseCode string
+	Email      primitive.EmailAddress
+}
+
+func (are AlreadyReservedError) Error() string {
+	return fmt.Sprintf("%s already holds a reservation in course %q", are.Email, are.CourseCode)
+}
+
+// CourseCancelledError is returned when attempting to change the enrollments of
+// a course that has been cancelled.
+type CourseCancelledError struct {
+	CourseCode string
+}
+
+func (cce CourseCancelledError) Error() string {
+	return fmt.Sprintf("course %q has been cancelled", cce.CourseCode)
+}
+
+// SessionNotFoundError is returned when a class has no session with the ID
+// provided.
+type SessionNotFoundError struct {
+	CourseCode string
+	SessionID  int64
+}
+
+func (snfe SessionNotFoundError) Error() string {
+	return fmt.Sprintf("course %q has no session with ID %d", snfe.CourseCode, snfe.SessionID)
+}
+
+// EmptyScheduleError is returned when a schedule describes no sessions.
+type EmptyScheduleError struct {
+	CourseCode string
+}
+
+func (ese EmptyScheduleError) Error() string {
+	return fmt.Sprintf("schedule for course %q contains no sessions", ese.CourseCode)
+}
+
+// ScheduleTooLongError is returned when a schedule spans a longer period than
+// sessions may be scheduled for at once.
+type ScheduleTooLongError struct {
+	CourseCode string
+	MaxSpan    time.Duration
+}
+
+func (stle ScheduleTooLongError) Error() string {
+	return fmt.Sprintf("schedule for course %q spans more than %d days", stle.CourseCode, int(stle.MaxSpan.Hours()/24))
+}
+
+// NotAwaitingPaymentError is returned when payment is confirmed for an invoice
+// whose student is no longer awaiting payment, e.g. because the course was
+// cancelled.
+type NotAwaitingPaymentError struct {
+	InvoiceID  string
+	CourseCode string
+	Student    Student
+}
+
+func (nape NotAwaitingPaymentError) Error() string {
+	return fmt.Sprintf("invoice %q was paid, but student %q is not awaiting payment for course %q",
+		nape.InvoiceID, nape.Student.Email, nape.CourseCode)
+}
+
+// InvoiceNotFoundError is returned when no invoice matches the ID provided.
+type InvoiceNotFoundError struct {
+	InvoiceID string
+}
+
+func (infe InvoiceNotFoundError) Error() string {
+	return fmt.Sprintf("no invoice with ID %q", infe.InvoiceID)
+}
+
+// CourseNotFoundError is returned when no course matches the course code
+// provided.
+type CourseNotFoundError struct {
+	CourseCode string
+}
+
+func (cnfe CourseNotFoundError) Error() string {
+	return fmt.Sprintf("no course with code %q", cnfe.CourseCode)
+}
+
+// InstructorNotFoundError is returned when no instructor has the ID provided.
+type InstructorNotFoundError struct {
+	ID int64
+}
+
+func (infe InstructorNotFoundError) Error() string {
+	return fmt.Sprintf("no instructor with ID %d", infe.ID)
+}
+
+// AlreadyAssignedError is returned when attempting to assign an instructor to
+// a class they already teach.
+type AlreadyAssignedError struct {
+	CourseCode string
+	Instructor Instructor
+}
+
+func (aae AlreadyAssignedError) Error() string {
+	return fmt.Sprintf("%s is already assigned to course %q", aae.Instructor.Email, aae.CourseCode)
+}
+
+// NotAssignedError is returned when attempting to unassign an instructor from
+// a class they don't teach.
+type NotAssignedError struct {
+	CourseCode string
+	Instructor Instructor
+}
+
+func (nae NotAssignedError) Error() string {
+	return fmt.Sprintf("%s is not assigned to course %q", nae.Instructor.Email, nae.CourseCode)
+}
+
+// PrerequisitesNotMetError is returned when attempting to enroll students who
+// haven't passed every prerequisite of a course.
+type PrerequisitesNotMetError struct {
+	CourseCode    string
+	Prerequisites []string
+	Students      Students
+}
+
+func (pnme PrerequisitesNotMetError) Error() string {
+	return fmt.Sprintf(
+		"students %s have not passed the prerequisites of course %q: %s",
+		pnme.Students, pnme.CourseCode, strings.Join(pnme.Prerequisites, ", "))
+}
+
+// UnknownGradeError is returned when attempting to award a grade that isn't on
+// the service's GradingScale.
+type UnknownGradeError struct {
+	Grade string
+}
+
+func (uge UnknownGradeError) Error() string {
+	return fmt.Sprintf("grade %q is not on the grading scale", uge.Grade)
+}
+
+// VoucherNotFoundError is returned when no voucher has the code provided.
+type VoucherNotFoundError struct {
+	Code string
+}
+
+func (vnfe VoucherNotFoundError) Error() string {
+	return fmt.Sprintf("no voucher with code %q", vnfe.Code)
+}
+
+// VoucherCodeTakenError is returned when attempting to create a voucher with
+// the code of an existing voucher.
+type VoucherCodeTakenError struct {
+	Code string
+}
+
+func (vcte VoucherCodeTakenError) Error() string {
+	return fmt.Sprintf("voucher code %q is already in use", vcte.Code)
+}
+
+// InvalidVoucherError is returned when attempting to create a voucher that
+// doesn't describe a usable discount.
+type InvalidVoucherError struct {
+	Code   string
+	Reason string
+}
+
+func (ive InvalidVoucherError) Error() string {
+	return fmt.Sprintf("voucher %q is invalid: %s", ive.Code, ive.Reason)
+}
+
+// VoucherNotApplicableError is returned when attempting to redeem a voucher
+// for a course that it doesn't discount, or outside its validity period.
+type VoucherNotApplicableError struct {
+	Code       string
+	CourseCode string
+	Reason     string
+}
+
+func (vnae VoucherNotApplicableError) Error() string {
+	return fmt.Sprintf("voucher %q can't be redeemed for course %q: %s", vnae.Code, vnae.CourseCode, vnae.Reason)
+}
+
+// VoucherExhaustedError is returned when redeeming a voucher would exceed the
+// number of times it may be used.
+type VoucherExhaustedError struct {
+	Code      string
+	Remaining uint32
+}
+
+func (vee VoucherExhaustedError) Error() string {
+	return fmt.Sprintf("voucher %q may only be redeemed %d more times", vee.Code, vee.Remaining)
+}
+
+// VoucherAlreadyRedeemedError is returned when students attempt to redeem a
+// voucher that they have redeemed before.
+type VoucherAlreadyRedeemedError struct {
+	Code     string
+	Students Students
+}
+
+func (vare VoucherAlreadyRedeemedError) Error() string {
+	return fmt.Sprintf("voucher %q has already been redeemed by students %s", vare.Code, vare.Students)
+}
+
+// CourseNotPassedError is returned when requesting a certificate for a course
+// in which the student hasn't been awarded a passing grade.
+type CourseNotPassedError struct {
+	CourseCode string
+	Email      primitive.EmailAddress
+}
+
+func (cnpe CourseNotPassedError) Error() string {
+	return fmt.Sprintf("%s has not passed course %q", cnpe.Email, cnpe.CourseCode)
+}
+
+// CertificateNotFoundError is returned when no certificate was issued with
+// the given verification code.
+type CertificateNotFoundError struct {
+	Code string
+}
+
+func (cnfe CertificateNotFoundError) Error() string {
+	return fmt.Sprintf("certificate %q not found", cnfe.Code)
+}
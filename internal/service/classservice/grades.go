@@ -0,0 +1,119 @@
+package classservice
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/angusgmorrison/hexagonal/internal/primitive"
+)
+
+// RecordGrade awards a final grade to the student with the given email address
+// for the course matching courseCode, completing their enrollment. The student
+// must be actively enrolled in the course, and the grade must be on the
+// service's GradingScale.
+//
+// Completing an enrollment frees the student's place in the class and no
+// longer counts toward their course load.
+func (svc *classService) RecordGrade(
+	ctx context.Context,
+	courseCode string,
+	email primitive.EmailAddress,
+	grade string,
+) error {
+	if err := svc.validateCourseAndEmail(courseCode, email); err != nil {
+		return fmt.Errorf("RecordGrade: %w", err)
+	}
+
+	if _, ok := svc.gradingScale.Grade(grade); !ok {
+		return UnknownGradeError{Grade: grade}
+	}
+
+	record := func(ctx context.Context, repo Repository) error {
+		class, err := svc.getClass(ctx, repo, courseCode)
+		if err != nil {
+			return fmt.Errorf("RecordGrade: %w", err)
+		}
+
+		student, ok := class.Students.ByEmail(email)
+		if !ok {
+			return NotEnrolledError{CourseCode: class.Code, Students: Students{{Email: email}}}
+		}
+
+		if _, err := repo.CompleteEnrollment(ctx, class.Course, student, grade, svc.now()); err != nil {
+			return fmt.Errorf("RecordGrade: %w", err)
+		}
+
+		return nil
+	}
+
+	if err := svc.repo.Execute(ctx, record); err != nil {
+		return err
+	}
+
+	svc.publishAvailability(ctx, courseCode)
+
+	return nil
+}
+
+// GetTranscript returns the academic record of the student with the given
+// email address: every course they have completed, in order of completion,
+// with the grade they were awarded.
+func (svc *classService) GetTranscript(ctx context.Context, email primitive.EmailAddress) (Transcript, error) {
+	if err := svc.validate.Var(email, "required"); err != nil {
+		return Transcript{}, fmt.Errorf("GetTranscript: %w", err)
+	}
+
+	var transcript Transcript
+
+	get := func(ctx context.Context, repo Repository) error {
+		registeredStudents, err := repo.GetStudentsByEmail(ctx, []primitive.EmailAddress{email})
+		if err != nil {
+			return fmt.Errorf("GetTranscript: %w", err)
+		}
+
+		students := Students{{Email: email}}.resolve(registeredStudents)
+
+		if err := verifyStudentsRegistered(ctx, repo, Class{}, students); err != nil {
+			return err
+		}
+
+		completions, err := repo.GetCompletions(ctx, students)
+		if err != nil {
+			return fmt.Errorf("GetTranscript: %w", err)
+		}
+
+		transcript = svc.transcript(students[0], completions[students[0].ID])
+
+		return nil
+	}
+
+	if err := svc.repo.Execute(ctx, get); err != nil {
+		return Transcript{}, err
+	}
+
+	return transcript, nil
+}
+
+// transcript grades the student's completions according to the service's
+// GradingScale.
+func (svc *classService) transcript(student Student, completions Completions) Transcript {
+	transcript := Transcript{
+		Student: student,
+		Entries: make([]TranscriptEntry, 0, len(completions)),
+	}
+
+	for _, completion := range completions {
+		grade, ok := svc.gradingScale.Grade(completion.Grade)
+		if !ok {
+			grade = Grade{Letter: completion.Grade, PassFail: true}
+		}
+
+		transcript.Entries = append(transcript.Entries, TranscriptEntry{
+			CourseCode:  completion.CourseCode,
+			Grade:       grade,
+			CompletedAt: completion.CompletedAt,
+		})
+	}
+
+	return transcript
+}
@@ -0,0 +1,319 @@
+//go:build unit
+
+package classservice
+
+import (
+	"context"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/angusgmorrison/hexagonal/internal/primitive"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordGrade(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2022, time.December, 16, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	t.Run("rejects grades not on the scale", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "rejects grades not on the scale ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			service    = New(logger, validate, atomicRepo)
+		)
+
+		err := service.RecordGrade(context.Background(), "SICP", "r.tifft@gmail.com", "E")
+		require.Equal(t, UnknownGradeError{Grade: "E"}, err)
+	})
+
+	t.Run("rejects students not enrolled", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "rejects students not enrolled ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			service    = New(logger, validate, atomicRepo)
+			ctx        = context.Background()
+			class      = Class{Course: defaultCourse()}
+			email      = primitive.EmailAddress("r.tifft@gmail.com")
+		)
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On("GetClassByCourseCode", ctx, class.Code).Return(class, nil)
+
+		err := service.RecordGrade(ctx, class.Code, email, "A")
+		require.Equal(t, NotEnrolledError{CourseCode: class.Code, Students: Students{{Email: email}}}, err)
+	})
+
+	t.Run("completes the enrollment", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "completes the enrollment ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			service    = New(logger, validate, atomicRepo, WithClock(clock))
+			ctx        = context.Background()
+			students   = registeredStudents(t, Students{defaultStudent(t)})
+			class      = Class{Course: defaultCourse(), Students: students}
+		)
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On("GetClassByCourseCode", ctx, class.Code).Return(class, nil)
+		repo.On(
+			"CompleteEnrollment",
+			ctx,
+			class.Course,
+			students[0],
+			"B+",
+			now,
+		).Return(Class{Course: class.Course}, nil)
+
+		err := service.RecordGrade(ctx, class.Code, students[0].Email, "B+")
+		require.NoError(t, err)
+	})
+
+	t.Run("publishes the place freed", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "publishes the place freed ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			publisher  = NewMockAvailabilityPublisher(t)
+			service    = New(logger, validate, atomicRepo, WithClock(clock), WithAvailabilityPublisher(publisher))
+			ctx        = context.Background()
+			students   = registeredStudents(t, Students{defaultStudent(t)})
+			class      = Class{Course: defaultCourse(), Students: students}
+			completed  = Class{Course: defaultCourse()}
+		)
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On("GetClassByCourseCode", ctx, class.Code).Return(class, nil).Once()
+		repo.On(
+			"CompleteEnrollment",
+			ctx,
+			class.Course,
+			students[0],
+			"B+",
+			now,
+		).Return(completed, nil)
+		repo.On("GetClassByCourseCode", ctx, class.Code).Return(completed, nil).Once()
+		publisher.On(
+			"PublishAvailability",
+			ctx,
+			Availability{CourseCode: class.Code, AvailableSpaces: completed.AvailableSpaces()},
+		).Return().Once()
+
+		err := service.RecordGrade(ctx, class.Code, students[0].Email, "B+")
+		require.NoError(t, err)
+	})
+}
+
+func TestGetTranscript(t *testing.T) {
+	t.Parallel()
+
+	completedAt := time.Date(2022, time.December, 16, 12, 0, 0, 0, time.UTC)
+
+	t.Run("grades completions", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "grades completions ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			service    = New(logger, validate, atomicRepo)
+			ctx        = context.Background()
+			students   = registeredStudents(t, Students{defaultStudent(t)})
+		)
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On("GetStudentsByEmail", ctx, students.EmailAddresses()).Return(students, nil)
+		repo.On("GetCompletions", ctx, students).Return(StudentCompletions{
+			students[0].ID: {
+				{CourseCode: "SICP", Grade: "A", CompletedAt: completedAt},
+				{CourseCode: "TAOCP", Grade: "Z", CompletedAt: completedAt},
+			},
+		}, nil)
+
+		got, err := service.GetTranscript(ctx, students[0].Email)
+		require.NoError(t, err)
+
+		want := Transcript{
+			Student: students[0],
+			Entries: []TranscriptEntry{
+				{
+					CourseCode:  "SICP",
+					Grade:       Grade{Letter: "A", Points: 4, Passing: true},
+					CompletedAt: completedAt,
+				},
+				{
+					CourseCode:  "TAOCP",
+					Grade:       Grade{Letter: "Z", PassFail: true},
+					CompletedAt: completedAt,
+				},
+			},
+		}
+		require.Equal(t, want, got)
+	})
+
+	t.Run("rejects unregistered students", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "rejects unregistered students ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			service    = New(logger, validate, atomicRepo)
+			ctx        = context.Background()
+			email      = primitive.EmailAddress("r.tifft@gmail.com")
+		)
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On("GetStudentsByEmail", ctx, []primitive.EmailAddress{email}).Return(Students{}, nil)
+
+		_, err := service.GetTranscript(ctx, email)
+
+		var unregisteredErr UnregisteredStudentsError
+		require.ErrorAs(t, err, &unregisteredErr)
+	})
+}
+
+func TestEnrollPrerequisites(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		completions Completions
+		wantErr     bool
+	}{
+		{
+			name:        "prerequisite not taken",
+			completions: nil,
+			wantErr:     true,
+		},
+		{
+			name:        "prerequisite failed",
+			completions: Completions{{CourseCode: "SICP", Grade: "F"}},
+			wantErr:     true,
+		},
+		{
+			name:        "prerequisite failed pass/fail",
+			completions: Completions{{CourseCode: "SICP", Grade: "NP"}},
+			wantErr:     true,
+		},
+		{
+			name:        "prerequisite passed",
+			completions: Completions{{CourseCode: "SICP", Grade: "C"}},
+		},
+		{
+			name:        "prerequisite passed pass/fail",
+			completions: Completions{{CourseCode: "SICP", Grade: "P"}},
+		},
+		{
+			name:        "prerequisite retaken and passed",
+			completions: Completions{{CourseCode: "SICP", Grade: "F"}, {CourseCode: "SICP", Grade: "B"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				logger     = log.New(os.Stdout, "TestEnrollPrerequisites ", log.LstdFlags)
+				validate   = validator.New()
+				atomicRepo = NewMockAtomicRepository(t)
+				repo       = NewMockRepository(t)
+				service    = New(logger, validate, atomicRepo)
+				ctx        = context.Background()
+				req        = EnrollmentRequest{CourseCode: "LISP", Students: Students{defaultStudent(t)}}
+				students   = registeredStudents(t, req.Students)
+				class      = Class{Course: Course{Code: "LISP", Capacity: 2, Prerequisites: []string{"SICP"}}}
+			)
+
+			atomicRepo.On(
+				"Execute",
+				ctx,
+				mock.AnythingOfType("AtomicOperation"),
+			).Return(func(ctx context.Context, op AtomicOperation) error {
+				return op(ctx, repo)
+			})
+
+			repo.On("GetClassForUpdate", ctx, req.CourseCode).Return(class, nil)
+			repo.On("GetStudentsByEmail", ctx, req.Students.EmailAddresses()).Return(students, nil)
+			repo.On("GetCompletions", ctx, students).Return(StudentCompletions{students[0].ID: tc.completions}, nil)
+
+			if !tc.wantErr {
+				repo.On("EnrollStudents", ctx, class.Course, students).Return(class, nil)
+			}
+
+			err := service.Enroll(ctx, req)
+
+			if !tc.wantErr {
+				require.NoError(t, err)
+
+				return
+			}
+
+			wantErr := PrerequisitesNotMetError{
+				CourseCode:    class.Code,
+				Prerequisites: class.Prerequisites,
+				Students:      students,
+			}
+			require.Equal(t, wantErr, err)
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package classservice
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Grade is a final grade that may be awarded for a course.
+//
+// PassFail grades record only whether the student passed, and don't count
+// toward the student's grade point average. Other grades are worth Points.
+type Grade struct {
+	Letter   string
+	Points   float64
+	Passing  bool
+	PassFail bool
+}
+
+// GradingScale is the set of grades that may be awarded.
+type GradingScale struct {
+	grades map[string]Grade
+}
+
+// NewGradingScale returns a GradingScale made up of the given grades, each of
+// which must have a distinct, non-empty letter.
+func NewGradingScale(grades ...Grade) (GradingScale, error) {
+	if len(grades) == 0 {
+		return GradingScale{}, fmt.Errorf("grading scale has no grades")
+	}
+
+	scale := GradingScale{grades: make(map[string]Grade, len(grades))}
+
+	for _, grade := range grades {
+		if grade.Letter == "" {
+			return GradingScale{}, fmt.Errorf("grading scale contains a grade with no letter")
+		}
+
+		if _, ok := scale.grades[grade.Letter]; ok {
+			return GradingScale{}, fmt.Errorf("grading scale contains grade %q more than once", grade.Letter)
+		}
+
+		scale.grades[grade.Letter] = grade
+	}
+
+	return scale, nil
+}
+
+// ParseGradingScale parses a GradingScale from a comma-separated list of
+// LETTER=VALUE pairs, e.g. "A=4,B=3,C=2,D=1,F=0,P=pass,NP=fail". A value of
+// "pass" or "fail" defines a PassFail grade. Any other value is the number of
+// points the grade is worth, and the grade is passing if it is worth at least
+// passingPoints.
+func ParseGradingScale(spec string, passingPoints float64) (GradingScale, error) {
+	var grades []Grade
+
+	for _, pair := range strings.Split(spec, ",") {
+		letter, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok {
+			return GradingScale{}, fmt.Errorf("parse grading scale: %q is not of the form LETTER=VALUE", pair)
+		}
+
+		grade := Grade{Letter: strings.TrimSpace(letter)}
+
+		switch value = strings.TrimSpace(value); value {
+		case "pass":
+			grade.PassFail, grade.Passing = true, true
+		case "fail":
+			grade.PassFail = true
+		default:
+			points, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return GradingScale{}, fmt.Errorf("parse grading scale: points of grade %q: %w", grade.Letter, err)
+			}
+
+			grade.Points = points
+			grade.Passing = points >= passingPoints
+		}
+
+		grades = append(grades, grade)
+	}
+
+	scale, err := NewGradingScale(grades...)
+	if err != nil {
+		return GradingScale{}, fmt.Errorf("parse grading scale: %w", err)
+	}
+
+	return scale, nil
+}
+
+// DefaultGradingScale returns the four-point letter grade scale used unless
+// the service is configured with WithGradingScale. D- and above are passing.
+// P and NP record passes and fails of courses taken pass/fail.
+func DefaultGradingScale() GradingScale {
+	scale, err := ParseGradingScale(
+		"A=4.0,A-=3.7,B+=3.3,B=3.0,B-=2.7,C+=2.3,C=2.0,C-=1.7,D+=1.3,D=1.0,D-=0.7,F=0,P=pass,NP=fail", 0.7)
+	if err != nil {
+		panic(err)
+	}
+
+	return scale
+}
+
+// Grade returns the grade with the given letter, and false if the scale has no
+// such grade.
+func (gs GradingScale) Grade(letter string) (Grade, bool) {
+	grade, ok := gs.grades[letter]
+
+	return grade, ok
+}
+
+// passed reports whether the completions include a passing grade in the
+// course with the given code.
+func (gs GradingScale) passed(completions Completions, courseCode string) bool {
+	for _, completion := range completions {
+		if completion.CourseCode != courseCode {
+			continue
+		}
+
+		if grade, ok := gs.Grade(completion.Grade); ok && grade.Passing {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Completion records that a student was awarded a final grade for a course.
+type Completion struct {
+	CourseCode  string
+	Grade       string
+	CompletedAt time.Time
+}
+
+// Completions is a convenience wrapper.
+type Completions []Completion
+
+// StudentCompletions maps student IDs to the courses each student has
+// completed.
+type StudentCompletions map[int64]Completions
+
+// Transcript is the academic record of a student.
+type Transcript struct {
+	Student Student
+	Entries []TranscriptEntry
+}
+
+// TranscriptEntry is the final grade of a student in one course. Grades no
+// longer on the service's GradingScale are reported as failing PassFail
+// grades.
+type TranscriptEntry struct {
+	CourseCode  string
+	Grade       Grade
+	CompletedAt time.Time
+}
+
+// GPA returns the student's grade point average: the mean points of their
+// grades, excluding PassFail grades. It returns false if the student has no
+// such grades.
+func (t Transcript) GPA() (float64, bool) {
+	var (
+		total  float64
+		graded int
+	)
+
+	for _, entry := range t.Entries {
+		if entry.Grade.PassFail {
+			continue
+		}
+
+		total += entry.Grade.Points
+		graded++
+	}
+
+	if graded == 0 {
+		return 0, false
+	}
+
+	return total / float64(graded), true
+}
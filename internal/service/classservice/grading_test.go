@@ -0,0 +1,103 @@
+//go:build unit
+
+package classservice
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGradingScale(t *testing.T) {
+	t.Parallel()
+
+	t.Run("parses points and pass/fail grades", func(t *testing.T) {
+		t.Parallel()
+
+		scale, err := ParseGradingScale("A=4, C=2,D=1 ,F=0,P=pass,NP=fail", 1.5)
+		require.NoError(t, err)
+
+		testCases := []struct {
+			letter string
+			want   Grade
+		}{
+			{letter: "A", want: Grade{Letter: "A", Points: 4, Passing: true}},
+			{letter: "C", want: Grade{Letter: "C", Points: 2, Passing: true}},
+			{letter: "D", want: Grade{Letter: "D", Points: 1}},
+			{letter: "F", want: Grade{Letter: "F"}},
+			{letter: "P", want: Grade{Letter: "P", Passing: true, PassFail: true}},
+			{letter: "NP", want: Grade{Letter: "NP", PassFail: true}},
+		}
+
+		for _, tc := range testCases {
+			got, ok := scale.Grade(tc.letter)
+			require.True(t, ok, tc.letter)
+			require.Equal(t, tc.want, got)
+		}
+
+		_, ok := scale.Grade("B")
+		require.False(t, ok)
+	})
+
+	t.Run("rejects invalid specs", func(t *testing.T) {
+		t.Parallel()
+
+		testCases := []struct {
+			name string
+			spec string
+		}{
+			{name: "empty", spec: ""},
+			{name: "missing value", spec: "A=4,B"},
+			{name: "invalid points", spec: "A=four"},
+			{name: "missing letter", spec: "=4"},
+			{name: "duplicate letter", spec: "A=4,A=3"},
+		}
+
+		for _, tc := range testCases {
+			tc := tc
+
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+
+				_, err := ParseGradingScale(tc.spec, 1)
+				require.Error(t, err)
+			})
+		}
+	})
+}
+
+func TestTranscriptGPA(t *testing.T) {
+	t.Parallel()
+
+	scale := DefaultGradingScale()
+	grade := func(letter string) Grade {
+		g, ok := scale.Grade(letter)
+		require.True(t, ok, letter)
+
+		return g
+	}
+
+	t.Run("excludes pass/fail grades", func(t *testing.T) {
+		t.Parallel()
+
+		transcript := Transcript{Entries: []TranscriptEntry{
+			{CourseCode: "SICP", Grade: grade("A")},
+			{CourseCode: "TAOCP", Grade: grade("B+")},
+			{CourseCode: "CLRS", Grade: grade("NP")},
+			{CourseCode: "HTDP", Grade: grade("P")},
+		}}
+
+		gpa, ok := transcript.GPA()
+		require.True(t, ok)
+		require.InDelta(t, 3.65, gpa, 1e-9)
+	})
+
+	t.Run("is undefined without graded courses", func(t *testing.T) {
+		t.Parallel()
+
+		transcript := Transcript{Entries: []TranscriptEntry{{CourseCode: "HTDP", Grade: grade("P")}}}
+
+		_, ok := transcript.GPA()
+		require.False(t, ok)
+	})
+}
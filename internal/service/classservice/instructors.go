@@ -0,0 +1,184 @@
+package classservice
+
+import (
+	"context"
+	"fmt"
+)
+
+// GetClass returns the roster of the course matching courseCode: its enrolled
+// and pending students, the sections they belong to, the places held by
+// unexpired reservations, the instructors assigned to teach it, and its
+// sessions and the attendance of its students.
+func (svc *classService) GetClass(ctx context.Context, courseCode string) (Class, error) {
+	if err := svc.validate.Var(courseCode, "required"); err != nil {
+		return Class{}, fmt.Errorf("GetClass: %w", err)
+	}
+
+	var class Class
+
+	get := func(ctx context.Context, repo Repository) error {
+		var err error
+
+		class, err = svc.getClass(ctx, repo, courseCode)
+		if err != nil {
+			return fmt.Errorf("GetClass: %w", err)
+		}
+
+		return nil
+	}
+
+	if err := svc.repo.Execute(ctx, get); err != nil {
+		return Class{}, err
+	}
+
+	return class, nil
+}
+
+// ListClasses returns the rosters of every class, including those of cancelled
+// courses, ordered by course code.
+func (svc *classService) ListClasses(ctx context.Context) ([]Class, error) {
+	var classes []Class
+
+	list := func(ctx context.Context, repo Repository) error {
+		var err error
+
+		classes, err = repo.GetClasses(ctx)
+		if err != nil {
+			return fmt.Errorf("ListClasses: %w", err)
+		}
+
+		now := svc.now()
+		for i := range classes {
+			classes[i].Reservations = classes[i].Reservations.activeAt(now)
+		}
+
+		return nil
+	}
+
+	if err := svc.repo.Execute(ctx, list); err != nil {
+		return nil, err
+	}
+
+	return classes, nil
+}
+
+// GetClassesTaughtBy returns the rosters of every class that the instructor
+// with the given ID is assigned to teach, ordered by course code.
+func (svc *classService) GetClassesTaughtBy(ctx context.Context, instructorID int64) ([]Class, error) {
+	if err := svc.validate.Var(instructorID, "gt=0"); err != nil {
+		return nil, fmt.Errorf("GetClassesTaughtBy: %w", err)
+	}
+
+	var classes []Class
+
+	get := func(ctx context.Context, repo Repository) error {
+		instructor, err := repo.GetInstructor(ctx, instructorID)
+		if err != nil {
+			return fmt.Errorf("GetClassesTaughtBy: %w", err)
+		}
+
+		classes, err = repo.GetClassesTaughtBy(ctx, instructor)
+		if err != nil {
+			return fmt.Errorf("GetClassesTaughtBy: %w", err)
+		}
+
+		now := svc.now()
+		for i := range classes {
+			classes[i].Reservations = classes[i].Reservations.activeAt(now)
+		}
+
+		return nil
+	}
+
+	if err := svc.repo.Execute(ctx, get); err != nil {
+		return nil, err
+	}
+
+	return classes, nil
+}
+
+// AssignInstructor assigns the instructor with the given ID to teach the course
+// matching courseCode. The course must not have been cancelled, and the
+// instructor must not already be assigned to it.
+func (svc *classService) AssignInstructor(ctx context.Context, courseCode string, instructorID int64) error {
+	if err := svc.validateCourseAndInstructor(courseCode, instructorID); err != nil {
+		return fmt.Errorf("AssignInstructor: %w", err)
+	}
+
+	assign := func(ctx context.Context, repo Repository) error {
+		class, instructor, err := svc.getClassAndInstructor(ctx, repo, courseCode, instructorID)
+		if err != nil {
+			return fmt.Errorf("AssignInstructor: %w", err)
+		}
+
+		if err := verifyCourseNotCancelled(ctx, repo, class, nil); err != nil {
+			return err
+		}
+
+		if _, ok := class.Instructors.ByID(instructor.ID); ok {
+			return AlreadyAssignedError{CourseCode: class.Code, Instructor: instructor}
+		}
+
+		if _, err := repo.AssignInstructor(ctx, class.Course, instructor); err != nil {
+			return fmt.Errorf("AssignInstructor: %w", err)
+		}
+
+		return nil
+	}
+
+	return svc.repo.Execute(ctx, assign)
+}
+
+// UnassignInstructor removes the instructor with the given ID from the course
+// matching courseCode, which they must be assigned to teach.
+func (svc *classService) UnassignInstructor(ctx context.Context, courseCode string, instructorID int64) error {
+	if err := svc.validateCourseAndInstructor(courseCode, instructorID); err != nil {
+		return fmt.Errorf("UnassignInstructor: %w", err)
+	}
+
+	unassign := func(ctx context.Context, repo Repository) error {
+		class, instructor, err := svc.getClassAndInstructor(ctx, repo, courseCode, instructorID)
+		if err != nil {
+			return fmt.Errorf("UnassignInstructor: %w", err)
+		}
+
+		if _, ok := class.Instructors.ByID(instructor.ID); !ok {
+			return NotAssignedError{CourseCode: class.Code, Instructor: instructor}
+		}
+
+		if _, err := repo.UnassignInstructor(ctx, class.Course, instructor); err != nil {
+			return fmt.Errorf("UnassignInstructor: %w", err)
+		}
+
+		return nil
+	}
+
+	return svc.repo.Execute(ctx, unassign)
+}
+
+func (svc *classService) validateCourseAndInstructor(courseCode string, instructorID int64) error {
+	if err := svc.validate.Var(courseCode, "required"); err != nil {
+		return err
+	}
+
+	return svc.validate.Var(instructorID, "gt=0")
+}
+
+func (svc *classService) getClassAndInstructor(
+	ctx context.Context,
+	repo Repository,
+	courseCode string,
+	instructorID int64,
+) (Class, Instructor, error) {
+	class, err := svc.getClass(ctx, repo, courseCode)
+	if err != nil {
+		return Class{}, Instructor{}, err
+	}
+
+	instructor, err := repo.GetInstructor(ctx, instructorID)
+	if err != nil {
+		return Class{}, Instructor{}, err
+	}
+
+	return class, instructor, nil
+}
@@ -0,0 +1,254 @@
+//go:build unit
+
+package classservice
+
+import (
+	"context"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetClass(t *testing.T) {
+	t.Parallel()
+
+	t.Run("omits expired reservations", func(t *testing.T) {
+		t.Parallel()
+
+		now := time.Date(2022, time.September, 1, 12, 0, 0, 0, time.UTC)
+
+		var (
+			logger     = log.New(os.Stdout, "omits expired reservations ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			service    = New(logger, validate, atomicRepo, WithClock(func() time.Time { return now }))
+			ctx        = context.Background()
+			active     = Reservation{ID: 1, StudentID: 1, ExpiresAt: now.Add(time.Minute)}
+			expired    = Reservation{ID: 2, StudentID: 2, ExpiresAt: now}
+			class      = Class{
+				Course:       defaultCourse(),
+				Reservations: Reservations{active, expired},
+				Instructors:  Instructors{defaultInstructor()},
+			}
+		)
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On("GetClassByCourseCode", ctx, class.Code).Return(class, nil)
+
+		got, err := service.GetClass(ctx, class.Code)
+		require.NoError(t, err)
+		require.Equal(t, Reservations{active}, got.Reservations, "unexpected reservations")
+		require.Equal(t, class.Instructors, got.Instructors, "unexpected instructors")
+	})
+}
+
+func TestGetClassesTaughtBy(t *testing.T) {
+	t.Parallel()
+
+	t.Run("rejects unknown instructor", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "rejects unknown instructor ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			service    = New(logger, validate, atomicRepo)
+			ctx        = context.Background()
+			wantErr    = InstructorNotFoundError{ID: 1}
+		)
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On("GetInstructor", ctx, int64(1)).Return(Instructor{}, wantErr)
+
+		_, err := service.GetClassesTaughtBy(ctx, 1)
+		require.ErrorIs(t, err, wantErr)
+	})
+
+	t.Run("returns the instructor's classes", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "returns the instructor's classes ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			service    = New(logger, validate, atomicRepo)
+			ctx        = context.Background()
+			instructor = defaultInstructor()
+			classes    = []Class{{Course: defaultCourse(), Instructors: Instructors{instructor}}}
+		)
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On("GetInstructor", ctx, instructor.ID).Return(instructor, nil)
+		repo.On("GetClassesTaughtBy", ctx, instructor).Return(classes, nil)
+
+		got, err := service.GetClassesTaughtBy(ctx, instructor.ID)
+		require.NoError(t, err)
+		require.Equal(t, classes, got, "unexpected classes")
+	})
+}
+
+func TestAssignInstructor(t *testing.T) {
+	t.Parallel()
+
+	instructor := defaultInstructor()
+
+	testCases := []struct {
+		name    string
+		class   Class
+		wantErr error
+	}{
+		{
+			name:    "assigns instructor",
+			class:   Class{Course: defaultCourse()},
+			wantErr: nil,
+		},
+		{
+			name:    "rejects instructor already assigned",
+			class:   Class{Course: defaultCourse(), Instructors: Instructors{instructor}},
+			wantErr: AlreadyAssignedError{CourseCode: defaultCourse().Code, Instructor: instructor},
+		},
+		{
+			name:    "rejects cancelled course",
+			class:   cancelledClass(),
+			wantErr: CourseCancelledError{CourseCode: defaultCourse().Code},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				logger     = log.New(os.Stdout, tc.name+" ", log.LstdFlags)
+				validate   = validator.New()
+				atomicRepo = NewMockAtomicRepository(t)
+				repo       = NewMockRepository(t)
+				service    = New(logger, validate, atomicRepo)
+				ctx        = context.Background()
+			)
+
+			atomicRepo.On(
+				"Execute",
+				ctx,
+				mock.AnythingOfType("AtomicOperation"),
+			).Return(func(ctx context.Context, op AtomicOperation) error {
+				return op(ctx, repo)
+			})
+
+			repo.On("GetClassByCourseCode", ctx, tc.class.Code).Return(tc.class, nil)
+			repo.On("GetInstructor", ctx, instructor.ID).Return(instructor, nil)
+
+			if tc.wantErr == nil {
+				repo.On("AssignInstructor", ctx, tc.class.Course, instructor).Return(tc.class, nil)
+			}
+
+			err := service.AssignInstructor(ctx, tc.class.Code, instructor.ID)
+			if tc.wantErr == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestUnassignInstructor(t *testing.T) {
+	t.Parallel()
+
+	instructor := defaultInstructor()
+
+	testCases := []struct {
+		name    string
+		class   Class
+		wantErr error
+	}{
+		{
+			name:    "unassigns instructor",
+			class:   Class{Course: defaultCourse(), Instructors: Instructors{instructor}},
+			wantErr: nil,
+		},
+		{
+			name:    "rejects instructor not assigned",
+			class:   Class{Course: defaultCourse()},
+			wantErr: NotAssignedError{CourseCode: defaultCourse().Code, Instructor: instructor},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				logger     = log.New(os.Stdout, tc.name+" ", log.LstdFlags)
+				validate   = validator.New()
+				atomicRepo = NewMockAtomicRepository(t)
+				repo       = NewMockRepository(t)
+				service    = New(logger, validate, atomicRepo)
+				ctx        = context.Background()
+			)
+
+			atomicRepo.On(
+				"Execute",
+				ctx,
+				mock.AnythingOfType("AtomicOperation"),
+			).Return(func(ctx context.Context, op AtomicOperation) error {
+				return op(ctx, repo)
+			})
+
+			repo.On("GetClassByCourseCode", ctx, tc.class.Code).Return(tc.class, nil)
+			repo.On("GetInstructor", ctx, instructor.ID).Return(instructor, nil)
+
+			if tc.wantErr == nil {
+				repo.On("UnassignInstructor", ctx, tc.class.Course, instructor).Return(Class{Course: tc.class.Course}, nil)
+			}
+
+			err := service.UnassignInstructor(ctx, tc.class.Code, instructor.ID)
+			if tc.wantErr == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func defaultInstructor() Instructor {
+	return Instructor{
+		ID:    1,
+		Name:  "Gerald Sussman",
+		Email: "gjs@mit.edu",
+	}
+}
@@ -2,6 +2,7 @@ package classservice
 
 import (
 	"context"
+	"time"
 
 	"github.com/angusgmorrison/hexagonal/internal/primitive"
 	"github.com/go-playground/validator/v10"
@@ -14,6 +15,32 @@ import (
 // that the service package is authoritative.
 type Interface interface {
 	Enroll(ctx context.Context, er EnrollmentRequest) error
+	ApproveEnrollment(ctx context.Context, courseCode string, email primitive.EmailAddress) error
+	RejectEnrollment(ctx context.Context, courseCode string, email primitive.EmailAddress) error
+	Transfer(ctx context.Context, fromCourseCode, toCourseCode string, students Students) error
+	Unenroll(ctx context.Context, courseCode string, students Students) error
+	Reserve(ctx context.Context, courseCode string, email primitive.EmailAddress) (Reservation, error)
+	ReleaseExpiredReservations(ctx context.Context) (int, error)
+	CancelCourse(ctx context.Context, courseCode string) error
+	ValidateEnrollmentRule(ctx context.Context, rule string) error
+	GetClass(ctx context.Context, courseCode string) (Class, error)
+	ListClasses(ctx context.Context) ([]Class, error)
+	GetClassesTaughtBy(ctx context.Context, instructorID int64) ([]Class, error)
+	AssignInstructor(ctx context.Context, courseCode string, instructorID int64) error
+	UnassignInstructor(ctx context.Context, courseCode string, instructorID int64) error
+	RecordGrade(ctx context.Context, courseCode string, email primitive.EmailAddress, grade string) error
+	GetTranscript(ctx context.Context, email primitive.EmailAddress) (Transcript, error)
+	GetStudents(ctx context.Context, emails []primitive.EmailAddress) (Students, error)
+	GetEnrollments(ctx context.Context, students Students) (StudentEnrollments, error)
+	GetStudentClasses(ctx context.Context, email primitive.EmailAddress) ([]Class, error)
+	CreateSessions(ctx context.Context, courseCode string, startTimes []time.Time) (Sessions, error)
+	ScheduleSessions(ctx context.Context, courseCode string, schedule Schedule) (Sessions, error)
+	RecordAttendance(ctx context.Context, courseCode string, records []AttendanceRecord) error
+	ConfirmPayment(ctx context.Context, invoiceID string) error
+	CreateVoucher(ctx context.Context, voucher Voucher) (Voucher, error)
+	GetVoucher(ctx context.Context, code string) (Voucher, error)
+	GetCertificate(ctx context.Context, email primitive.EmailAddress, courseCode string) (Certificate, error)
+	VerifyCertificate(ctx context.Context, code string) (Certificate, error)
 }
 
 // New configures and returns an Interface implementation.
@@ -21,12 +48,28 @@ func New(
 	logger logger,
 	validate *validator.Validate,
 	repo AtomicRepository,
+	opts ...Option,
 ) Interface {
-	return &classService{
+	svc := classService{
 		logger:   logger,
 		validate: validate,
 		repo:     repo,
+		now:      time.Now,
+		rules:    newRuleCache(),
+
+		notifier:       nopNotifier{},
+		billing:        unconfiguredBilling{},
+		reservationTTL: defaultReservationTTL,
+		gradingScale:   DefaultGradingScale(),
+	}
+
+	for _, opt := range opts {
+		opt(&svc)
 	}
+
+	svc.policies = append(svc.builtinPolicies(), svc.customPolicies...)
+
+	return &svc
 }
 
 // classService implements classservice.Interface.
@@ -34,6 +77,46 @@ type classService struct {
 	logger   logger
 	validate *validator.Validate
 	repo     AtomicRepository
+
+	// now returns the current time.
+	now func() time.Time
+
+	// ruleSource provides declarative enrollment rules, if configured using
+	// WithRuleSource.
+	ruleSource RuleSource
+
+	// rules compiles and caches enrollment rules.
+	rules *ruleCache
+
+	// defaultMaxCourseLoad is the maximum number of courses a student may be
+	// enrolled in at once, unless overridden for that student. Zero means
+	// unlimited.
+	defaultMaxCourseLoad uint32
+
+	// notifier informs students of events affecting their enrollments.
+	notifier Notifier
+
+	// billing invoices students who enroll in courses that charge a fee.
+	billing Billing
+
+	// availabilityPublisher is informed of changes to the places remaining in
+	// courses, if configured using WithAvailabilityPublisher.
+	availabilityPublisher AvailabilityPublisher
+
+	// reservationTTL is the length of time for which a reservation holds a
+	// place in a class.
+	reservationTTL time.Duration
+
+	// gradingScale defines the grades that may be awarded to students who
+	// complete a course.
+	gradingScale GradingScale
+
+	// customPolicies are the EnrollmentPolicies registered using
+	// WithPolicies.
+	customPolicies []EnrollmentPolicy
+
+	// policies are all the EnrollmentPolicies evaluated by Enroll, in order.
+	policies []EnrollmentPolicy
 }
 
 type AtomicOperation func(context.Context, Repository) error
@@ -47,12 +130,155 @@ type Repository interface {
 	// code.
 	GetClassByCourseCode(ctx context.Context, courseCode string) (Class, error)
 
+	// GetClassForUpdate loads a course and its students like
+	// GetClassByCourseCode, and locks the course until the end of the atomic
+	// operation, so that operations that check the places remaining in the
+	// course can't interleave.
+	GetClassForUpdate(ctx context.Context, courseCode string) (Class, error)
+
+	// GetClasses loads every class, including those of cancelled courses,
+	// ordered by course code.
+	GetClasses(ctx context.Context) ([]Class, error)
+
 	// GetStudentsByEmail loads all the students corresponding to the email
 	// addresses provided.
 	GetStudentsByEmail(ctx context.Context, emails []primitive.EmailAddress) (Students, error)
 
+	// RecordRequest records that the enrollment request with the given ID was
+	// carried out at time t. If it has been recorded before, it returns
+	// DuplicateRequestError, even when called concurrently.
+	RecordRequest(ctx context.Context, requestID string, t time.Time) error
+
 	// Enroll writes the enrollment of students in a class to a repository.
 	EnrollStudents(ctx context.Context, c Course, s Students) (Class, error)
+
+	// UnenrollStudents withdraws the active enrollments of students from a
+	// class.
+	UnenrollStudents(ctx context.Context, c Course, s Students) (Class, error)
+
+	// RequestEnrollment records that students are awaiting approval to enroll
+	// in a class. If sec is non-zero, the students asked to join that section.
+	RequestEnrollment(ctx context.Context, c Course, sec Section, s Students) (Class, error)
+
+	// ApproveEnrollment enrolls a student whose enrollment is pending,
+	// placing them in the given section. sec is zero if the course has no
+	// sections.
+	ApproveEnrollment(ctx context.Context, c Course, sec Section, s Student) (Class, error)
+
+	// RejectEnrollment records that a student's pending enrollment was
+	// refused.
+	RejectEnrollment(ctx context.Context, c Course, s Student) (Class, error)
+
+	// Reserve holds a place in a class for a student until expiresAt,
+	// replacing any reservation the student already holds in the class.
+	Reserve(ctx context.Context, c Course, s Student, expiresAt time.Time) (Reservation, error)
+
+	// ReleaseReservations releases the places reserved for students in a
+	// class.
+	ReleaseReservations(ctx context.Context, c Course, s Students) error
+
+	// ReleaseExpiredReservations releases every reservation that expired at or
+	// before t, returning the number released and the codes of the courses
+	// they were held in.
+	ReleaseExpiredReservations(ctx context.Context, t time.Time) (int, []string, error)
+
+	// CancelCourse archives a course at time t, cancels all of its active and
+	// pending enrollments, and releases its reservations.
+	CancelCourse(ctx context.Context, c Course, t time.Time) (Class, error)
+
+	// GetCourseLoads returns the number of courses each of the given students
+	// is enrolled in, including those awaiting payment. Each student's ID field
+	// must be populated.
+	GetCourseLoads(ctx context.Context, s Students) (CourseLoads, error)
+
+	// GetEnrollments returns the active, pending and unpaid enrollments of
+	// each of the given students, ordered by course code. Each student's ID
+	// field must be populated.
+	GetEnrollments(ctx context.Context, s Students) (StudentEnrollments, error)
+
+	// GetInstructor loads the instructor with the given ID.
+	GetInstructor(ctx context.Context, id int64) (Instructor, error)
+
+	// GetClassesTaughtBy loads every class that the instructor is assigned to
+	// teach.
+	GetClassesTaughtBy(ctx context.Context, i Instructor) ([]Class, error)
+
+	// AssignInstructor records that an instructor teaches a class.
+	AssignInstructor(ctx context.Context, c Course, i Instructor) (Class, error)
+
+	// UnassignInstructor records that an instructor no longer teaches a class.
+	UnassignInstructor(ctx context.Context, c Course, i Instructor) (Class, error)
+
+	// CompleteEnrollment ends the active enrollment of a student in a class
+	// at time t, recording the grade they were awarded.
+	CompleteEnrollment(ctx context.Context, c Course, s Student, grade string, t time.Time) (Class, error)
+
+	// GetCompletions returns the courses each of the given students has
+	// completed. Each student's ID field must be populated.
+	GetCompletions(ctx context.Context, s Students) (StudentCompletions, error)
+
+	// CreateSessions adds sessions starting at each of the given times to a
+	// class, skipping times at which the class already has a session, and
+	// returns the sessions created.
+	CreateSessions(ctx context.Context, c Course, startTimes []time.Time) (Sessions, error)
+
+	// RecordAttendance records at time t whether students were present at
+	// sessions of a class. Each record's Student must be enrolled in the class
+	// and have its ID field populated.
+	RecordAttendance(ctx context.Context, c Course, records []AttendanceRecord, t time.Time) (Class, error)
+
+	// EnrollStudentsAwaitingPayment writes the enrollment of students in a
+	// class that charges a fee, pending payment of their invoices. sec is zero
+	// if the course has no sections.
+	EnrollStudentsAwaitingPayment(ctx context.Context, c Course, sec Section, s Students) (Class, error)
+
+	// AwaitPayment moves the pending enrollment of an approved student to
+	// await payment, placing them in the given section. sec is zero if the
+	// course has no sections.
+	AwaitPayment(ctx context.Context, c Course, sec Section, s Student) (Class, error)
+
+	// SaveInvoice records an invoice issued to a student awaiting payment for
+	// a class.
+	SaveInvoice(ctx context.Context, c Course, inv Invoice) error
+
+	// GetInvoice loads the invoice with the given ID.
+	GetInvoice(ctx context.Context, id string) (Invoice, error)
+
+	// MarkInvoicePaid records that an invoice was paid at time t, and enrolls
+	// the student it billed.
+	MarkInvoicePaid(ctx context.Context, c Course, inv Invoice, t time.Time) (Class, error)
+
+	// CreateVoucher records a new voucher and returns it with its ID
+	// populated.
+	CreateVoucher(ctx context.Context, v Voucher) (Voucher, error)
+
+	// GetVoucherByCode loads the voucher with the given code.
+	GetVoucherByCode(ctx context.Context, code string) (Voucher, error)
+
+	// RedeemVoucher adds uses to the number of times a voucher has been
+	// redeemed and returns the updated voucher. It must count redemptions
+	// atomically, returning VoucherExhaustedError rather than exceeding the
+	// voucher's MaxUses, even when called concurrently.
+	RedeemVoucher(ctx context.Context, v Voucher, uses uint32) (Voucher, error)
+
+	// RecordVoucherRedemptions records that each of the students has redeemed
+	// a voucher. If any of them have redeemed it before, it returns
+	// VoucherAlreadyRedeemedError listing them, even when called
+	// concurrently.
+	RecordVoucherRedemptions(ctx context.Context, v Voucher, s Students) error
+
+	// IssueCertificate records at time t that a student was issued a
+	// certificate with the given code attesting to a completion, and returns
+	// it. If a certificate was already issued for the completion, the existing
+	// certificate is returned instead.
+	IssueCertificate(ctx context.Context, s Student, c Completion, code string, t time.Time) (Certificate, error)
+
+	// GetCertificateByCode loads the certificate with the given code.
+	GetCertificateByCode(ctx context.Context, code string) (Certificate, error)
+
+	// EnrollStudentsInSection writes the enrollment of students in a section
+	// of a class to a repository.
+	EnrollStudentsInSection(ctx context.Context, c Course, sec Section, s Students) (Class, error)
 }
 
 type logger interface {
@@ -4,9 +4,13 @@ package classservice
 
 import (
 	context "context"
-	testing "testing"
 
+	primitive "github.com/angusgmorrison/hexagonal/internal/primitive"
 	mock "github.com/stretchr/testify/mock"
+
+	testing "testing"
+
+	time "time"
 )
 
 // MockInterface is an autogenerated mock type for the Interface type
@@ -14,6 +18,106 @@ type MockInterface struct {
 	mock.Mock
 }
 
+// ApproveEnrollment provides a mock function with given fields: ctx, courseCode, email
+func (_m *MockInterface) ApproveEnrollment(ctx context.Context, courseCode string, email primitive.EmailAddress) error {
+	ret := _m.Called(ctx, courseCode, email)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, primitive.EmailAddress) error); ok {
+		r0 = rf(ctx, courseCode, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AssignInstructor provides a mock function with given fields: ctx, courseCode, instructorID
+func (_m *MockInterface) AssignInstructor(ctx context.Context, courseCode string, instructorID int64) error {
+	ret := _m.Called(ctx, courseCode, instructorID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) error); ok {
+		r0 = rf(ctx, courseCode, instructorID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CancelCourse provides a mock function with given fields: ctx, courseCode
+func (_m *MockInterface) CancelCourse(ctx context.Context, courseCode string) error {
+	ret := _m.Called(ctx, courseCode)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, courseCode)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ConfirmPayment provides a mock function with given fields: ctx, invoiceID
+func (_m *MockInterface) ConfirmPayment(ctx context.Context, invoiceID string) error {
+	ret := _m.Called(ctx, invoiceID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, invoiceID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateSessions provides a mock function with given fields: ctx, courseCode, startTimes
+func (_m *MockInterface) CreateSessions(ctx context.Context, courseCode string, startTimes []time.Time) (Sessions, error) {
+	ret := _m.Called(ctx, courseCode, startTimes)
+
+	var r0 Sessions
+	if rf, ok := ret.Get(0).(func(context.Context, string, []time.Time) Sessions); ok {
+		r0 = rf(ctx, courseCode, startTimes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(Sessions)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, []time.Time) error); ok {
+		r1 = rf(ctx, courseCode, startTimes)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateVoucher provides a mock function with given fields: ctx, voucher
+func (_m *MockInterface) CreateVoucher(ctx context.Context, voucher Voucher) (Voucher, error) {
+	ret := _m.Called(ctx, voucher)
+
+	var r0 Voucher
+	if rf, ok := ret.Get(0).(func(context.Context, Voucher) Voucher); ok {
+		r0 = rf(ctx, voucher)
+	} else {
+		r0 = ret.Get(0).(Voucher)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, Voucher) error); ok {
+		r1 = rf(ctx, voucher)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Enroll provides a mock function with given fields: ctx, er
 func (_m *MockInterface) Enroll(ctx context.Context, er EnrollmentRequest) error {
 	ret := _m.Called(ctx, er)
@@ -28,6 +132,389 @@ func (_m *MockInterface) Enroll(ctx context.Context, er EnrollmentRequest) error
 	return r0
 }
 
+// GetCertificate provides a mock function with given fields: ctx, email, courseCode
+func (_m *MockInterface) GetCertificate(ctx context.Context, email primitive.EmailAddress, courseCode string) (Certificate, error) {
+	ret := _m.Called(ctx, email, courseCode)
+
+	var r0 Certificate
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.EmailAddress, string) Certificate); ok {
+		r0 = rf(ctx, email, courseCode)
+	} else {
+		r0 = ret.Get(0).(Certificate)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, primitive.EmailAddress, string) error); ok {
+		r1 = rf(ctx, email, courseCode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetClass provides a mock function with given fields: ctx, courseCode
+func (_m *MockInterface) GetClass(ctx context.Context, courseCode string) (Class, error) {
+	ret := _m.Called(ctx, courseCode)
+
+	var r0 Class
+	if rf, ok := ret.Get(0).(func(context.Context, string) Class); ok {
+		r0 = rf(ctx, courseCode)
+	} else {
+		r0 = ret.Get(0).(Class)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, courseCode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetClassesTaughtBy provides a mock function with given fields: ctx, instructorID
+func (_m *MockInterface) GetClassesTaughtBy(ctx context.Context, instructorID int64) ([]Class, error) {
+	ret := _m.Called(ctx, instructorID)
+
+	var r0 []Class
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []Class); ok {
+		r0 = rf(ctx, instructorID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Class)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, instructorID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetEnrollments provides a mock function with given fields: ctx, students
+func (_m *MockInterface) GetEnrollments(ctx context.Context, students Students) (StudentEnrollments, error) {
+	ret := _m.Called(ctx, students)
+
+	var r0 StudentEnrollments
+	if rf, ok := ret.Get(0).(func(context.Context, Students) StudentEnrollments); ok {
+		r0 = rf(ctx, students)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(StudentEnrollments)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, Students) error); ok {
+		r1 = rf(ctx, students)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStudentClasses provides a mock function with given fields: ctx, email
+func (_m *MockInterface) GetStudentClasses(ctx context.Context, email primitive.EmailAddress) ([]Class, error) {
+	ret := _m.Called(ctx, email)
+
+	var r0 []Class
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.EmailAddress) []Class); ok {
+		r0 = rf(ctx, email)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Class)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, primitive.EmailAddress) error); ok {
+		r1 = rf(ctx, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStudents provides a mock function with given fields: ctx, emails
+func (_m *MockInterface) GetStudents(ctx context.Context, emails []primitive.EmailAddress) (Students, error) {
+	ret := _m.Called(ctx, emails)
+
+	var r0 Students
+	if rf, ok := ret.Get(0).(func(context.Context, []primitive.EmailAddress) Students); ok {
+		r0 = rf(ctx, emails)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(Students)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []primitive.EmailAddress) error); ok {
+		r1 = rf(ctx, emails)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTranscript provides a mock function with given fields: ctx, email
+func (_m *MockInterface) GetTranscript(ctx context.Context, email primitive.EmailAddress) (Transcript, error) {
+	ret := _m.Called(ctx, email)
+
+	var r0 Transcript
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.EmailAddress) Transcript); ok {
+		r0 = rf(ctx, email)
+	} else {
+		r0 = ret.Get(0).(Transcript)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, primitive.EmailAddress) error); ok {
+		r1 = rf(ctx, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetVoucher provides a mock function with given fields: ctx, code
+func (_m *MockInterface) GetVoucher(ctx context.Context, code string) (Voucher, error) {
+	ret := _m.Called(ctx, code)
+
+	var r0 Voucher
+	if rf, ok := ret.Get(0).(func(context.Context, string) Voucher); ok {
+		r0 = rf(ctx, code)
+	} else {
+		r0 = ret.Get(0).(Voucher)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListClasses provides a mock function with given fields: ctx
+func (_m *MockInterface) ListClasses(ctx context.Context) ([]Class, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []Class
+	if rf, ok := ret.Get(0).(func(context.Context) []Class); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Class)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecordAttendance provides a mock function with given fields: ctx, courseCode, records
+func (_m *MockInterface) RecordAttendance(ctx context.Context, courseCode string, records []AttendanceRecord) error {
+	ret := _m.Called(ctx, courseCode, records)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []AttendanceRecord) error); ok {
+		r0 = rf(ctx, courseCode, records)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RecordGrade provides a mock function with given fields: ctx, courseCode, email, grade
+func (_m *MockInterface) RecordGrade(ctx context.Context, courseCode string, email primitive.EmailAddress, grade string) error {
+	ret := _m.Called(ctx, courseCode, email, grade)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, primitive.EmailAddress, string) error); ok {
+		r0 = rf(ctx, courseCode, email, grade)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RejectEnrollment provides a mock function with given fields: ctx, courseCode, email
+func (_m *MockInterface) RejectEnrollment(ctx context.Context, courseCode string, email primitive.EmailAddress) error {
+	ret := _m.Called(ctx, courseCode, email)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, primitive.EmailAddress) error); ok {
+		r0 = rf(ctx, courseCode, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReleaseExpiredReservations provides a mock function with given fields: ctx
+func (_m *MockInterface) ReleaseExpiredReservations(ctx context.Context) (int, error) {
+	ret := _m.Called(ctx)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Reserve provides a mock function with given fields: ctx, courseCode, email
+func (_m *MockInterface) Reserve(ctx context.Context, courseCode string, email primitive.EmailAddress) (Reservation, error) {
+	ret := _m.Called(ctx, courseCode, email)
+
+	var r0 Reservation
+	if rf, ok := ret.Get(0).(func(context.Context, string, primitive.EmailAddress) Reservation); ok {
+		r0 = rf(ctx, courseCode, email)
+	} else {
+		r0 = ret.Get(0).(Reservation)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, primitive.EmailAddress) error); ok {
+		r1 = rf(ctx, courseCode, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ScheduleSessions provides a mock function with given fields: ctx, courseCode, schedule
+func (_m *MockInterface) ScheduleSessions(ctx context.Context, courseCode string, schedule Schedule) (Sessions, error) {
+	ret := _m.Called(ctx, courseCode, schedule)
+
+	var r0 Sessions
+	if rf, ok := ret.Get(0).(func(context.Context, string, Schedule) Sessions); ok {
+		r0 = rf(ctx, courseCode, schedule)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(Sessions)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, Schedule) error); ok {
+		r1 = rf(ctx, courseCode, schedule)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Transfer provides a mock function with given fields: ctx, fromCourseCode, toCourseCode, students
+func (_m *MockInterface) Transfer(ctx context.Context, fromCourseCode string, toCourseCode string, students Students) error {
+	ret := _m.Called(ctx, fromCourseCode, toCourseCode, students)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, Students) error); ok {
+		r0 = rf(ctx, fromCourseCode, toCourseCode, students)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UnassignInstructor provides a mock function with given fields: ctx, courseCode, instructorID
+func (_m *MockInterface) UnassignInstructor(ctx context.Context, courseCode string, instructorID int64) error {
+	ret := _m.Called(ctx, courseCode, instructorID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) error); ok {
+		r0 = rf(ctx, courseCode, instructorID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Unenroll provides a mock function with given fields: ctx, courseCode, students
+func (_m *MockInterface) Unenroll(ctx context.Context, courseCode string, students Students) error {
+	ret := _m.Called(ctx, courseCode, students)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, Students) error); ok {
+		r0 = rf(ctx, courseCode, students)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ValidateEnrollmentRule provides a mock function with given fields: ctx, rule
+func (_m *MockInterface) ValidateEnrollmentRule(ctx context.Context, rule string) error {
+	ret := _m.Called(ctx, rule)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, rule)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// VerifyCertificate provides a mock function with given fields: ctx, code
+func (_m *MockInterface) VerifyCertificate(ctx context.Context, code string) (Certificate, error) {
+	ret := _m.Called(ctx, code)
+
+	var r0 Certificate
+	if rf, ok := ret.Get(0).(func(context.Context, string) Certificate); ok {
+		r0 = rf(ctx, code)
+	} else {
+		r0 = ret.Get(0).(Certificate)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewMockInterface creates a new instance of MockInterface. It also registers the testing.TB interface on the mock and a cleanup function to assert the mocks expectations.
 func NewMockInterface(t testing.TB) *MockInterface {
 	mock := &MockInterface{}
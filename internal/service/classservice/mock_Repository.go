@@ -9,6 +9,8 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	testing "testing"
+
+	time "time"
 )
 
 // MockRepository is an autogenerated mock type for the Repository type
@@ -16,6 +18,155 @@ type MockRepository struct {
 	mock.Mock
 }
 
+// ApproveEnrollment provides a mock function with given fields: ctx, c, sec, s
+func (_m *MockRepository) ApproveEnrollment(ctx context.Context, c Course, sec Section, s Student) (Class, error) {
+	ret := _m.Called(ctx, c, sec, s)
+
+	var r0 Class
+	if rf, ok := ret.Get(0).(func(context.Context, Course, Section, Student) Class); ok {
+		r0 = rf(ctx, c, sec, s)
+	} else {
+		r0 = ret.Get(0).(Class)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, Course, Section, Student) error); ok {
+		r1 = rf(ctx, c, sec, s)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AssignInstructor provides a mock function with given fields: ctx, c, i
+func (_m *MockRepository) AssignInstructor(ctx context.Context, c Course, i Instructor) (Class, error) {
+	ret := _m.Called(ctx, c, i)
+
+	var r0 Class
+	if rf, ok := ret.Get(0).(func(context.Context, Course, Instructor) Class); ok {
+		r0 = rf(ctx, c, i)
+	} else {
+		r0 = ret.Get(0).(Class)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, Course, Instructor) error); ok {
+		r1 = rf(ctx, c, i)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AwaitPayment provides a mock function with given fields: ctx, c, sec, s
+func (_m *MockRepository) AwaitPayment(ctx context.Context, c Course, sec Section, s Student) (Class, error) {
+	ret := _m.Called(ctx, c, sec, s)
+
+	var r0 Class
+	if rf, ok := ret.Get(0).(func(context.Context, Course, Section, Student) Class); ok {
+		r0 = rf(ctx, c, sec, s)
+	} else {
+		r0 = ret.Get(0).(Class)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, Course, Section, Student) error); ok {
+		r1 = rf(ctx, c, sec, s)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CancelCourse provides a mock function with given fields: ctx, c, t
+func (_m *MockRepository) CancelCourse(ctx context.Context, c Course, t time.Time) (Class, error) {
+	ret := _m.Called(ctx, c, t)
+
+	var r0 Class
+	if rf, ok := ret.Get(0).(func(context.Context, Course, time.Time) Class); ok {
+		r0 = rf(ctx, c, t)
+	} else {
+		r0 = ret.Get(0).(Class)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, Course, time.Time) error); ok {
+		r1 = rf(ctx, c, t)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CompleteEnrollment provides a mock function with given fields: ctx, c, s, grade, t
+func (_m *MockRepository) CompleteEnrollment(ctx context.Context, c Course, s Student, grade string, t time.Time) (Class, error) {
+	ret := _m.Called(ctx, c, s, grade, t)
+
+	var r0 Class
+	if rf, ok := ret.Get(0).(func(context.Context, Course, Student, string, time.Time) Class); ok {
+		r0 = rf(ctx, c, s, grade, t)
+	} else {
+		r0 = ret.Get(0).(Class)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, Course, Student, string, time.Time) error); ok {
+		r1 = rf(ctx, c, s, grade, t)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateSessions provides a mock function with given fields: ctx, c, startTimes
+func (_m *MockRepository) CreateSessions(ctx context.Context, c Course, startTimes []time.Time) (Sessions, error) {
+	ret := _m.Called(ctx, c, startTimes)
+
+	var r0 Sessions
+	if rf, ok := ret.Get(0).(func(context.Context, Course, []time.Time) Sessions); ok {
+		r0 = rf(ctx, c, startTimes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(Sessions)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, Course, []time.Time) error); ok {
+		r1 = rf(ctx, c, startTimes)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateVoucher provides a mock function with given fields: ctx, v
+func (_m *MockRepository) CreateVoucher(ctx context.Context, v Voucher) (Voucher, error) {
+	ret := _m.Called(ctx, v)
+
+	var r0 Voucher
+	if rf, ok := ret.Get(0).(func(context.Context, Voucher) Voucher); ok {
+		r0 = rf(ctx, v)
+	} else {
+		r0 = ret.Get(0).(Voucher)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, Voucher) error); ok {
+		r1 = rf(ctx, v)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // EnrollStudents provides a mock function with given fields: ctx, c, s
 func (_m *MockRepository) EnrollStudents(ctx context.Context, c Course, s Students) (Class, error) {
 	ret := _m.Called(ctx, c, s)
@@ -37,6 +188,69 @@ func (_m *MockRepository) EnrollStudents(ctx context.Context, c Course, s Studen
 	return r0, r1
 }
 
+// EnrollStudentsAwaitingPayment provides a mock function with given fields: ctx, c, sec, s
+func (_m *MockRepository) EnrollStudentsAwaitingPayment(ctx context.Context, c Course, sec Section, s Students) (Class, error) {
+	ret := _m.Called(ctx, c, sec, s)
+
+	var r0 Class
+	if rf, ok := ret.Get(0).(func(context.Context, Course, Section, Students) Class); ok {
+		r0 = rf(ctx, c, sec, s)
+	} else {
+		r0 = ret.Get(0).(Class)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, Course, Section, Students) error); ok {
+		r1 = rf(ctx, c, sec, s)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EnrollStudentsInSection provides a mock function with given fields: ctx, c, sec, s
+func (_m *MockRepository) EnrollStudentsInSection(ctx context.Context, c Course, sec Section, s Students) (Class, error) {
+	ret := _m.Called(ctx, c, sec, s)
+
+	var r0 Class
+	if rf, ok := ret.Get(0).(func(context.Context, Course, Section, Students) Class); ok {
+		r0 = rf(ctx, c, sec, s)
+	} else {
+		r0 = ret.Get(0).(Class)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, Course, Section, Students) error); ok {
+		r1 = rf(ctx, c, sec, s)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCertificateByCode provides a mock function with given fields: ctx, code
+func (_m *MockRepository) GetCertificateByCode(ctx context.Context, code string) (Certificate, error) {
+	ret := _m.Called(ctx, code)
+
+	var r0 Certificate
+	if rf, ok := ret.Get(0).(func(context.Context, string) Certificate); ok {
+		r0 = rf(ctx, code)
+	} else {
+		r0 = ret.Get(0).(Certificate)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetClassByCourseCode provides a mock function with given fields: ctx, courseCode
 func (_m *MockRepository) GetClassByCourseCode(ctx context.Context, courseCode string) (Class, error) {
 	ret := _m.Called(ctx, courseCode)
@@ -58,6 +272,184 @@ func (_m *MockRepository) GetClassByCourseCode(ctx context.Context, courseCode s
 	return r0, r1
 }
 
+// GetClassForUpdate provides a mock function with given fields: ctx, courseCode
+func (_m *MockRepository) GetClassForUpdate(ctx context.Context, courseCode string) (Class, error) {
+	ret := _m.Called(ctx, courseCode)
+
+	var r0 Class
+	if rf, ok := ret.Get(0).(func(context.Context, string) Class); ok {
+		r0 = rf(ctx, courseCode)
+	} else {
+		r0 = ret.Get(0).(Class)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, courseCode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetClasses provides a mock function with given fields: ctx
+func (_m *MockRepository) GetClasses(ctx context.Context) ([]Class, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []Class
+	if rf, ok := ret.Get(0).(func(context.Context) []Class); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Class)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetClassesTaughtBy provides a mock function with given fields: ctx, i
+func (_m *MockRepository) GetClassesTaughtBy(ctx context.Context, i Instructor) ([]Class, error) {
+	ret := _m.Called(ctx, i)
+
+	var r0 []Class
+	if rf, ok := ret.Get(0).(func(context.Context, Instructor) []Class); ok {
+		r0 = rf(ctx, i)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Class)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, Instructor) error); ok {
+		r1 = rf(ctx, i)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCompletions provides a mock function with given fields: ctx, s
+func (_m *MockRepository) GetCompletions(ctx context.Context, s Students) (StudentCompletions, error) {
+	ret := _m.Called(ctx, s)
+
+	var r0 StudentCompletions
+	if rf, ok := ret.Get(0).(func(context.Context, Students) StudentCompletions); ok {
+		r0 = rf(ctx, s)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(StudentCompletions)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, Students) error); ok {
+		r1 = rf(ctx, s)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCourseLoads provides a mock function with given fields: ctx, s
+func (_m *MockRepository) GetCourseLoads(ctx context.Context, s Students) (CourseLoads, error) {
+	ret := _m.Called(ctx, s)
+
+	var r0 CourseLoads
+	if rf, ok := ret.Get(0).(func(context.Context, Students) CourseLoads); ok {
+		r0 = rf(ctx, s)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(CourseLoads)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, Students) error); ok {
+		r1 = rf(ctx, s)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetEnrollments provides a mock function with given fields: ctx, s
+func (_m *MockRepository) GetEnrollments(ctx context.Context, s Students) (StudentEnrollments, error) {
+	ret := _m.Called(ctx, s)
+
+	var r0 StudentEnrollments
+	if rf, ok := ret.Get(0).(func(context.Context, Students) StudentEnrollments); ok {
+		r0 = rf(ctx, s)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(StudentEnrollments)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, Students) error); ok {
+		r1 = rf(ctx, s)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetInstructor provides a mock function with given fields: ctx, id
+func (_m *MockRepository) GetInstructor(ctx context.Context, id int64) (Instructor, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 Instructor
+	if rf, ok := ret.Get(0).(func(context.Context, int64) Instructor); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(Instructor)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetInvoice provides a mock function with given fields: ctx, id
+func (_m *MockRepository) GetInvoice(ctx context.Context, id string) (Invoice, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 Invoice
+	if rf, ok := ret.Get(0).(func(context.Context, string) Invoice); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(Invoice)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetStudentsByEmail provides a mock function with given fields: ctx, emails
 func (_m *MockRepository) GetStudentsByEmail(ctx context.Context, emails []primitive.EmailAddress) (Students, error) {
 	ret := _m.Called(ctx, emails)
@@ -81,6 +473,302 @@ func (_m *MockRepository) GetStudentsByEmail(ctx context.Context, emails []primi
 	return r0, r1
 }
 
+// GetVoucherByCode provides a mock function with given fields: ctx, code
+func (_m *MockRepository) GetVoucherByCode(ctx context.Context, code string) (Voucher, error) {
+	ret := _m.Called(ctx, code)
+
+	var r0 Voucher
+	if rf, ok := ret.Get(0).(func(context.Context, string) Voucher); ok {
+		r0 = rf(ctx, code)
+	} else {
+		r0 = ret.Get(0).(Voucher)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IssueCertificate provides a mock function with given fields: ctx, s, c, code, t
+func (_m *MockRepository) IssueCertificate(ctx context.Context, s Student, c Completion, code string, t time.Time) (Certificate, error) {
+	ret := _m.Called(ctx, s, c, code, t)
+
+	var r0 Certificate
+	if rf, ok := ret.Get(0).(func(context.Context, Student, Completion, string, time.Time) Certificate); ok {
+		r0 = rf(ctx, s, c, code, t)
+	} else {
+		r0 = ret.Get(0).(Certificate)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, Student, Completion, string, time.Time) error); ok {
+		r1 = rf(ctx, s, c, code, t)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkInvoicePaid provides a mock function with given fields: ctx, c, inv, t
+func (_m *MockRepository) MarkInvoicePaid(ctx context.Context, c Course, inv Invoice, t time.Time) (Class, error) {
+	ret := _m.Called(ctx, c, inv, t)
+
+	var r0 Class
+	if rf, ok := ret.Get(0).(func(context.Context, Course, Invoice, time.Time) Class); ok {
+		r0 = rf(ctx, c, inv, t)
+	} else {
+		r0 = ret.Get(0).(Class)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, Course, Invoice, time.Time) error); ok {
+		r1 = rf(ctx, c, inv, t)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecordAttendance provides a mock function with given fields: ctx, c, records, t
+func (_m *MockRepository) RecordAttendance(ctx context.Context, c Course, records []AttendanceRecord, t time.Time) (Class, error) {
+	ret := _m.Called(ctx, c, records, t)
+
+	var r0 Class
+	if rf, ok := ret.Get(0).(func(context.Context, Course, []AttendanceRecord, time.Time) Class); ok {
+		r0 = rf(ctx, c, records, t)
+	} else {
+		r0 = ret.Get(0).(Class)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, Course, []AttendanceRecord, time.Time) error); ok {
+		r1 = rf(ctx, c, records, t)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RedeemVoucher provides a mock function with given fields: ctx, v, uses
+func (_m *MockRepository) RedeemVoucher(ctx context.Context, v Voucher, uses uint32) (Voucher, error) {
+	ret := _m.Called(ctx, v, uses)
+
+	var r0 Voucher
+	if rf, ok := ret.Get(0).(func(context.Context, Voucher, uint32) Voucher); ok {
+		r0 = rf(ctx, v, uses)
+	} else {
+		r0 = ret.Get(0).(Voucher)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, Voucher, uint32) error); ok {
+		r1 = rf(ctx, v, uses)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RejectEnrollment provides a mock function with given fields: ctx, c, s
+func (_m *MockRepository) RejectEnrollment(ctx context.Context, c Course, s Student) (Class, error) {
+	ret := _m.Called(ctx, c, s)
+
+	var r0 Class
+	if rf, ok := ret.Get(0).(func(context.Context, Course, Student) Class); ok {
+		r0 = rf(ctx, c, s)
+	} else {
+		r0 = ret.Get(0).(Class)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, Course, Student) error); ok {
+		r1 = rf(ctx, c, s)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecordVoucherRedemptions provides a mock function with given fields: ctx, v, s
+func (_m *MockRepository) RecordVoucherRedemptions(ctx context.Context, v Voucher, s Students) error {
+	ret := _m.Called(ctx, v, s)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, Voucher, Students) error); ok {
+		r0 = rf(ctx, v, s)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReleaseExpiredReservations provides a mock function with given fields: ctx, t
+func (_m *MockRepository) ReleaseExpiredReservations(ctx context.Context, t time.Time) (int, []string, error) {
+	ret := _m.Called(ctx, t)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int); ok {
+		r0 = rf(ctx, t)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 []string
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) []string); ok {
+		r1 = rf(ctx, t)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]string)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, time.Time) error); ok {
+		r2 = rf(ctx, t)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ReleaseReservations provides a mock function with given fields: ctx, c, s
+func (_m *MockRepository) ReleaseReservations(ctx context.Context, c Course, s Students) error {
+	ret := _m.Called(ctx, c, s)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, Course, Students) error); ok {
+		r0 = rf(ctx, c, s)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RecordRequest provides a mock function with given fields: ctx, requestID, t
+func (_m *MockRepository) RecordRequest(ctx context.Context, requestID string, t time.Time) error {
+	ret := _m.Called(ctx, requestID, t)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = rf(ctx, requestID, t)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RequestEnrollment provides a mock function with given fields: ctx, c, sec, s
+func (_m *MockRepository) RequestEnrollment(ctx context.Context, c Course, sec Section, s Students) (Class, error) {
+	ret := _m.Called(ctx, c, sec, s)
+
+	var r0 Class
+	if rf, ok := ret.Get(0).(func(context.Context, Course, Section, Students) Class); ok {
+		r0 = rf(ctx, c, sec, s)
+	} else {
+		r0 = ret.Get(0).(Class)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, Course, Section, Students) error); ok {
+		r1 = rf(ctx, c, sec, s)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Reserve provides a mock function with given fields: ctx, c, s, expiresAt
+func (_m *MockRepository) Reserve(ctx context.Context, c Course, s Student, expiresAt time.Time) (Reservation, error) {
+	ret := _m.Called(ctx, c, s, expiresAt)
+
+	var r0 Reservation
+	if rf, ok := ret.Get(0).(func(context.Context, Course, Student, time.Time) Reservation); ok {
+		r0 = rf(ctx, c, s, expiresAt)
+	} else {
+		r0 = ret.Get(0).(Reservation)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, Course, Student, time.Time) error); ok {
+		r1 = rf(ctx, c, s, expiresAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveInvoice provides a mock function with given fields: ctx, c, inv
+func (_m *MockRepository) SaveInvoice(ctx context.Context, c Course, inv Invoice) error {
+	ret := _m.Called(ctx, c, inv)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, Course, Invoice) error); ok {
+		r0 = rf(ctx, c, inv)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UnassignInstructor provides a mock function with given fields: ctx, c, i
+func (_m *MockRepository) UnassignInstructor(ctx context.Context, c Course, i Instructor) (Class, error) {
+	ret := _m.Called(ctx, c, i)
+
+	var r0 Class
+	if rf, ok := ret.Get(0).(func(context.Context, Course, Instructor) Class); ok {
+		r0 = rf(ctx, c, i)
+	} else {
+		r0 = ret.Get(0).(Class)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, Course, Instructor) error); ok {
+		r1 = rf(ctx, c, i)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UnenrollStudents provides a mock function with given fields: ctx, c, s
+func (_m *MockRepository) UnenrollStudents(ctx context.Context, c Course, s Students) (Class, error) {
+	ret := _m.Called(ctx, c, s)
+
+	var r0 Class
+	if rf, ok := ret.Get(0).(func(context.Context, Course, Students) Class); ok {
+		r0 = rf(ctx, c, s)
+	} else {
+		r0 = ret.Get(0).(Class)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, Course, Students) error); ok {
+		r1 = rf(ctx, c, s)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewMockRepository creates a new instance of MockRepository. It also registers the testing.TB interface on the mock and a cleanup function to assert the mocks expectations.
 func NewMockRepository(t testing.TB) *MockRepository {
 	mock := &MockRepository{}
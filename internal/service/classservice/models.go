@@ -2,17 +2,125 @@ package classservice
 
 import (
 	"strings"
+	"time"
 
 	"github.com/angusgmorrison/hexagonal/internal/primitive"
+	"github.com/angusgmorrison/hexagonal/pkg/slice"
 )
 
 // Course represents the service's understanding of a course. Note that although
 // there are more columns in the course database table, these don't feature in
 // the service's model because the service has no need to know about them.
+//
+// Enrollment in a course that RequiresApproval is pending until approved. A
+// Cancelled course accepts no further enrollments. Students must have passed
+// each of the courses whose codes are listed in Prerequisites before they can
+// enroll. Students enrolling in a course with a non-zero Fee are invoiced, and
+// their enrollment awaits payment.
 type Course struct {
-	ID       int64
-	Code     string
-	Capacity uint32
+	ID               int64
+	Code             string
+	Capacity         uint32
+	RequiresApproval bool
+	Cancelled        bool
+	Fee              primitive.Money
+	Prerequisites    []string
+	Sections         Sections
+}
+
+// Sections is a convenience wrapper.
+type Sections []Section
+
+// pendingFor returns the section that the pending student asked to join, and
+// false if the student named no section.
+func (s Sections) pendingFor(student Student) (Section, bool) {
+	for _, section := range s {
+		for _, pending := range section.Pending {
+			if pending.ID == student.ID {
+				return section, true
+			}
+		}
+	}
+
+	return Section{}, false
+}
+
+// awaitingPaymentFor returns the section in which the student awaiting
+// payment has been placed, and false if the course has no sections.
+func (s Sections) awaitingPaymentFor(student Student) (Section, bool) {
+	for _, section := range s {
+		for _, unpaid := range section.AwaitingPayment {
+			if unpaid.ID == student.ID {
+				return section, true
+			}
+		}
+	}
+
+	return Section{}, false
+}
+
+// ByCode returns the section with the given code, and false if no such section
+// exists.
+func (s Sections) ByCode(code string) (Section, bool) {
+	for _, section := range s {
+		if section.Code == code {
+			return section, true
+		}
+	}
+
+	return Section{}, false
+}
+
+func (s Sections) availableSpaces() uint32 {
+	var spaces uint32
+
+	for _, section := range s {
+		spaces += section.availableSpaces()
+	}
+
+	return spaces
+}
+
+// spacesByCode returns the number of spaces available in each section, keyed
+// by section code.
+func (s Sections) spacesByCode() map[string]uint32 {
+	if len(s) == 0 {
+		return nil
+	}
+
+	spaces := make(map[string]uint32, len(s))
+
+	for _, section := range s {
+		spaces[section.Code] = section.availableSpaces()
+	}
+
+	return spaces
+}
+
+// Section represents a subdivision of a course with its own capacity and
+// enrolled students. Every student enrolled in a sectioned course belongs to
+// exactly one of its sections.
+//
+// Pending holds the students awaiting approval who asked to join this section
+// specifically. Pending students who named no section are assigned one on
+// approval. AwaitingPayment holds the students placed in this section whose
+// enrollment hasn't yet been paid for. Like enrolled Students, they occupy a
+// place in the section.
+type Section struct {
+	ID              int64
+	Code            string
+	Capacity        uint32
+	Students        Students
+	Pending         Students
+	AwaitingPayment Students
+}
+
+func (s Section) availableSpaces() uint32 {
+	if enrolled := uint32(len(s.Students) + len(s.AwaitingPayment)); enrolled < s.Capacity {
+		return s.Capacity - enrolled
+	}
+
+	return 0
 }
 
 // Students is a convenience wrapper.
@@ -38,6 +146,41 @@ func (s Students) EmailAddresses() []primitive.EmailAddress {
 	return emails
 }
 
+// ByEmail returns the student with the given email address, and false if no
+// such student exists.
+func (s Students) ByEmail(email primitive.EmailAddress) (Student, bool) {
+	for _, student := range s {
+		if student.Email == email {
+			return student, true
+		}
+	}
+
+	return Student{}, false
+}
+
+// resolve returns the students with each replaced by the matching registered
+// student, if any. Matching is performed by email address. Students with no
+// registered match are returned as they are.
+func (s Students) resolve(registered Students) Students {
+	registeredByEmail := make(map[primitive.EmailAddress]Student, len(registered))
+
+	for _, student := range registered {
+		registeredByEmail[student.Email] = student
+	}
+
+	resolved := make(Students, 0, len(s))
+
+	for _, student := range s {
+		if registeredStudent, ok := registeredByEmail[student.Email]; ok {
+			student = registeredStudent
+		}
+
+		resolved = append(resolved, student)
+	}
+
+	return resolved
+}
+
 // String returns a comma-separated list of student email addresses. Satisfies
 // fmt.Stringer.
 func (s Students) String() string {
@@ -55,31 +198,369 @@ func (s Students) String() string {
 }
 
 // Student represents a student.
+//
+// MaxCourseLoad overrides the service's default limit on the number of courses
+// the student may be enrolled in at once. Zero means no override.
 type Student struct {
+	ID            int64
+	Name          string
+	Birthdate     primitive.Birthdate
+	Email         primitive.EmailAddress
+	MaxCourseLoad uint32
+}
+
+// Instructors is a convenience wrapper.
+type Instructors []Instructor
+
+// ByID returns the instructor with the given ID, and false if no such
+// instructor exists.
+func (i Instructors) ByID(id int64) (Instructor, bool) {
+	for _, instructor := range i {
+		if instructor.ID == id {
+			return instructor, true
+		}
+	}
+
+	return Instructor{}, false
+}
+
+// Instructor represents a member of staff who teaches classes.
+type Instructor struct {
+	ID    int64
+	Name  string
+	Email primitive.EmailAddress
+}
+
+// CourseLoads maps student IDs to the number of courses each student is
+// enrolled in, including those awaiting payment.
+type CourseLoads map[int64]uint32
+
+// Reservation holds a place in a class for a student until it expires or the
+// student enrolls.
+type Reservation struct {
 	ID        int64
-	Name      string
-	Birthdate primitive.Birthdate
-	Email     primitive.EmailAddress
+	StudentID int64
+	ExpiresAt time.Time
+}
+
+// Reservations is a convenience wrapper.
+type Reservations []Reservation
+
+// activeAt returns the reservations that have not expired at time t.
+func (r Reservations) activeAt(t time.Time) Reservations {
+	return slice.Filter(r, func(reservation Reservation) bool {
+		return reservation.ExpiresAt.After(t)
+	})
+}
+
+// heldBy returns the reservations held by any of the given students.
+func (r Reservations) heldBy(s Students) Reservations {
+	ids := slice.ToSet(s.IDs())
+
+	return slice.Filter(r, func(reservation Reservation) bool {
+		return ids[reservation.StudentID]
+	})
+}
+
+// Session is a scheduled meeting of a class.
+type Session struct {
+	ID       int64
+	StartsAt time.Time
+}
+
+// Sessions is a convenience wrapper.
+type Sessions []Session
+
+// ByID returns the session with the given ID, and false if no such session
+// exists.
+func (s Sessions) ByID(id int64) (Session, bool) {
+	for _, session := range s {
+		if session.ID == id {
+			return session, true
+		}
+	}
+
+	return Session{}, false
+}
+
+// Limits on the sessions created at once, so that a single request can't
+// create an unbounded number of them.
+const (
+	// MaxSessions is the number of sessions that may be created at once.
+	MaxSessions = 1000
+
+	// MaxScheduleSpan is the longest period that a Schedule may cover.
+	MaxScheduleSpan = 366 * 24 * time.Hour
+)
+
+// Schedule describes sessions that recur weekly. A session starts at the time
+// of day of First on each of the given Weekdays, from the date of First until
+// Until, inclusive. Until may be no more than MaxScheduleSpan after First.
+type Schedule struct {
+	First    time.Time      `validate:"required"`
+	Until    time.Time      `validate:"required,gtefield=First"`
+	Weekdays []time.Weekday `validate:"min=1,dive,gte=0,lte=6"`
+}
+
+// StartTimes returns the start time of every session described by the
+// schedule, in chronological order. It visits every day of the schedule, so
+// callers must check that the schedule doesn't exceed MaxScheduleSpan.
+func (s Schedule) StartTimes() []time.Time {
+	weekdays := slice.ToSet(s.Weekdays)
+
+	var starts []time.Time
+
+	for day := s.First; !day.After(s.Until); day = day.AddDate(0, 0, 1) {
+		if weekdays[day.Weekday()] {
+			starts = append(starts, day)
+		}
+	}
+
+	return starts
+}
+
+// AttendanceRecord records whether a student was present at a session.
+type AttendanceRecord struct {
+	SessionID int64 `validate:"gt=0"`
+	Student   Student
+	Present   bool
+}
+
+// Attendance is the number of sessions of a class for which a student's
+// attendance was recorded, and the number of those they attended.
+type Attendance struct {
+	Attended uint32
+	Recorded uint32
 }
 
+// Percentage returns the percentage of recorded sessions that the student
+// attended, and false if no attendance has been recorded.
+func (a Attendance) Percentage() (float64, bool) {
+	if a.Recorded == 0 {
+		return 0, false
+	}
+
+	return 100 * float64(a.Attended) / float64(a.Recorded), true
+}
+
+// StudentAttendance maps student IDs to their attendance of a class.
+type StudentAttendance map[int64]Attendance
+
 // Class represents a course and its enrolled students. Note that the existence
 // of a database join table between classes and students is invisible. Their
 // relationship is described entirely by their colocation in the Class struct.
+//
+// Pending holds the students whose enrollment is awaiting approval. Pending
+// students don't occupy a place in the class.
+//
+// Reservations hold places in the class for students who have yet to enroll.
+//
+// Instructors are the staff assigned to teach the class.
+//
+// Sessions are the scheduled meetings of the class, and Attendance summarizes
+// the attendance of each enrolled student at those sessions.
+//
+// AwaitingPayment holds the students who have been invoiced for the course's
+// fee but have yet to pay. They occupy a place in the class, and become
+// enrolled Students once their payment is confirmed.
 type Class struct {
 	Course
 	Students
+	Pending         Students
+	AwaitingPayment Students
+	Reservations    Reservations
+	Instructors     Instructors
+	Sessions        Sessions
+	Attendance      StudentAttendance
+}
+
+// RosterStatus describes why a student is listed on a class roster.
+type RosterStatus string
+
+const (
+	RosterEnrolled        RosterStatus = "enrolled"
+	RosterPending         RosterStatus = "pending"
+	RosterAwaitingPayment RosterStatus = "awaiting_payment"
+)
+
+// RosterEntry lists a student on a class roster. SectionCode is empty if the
+// course has no sections, or if a pending student named no section.
+type RosterEntry struct {
+	Student
+	Status      RosterStatus
+	SectionCode string
+	Attendance  Attendance
 }
 
+// Roster lists the students enrolled in the class, followed by those pending
+// approval and those awaiting payment.
+func (c Class) Roster() []RosterEntry {
+	sectionCodes := make(map[int64]string)
+
+	for _, section := range c.Sections {
+		for _, students := range []Students{section.Students, section.Pending, section.AwaitingPayment} {
+			for _, student := range students {
+				sectionCodes[student.ID] = section.Code
+			}
+		}
+	}
+
+	roster := make([]RosterEntry, 0, len(c.Students)+len(c.Pending)+len(c.AwaitingPayment))
+
+	for _, group := range []struct {
+		status   RosterStatus
+		students Students
+	}{
+		{status: RosterEnrolled, students: c.Students},
+		{status: RosterPending, students: c.Pending},
+		{status: RosterAwaitingPayment, students: c.AwaitingPayment},
+	} {
+		for _, student := range group.students {
+			roster = append(roster, RosterEntry{
+				Student:     student,
+				Status:      group.status,
+				SectionCode: sectionCodes[student.ID],
+				Attendance:  c.Attendance[student.ID],
+			})
+		}
+	}
+
+	return roster
+}
+
+// hasCapacityFor reports whether the students can be enrolled in the class,
+// counting any places reserved for them as available.
 func (c Class) hasCapacityFor(s Students) bool {
-	return c.availableSpaces() >= uint32(len(s))
+	return c.availableSpacesFor(s) >= uint32(len(s))
+}
+
+// AvailableSpaces returns the number of students that can still be enrolled in
+// the class, excluding places held by reservations. For sectioned courses, this
+// is the total of the spaces available in each section. Cancelled classes have
+// no available spaces.
+func (c Class) AvailableSpaces() uint32 {
+	if c.Cancelled {
+		return 0
+	}
+
+	var spaces uint32
+	if len(c.Sections) > 0 {
+		spaces = c.Sections.availableSpaces()
+	} else if enrolled := uint32(len(c.Students) + len(c.AwaitingPayment)); enrolled < c.Course.Capacity {
+		// A class may be over capacity if its capacity was reduced after
+		// students enrolled.
+		spaces = c.Course.Capacity - enrolled
+	}
+
+	if reserved := uint32(len(c.Reservations)); reserved < spaces {
+		return spaces - reserved
+	}
+
+	return 0
 }
 
-func (c Class) availableSpaces() uint32 {
-	return c.Course.Capacity - uint32(len(c.Students))
+// availableSpacesFor returns the number of places in the class available to
+// the given students, which includes any places they have reserved.
+func (c Class) availableSpacesFor(s Students) uint32 {
+	return c.AvailableSpaces() + uint32(len(c.Reservations.heldBy(s)))
+}
+
+// assignSections distributes students between the class's sections. If
+// sectionCode is non-empty, all students are assigned to that section.
+// Otherwise, each student in turn is assigned to the least-full section with
+// space available.
+//
+// The returned sections contain only the students assigned to them, in the
+// order the sections appear in the class.
+func (c Class) assignSections(sectionCode string, students Students) (Sections, error) {
+	if sectionCode != "" {
+		section, ok := c.Sections.ByCode(sectionCode)
+		if !ok {
+			return nil, SectionNotFoundError{CourseCode: c.Code, SectionCode: sectionCode}
+		}
+
+		if section.availableSpaces() < uint32(len(students)) {
+			return nil, OversubscribedError{
+				CourseCode:             c.Code,
+				SectionCode:            sectionCode,
+				AvailableSpaces:        section.availableSpaces(),
+				AvailableSectionSpaces: c.Sections.spacesByCode(),
+				AttemptedEnrollments:   uint32(len(students)),
+			}
+		}
+
+		section.Students = students
+
+		return Sections{section}, nil
+	}
+
+	if !c.hasCapacityFor(students) {
+		return nil, c.oversubscribedError(students)
+	}
+
+	enrolled := make([]int, len(c.Sections))
+	assigned := make([]Students, len(c.Sections))
+
+	for i, section := range c.Sections {
+		enrolled[i] = len(section.Students) + len(section.AwaitingPayment)
+	}
+
+	for _, student := range students {
+		leastFull := -1
+
+		for i, section := range c.Sections {
+			if enrolled[i] >= int(section.Capacity) {
+				continue
+			}
+
+			if leastFull == -1 || enrolled[i] < enrolled[leastFull] {
+				leastFull = i
+			}
+		}
+
+		enrolled[leastFull]++
+		assigned[leastFull] = append(assigned[leastFull], student)
+	}
+
+	assignments := make(Sections, 0, len(c.Sections))
+
+	for i, section := range c.Sections {
+		if len(assigned[i]) == 0 {
+			continue
+		}
+
+		section.Students = assigned[i]
+		assignments = append(assignments, section)
+	}
+
+	return assignments, nil
+}
+
+func (c Class) oversubscribedError(students Students) OversubscribedError {
+	return OversubscribedError{
+		CourseCode:             c.Code,
+		AvailableSpaces:        c.availableSpacesFor(students),
+		AvailableSectionSpaces: c.Sections.spacesByCode(),
+		AttemptedEnrollments:   uint32(len(students)),
+	}
 }
 
 // EnrollmentRequest represents a batch of students to be enrolled in a course.
+//
+// SectionCode is optional. If the course is divided into sections and no
+// section is specified, students are assigned automatically to the least-full
+// sections.
+//
+// VoucherCode is optional. If given, the voucher is redeemed by each of the
+// students.
+//
+// RequestID is optional. If given, it's recorded with the enrollment, so that a
+// request that is retried after it succeeded fails with DuplicateRequestError
+// rather than being carried out twice.
 type EnrollmentRequest struct {
-	CourseCode string   `validate:"required"`
-	Students   Students `validate:"min=1"`
+	RequestID   string `validate:"max=255"`
+	CourseCode  string `validate:"required"`
+	SectionCode string
+	VoucherCode string
+	Students    Students `validate:"min=1"`
 }
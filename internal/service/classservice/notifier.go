@@ -0,0 +1,74 @@
+package classservice
+
+import (
+	"context"
+)
+
+// NotificationKind identifies the event that a Notification describes.
+type NotificationKind string
+
+// The events about which students are notified.
+const (
+	// NotificationEnrolled confirms a student's enrollment in a course.
+	NotificationEnrolled NotificationKind = "enrolled"
+
+	// NotificationUnenrolled tells a student that they are no longer enrolled
+	// in a course, e.g. because they were transferred out of it.
+	NotificationUnenrolled NotificationKind = "unenrolled"
+
+	// NotificationCourseCancelled tells a student that a course they were
+	// enrolled in, or awaiting approval to join, has been cancelled.
+	NotificationCourseCancelled NotificationKind = "course_cancelled"
+)
+
+// Notification describes an event of interest to a student. SectionCode is
+// populated when the student was enrolled in a specific section.
+type Notification struct {
+	Kind        NotificationKind
+	CourseCode  string
+	SectionCode string
+	Student     Student
+}
+
+// Notifier delivers Notifications to students. Notifiers are called only once
+// the atomic operation that gave rise to the notification has been committed,
+// so that students are never told of changes that were rolled back. An error
+// returned by Notify can't undo the operation, and is logged.
+type Notifier interface {
+	Notify(ctx context.Context, n Notification) error
+}
+
+// nopNotifier discards Notifications. It is used when the service has no
+// Notifier configured.
+type nopNotifier struct{}
+
+func (nopNotifier) Notify(context.Context, Notification) error {
+	return nil
+}
+
+// notifications collects the Notifications raised by an atomic operation, to
+// be delivered once the operation has been committed.
+type notifications []Notification
+
+// add queues a Notification of the given kind for each of the students.
+func (n *notifications) add(kind NotificationKind, courseCode, sectionCode string, students Students) {
+	for _, student := range students {
+		*n = append(*n, Notification{
+			Kind:        kind,
+			CourseCode:  courseCode,
+			SectionCode: sectionCode,
+			Student:     student,
+		})
+	}
+}
+
+// deliver sends each of the pending notifications, logging those that fail so
+// that the rest are still sent.
+func (svc *classService) deliver(ctx context.Context, pending notifications) {
+	for _, notification := range pending {
+		if err := svc.notifier.Notify(ctx, notification); err != nil {
+			svc.logger.Printf("Failed to notify %s that they were %s for %s: %v",
+				notification.Student.Email, notification.Kind, notification.CourseCode, err)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package classservice
+
+import "time"
+
+// Option configures optional behaviour of the service returned by New.
+type Option func(*classService)
+
+// WithDefaultMaxCourseLoad limits the number of courses a student may be
+// actively enrolled in at once. Students with their own MaxCourseLoad are
+// subject to that limit instead. A limit of zero, the default, means that
+// students may enroll in any number of courses.
+func WithDefaultMaxCourseLoad(limit uint32) Option {
+	return func(svc *classService) {
+		svc.defaultMaxCourseLoad = limit
+	}
+}
+
+// WithPolicies registers custom EnrollmentPolicies. These are evaluated in the
+// order given, after the service's built-in policies.
+func WithPolicies(policies ...EnrollmentPolicy) Option {
+	return func(svc *classService) {
+		svc.customPolicies = append(svc.customPolicies, policies...)
+	}
+}
+
+// WithRuleSource requires students to satisfy the declarative enrollment rules
+// provided by source.
+func WithRuleSource(source RuleSource) Option {
+	return func(svc *classService) {
+		svc.ruleSource = source
+	}
+}
+
+// WithBilling sets the Billing through which students are invoiced for course
+// fees. Without it, students can't enroll in courses that charge a fee.
+func WithBilling(billing Billing) Option {
+	return func(svc *classService) {
+		svc.billing = billing
+	}
+}
+
+// WithReservationTTL sets the length of time for which a reservation holds a
+// place in a class. The default is ten minutes.
+func WithReservationTTL(ttl time.Duration) Option {
+	return func(svc *classService) {
+		svc.reservationTTL = ttl
+	}
+}
+
+// WithNotifier sets the Notifier through which students are informed of events
+// affecting their enrollments. By default, notifications are discarded.
+func WithNotifier(notifier Notifier) Option {
+	return func(svc *classService) {
+		svc.notifier = notifier
+	}
+}
+
+// WithAvailabilityPublisher sets the AvailabilityPublisher informed of the
+// places remaining in a course whenever its enrollments or reservations
+// change. By default, availability isn't published.
+func WithAvailabilityPublisher(publisher AvailabilityPublisher) Option {
+	return func(svc *classService) {
+		svc.availabilityPublisher = publisher
+	}
+}
+
+// WithGradingScale sets the grades that may be awarded for completing a course.
+// The default is DefaultGradingScale.
+func WithGradingScale(scale GradingScale) Option {
+	return func(svc *classService) {
+		svc.gradingScale = scale
+	}
+}
+
+// WithClock replaces the function used by the service to tell the time.
+func WithClock(now func() time.Time) Option {
+	return func(svc *classService) {
+		svc.now = now
+	}
+}
@@ -0,0 +1,232 @@
+package classservice
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/angusgmorrison/hexagonal/pkg/slice"
+)
+
+// EnrollmentPolicy is a business rule that must be satisfied before students
+// can be enrolled in a class.
+//
+// Policies are evaluated in order against the class and the students
+// attempting to enroll in it. Students are those loaded from the repository,
+// except that any student who isn't registered is represented as requested,
+// with a zero ID. Policies run inside the atomic operation that performs the
+// enrollment, and may use the Repository they're given to load any further
+// data they need.
+//
+// Evaluate must return nil if the enrollment is permitted. Otherwise, the
+// returned error is passed back to the caller of Enroll, and no further
+// policies are evaluated.
+type EnrollmentPolicy interface {
+	Evaluate(ctx context.Context, repo Repository, class Class, students Students) error
+}
+
+// EnrollmentPolicyFunc allows ordinary functions to be used as
+// EnrollmentPolicies.
+type EnrollmentPolicyFunc func(ctx context.Context, repo Repository, class Class, students Students) error
+
+// Evaluate calls f(ctx, repo, class, students).
+func (f EnrollmentPolicyFunc) Evaluate(
+	ctx context.Context,
+	repo Repository,
+	class Class,
+	students Students,
+) error {
+	return f(ctx, repo, class, students)
+}
+
+var _ EnrollmentPolicy = EnrollmentPolicyFunc(nil)
+
+// builtinPolicies returns the policies that apply to every enrollment, in the
+// order in which they're evaluated. Custom policies are evaluated after these.
+//
+// If the service has a RuleSource, the declarative rule of the course is
+// evaluated last of the built-in policies.
+func (svc *classService) builtinPolicies() []EnrollmentPolicy {
+	policies := []EnrollmentPolicy{
+		EnrollmentPolicyFunc(verifyCourseNotCancelled),
+		EnrollmentPolicyFunc(verifyStudentsRegistered),
+		EnrollmentPolicyFunc(verifyStudentsNotAlreadyEnrolled),
+		prerequisitePolicy{scale: svc.gradingScale},
+		courseLoadPolicy{defaultMaxCourseLoad: svc.defaultMaxCourseLoad},
+		EnrollmentPolicyFunc(verifyClassHasCapacity),
+	}
+
+	if svc.ruleSource != nil {
+		policies = append(policies, rulePolicy{source: svc.ruleSource, rules: svc.rules, now: svc.now})
+	}
+
+	return policies
+}
+
+func (svc *classService) evaluatePolicies(
+	ctx context.Context,
+	repo Repository,
+	class Class,
+	students Students,
+) error {
+	for _, policy := range svc.policies {
+		if err := policy.Evaluate(ctx, repo, class, students); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func verifyCourseNotCancelled(
+	_ context.Context,
+	_ Repository,
+	class Class,
+	_ Students,
+) error {
+	if class.Cancelled {
+		return CourseCancelledError{CourseCode: class.Code}
+	}
+
+	return nil
+}
+
+func verifyStudentsRegistered(
+	_ context.Context,
+	_ Repository,
+	_ Class,
+	students Students,
+) error {
+	unregistered := slice.Filter(students, func(student Student) bool {
+		return student.ID == 0
+	})
+
+	if len(unregistered) > 0 {
+		return UnregisteredStudentsError{Students: unregistered}
+	}
+
+	return nil
+}
+
+func verifyStudentsNotAlreadyEnrolled(
+	_ context.Context,
+	_ Repository,
+	class Class,
+	students Students,
+) error {
+	enrolledOrPending := append(class.Students.EmailAddresses(), class.Pending.EmailAddresses()...)
+	enrolledOrPending = append(enrolledOrPending, class.AwaitingPayment.EmailAddresses()...)
+	alreadyEnrolledEmails := slice.Intersection(enrolledOrPending, students.EmailAddresses())
+
+	if len(alreadyEnrolledEmails) > 0 {
+		alreadyEnrolledEmailSet := slice.ToSet(alreadyEnrolledEmails)
+		alreadyEnrolledStudents := slice.Filter(students, func(student Student) bool {
+			return alreadyEnrolledEmailSet[student.Email]
+		})
+
+		return AlreadyEnrolledError{Students: alreadyEnrolledStudents}
+	}
+
+	return nil
+}
+
+// verifyClassHasCapacity checks the capacity of the class as a whole. Where
+// students request a specific section, the capacity of that section is
+// checked when they are assigned to it.
+func verifyClassHasCapacity(
+	_ context.Context,
+	_ Repository,
+	class Class,
+	students Students,
+) error {
+	if !class.hasCapacityFor(students) {
+		return class.oversubscribedError(students)
+	}
+
+	return nil
+}
+
+// courseLoadPolicy checks that no student would exceed their maximum course
+// load by enrolling in the class. Course loads are only loaded from the
+// repository if some limit applies to the students.
+type courseLoadPolicy struct {
+	defaultMaxCourseLoad uint32
+}
+
+func (clp courseLoadPolicy) Evaluate(
+	ctx context.Context,
+	repo Repository,
+	class Class,
+	students Students,
+) error {
+	limited := slice.Filter(students, func(student Student) bool {
+		return clp.maxCourseLoad(student) > 0
+	})
+	if len(limited) == 0 {
+		return nil
+	}
+
+	loads, err := repo.GetCourseLoads(ctx, limited)
+	if err != nil {
+		return fmt.Errorf("evaluate course load policy: %w", err)
+	}
+
+	overloaded := slice.Filter(limited, func(student Student) bool {
+		return loads[student.ID] >= clp.maxCourseLoad(student)
+	})
+	if len(overloaded) > 0 {
+		return CourseLoadExceededError{CourseCode: class.Code, Students: overloaded}
+	}
+
+	return nil
+}
+
+// maxCourseLoad returns the maximum course load that applies to the student.
+func (clp courseLoadPolicy) maxCourseLoad(student Student) uint32 {
+	if student.MaxCourseLoad > 0 {
+		return student.MaxCourseLoad
+	}
+
+	return clp.defaultMaxCourseLoad
+}
+
+// prerequisitePolicy checks that every student has passed each of the
+// prerequisites of the class. Completions are only loaded from the repository
+// if the class has prerequisites.
+type prerequisitePolicy struct {
+	scale GradingScale
+}
+
+func (pp prerequisitePolicy) Evaluate(
+	ctx context.Context,
+	repo Repository,
+	class Class,
+	students Students,
+) error {
+	if len(class.Prerequisites) == 0 {
+		return nil
+	}
+
+	completions, err := repo.GetCompletions(ctx, students)
+	if err != nil {
+		return fmt.Errorf("evaluate prerequisite policy: %w", err)
+	}
+
+	unqualified := slice.Filter(students, func(student Student) bool {
+		for _, prerequisite := range class.Prerequisites {
+			if !pp.scale.passed(completions[student.ID], prerequisite) {
+				return true
+			}
+		}
+
+		return false
+	})
+	if len(unqualified) > 0 {
+		return PrerequisitesNotMetError{
+			CourseCode:    class.Code,
+			Prerequisites: class.Prerequisites,
+			Students:      unqualified,
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,152 @@
+package classservice
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/angusgmorrison/hexagonal/internal/primitive"
+)
+
+// defaultReservationTTL is the length of time for which a reservation holds a
+// place in a class, unless configured using WithReservationTTL.
+const defaultReservationTTL = 10 * time.Minute
+
+// Reserve holds a place in the course matching courseCode for the student with
+// the given email address, giving them time to complete their enrollment. The
+// place counts against the capacity of the class until the reservation expires
+// or the student enrolls.
+//
+// The student must be registered, must not already be enrolled in the course
+// or awaiting approval to enroll, and must not already hold an unexpired
+// reservation in the course. The course must have a place available.
+func (svc *classService) Reserve(
+	ctx context.Context,
+	courseCode string,
+	email primitive.EmailAddress,
+) (Reservation, error) {
+	if err := svc.validateCourseAndEmail(courseCode, email); err != nil {
+		return Reservation{}, fmt.Errorf("Reserve: %w", err)
+	}
+
+	var reservation Reservation
+
+	reserve := func(ctx context.Context, repo Repository) error {
+		class, err := svc.getClassForUpdate(ctx, repo, courseCode)
+		if err != nil {
+			return fmt.Errorf("Reserve: %w", err)
+		}
+
+		if err := verifyCourseNotCancelled(ctx, repo, class, nil); err != nil {
+			return err
+		}
+
+		registeredStudents, err := repo.GetStudentsByEmail(ctx, []primitive.EmailAddress{email})
+		if err != nil {
+			return fmt.Errorf("Reserve: %w", err)
+		}
+
+		students := Students{{Email: email}}.resolve(registeredStudents)
+
+		if err := verifyStudentsRegistered(ctx, repo, class, students); err != nil {
+			return err
+		}
+
+		if err := verifyStudentsNotAlreadyEnrolled(ctx, repo, class, students); err != nil {
+			return err
+		}
+
+		if len(class.Reservations.heldBy(students)) > 0 {
+			return AlreadyReservedError{CourseCode: class.Code, Email: email}
+		}
+
+		if err := verifyClassHasCapacity(ctx, repo, class, students); err != nil {
+			return err
+		}
+
+		expiresAt := svc.now().Add(svc.reservationTTL)
+
+		reservation, err = repo.Reserve(ctx, class.Course, students[0], expiresAt)
+		if err != nil {
+			return fmt.Errorf("Reserve: %w", err)
+		}
+
+		return nil
+	}
+
+	if err := svc.repo.Execute(ctx, reserve); err != nil {
+		return Reservation{}, err
+	}
+
+	svc.publishAvailability(ctx, courseCode)
+
+	return reservation, nil
+}
+
+// ReleaseExpiredReservations releases every reservation that has expired,
+// returning the number released. Expired reservations don't hold a place in a
+// class even before they're released, but the availability of the courses
+// they were held in is published on release, since nothing else announces the
+// places they free.
+func (svc *classService) ReleaseExpiredReservations(ctx context.Context) (int, error) {
+	var (
+		released    int
+		courseCodes []string
+	)
+
+	release := func(ctx context.Context, repo Repository) error {
+		var err error
+
+		released, courseCodes, err = repo.ReleaseExpiredReservations(ctx, svc.now())
+		if err != nil {
+			return fmt.Errorf("ReleaseExpiredReservations: %w", err)
+		}
+
+		return nil
+	}
+
+	if err := svc.repo.Execute(ctx, release); err != nil {
+		return 0, err
+	}
+
+	svc.publishAvailability(ctx, courseCodes...)
+
+	return released, nil
+}
+
+// getClass loads the class with the given course code, disregarding any
+// reservations that have expired.
+func (svc *classService) getClass(ctx context.Context, repo Repository, courseCode string) (Class, error) {
+	class, err := repo.GetClassByCourseCode(ctx, courseCode)
+	if err != nil {
+		return Class{}, err
+	}
+
+	class.Reservations = class.Reservations.activeAt(svc.now())
+
+	return class, nil
+}
+
+// getClassForUpdate is getClass for operations that check the places remaining
+// in the class. The class is locked until the end of the atomic operation, so
+// that concurrent operations can't claim the same places.
+func (svc *classService) getClassForUpdate(ctx context.Context, repo Repository, courseCode string) (Class, error) {
+	class, err := repo.GetClassForUpdate(ctx, courseCode)
+	if err != nil {
+		return Class{}, err
+	}
+
+	class.Reservations = class.Reservations.activeAt(svc.now())
+
+	return class, nil
+}
+
+// convertReservations releases any places reserved in the class by students
+// who have now enrolled in it.
+func convertReservations(ctx context.Context, repo Repository, class Class, students Students) error {
+	if len(class.Reservations.heldBy(students)) == 0 {
+		return nil
+	}
+
+	return repo.ReleaseReservations(ctx, class.Course, students)
+}
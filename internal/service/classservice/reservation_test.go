@@ -0,0 +1,245 @@
+//go:build unit
+
+package classservice
+
+import (
+	"context"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/angusgmorrison/hexagonal/internal/primitive"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReserve(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2022, time.September, 1, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	t.Run("validates arguments", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "validates arguments ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			service    = New(logger, validate, atomicRepo)
+		)
+
+		testCases := []struct {
+			name       string
+			courseCode string
+			email      primitive.EmailAddress
+		}{
+			{name: "missing course code", courseCode: "", email: "r.tifft@gmail.com"},
+			{name: "missing email", courseCode: "SICP", email: ""},
+		}
+
+		for _, tc := range testCases {
+			tc := tc
+
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+
+				_, err := service.Reserve(context.Background(), tc.courseCode, tc.email)
+
+				var validationErrs validator.ValidationErrors
+				require.ErrorAs(t, err, &validationErrs)
+			})
+		}
+	})
+
+	t.Run("rejects invalid reservations", func(t *testing.T) {
+		t.Parallel()
+
+		student := defaultStudent(t)
+		student.ID = 1
+
+		testCases := []struct {
+			name    string
+			class   Class
+			wantErr error
+		}{
+			{
+				name:    "student already enrolled",
+				class:   Class{Course: defaultCourse(), Students: Students{student}},
+				wantErr: AlreadyEnrolledError{Students: Students{student}},
+			},
+			{
+				name: "student already holds reservation",
+				class: Class{
+					Course:       defaultCourse(),
+					Reservations: Reservations{{ID: 1, StudentID: student.ID, ExpiresAt: now.Add(time.Minute)}},
+				},
+				wantErr: AlreadyReservedError{CourseCode: "SICP", Email: student.Email},
+			},
+			{
+				name: "remaining places reserved",
+				class: Class{
+					Course:   defaultCourse(),
+					Students: Students{{ID: 2}},
+					Reservations: Reservations{
+						{ID: 1, StudentID: 3, ExpiresAt: now.Add(time.Minute)},
+					},
+				},
+				wantErr: OversubscribedError{
+					CourseCode:           "SICP",
+					AvailableSpaces:      0,
+					AttemptedEnrollments: 1,
+				},
+			},
+		}
+
+		for _, tc := range testCases {
+			tc := tc
+
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+
+				var (
+					logger     = log.New(os.Stdout, "rejects invalid reservations ", log.LstdFlags)
+					validate   = validator.New()
+					atomicRepo = NewMockAtomicRepository(t)
+					repo       = NewMockRepository(t)
+					service    = New(logger, validate, atomicRepo, WithClock(clock))
+					ctx        = context.Background()
+				)
+
+				atomicRepo.On(
+					"Execute",
+					ctx,
+					mock.AnythingOfType("AtomicOperation"),
+				).Return(func(ctx context.Context, op AtomicOperation) error {
+					return op(ctx, repo)
+				})
+
+				repo.On("GetClassForUpdate", ctx, tc.class.Code).Return(tc.class, nil)
+				repo.On(
+					"GetStudentsByEmail",
+					ctx,
+					[]primitive.EmailAddress{student.Email},
+				).Return(Students{student}, nil)
+
+				_, err := service.Reserve(ctx, tc.class.Code, student.Email)
+				require.Equal(t, tc.wantErr, err)
+			})
+		}
+	})
+
+	t.Run("reserves place until TTL elapses", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "reserves place until TTL elapses ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			service    = New(logger, validate, atomicRepo, WithClock(clock), WithReservationTTL(5*time.Minute))
+			ctx        = context.Background()
+			student    = defaultStudent(t)
+		)
+
+		student.ID = 1
+
+		// The last place in the class was reserved by another student, but
+		// their reservation has expired.
+		class := Class{
+			Course:       defaultCourse(),
+			Students:     Students{{ID: 2}},
+			Reservations: Reservations{{ID: 1, StudentID: 3, ExpiresAt: now}},
+		}
+		want := Reservation{ID: 2, StudentID: student.ID, ExpiresAt: now.Add(5 * time.Minute)}
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On("GetClassForUpdate", ctx, class.Code).Return(class, nil)
+		repo.On(
+			"GetStudentsByEmail",
+			ctx,
+			[]primitive.EmailAddress{student.Email},
+		).Return(Students{student}, nil)
+		repo.On("Reserve", ctx, class.Course, student, want.ExpiresAt).Return(want, nil)
+
+		got, err := service.Reserve(ctx, class.Code, student.Email)
+		require.NoError(t, err)
+		require.Equal(t, want, got)
+	})
+}
+
+func TestEnrollWithReservation(t *testing.T) {
+	t.Parallel()
+
+	var (
+		logger     = log.New(os.Stdout, "TestEnrollWithReservation ", log.LstdFlags)
+		validate   = validator.New()
+		atomicRepo = NewMockAtomicRepository(t)
+		repo       = NewMockRepository(t)
+		now        = time.Date(2022, time.September, 1, 12, 0, 0, 0, time.UTC)
+		service    = New(logger, validate, atomicRepo, WithClock(func() time.Time { return now }))
+		ctx        = context.Background()
+		req        = defaultEnrollmentRequest(t)
+		students   = registeredStudents(t, req.Students)
+	)
+
+	// The student's own reservation holds the last place in the class.
+	class := Class{
+		Course:       defaultCourse(),
+		Students:     Students{{ID: 2}},
+		Reservations: Reservations{{ID: 1, StudentID: students[0].ID, ExpiresAt: now.Add(time.Minute)}},
+	}
+
+	atomicRepo.On(
+		"Execute",
+		ctx,
+		mock.AnythingOfType("AtomicOperation"),
+	).Return(func(ctx context.Context, op AtomicOperation) error {
+		return op(ctx, repo)
+	})
+
+	repo.On("GetClassForUpdate", ctx, req.CourseCode).Return(class, nil)
+	repo.On("GetStudentsByEmail", ctx, req.Students.EmailAddresses()).Return(students, nil)
+	repo.On("EnrollStudents", ctx, class.Course, students).Return(class, nil)
+	repo.On("ReleaseReservations", ctx, class.Course, students).Return(nil)
+
+	err := service.Enroll(ctx, req)
+	require.NoError(t, err)
+}
+
+func TestReleaseExpiredReservations(t *testing.T) {
+	t.Parallel()
+
+	var (
+		logger     = log.New(os.Stdout, "TestReleaseExpiredReservations ", log.LstdFlags)
+		validate   = validator.New()
+		atomicRepo = NewMockAtomicRepository(t)
+		repo       = NewMockRepository(t)
+		now        = time.Date(2022, time.September, 1, 12, 0, 0, 0, time.UTC)
+		service    = New(logger, validate, atomicRepo, WithClock(func() time.Time { return now }))
+		ctx        = context.Background()
+	)
+
+	atomicRepo.On(
+		"Execute",
+		ctx,
+		mock.AnythingOfType("AtomicOperation"),
+	).Return(func(ctx context.Context, op AtomicOperation) error {
+		return op(ctx, repo)
+	})
+
+	repo.On("ReleaseExpiredReservations", ctx, now).Return(3, []string{"SICP"}, nil)
+
+	released, err := service.ReleaseExpiredReservations(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 3, released)
+}
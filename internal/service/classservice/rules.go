@@ -0,0 +1,174 @@
+package classservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/angusgmorrison/hexagonal/pkg/rule"
+)
+
+// RuleSource provides the declarative enrollment rules that apply to courses.
+// Rules are written in the language of package rule, using the variables
+// described by RuleVariables.
+type RuleSource interface {
+	// EnrollmentRule returns the rule that students must satisfy to enroll in
+	// the course with the given code, or the empty string if the course has no
+	// rule.
+	EnrollmentRule(ctx context.Context, courseCode string) (string, error)
+}
+
+// RuleVariables declares the variables available to enrollment rules. Rules
+// are evaluated once for each student attempting to enroll.
+func RuleVariables() rule.Env {
+	return rule.Env{
+		"student.name":            rule.String,
+		"student.email":           rule.String,
+		"student.age":             rule.Int,
+		"course.code":             rule.String,
+		"course.capacity":         rule.Int,
+		"course.enrolled":         rule.Int,
+		"course.available_spaces": rule.Int,
+	}
+}
+
+// ValidateEnrollmentRule compiles an enrollment rule, returning an
+// InvalidRuleError describing any problems found. Rules submitted for
+// validation aren't cached, since callers may submit any number of them.
+func (svc *classService) ValidateEnrollmentRule(_ context.Context, source string) error {
+	if _, err := compileRule(source); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// maxCachedRules bounds the number of programs retained by a ruleCache. Rules
+// are configured per course, so a cache that fills up holds rules that have
+// since been replaced, and is emptied.
+const maxCachedRules = 1024
+
+// ruleCache compiles enrollment rules, retaining the result so that each rule
+// is compiled only once. It is safe for concurrent use.
+type ruleCache struct {
+	mu       sync.Mutex
+	programs map[string]*rule.Program
+}
+
+func newRuleCache() *ruleCache {
+	return &ruleCache{programs: make(map[string]*rule.Program)}
+}
+
+func (rc *ruleCache) compile(source string) (*rule.Program, error) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	if program, ok := rc.programs[source]; ok {
+		return program, nil
+	}
+
+	program, err := compileRule(source)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rc.programs) >= maxCachedRules {
+		clear(rc.programs)
+	}
+
+	rc.programs[source] = program
+
+	return program, nil
+}
+
+// compileRule compiles an enrollment rule, returning an InvalidRuleError
+// describing any problems found.
+func compileRule(source string) (*rule.Program, error) {
+	program, err := rule.Compile(source, RuleVariables())
+	if err != nil {
+		invalidRuleErr := InvalidRuleError{Rule: source}
+
+		var errs rule.ErrorList
+		if !errors.As(err, &errs) {
+			return nil, fmt.Errorf("compile rule %q: %w", source, err)
+		}
+
+		for _, e := range errs {
+			invalidRuleErr.Problems = append(invalidRuleErr.Problems, RuleProblem{
+				Offset:  e.Offset,
+				Message: e.Message,
+			})
+		}
+
+		return nil, invalidRuleErr
+	}
+
+	return program, nil
+}
+
+// rulePolicy requires each student to satisfy the declarative enrollment rule
+// of the course, if it has one.
+type rulePolicy struct {
+	source RuleSource
+	rules  *ruleCache
+	now    func() time.Time
+}
+
+func (rp rulePolicy) Evaluate(
+	ctx context.Context,
+	_ Repository,
+	class Class,
+	students Students,
+) error {
+	source, err := rp.source.EnrollmentRule(ctx, class.Code)
+	if err != nil {
+		return fmt.Errorf("load enrollment rule for course %q: %w", class.Code, err)
+	}
+
+	if source == "" {
+		return nil
+	}
+
+	program, err := rp.rules.compile(source)
+	if err != nil {
+		return fmt.Errorf("enrollment rule for course %q: %w", class.Code, err)
+	}
+
+	var (
+		now       = rp.now()
+		violators Students
+	)
+
+	for _, student := range students {
+		ok, err := program.Eval(ruleVars(class, student, now))
+		if err != nil {
+			return fmt.Errorf("evaluate enrollment rule for course %q: %w", class.Code, err)
+		}
+
+		if !ok {
+			violators = append(violators, student)
+		}
+	}
+
+	if len(violators) > 0 {
+		return RuleViolationError{CourseCode: class.Code, Rule: source, Students: violators}
+	}
+
+	return nil
+}
+
+// ruleVars returns the values of the variables declared by RuleVariables for
+// a student enrolling in a class at the given time.
+func ruleVars(class Class, student Student, now time.Time) rule.Vars {
+	return rule.Vars{
+		"student.name":            student.Name,
+		"student.email":           string(student.Email),
+		"student.age":             int64(student.Birthdate.AgeAt(now)),
+		"course.code":             class.Code,
+		"course.capacity":         int64(class.Capacity),
+		"course.enrolled":         int64(len(class.Students)),
+		"course.available_spaces": int64(class.AvailableSpaces()),
+	}
+}
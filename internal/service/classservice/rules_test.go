@@ -0,0 +1,174 @@
+//go:build unit
+
+package classservice
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnrollmentRules(t *testing.T) {
+	t.Parallel()
+
+	// defaultStudent was born on 1990-03-04, so is 16 on this date.
+	now := func() time.Time { return time.Date(2006, time.March, 4, 0, 0, 0, 0, time.UTC) }
+
+	testCases := []struct {
+		name          string
+		rule          string
+		wantEnrolled  bool
+		wantViolation bool
+	}{
+		{
+			name:         "course has no rule",
+			rule:         "",
+			wantEnrolled: true,
+		},
+		{
+			name:         "rule satisfied",
+			rule:         `student.age >= 16 && course.code startsWith "SIC"`,
+			wantEnrolled: true,
+		},
+		{
+			name:          "rule violated",
+			rule:          `student.age >= 17 && course.code startsWith "SIC"`,
+			wantViolation: true,
+		},
+		{
+			name: "rule invalid",
+			rule: `student.age >= "16"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				logger     = log.New(os.Stdout, "TestEnrollmentRules ", log.LstdFlags)
+				validate   = validator.New()
+				atomicRepo = NewMockAtomicRepository(t)
+				repo       = NewMockRepository(t)
+				ruleSource = NewMockRuleSource(t)
+				service    = New(logger, validate, atomicRepo, WithRuleSource(ruleSource), WithClock(now))
+				ctx        = context.Background()
+				req        = defaultEnrollmentRequest(t)
+				class      = Class{Course: Course{Code: "SICP", Capacity: 1}}
+				students   = registeredStudents(t, req.Students)
+			)
+
+			atomicRepo.On(
+				"Execute",
+				ctx,
+				mock.AnythingOfType("AtomicOperation"),
+			).Return(func(ctx context.Context, op AtomicOperation) error {
+				return op(ctx, repo)
+			})
+
+			repo.On(
+				"GetClassForUpdate",
+				ctx,
+				req.CourseCode,
+			).Return(class, nil)
+
+			repo.On(
+				"GetStudentsByEmail",
+				ctx,
+				req.Students.EmailAddresses(),
+			).Return(students, nil)
+
+			ruleSource.On(
+				"EnrollmentRule",
+				ctx,
+				class.Code,
+			).Return(tc.rule, nil)
+
+			if tc.wantEnrolled {
+				repo.On(
+					"EnrollStudents",
+					ctx,
+					class.Course,
+					students,
+				).Return(class, nil)
+			}
+
+			err := service.Enroll(ctx, req)
+
+			switch {
+			case tc.wantEnrolled:
+				require.NoError(t, err)
+			case tc.wantViolation:
+				wantErr := RuleViolationError{CourseCode: class.Code, Rule: tc.rule, Students: students}
+
+				var gotErr RuleViolationError
+				require.ErrorAs(t, err, &gotErr)
+				require.Equal(t, wantErr, gotErr, "unequal RuleViolationErrors")
+			default:
+				var gotErr InvalidRuleError
+				require.ErrorAs(t, err, &gotErr)
+			}
+		})
+	}
+}
+
+func TestValidateEnrollmentRule(t *testing.T) {
+	t.Parallel()
+
+	var (
+		logger     = log.New(os.Stdout, "TestValidateEnrollmentRule ", log.LstdFlags)
+		validate   = validator.New()
+		atomicRepo = NewMockAtomicRepository(t)
+		service    = New(logger, validate, atomicRepo)
+		ctx        = context.Background()
+	)
+
+	t.Run("valid rule", func(t *testing.T) {
+		t.Parallel()
+
+		err := service.ValidateEnrollmentRule(ctx, `course.available_spaces > 0 || student.email endsWith "@staff.edu"`)
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid rule", func(t *testing.T) {
+		t.Parallel()
+
+		const rule = `student.height > 150 && course.code > 1`
+
+		wantErr := InvalidRuleError{
+			Rule: rule,
+			Problems: []RuleProblem{
+				{Offset: 0, Message: `unknown variable "student.height"`},
+				{Offset: 36, Message: "operator > cannot be applied to string and int"},
+			},
+		}
+
+		err := service.ValidateEnrollmentRule(ctx, rule)
+
+		var gotErr InvalidRuleError
+		require.ErrorAs(t, err, &gotErr)
+		require.Equal(t, wantErr, gotErr, "unequal InvalidRuleErrors")
+	})
+}
+
+func TestRuleCache(t *testing.T) {
+	t.Parallel()
+
+	rules := newRuleCache()
+
+	for i := 0; i <= maxCachedRules; i++ {
+		_, err := rules.compile(fmt.Sprintf("student.age >= %d", i))
+		require.NoError(t, err)
+	}
+
+	require.LessOrEqual(t, len(rules.programs), maxCachedRules, "cache exceeds its bound")
+}
@@ -0,0 +1,155 @@
+package classservice
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/angusgmorrison/hexagonal/internal/primitive"
+)
+
+// EnrollmentStatus describes the progress of a student's current enrollment in
+// a course.
+type EnrollmentStatus string
+
+const (
+	// EnrollmentActive enrollments hold a place in a course.
+	EnrollmentActive EnrollmentStatus = "active"
+
+	// EnrollmentPending enrollments are awaiting approval.
+	EnrollmentPending EnrollmentStatus = "pending"
+
+	// EnrollmentAwaitingPayment enrollments hold a place in a course that
+	// charges a fee, and become active once the student's invoice is paid.
+	EnrollmentAwaitingPayment EnrollmentStatus = "awaiting_payment"
+)
+
+// Enrollment describes a student's current enrollment in a course. SectionCode
+// is empty if the student hasn't been placed in a section.
+type Enrollment struct {
+	CourseCode  string
+	SectionCode string
+	Status      EnrollmentStatus
+}
+
+// Enrollments is a convenience wrapper.
+type Enrollments []Enrollment
+
+// StudentEnrollments maps student IDs to each student's current enrollments.
+type StudentEnrollments map[int64]Enrollments
+
+// GetStudents returns the registered students with the given email addresses.
+// Email addresses that don't belong to a registered student are ignored, so
+// the result may contain fewer students than there are addresses.
+func (svc *classService) GetStudents(ctx context.Context, emails []primitive.EmailAddress) (Students, error) {
+	if err := svc.validate.Var(emails, "dive,required"); err != nil {
+		return nil, fmt.Errorf("GetStudents: %w", err)
+	}
+
+	if len(emails) == 0 {
+		return Students{}, nil
+	}
+
+	var students Students
+
+	get := func(ctx context.Context, repo Repository) error {
+		registeredStudents, err := repo.GetStudentsByEmail(ctx, emails)
+		if err != nil {
+			return fmt.Errorf("GetStudents: %w", err)
+		}
+
+		students = registeredStudents
+
+		return nil
+	}
+
+	if err := svc.repo.Execute(ctx, get); err != nil {
+		return nil, err
+	}
+
+	return students, nil
+}
+
+// GetEnrollments returns the active, pending and unpaid enrollments of each of
+// the given students, ordered by course code. The students must be registered.
+func (svc *classService) GetEnrollments(ctx context.Context, students Students) (StudentEnrollments, error) {
+	if len(students) == 0 {
+		return StudentEnrollments{}, nil
+	}
+
+	var enrollments StudentEnrollments
+
+	get := func(ctx context.Context, repo Repository) error {
+		if err := verifyStudentsRegistered(ctx, repo, Class{}, students); err != nil {
+			return err
+		}
+
+		studentEnrollments, err := repo.GetEnrollments(ctx, students)
+		if err != nil {
+			return fmt.Errorf("GetEnrollments: %w", err)
+		}
+
+		enrollments = studentEnrollments
+
+		return nil
+	}
+
+	if err := svc.repo.Execute(ctx, get); err != nil {
+		return nil, err
+	}
+
+	return enrollments, nil
+}
+
+// GetStudentClasses returns the classes in which the student with the given
+// email address is actively enrolled, ordered by course code. Cancelled courses
+// are omitted.
+func (svc *classService) GetStudentClasses(ctx context.Context, email primitive.EmailAddress) ([]Class, error) {
+	if err := svc.validate.Var(email, "required"); err != nil {
+		return nil, fmt.Errorf("GetStudentClasses: %w", err)
+	}
+
+	var classes []Class
+
+	get := func(ctx context.Context, repo Repository) error {
+		registeredStudents, err := repo.GetStudentsByEmail(ctx, []primitive.EmailAddress{email})
+		if err != nil {
+			return fmt.Errorf("GetStudentClasses: %w", err)
+		}
+
+		students := Students{{Email: email}}.resolve(registeredStudents)
+
+		if err := verifyStudentsRegistered(ctx, repo, Class{}, students); err != nil {
+			return err
+		}
+
+		enrollments, err := repo.GetEnrollments(ctx, students)
+		if err != nil {
+			return fmt.Errorf("GetStudentClasses: %w", err)
+		}
+
+		classes = make([]Class, 0, len(enrollments[students[0].ID]))
+
+		for _, enrollment := range enrollments[students[0].ID] {
+			if enrollment.Status != EnrollmentActive {
+				continue
+			}
+
+			class, err := repo.GetClassByCourseCode(ctx, enrollment.CourseCode)
+			if err != nil {
+				return fmt.Errorf("GetStudentClasses: %w", err)
+			}
+
+			if !class.Cancelled {
+				classes = append(classes, class)
+			}
+		}
+
+		return nil
+	}
+
+	if err := svc.repo.Execute(ctx, get); err != nil {
+		return nil, err
+	}
+
+	return classes, nil
+}
@@ -0,0 +1,215 @@
+//go:build unit
+
+package classservice
+
+import (
+	"context"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/angusgmorrison/hexagonal/internal/primitive"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetStudents(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns the registered students", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "returns the registered students ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			service    = New(logger, validate, atomicRepo)
+			ctx        = context.Background()
+			students   = registeredStudents(t, Students{defaultStudent(t)})
+			emails     = []primitive.EmailAddress{students[0].Email, "unregistered@example.com"}
+		)
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On("GetStudentsByEmail", ctx, emails).Return(students, nil)
+
+		got, err := service.GetStudents(ctx, emails)
+		require.NoError(t, err)
+		require.Equal(t, students, got)
+	})
+
+	t.Run("doesn't query the repository for no email addresses", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "doesn't query the repository ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			service    = New(logger, validate, atomicRepo)
+		)
+
+		got, err := service.GetStudents(context.Background(), nil)
+		require.NoError(t, err)
+		require.Empty(t, got)
+	})
+
+	t.Run("rejects empty email addresses", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "rejects empty email addresses ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			service    = New(logger, validate, atomicRepo)
+		)
+
+		_, err := service.GetStudents(context.Background(), []primitive.EmailAddress{""})
+
+		var validationErrs validator.ValidationErrors
+		require.ErrorAs(t, err, &validationErrs)
+	})
+}
+
+func TestGetEnrollments(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns the enrollments of each student", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger      = log.New(os.Stdout, "returns the enrollments of each student ", log.LstdFlags)
+			validate    = validator.New()
+			atomicRepo  = NewMockAtomicRepository(t)
+			repo        = NewMockRepository(t)
+			service     = New(logger, validate, atomicRepo)
+			ctx         = context.Background()
+			students    = registeredStudents(t, Students{defaultStudent(t)})
+			enrollments = StudentEnrollments{
+				students[0].ID: {
+					{CourseCode: "SICP", SectionCode: "A", Status: EnrollmentActive},
+					{CourseCode: "TAOCP", Status: EnrollmentPending},
+				},
+			}
+		)
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On("GetEnrollments", ctx, students).Return(enrollments, nil)
+
+		got, err := service.GetEnrollments(ctx, students)
+		require.NoError(t, err)
+		require.Equal(t, enrollments, got)
+	})
+
+	t.Run("rejects unregistered students", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "rejects unregistered students ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			service    = New(logger, validate, atomicRepo)
+			ctx        = context.Background()
+			students   = Students{defaultStudent(t)}
+		)
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		_, err := service.GetEnrollments(ctx, students)
+
+		var unregisteredErr UnregisteredStudentsError
+		require.ErrorAs(t, err, &unregisteredErr)
+		require.Equal(t, students, unregisteredErr.Students)
+	})
+}
+
+func TestGetStudentClasses(t *testing.T) {
+	t.Parallel()
+
+	var (
+		logger     = log.New(os.Stdout, "TestGetStudentClasses ", log.LstdFlags)
+		validate   = validator.New()
+		atomicRepo = NewMockAtomicRepository(t)
+		repo       = NewMockRepository(t)
+		service    = New(logger, validate, atomicRepo)
+		ctx        = context.Background()
+		students   = registeredStudents(t, Students{defaultStudent(t)})
+		sicp       = transferClass("SICP", 2, students[0])
+		taocp      = transferClass("TAOCP", 2, students[0])
+	)
+
+	taocp.Cancelled = true
+
+	atomicRepo.On(
+		"Execute",
+		ctx,
+		mock.AnythingOfType("AtomicOperation"),
+	).Return(func(ctx context.Context, op AtomicOperation) error {
+		return op(ctx, repo)
+	})
+
+	repo.On("GetStudentsByEmail", ctx, []primitive.EmailAddress{students[0].Email}).Return(students, nil)
+	repo.On("GetEnrollments", ctx, students).Return(StudentEnrollments{
+		students[0].ID: {
+			{CourseCode: "LISP", Status: EnrollmentPending},
+			{CourseCode: "SICP", Status: EnrollmentActive},
+			{CourseCode: "TAOCP", Status: EnrollmentActive},
+		},
+	}, nil)
+	repo.On("GetClassByCourseCode", ctx, "SICP").Return(sicp, nil)
+	repo.On("GetClassByCourseCode", ctx, "TAOCP").Return(taocp, nil)
+
+	got, err := service.GetStudentClasses(ctx, students[0].Email)
+	require.NoError(t, err)
+	require.Equal(t, []Class{sicp}, got)
+}
+
+func TestClassRoster(t *testing.T) {
+	t.Parallel()
+
+	var (
+		enrolled = Student{ID: 1, Email: "angus@example.com"}
+		pending  = Student{ID: 2, Email: "r.tifft@gmail.com"}
+		awaiting = Student{ID: 3, Email: "bert@rainey.org"}
+		class    = Class{
+			Course: Course{
+				Code: "TAOCP",
+				Sections: Sections{
+					{Code: "A", Students: Students{enrolled}},
+					{Code: "B", AwaitingPayment: Students{awaiting}},
+				},
+			},
+			Students:        Students{enrolled},
+			Pending:         Students{pending},
+			AwaitingPayment: Students{awaiting},
+			Attendance:      StudentAttendance{enrolled.ID: {Attended: 1, Recorded: 2}},
+		}
+	)
+
+	require.Equal(t, []RosterEntry{
+		{Student: enrolled, Status: RosterEnrolled, SectionCode: "A", Attendance: Attendance{Attended: 1, Recorded: 2}},
+		{Student: pending, Status: RosterPending},
+		{Student: awaiting, Status: RosterAwaitingPayment, SectionCode: "B"},
+	}, class.Roster())
+}
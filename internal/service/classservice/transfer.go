@@ -0,0 +1,149 @@
+package classservice
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/angusgmorrison/hexagonal/pkg/slice"
+)
+
+// transferRequest is used to validate the arguments to Transfer.
+type transferRequest struct {
+	FromCourseCode string   `validate:"required"`
+	ToCourseCode   string   `validate:"required,nefield=FromCourseCode"`
+	Students       Students `validate:"min=1"`
+}
+
+// Transfer moves the given students from the course matching fromCourseCode to
+// the course matching toCourseCode as a single atomic operation, so that no
+// student loses their place in the first course unless they're enrolled in the
+// second.
+//
+// Each student must be registered and actively enrolled in the first course,
+// and the students must satisfy every policy that Enroll would evaluate for
+// the second course, including its declarative rule and any custom policies,
+// except for their course load, which a transfer doesn't change. If the second
+// course is divided into sections, the students are distributed between the
+// least-full sections. Students can't be transferred into a course that
+// requires approval.
+//
+// If any check fails, an error is returned and neither course is changed.
+func (svc *classService) Transfer(
+	ctx context.Context,
+	fromCourseCode string,
+	toCourseCode string,
+	students Students,
+) error {
+	req := transferRequest{
+		FromCourseCode: fromCourseCode,
+		ToCourseCode:   toCourseCode,
+		Students:       students,
+	}
+	if err := svc.validate.Struct(req); err != nil {
+		return fmt.Errorf("Transfer: %w", err)
+	}
+
+	var pending notifications
+
+	transfer := func(ctx context.Context, repo Repository) error {
+		from, err := svc.getClass(ctx, repo, fromCourseCode)
+		if err != nil {
+			return fmt.Errorf("Transfer: %w", err)
+		}
+
+		// Only the destination is locked, since it's the only course whose
+		// places are claimed.
+		to, err := svc.getClassForUpdate(ctx, repo, toCourseCode)
+		if err != nil {
+			return fmt.Errorf("Transfer: %w", err)
+		}
+
+		for _, class := range []Class{from, to} {
+			if err := verifyCourseNotCancelled(ctx, repo, class, students); err != nil {
+				return err
+			}
+		}
+
+		if to.RequiresApproval {
+			return TransferRequiresApprovalError{CourseCode: to.Code}
+		}
+
+		registeredStudents, err := repo.GetStudentsByEmail(ctx, students.EmailAddresses())
+		if err != nil {
+			return fmt.Errorf("Transfer: %w", err)
+		}
+
+		students := students.resolve(registeredStudents)
+
+		if err := verifyStudentsRegistered(ctx, repo, to, students); err != nil {
+			return err
+		}
+
+		if err := verifyStudentsEnrolled(from, students); err != nil {
+			return err
+		}
+
+		if err := svc.evaluateTransferPolicies(ctx, repo, to, students); err != nil {
+			return err
+		}
+
+		if _, err := repo.UnenrollStudents(ctx, from.Course, students); err != nil {
+			return fmt.Errorf("Transfer: %w", err)
+		}
+
+		pending.add(NotificationUnenrolled, from.Code, "", students)
+
+		if err := svc.enrollStudents(ctx, repo, to, "", Voucher{}, students, &pending); err != nil {
+			return fmt.Errorf("Transfer: %w", err)
+		}
+
+		return nil
+	}
+
+	if err := svc.repo.Execute(ctx, transfer); err != nil {
+		return err
+	}
+
+	svc.deliver(ctx, pending)
+
+	svc.publishAvailability(ctx, fromCourseCode, toCourseCode)
+
+	return nil
+}
+
+// evaluateTransferPolicies evaluates the policies of the service against the
+// class into which the students are being transferred. The course-load policy
+// is skipped, since the students leave one course for each they join.
+func (svc *classService) evaluateTransferPolicies(
+	ctx context.Context,
+	repo Repository,
+	class Class,
+	students Students,
+) error {
+	for _, policy := range svc.policies {
+		if _, ok := policy.(courseLoadPolicy); ok {
+			continue
+		}
+
+		if err := policy.Evaluate(ctx, repo, class, students); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// verifyStudentsEnrolled checks that all of the students are actively enrolled
+// in the class.
+func verifyStudentsEnrolled(class Class, students Students) error {
+	enrolled := slice.ToSet(class.Students.EmailAddresses())
+	notEnrolled := slice.Filter(students, func(student Student) bool {
+		return !enrolled[student.Email]
+	})
+
+	if len(notEnrolled) > 0 {
+		return NotEnrolledError{CourseCode: class.Code, Students: notEnrolled}
+	}
+
+	return nil
+}
@@ -0,0 +1,353 @@
+//go:build unit
+
+package classservice
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransfer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("validates arguments", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "validates arguments ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			service    = New(logger, validate, atomicRepo)
+			students   = Students{defaultStudent(t)}
+		)
+
+		testCases := []struct {
+			name     string
+			from     string
+			to       string
+			students Students
+		}{
+			{name: "missing from course code", from: "", to: "TAOCP", students: students},
+			{name: "missing to course code", from: "SICP", to: "", students: students},
+			{name: "same course", from: "SICP", to: "SICP", students: students},
+			{name: "empty Students", from: "SICP", to: "TAOCP", students: Students{}},
+		}
+
+		for _, tc := range testCases {
+			tc := tc
+
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+
+				err := service.Transfer(context.Background(), tc.from, tc.to, tc.students)
+
+				var validationErrs validator.ValidationErrors
+				require.ErrorAs(t, err, &validationErrs)
+			})
+		}
+	})
+
+	t.Run("rejects invalid transfers", func(t *testing.T) {
+		t.Parallel()
+
+		student := defaultStudent(t)
+		student.ID = 1
+
+		testCases := []struct {
+			name    string
+			from    Class
+			to      Class
+			wantErr error
+		}{
+			{
+				name:    "student not enrolled in from course",
+				from:    transferClass("SICP", 2),
+				to:      transferClass("TAOCP", 2),
+				wantErr: NotEnrolledError{CourseCode: "SICP", Students: Students{student}},
+			},
+			{
+				name:    "student already enrolled in to course",
+				from:    transferClass("SICP", 2, student),
+				to:      transferClass("TAOCP", 2, student),
+				wantErr: AlreadyEnrolledError{Students: Students{student}},
+			},
+			{
+				name: "to course oversubscribed",
+				from: transferClass("SICP", 2, student),
+				to:   transferClass("TAOCP", 1, Student{ID: 2}),
+				wantErr: OversubscribedError{
+					CourseCode:           "TAOCP",
+					AvailableSpaces:      0,
+					AttemptedEnrollments: 1,
+				},
+			},
+			{
+				name: "to course requires approval",
+				from: transferClass("SICP", 2, student),
+				to: func() Class {
+					class := transferClass("TAOCP", 2)
+					class.RequiresApproval = true
+
+					return class
+				}(),
+				wantErr: TransferRequiresApprovalError{CourseCode: "TAOCP"},
+			},
+		}
+
+		for _, tc := range testCases {
+			tc := tc
+
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+
+				var (
+					logger     = log.New(os.Stdout, "rejects invalid transfers ", log.LstdFlags)
+					validate   = validator.New()
+					atomicRepo = NewMockAtomicRepository(t)
+					repo       = NewMockRepository(t)
+					service    = New(logger, validate, atomicRepo)
+					ctx        = context.Background()
+					students   = Students{defaultStudent(t)}
+				)
+
+				atomicRepo.On(
+					"Execute",
+					ctx,
+					mock.AnythingOfType("AtomicOperation"),
+				).Return(func(ctx context.Context, op AtomicOperation) error {
+					return op(ctx, repo)
+				})
+
+				repo.On("GetClassByCourseCode", ctx, tc.from.Code).Return(tc.from, nil)
+				repo.On("GetClassForUpdate", ctx, tc.to.Code).Return(tc.to, nil)
+				repo.On(
+					"GetStudentsByEmail",
+					ctx,
+					students.EmailAddresses(),
+				).Return(Students{student}, nil).Maybe()
+
+				err := service.Transfer(ctx, tc.from.Code, tc.to.Code, students)
+				require.Equal(t, tc.wantErr, err)
+				repo.AssertNotCalled(t, "UnenrollStudents", mock.Anything, mock.Anything, mock.Anything)
+			})
+		}
+	})
+
+	t.Run("moves students between courses", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "moves students between courses ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			service    = New(logger, validate, atomicRepo)
+			ctx        = context.Background()
+			students   = Students{defaultStudent(t)}
+			registered = registeredStudents(t, students)
+			from       = transferClass("SICP", 2, registered...)
+			to         = transferClass("TAOCP", 2)
+		)
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On("GetClassByCourseCode", ctx, from.Code).Return(from, nil)
+		repo.On("GetClassForUpdate", ctx, to.Code).Return(to, nil)
+		repo.On("GetStudentsByEmail", ctx, students.EmailAddresses()).Return(registered, nil)
+		repo.On("UnenrollStudents", ctx, from.Course, registered).Return(Class{Course: from.Course}, nil)
+		repo.On("EnrollStudents", ctx, to.Course, registered).Return(Class{Course: to.Course, Students: registered}, nil)
+
+		err := service.Transfer(ctx, from.Code, to.Code, students)
+		require.NoError(t, err)
+	})
+
+	t.Run("evaluates the policies of the to course", func(t *testing.T) {
+		t.Parallel()
+
+		policyErr := errors.New("TAOCP is closed to transfers")
+
+		var (
+			logger     = log.New(os.Stdout, "evaluates the policies of the to course ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			ctx        = context.Background()
+			students   = Students{defaultStudent(t)}
+			registered = registeredStudents(t, students)
+			from       = transferClass("SICP", 2, registered...)
+			to         = transferClass("TAOCP", 2)
+			service    = New(logger, validate, atomicRepo, WithPolicies(EnrollmentPolicyFunc(
+				func(_ context.Context, _ Repository, class Class, _ Students) error {
+					if class.Code == to.Code {
+						return policyErr
+					}
+
+					return nil
+				},
+			)))
+		)
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On("GetClassByCourseCode", ctx, from.Code).Return(from, nil)
+		repo.On("GetClassForUpdate", ctx, to.Code).Return(to, nil)
+		repo.On("GetStudentsByEmail", ctx, students.EmailAddresses()).Return(registered, nil)
+
+		err := service.Transfer(ctx, from.Code, to.Code, students)
+		require.ErrorIs(t, err, policyErr)
+		repo.AssertNotCalled(t, "UnenrollStudents", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("ignores course loads, which a transfer doesn't change", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "ignores course loads, which a transfer doesn't change ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			service    = New(logger, validate, atomicRepo, WithDefaultMaxCourseLoad(1))
+			ctx        = context.Background()
+			students   = Students{defaultStudent(t)}
+			registered = registeredStudents(t, students)
+			from       = transferClass("SICP", 2, registered...)
+			to         = transferClass("TAOCP", 2)
+		)
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		// The mock repository fails the test if the course-load policy asks it
+		// for the students' course loads.
+		repo.On("GetClassByCourseCode", ctx, from.Code).Return(from, nil)
+		repo.On("GetClassForUpdate", ctx, to.Code).Return(to, nil)
+		repo.On("GetStudentsByEmail", ctx, students.EmailAddresses()).Return(registered, nil)
+		repo.On("UnenrollStudents", ctx, from.Course, registered).Return(Class{Course: from.Course}, nil)
+		repo.On("EnrollStudents", ctx, to.Course, registered).Return(Class{Course: to.Course, Students: registered}, nil)
+
+		err := service.Transfer(ctx, from.Code, to.Code, students)
+		require.NoError(t, err)
+	})
+
+	t.Run("notifies transferred students", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "notifies transferred students ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			notifier   = NewMockNotifier(t)
+			service    = New(logger, validate, atomicRepo, WithNotifier(notifier))
+			ctx        = context.Background()
+			students   = Students{defaultStudent(t)}
+			registered = registeredStudents(t, students)
+			from       = transferClass("SICP", 2, registered...)
+			to         = transferClass("TAOCP", 2)
+		)
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On("GetClassByCourseCode", ctx, from.Code).Return(from, nil)
+		repo.On("GetClassForUpdate", ctx, to.Code).Return(to, nil)
+		repo.On("GetStudentsByEmail", ctx, students.EmailAddresses()).Return(registered, nil)
+		repo.On("UnenrollStudents", ctx, from.Course, registered).Return(Class{Course: from.Course}, nil)
+		repo.On("EnrollStudents", ctx, to.Course, registered).Return(Class{Course: to.Course, Students: registered}, nil)
+
+		notifier.On("Notify", ctx, Notification{
+			Kind:       NotificationUnenrolled,
+			CourseCode: from.Code,
+			Student:    registered[0],
+		}).Return(nil).Once()
+		notifier.On("Notify", ctx, Notification{
+			Kind:       NotificationEnrolled,
+			CourseCode: to.Code,
+			Student:    registered[0],
+		}).Return(nil).Once()
+
+		err := service.Transfer(ctx, from.Code, to.Code, students)
+		require.NoError(t, err)
+	})
+
+	t.Run("places students in least-full section", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "places students in least-full section ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			service    = New(logger, validate, atomicRepo)
+			ctx        = context.Background()
+			students   = Students{defaultStudent(t)}
+			registered = registeredStudents(t, students)
+			from       = transferClass("ADV101", 2, registered...)
+			to         = sectionedClass(t)
+		)
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On("GetClassByCourseCode", ctx, from.Code).Return(from, nil)
+		repo.On("GetClassForUpdate", ctx, to.Code).Return(to, nil)
+		repo.On("GetStudentsByEmail", ctx, students.EmailAddresses()).Return(registered, nil)
+		repo.On("UnenrollStudents", ctx, from.Course, registered).Return(Class{Course: from.Course}, nil)
+		repo.On(
+			"EnrollStudentsInSection",
+			ctx,
+			to.Course,
+			mock.MatchedBy(func(sec Section) bool { return sec.Code == "B" }),
+			registered,
+		).Return(to, nil)
+
+		err := service.Transfer(ctx, from.Code, to.Code, students)
+		require.NoError(t, err)
+	})
+}
+
+// transferClass returns an unsectioned class with the given capacity and
+// enrolled students.
+func transferClass(code string, capacity uint32, students ...Student) Class {
+	return Class{
+		Course: Course{
+			Code:     code,
+			Capacity: capacity,
+		},
+		Students: students,
+	}
+}
@@ -0,0 +1,59 @@
+package classservice
+
+import (
+	"context"
+	"fmt"
+)
+
+// unenrollmentRequest is used to validate the arguments to Unenroll.
+type unenrollmentRequest struct {
+	CourseCode string   `validate:"required"`
+	Students   Students `validate:"min=1"`
+}
+
+// Unenroll withdraws the given students from the course matching courseCode,
+// freeing their places, and notifies them that they're no longer enrolled.
+// Students are identified by email address, and each must be actively enrolled
+// in the course.
+//
+// If any student isn't enrolled, an error is returned and the class is
+// unchanged.
+func (svc *classService) Unenroll(ctx context.Context, courseCode string, students Students) error {
+	req := unenrollmentRequest{CourseCode: courseCode, Students: students}
+	if err := svc.validate.Struct(req); err != nil {
+		return fmt.Errorf("Unenroll: %w", err)
+	}
+
+	var pending notifications
+
+	unenroll := func(ctx context.Context, repo Repository) error {
+		class, err := svc.getClass(ctx, repo, courseCode)
+		if err != nil {
+			return fmt.Errorf("Unenroll: %w", err)
+		}
+
+		if err := verifyStudentsEnrolled(class, students); err != nil {
+			return err
+		}
+
+		students := students.resolve(class.Students)
+
+		if _, err := repo.UnenrollStudents(ctx, class.Course, students); err != nil {
+			return fmt.Errorf("Unenroll: %w", err)
+		}
+
+		pending.add(NotificationUnenrolled, class.Code, "", students)
+
+		return nil
+	}
+
+	if err := svc.repo.Execute(ctx, unenroll); err != nil {
+		return err
+	}
+
+	svc.deliver(ctx, pending)
+
+	svc.publishAvailability(ctx, courseCode)
+
+	return nil
+}
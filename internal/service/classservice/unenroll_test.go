@@ -0,0 +1,143 @@
+//go:build unit
+
+package classservice
+
+import (
+	"context"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnenroll(t *testing.T) {
+	t.Parallel()
+
+	t.Run("validates arguments", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "validates arguments ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			service    = New(logger, validate, atomicRepo)
+		)
+
+		testCases := []struct {
+			name       string
+			courseCode string
+			students   Students
+		}{
+			{name: "missing course code", courseCode: "", students: Students{defaultStudent(t)}},
+			{name: "empty Students", courseCode: "SICP", students: Students{}},
+		}
+
+		for _, tc := range testCases {
+			tc := tc
+
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+
+				err := service.Unenroll(context.Background(), tc.courseCode, tc.students)
+
+				var validationErrs validator.ValidationErrors
+				require.ErrorAs(t, err, &validationErrs)
+			})
+		}
+	})
+
+	t.Run("withdraws enrolled students and notifies them", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "withdraws enrolled students ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			notifier   = NewMockNotifier(t)
+			service    = New(logger, validate, atomicRepo, WithNotifier(notifier))
+			ctx        = context.Background()
+			student    = defaultStudent(t)
+		)
+
+		student.ID = 1
+		class := transferClass("SICP", 2, student)
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On("GetClassByCourseCode", ctx, "SICP").Return(class, nil)
+		repo.On("UnenrollStudents", ctx, class.Course, Students{student}).Return(transferClass("SICP", 2), nil)
+		notifier.On("Notify", ctx, Notification{
+			Kind:       NotificationUnenrolled,
+			CourseCode: "SICP",
+			Student:    student,
+		}).Return(nil)
+
+		err := service.Unenroll(ctx, "SICP", Students{{Email: student.Email}})
+		require.NoError(t, err)
+	})
+
+	t.Run("rejects students who aren't enrolled", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "rejects students who aren't enrolled ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			service    = New(logger, validate, atomicRepo)
+			ctx        = context.Background()
+			students   = Students{{Email: "r.tifft@gmail.com"}}
+		)
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On("GetClassByCourseCode", ctx, "SICP").Return(transferClass("SICP", 2), nil)
+
+		err := service.Unenroll(ctx, "SICP", students)
+		require.Equal(t, NotEnrolledError{CourseCode: "SICP", Students: students}, err)
+	})
+}
+
+func TestListClasses(t *testing.T) {
+	t.Parallel()
+
+	var (
+		logger     = log.New(os.Stdout, "TestListClasses ", log.LstdFlags)
+		validate   = validator.New()
+		atomicRepo = NewMockAtomicRepository(t)
+		repo       = NewMockRepository(t)
+		service    = New(logger, validate, atomicRepo)
+		ctx        = context.Background()
+		classes    = []Class{transferClass("SICP", 2), transferClass("TAOCP", 3)}
+	)
+
+	atomicRepo.On(
+		"Execute",
+		ctx,
+		mock.AnythingOfType("AtomicOperation"),
+	).Return(func(ctx context.Context, op AtomicOperation) error {
+		return op(ctx, repo)
+	})
+
+	repo.On("GetClasses", ctx).Return(classes, nil)
+
+	got, err := service.ListClasses(ctx)
+	require.NoError(t, err)
+	require.Equal(t, classes, got)
+}
@@ -0,0 +1,257 @@
+package classservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/angusgmorrison/hexagonal/internal/primitive"
+	"github.com/angusgmorrison/hexagonal/pkg/slice"
+)
+
+// DiscountKind describes how a voucher reduces the fee of a course.
+type DiscountKind string
+
+const (
+	// DiscountPercentage vouchers take Percent percent off the fee.
+	DiscountPercentage DiscountKind = "percentage"
+
+	// DiscountFixed vouchers take a fixed Amount off the fee.
+	DiscountFixed DiscountKind = "fixed"
+)
+
+// Voucher discounts the fee of courses for the students who redeem it.
+//
+// A voucher may be redeemed once per student by at most MaxUses students, or
+// any number if MaxUses is zero. Redemptions aren't returned when enrollments
+// are cancelled. It may be redeemed from ValidFrom until
+// ValidUntil, either of which may be zero to leave the period open, and only
+// for the courses listed in CourseCodes, unless CourseCodes is empty.
+type Voucher struct {
+	ID          int64
+	Code        string       `validate:"required,max=64"`
+	Kind        DiscountKind `validate:"oneof=percentage fixed"`
+	Percent     uint32       `validate:"lte=100"`
+	Amount      primitive.Money
+	MaxUses     uint32
+	Uses        uint32
+	ValidFrom   time.Time
+	ValidUntil  time.Time
+	CourseCodes []string
+}
+
+// Remaining returns the number of times the voucher may still be redeemed, and
+// false if its use is unlimited.
+func (v Voucher) Remaining() (uint32, bool) {
+	if v.MaxUses == 0 {
+		return 0, false
+	}
+
+	if v.Uses >= v.MaxUses {
+		return 0, true
+	}
+
+	return v.MaxUses - v.Uses, true
+}
+
+// verify checks that the voucher describes a discount that can be applied to
+// a fee, which isn't enforced by its validation tags.
+func (v Voucher) verify() error {
+	invalid := func(reason string) error {
+		return InvalidVoucherError{Code: v.Code, Reason: reason}
+	}
+
+	switch v.Kind {
+	case DiscountPercentage:
+		if v.Percent == 0 {
+			return invalid("percentage vouchers must take between 1 and 100 percent off")
+		}
+
+		if !v.Amount.IsZero() {
+			return invalid("percentage vouchers can't take an amount off")
+		}
+	case DiscountFixed:
+		if v.Amount.Amount <= 0 {
+			return invalid("fixed vouchers must take a positive amount off")
+		}
+
+		if _, err := primitive.ParseCurrency(string(v.Amount.Currency)); err != nil {
+			return invalid(err.Error())
+		}
+
+		if v.Percent != 0 {
+			return invalid("fixed vouchers can't take a percentage off")
+		}
+	}
+
+	if !v.ValidFrom.IsZero() && !v.ValidUntil.IsZero() && !v.ValidUntil.After(v.ValidFrom) {
+		return invalid("the validity period ends before it begins")
+	}
+
+	return nil
+}
+
+// discountedFee returns the fee each student pays to enroll in the class
+// after redeeming the voucher at time t, or VoucherNotApplicableError if the
+// voucher can't be redeemed for the class at that time.
+func (v Voucher) discountedFee(class Class, t time.Time) (primitive.Money, error) {
+	notApplicable := func(reason string) error {
+		return VoucherNotApplicableError{Code: v.Code, CourseCode: class.Code, Reason: reason}
+	}
+
+	switch {
+	case class.Fee.IsZero():
+		return primitive.Money{}, notApplicable("the course is free")
+	case class.RequiresApproval:
+		return primitive.Money{}, notApplicable("the course requires approval")
+	case !v.ValidFrom.IsZero() && t.Before(v.ValidFrom):
+		return primitive.Money{}, notApplicable("the voucher is not yet valid")
+	case !v.ValidUntil.IsZero() && !t.Before(v.ValidUntil):
+		return primitive.Money{}, notApplicable("the voucher has expired")
+	case len(v.CourseCodes) > 0 && !slice.Includes(v.CourseCodes, class.Code):
+		return primitive.Money{}, notApplicable("the voucher is not valid for the course")
+	case v.Kind == DiscountFixed && v.Amount.Currency != class.Fee.Currency:
+		return primitive.Money{}, notApplicable(fmt.Sprintf(
+			"the voucher is in %s, but the course fee is in %s", v.Amount.Currency, class.Fee.Currency))
+	}
+
+	return v.apply(class.Fee), nil
+}
+
+// apply returns the fee less the voucher's discount, which is never more than
+// the fee itself. Percentage discounts are rounded down to a whole number of
+// minor units. The zero Voucher leaves the fee unchanged.
+func (v Voucher) apply(fee primitive.Money) primitive.Money {
+	var discount int64
+
+	switch v.Kind {
+	case DiscountPercentage:
+		discount = fee.Amount * int64(v.Percent) / 100
+	case DiscountFixed:
+		discount = v.Amount.Amount
+	}
+
+	if discount > fee.Amount {
+		discount = fee.Amount
+	}
+
+	return primitive.Money{Amount: fee.Amount - discount, Currency: fee.Currency}
+}
+
+// normalizeVoucherCode returns the canonical form of a voucher code, so that
+// students needn't match the case in which it was issued.
+func normalizeVoucherCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// CreateVoucher defines a new voucher, returning it with its ID populated.
+// Voucher codes are case-insensitive and must be unique. Every course named
+// by the voucher's CourseCodes must exist.
+func (svc *classService) CreateVoucher(ctx context.Context, voucher Voucher) (Voucher, error) {
+	voucher.Code = normalizeVoucherCode(voucher.Code)
+	voucher.Uses = 0
+
+	if err := svc.validate.Struct(voucher); err != nil {
+		return Voucher{}, fmt.Errorf("CreateVoucher: %w", err)
+	}
+
+	if err := voucher.verify(); err != nil {
+		return Voucher{}, err
+	}
+
+	var created Voucher
+
+	create := func(ctx context.Context, repo Repository) error {
+		_, err := repo.GetVoucherByCode(ctx, voucher.Code)
+		if err == nil {
+			return VoucherCodeTakenError{Code: voucher.Code}
+		}
+
+		var notFoundErr VoucherNotFoundError
+		if !errors.As(err, &notFoundErr) {
+			return fmt.Errorf("CreateVoucher: %w", err)
+		}
+
+		created, err = repo.CreateVoucher(ctx, voucher)
+		if err != nil {
+			return fmt.Errorf("CreateVoucher: %w", err)
+		}
+
+		return nil
+	}
+
+	if err := svc.repo.Execute(ctx, create); err != nil {
+		return Voucher{}, err
+	}
+
+	return created, nil
+}
+
+// GetVoucher returns the voucher with the given code, including the number of
+// times it has been redeemed.
+func (svc *classService) GetVoucher(ctx context.Context, code string) (Voucher, error) {
+	code = normalizeVoucherCode(code)
+
+	if err := svc.validate.Var(code, "required"); err != nil {
+		return Voucher{}, fmt.Errorf("GetVoucher: %w", err)
+	}
+
+	var voucher Voucher
+
+	get := func(ctx context.Context, repo Repository) error {
+		var err error
+
+		voucher, err = repo.GetVoucherByCode(ctx, code)
+		if err != nil {
+			return fmt.Errorf("GetVoucher: %w", err)
+		}
+
+		return nil
+	}
+
+	if err := svc.repo.Execute(ctx, get); err != nil {
+		return Voucher{}, err
+	}
+
+	return voucher, nil
+}
+
+// redeemVoucher redeems the voucher with the given code once for each of the
+// students enrolling in the class, who must not have redeemed it before. The
+// repository records the redemptions atomically, so that concurrent
+// enrollments can't redeem a voucher more than MaxUses times, or more than
+// once for any student.
+func (svc *classService) redeemVoucher(
+	ctx context.Context,
+	repo Repository,
+	class Class,
+	code string,
+	students Students,
+) (Voucher, error) {
+	voucher, err := repo.GetVoucherByCode(ctx, normalizeVoucherCode(code))
+	if err != nil {
+		return Voucher{}, fmt.Errorf("redeem voucher: %w", err)
+	}
+
+	if _, err := voucher.discountedFee(class, svc.now()); err != nil {
+		return Voucher{}, err
+	}
+
+	if err := repo.RecordVoucherRedemptions(ctx, voucher, students); err != nil {
+		var alreadyRedeemedErr VoucherAlreadyRedeemedError
+		if errors.As(err, &alreadyRedeemedErr) {
+			return Voucher{}, err
+		}
+
+		return Voucher{}, fmt.Errorf("redeem voucher: %w", err)
+	}
+
+	voucher, err = repo.RedeemVoucher(ctx, voucher, uint32(len(students)))
+	if err != nil {
+		return Voucher{}, fmt.Errorf("redeem voucher: %w", err)
+	}
+
+	return voucher, nil
+}
@@ -0,0 +1,466 @@
+//go:build unit
+
+package classservice
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/angusgmorrison/hexagonal/internal/primitive"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVoucherDiscountedFee(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2022, time.September, 1, 12, 0, 0, 0, time.UTC)
+	gbp := func(amount int64) primitive.Money { return primitive.Money{Amount: amount, Currency: "GBP"} }
+
+	testCases := []struct {
+		name       string
+		voucher    Voucher
+		modify     func(class *Class)
+		want       primitive.Money
+		wantReason string
+	}{
+		{
+			name:    "percentage",
+			voucher: Voucher{Code: "TENOFF", Kind: DiscountPercentage, Percent: 10},
+			want:    gbp(11250),
+		},
+		{
+			name:    "percentage rounds the discount down",
+			voucher: Voucher{Code: "THIRD", Kind: DiscountPercentage, Percent: 33},
+			want:    gbp(8375),
+		},
+		{
+			name:    "fixed",
+			voucher: Voucher{Code: "LISP25", Kind: DiscountFixed, Amount: gbp(2500)},
+			want:    gbp(10000),
+		},
+		{
+			name:    "fixed discount is capped at the fee",
+			voucher: Voucher{Code: "FREE", Kind: DiscountFixed, Amount: gbp(20000)},
+			want:    gbp(0),
+		},
+		{
+			name: "within validity period",
+			voucher: Voucher{
+				Code:       "AUTUMN",
+				Kind:       DiscountPercentage,
+				Percent:    50,
+				ValidFrom:  now,
+				ValidUntil: now.Add(time.Hour),
+			},
+			want: gbp(6250),
+		},
+		{
+			name:       "not yet valid",
+			voucher:    Voucher{Code: "WINTER", Kind: DiscountPercentage, Percent: 50, ValidFrom: now.Add(time.Hour)},
+			wantReason: "the voucher is not yet valid",
+		},
+		{
+			name:       "expired",
+			voucher:    Voucher{Code: "SUMMER", Kind: DiscountPercentage, Percent: 50, ValidUntil: now},
+			wantReason: "the voucher has expired",
+		},
+		{
+			name:       "restricted to other courses",
+			voucher:    Voucher{Code: "TAOCP10", Kind: DiscountPercentage, Percent: 10, CourseCodes: []string{"TAOCP"}},
+			wantReason: "the voucher is not valid for the course",
+		},
+		{
+			name:    "restricted to the course",
+			voucher: Voucher{Code: "SICP10", Kind: DiscountPercentage, Percent: 10, CourseCodes: []string{"TAOCP", "SICP"}},
+			want:    gbp(11250),
+		},
+		{
+			name:       "different currency",
+			voucher:    Voucher{Code: "USD25", Kind: DiscountFixed, Amount: primitive.Money{Amount: 2500, Currency: "USD"}},
+			wantReason: "the voucher is in USD, but the course fee is in GBP",
+		},
+		{
+			name:       "free course",
+			voucher:    Voucher{Code: "TENOFF", Kind: DiscountPercentage, Percent: 10},
+			modify:     func(class *Class) { class.Fee = primitive.Money{} },
+			wantReason: "the course is free",
+		},
+		{
+			name:       "course requires approval",
+			voucher:    Voucher{Code: "TENOFF", Kind: DiscountPercentage, Percent: 10},
+			modify:     func(class *Class) { class.RequiresApproval = true },
+			wantReason: "the course requires approval",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			class := feeClass(t)
+			if tc.modify != nil {
+				tc.modify(&class)
+			}
+
+			got, err := tc.voucher.discountedFee(class, now)
+
+			if tc.wantReason != "" {
+				var gotErr VoucherNotApplicableError
+				require.ErrorAs(t, err, &gotErr)
+				require.Equal(t, tc.wantReason, gotErr.Reason)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestCreateVoucher(t *testing.T) {
+	t.Parallel()
+
+	t.Run("creates the voucher with a normalized code", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "creates the voucher with a normalized code ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			service    = New(logger, validate, atomicRepo)
+			ctx        = context.Background()
+			voucher    = Voucher{Code: " welcome ", Kind: DiscountPercentage, Percent: 10, MaxUses: 100}
+			normalized = Voucher{Code: "WELCOME", Kind: DiscountPercentage, Percent: 10, MaxUses: 100}
+			created    = normalized
+		)
+
+		created.ID = 1
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On("GetVoucherByCode", ctx, "WELCOME").Return(Voucher{}, VoucherNotFoundError{Code: "WELCOME"})
+		repo.On("CreateVoucher", ctx, normalized).Return(created, nil)
+
+		got, err := service.CreateVoucher(ctx, voucher)
+		require.NoError(t, err)
+		require.Equal(t, created, got)
+	})
+
+	t.Run("rejects codes in use", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "rejects codes in use ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			service    = New(logger, validate, atomicRepo)
+			ctx        = context.Background()
+			voucher    = Voucher{Code: "WELCOME", Kind: DiscountPercentage, Percent: 10}
+		)
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On("GetVoucherByCode", ctx, "WELCOME").Return(Voucher{ID: 1, Code: "WELCOME"}, nil)
+
+		_, err := service.CreateVoucher(ctx, voucher)
+		require.ErrorIs(t, err, VoucherCodeTakenError{Code: "WELCOME"})
+	})
+
+	invalidCases := []struct {
+		name    string
+		voucher Voucher
+	}{
+		{
+			name:    "percentage without a percentage",
+			voucher: Voucher{Code: "NOTHING", Kind: DiscountPercentage},
+		},
+		{
+			name:    "percentage with an amount",
+			voucher: Voucher{Code: "BOTH", Kind: DiscountPercentage, Percent: 10, Amount: primitive.Money{Amount: 100, Currency: "GBP"}},
+		},
+		{
+			name:    "fixed without an amount",
+			voucher: Voucher{Code: "NOTHING", Kind: DiscountFixed},
+		},
+		{
+			name:    "fixed with an invalid currency",
+			voucher: Voucher{Code: "POUNDS", Kind: DiscountFixed, Amount: primitive.Money{Amount: 100, Currency: "£"}},
+		},
+		{
+			name: "validity period ends before it begins",
+			voucher: Voucher{
+				Code:       "BACKWARDS",
+				Kind:       DiscountPercentage,
+				Percent:    10,
+				ValidFrom:  time.Date(2022, time.September, 1, 0, 0, 0, 0, time.UTC),
+				ValidUntil: time.Date(2022, time.August, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	for _, tc := range invalidCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				logger     = log.New(os.Stdout, tc.name+" ", log.LstdFlags)
+				validate   = validator.New()
+				atomicRepo = NewMockAtomicRepository(t)
+				service    = New(logger, validate, atomicRepo)
+			)
+
+			_, err := service.CreateVoucher(context.Background(), tc.voucher)
+
+			var gotErr InvalidVoucherError
+			require.ErrorAs(t, err, &gotErr)
+		})
+	}
+}
+
+func TestEnrollWithVoucher(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2022, time.September, 1, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	t.Run("redeems the voucher and invoices the discounted fee", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "redeems the voucher and invoices the discounted fee ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			billing    = NewMockBilling(t)
+			service    = New(logger, validate, atomicRepo, WithClock(clock), WithBilling(billing))
+			ctx        = context.Background()
+			req        = threeStudentEnrollmentRequest(t)
+			class      = feeClass(t)
+			students   = registeredStudents(t, req.Students)
+			voucher    = Voucher{ID: 1, Code: "TENOFF", Kind: DiscountPercentage, Percent: 10, MaxUses: 5}
+			redeemed   = voucher
+		)
+
+		req.VoucherCode = "tenoff"
+		class.Capacity = 3
+		redeemed.Uses = 3
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On("GetClassForUpdate", ctx, req.CourseCode).Return(class, nil)
+		repo.On("GetStudentsByEmail", ctx, req.Students.EmailAddresses()).Return(students, nil)
+		repo.On("GetVoucherByCode", ctx, "TENOFF").Return(voucher, nil)
+		repo.On("RecordVoucherRedemptions", ctx, voucher, students).Return(nil)
+		repo.On("RedeemVoucher", ctx, voucher, uint32(3)).Return(redeemed, nil)
+		repo.On("EnrollStudentsAwaitingPayment", ctx, class.Course, Section{Students: students}, students).
+			Return(class, nil)
+
+		for i, student := range students {
+			unissued := Invoice{
+				CourseCode:  class.Code,
+				Student:     student,
+				Amount:      primitive.Money{Amount: 11250, Currency: "GBP"},
+				VoucherCode: "TENOFF",
+				IssuedAt:    now,
+			}
+			issued := unissued
+			issued.ID = fmt.Sprintf("inv_%06d", i+1)
+
+			billing.On("Issue", ctx, unissued).Return(issued, nil).Once()
+			repo.On("SaveInvoice", ctx, class.Course, issued).Return(nil).Once()
+		}
+
+		err := service.Enroll(ctx, req)
+		require.NoError(t, err)
+	})
+
+	t.Run("enrolls students immediately if the fee is waived", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "enrolls students immediately if the fee is waived ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			notifier   = NewMockNotifier(t)
+			service    = New(logger, validate, atomicRepo, WithClock(clock), WithNotifier(notifier))
+			ctx        = context.Background()
+			req        = defaultEnrollmentRequest(t)
+			class      = feeClass(t)
+			students   = registeredStudents(t, req.Students)
+			voucher    = Voucher{ID: 1, Code: "FREE", Kind: DiscountPercentage, Percent: 100}
+		)
+
+		req.VoucherCode = "FREE"
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On("GetClassForUpdate", ctx, req.CourseCode).Return(class, nil)
+		repo.On("GetStudentsByEmail", ctx, req.Students.EmailAddresses()).Return(students, nil)
+		repo.On("GetVoucherByCode", ctx, "FREE").Return(voucher, nil)
+		repo.On("RecordVoucherRedemptions", ctx, voucher, students).Return(nil)
+		repo.On("RedeemVoucher", ctx, voucher, uint32(1)).Return(voucher, nil)
+		repo.On("EnrollStudents", ctx, class.Course, students).Return(class, nil)
+		notifier.On("Notify", ctx, Notification{
+			Kind:       NotificationEnrolled,
+			CourseCode: class.Code,
+			Student:    students[0],
+		}).Return(nil)
+
+		err := service.Enroll(ctx, req)
+		require.NoError(t, err)
+	})
+
+	t.Run("fails if the voucher is exhausted", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "fails if the voucher is exhausted ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			service    = New(logger, validate, atomicRepo, WithClock(clock))
+			ctx        = context.Background()
+			req        = defaultEnrollmentRequest(t)
+			class      = feeClass(t)
+			students   = registeredStudents(t, req.Students)
+			voucher    = Voucher{ID: 1, Code: "TENOFF", Kind: DiscountPercentage, Percent: 10, MaxUses: 5, Uses: 5}
+			wantErr    = VoucherExhaustedError{Code: "TENOFF"}
+		)
+
+		req.VoucherCode = "TENOFF"
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On("GetClassForUpdate", ctx, req.CourseCode).Return(class, nil)
+		repo.On("GetStudentsByEmail", ctx, req.Students.EmailAddresses()).Return(students, nil)
+		repo.On("GetVoucherByCode", ctx, "TENOFF").Return(voucher, nil)
+		repo.On("RecordVoucherRedemptions", ctx, voucher, students).Return(nil)
+		repo.On("RedeemVoucher", ctx, voucher, uint32(1)).Return(Voucher{}, wantErr)
+
+		err := service.Enroll(ctx, req)
+
+		var gotErr VoucherExhaustedError
+		require.ErrorAs(t, err, &gotErr)
+		require.Equal(t, wantErr, gotErr, "unequal VoucherExhaustedErrors")
+	})
+
+	t.Run("fails if a student has already redeemed the voucher", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "fails if a student has already redeemed the voucher ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			service    = New(logger, validate, atomicRepo, WithClock(clock))
+			ctx        = context.Background()
+			req        = defaultEnrollmentRequest(t)
+			class      = feeClass(t)
+			students   = registeredStudents(t, req.Students)
+			voucher    = Voucher{ID: 1, Code: "TENOFF", Kind: DiscountPercentage, Percent: 10}
+			wantErr    = VoucherAlreadyRedeemedError{Code: "TENOFF", Students: students}
+		)
+
+		req.VoucherCode = "TENOFF"
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On("GetClassForUpdate", ctx, req.CourseCode).Return(class, nil)
+		repo.On("GetStudentsByEmail", ctx, req.Students.EmailAddresses()).Return(students, nil)
+		repo.On("GetVoucherByCode", ctx, "TENOFF").Return(voucher, nil)
+		repo.On("RecordVoucherRedemptions", ctx, voucher, students).Return(wantErr)
+
+		err := service.Enroll(ctx, req)
+
+		var gotErr VoucherAlreadyRedeemedError
+		require.ErrorAs(t, err, &gotErr)
+		require.Equal(t, wantErr, gotErr, "unequal VoucherAlreadyRedeemedErrors")
+		repo.AssertNotCalled(t, "RedeemVoucher", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("fails if the voucher doesn't apply", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "fails if the voucher doesn't apply ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			service    = New(logger, validate, atomicRepo, WithClock(clock))
+			ctx        = context.Background()
+			req        = defaultEnrollmentRequest(t)
+			class      = feeClass(t)
+			students   = registeredStudents(t, req.Students)
+			voucher    = Voucher{ID: 1, Code: "TAOCP10", Kind: DiscountPercentage, Percent: 10, CourseCodes: []string{"TAOCP"}}
+		)
+
+		req.VoucherCode = "TAOCP10"
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On("GetClassForUpdate", ctx, req.CourseCode).Return(class, nil)
+		repo.On("GetStudentsByEmail", ctx, req.Students.EmailAddresses()).Return(students, nil)
+		repo.On("GetVoucherByCode", ctx, "TAOCP10").Return(voucher, nil)
+
+		err := service.Enroll(ctx, req)
+
+		var gotErr VoucherNotApplicableError
+		require.ErrorAs(t, err, &gotErr)
+	})
+}
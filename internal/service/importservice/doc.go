@@ -0,0 +1,4 @@
+// Package importservice holds the business logic and data structures
+// associated with bulk imports of enrollments. Imports are processed
+// asynchronously, in batches, through the class service.
+package importservice
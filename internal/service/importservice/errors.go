@@ -0,0 +1,33 @@
+package importservice
+
+import "fmt"
+
+// ImportNotFoundError is returned when no import has the ID provided.
+type ImportNotFoundError struct {
+	ID int64
+}
+
+func (infe ImportNotFoundError) Error() string {
+	return fmt.Sprintf("no import with ID %d", infe.ID)
+}
+
+// TooManyRowsError is returned when an import lists more rows than the service
+// accepts in one import.
+type TooManyRowsError struct {
+	Rows    int
+	MaxRows int
+}
+
+func (tmre TooManyRowsError) Error() string {
+	return fmt.Sprintf("import lists %d rows, but at most %d are accepted", tmre.Rows, tmre.MaxRows)
+}
+
+// TooManyImportsError is returned when an import is started while the service
+// is already processing as many imports as it may run at once.
+type TooManyImportsError struct {
+	MaxConcurrent int
+}
+
+func (tmie TooManyImportsError) Error() string {
+	return fmt.Sprintf("%d imports are already running", tmie.MaxConcurrent)
+}
@@ -0,0 +1,254 @@
+package importservice
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/angusgmorrison/hexagonal/internal/service/classservice"
+)
+
+// StartImport records an import of the rows given and begins enrolling them in
+// the background. The import is returned in its pending state; its progress
+// may be followed using GetImport.
+//
+// Imports listing more rows than the service's maximum are refused with
+// TooManyRowsError, and imports started while the service is running as many
+// imports as it may at once are refused with TooManyImportsError.
+func (svc *importService) StartImport(ctx context.Context, rows Rows) (Import, error) {
+	if err := svc.validate.Var(rows, "min=1"); err != nil {
+		return Import{}, fmt.Errorf("StartImport: %w", err)
+	}
+
+	if len(rows) > svc.maxRows {
+		return Import{}, fmt.Errorf("StartImport: %w", TooManyRowsError{Rows: len(rows), MaxRows: svc.maxRows})
+	}
+
+	select {
+	case svc.slots <- struct{}{}:
+	default:
+		return Import{}, fmt.Errorf("StartImport: %w", TooManyImportsError{MaxConcurrent: svc.maxConcurrent})
+	}
+
+	now := svc.now()
+	imp := Import{
+		Status:    StatusPending,
+		TotalRows: uint32(len(rows)),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	create := func(ctx context.Context, repo Repository) error {
+		var err error
+
+		imp, err = repo.CreateImport(ctx, imp)
+		if err != nil {
+			return fmt.Errorf("StartImport: %w", err)
+		}
+
+		return nil
+	}
+
+	if err := svc.repo.Execute(ctx, create); err != nil {
+		<-svc.slots
+
+		return Import{}, err
+	}
+
+	// The import outlives the request that started it.
+	processCtx := context.WithoutCancel(ctx)
+
+	svc.running.Add(1)
+	svc.run(func() {
+		defer svc.running.Done()
+		defer func() { <-svc.slots }()
+
+		svc.process(processCtx, imp, rows)
+	})
+
+	return imp, nil
+}
+
+// GetImport returns the import with the given ID, including the errors
+// recorded against its rows so far.
+func (svc *importService) GetImport(ctx context.Context, id int64) (Import, error) {
+	if err := svc.validate.Var(id, "gt=0"); err != nil {
+		return Import{}, fmt.Errorf("GetImport: %w", err)
+	}
+
+	var imp Import
+
+	get := func(ctx context.Context, repo Repository) error {
+		var err error
+
+		imp, err = repo.GetImport(ctx, id)
+		if err != nil {
+			return fmt.Errorf("GetImport: %w", err)
+		}
+
+		return nil
+	}
+
+	if err := svc.repo.Execute(ctx, get); err != nil {
+		return Import{}, err
+	}
+
+	return imp, nil
+}
+
+// FailStalledImports marks as failed the unfinished imports whose progress
+// hasn't been saved within the stall timeout, such as those interrupted by the
+// server stopping, and returns the number of imports failed.
+func (svc *importService) FailStalledImports(ctx context.Context) (int, error) {
+	var (
+		now    = svc.now()
+		failed int
+	)
+
+	fail := func(ctx context.Context, repo Repository) error {
+		var err error
+
+		failed, err = repo.FailStalledImports(ctx, now.Add(-svc.stallTimeout), now)
+		if err != nil {
+			return fmt.Errorf("FailStalledImports: %w", err)
+		}
+
+		return nil
+	}
+
+	if err := svc.repo.Execute(ctx, fail); err != nil {
+		return 0, err
+	}
+
+	return failed, nil
+}
+
+// Shutdown waits for the imports being processed in the background to finish,
+// returning an error if ctx is done first.
+func (svc *importService) Shutdown(ctx context.Context) error {
+	done := make(chan struct{})
+
+	go func() {
+		svc.running.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("Shutdown: %w", ctx.Err())
+	}
+}
+
+// process enrolls the rows of an import in batches, saving its progress after
+// each batch. Failures to save progress are logged rather than abandoning the
+// import, since the rows already enrolled can't be undone.
+func (svc *importService) process(ctx context.Context, imp Import, rows Rows) {
+	imp.Status = StatusRunning
+	svc.saveProgress(ctx, imp, nil)
+
+	for start := 0; start < len(rows); start += svc.batchSize {
+		end := min(start+svc.batchSize, len(rows))
+
+		errs := svc.enrollBatch(ctx, rows[start:end])
+
+		imp.ProcessedRows += uint32(end - start)
+		imp.FailedRows += uint32(len(errs))
+
+		if end == len(rows) {
+			imp.Status = StatusCompleted
+			imp.CompletedAt = svc.now()
+		}
+
+		svc.saveProgress(ctx, imp, errs)
+	}
+}
+
+func (svc *importService) saveProgress(ctx context.Context, imp Import, errs RowErrors) {
+	imp.UpdatedAt = svc.now()
+
+	save := func(ctx context.Context, repo Repository) error {
+		return repo.SaveProgress(ctx, imp, errs)
+	}
+
+	if err := svc.repo.Execute(ctx, save); err != nil {
+		svc.logger.Printf("Failed to save progress of import %d: %v", imp.ID, err)
+	}
+}
+
+// enrollBatch enrolls the valid rows of a batch, making one enrollment request
+// per course. Since enrollment requests succeed or fail as a whole, the rows of
+// a failed request are retried individually to identify those at fault. The
+// errors for all failed rows are returned, ordered by line.
+func (svc *importService) enrollBatch(ctx context.Context, rows Rows) RowErrors {
+	var (
+		errs        RowErrors
+		courseCodes []string
+		byCourse    = make(map[string]Rows)
+		students    = make(map[int]classservice.Student, len(rows))
+	)
+
+	for _, row := range rows {
+		student, err := row.student()
+		if err != nil {
+			errs = append(errs, newRowError(row, err))
+
+			continue
+		}
+
+		if _, ok := byCourse[row.CourseCode]; !ok {
+			courseCodes = append(courseCodes, row.CourseCode)
+		}
+
+		byCourse[row.CourseCode] = append(byCourse[row.CourseCode], row)
+		students[row.Line] = student
+	}
+
+	enroll := func(courseCode string, rows Rows) error {
+		er := classservice.EnrollmentRequest{
+			CourseCode: courseCode,
+			Students:   make(classservice.Students, 0, len(rows)),
+		}
+
+		for _, row := range rows {
+			er.Students = append(er.Students, students[row.Line])
+		}
+
+		return svc.classService.Enroll(ctx, er)
+	}
+
+	for _, courseCode := range courseCodes {
+		group := byCourse[courseCode]
+
+		err := enroll(courseCode, group)
+		if err == nil {
+			continue
+		}
+
+		if len(group) == 1 {
+			errs = append(errs, newRowError(group[0], err))
+
+			continue
+		}
+
+		for _, row := range group {
+			if err := enroll(courseCode, Rows{row}); err != nil {
+				errs = append(errs, newRowError(row, err))
+			}
+		}
+	}
+
+	sort.Slice(errs, func(i, j int) bool { return errs[i].Line < errs[j].Line })
+
+	return errs
+}
+
+func newRowError(row Row, err error) RowError {
+	return RowError{
+		Line:       row.Line,
+		CourseCode: row.CourseCode,
+		Email:      row.Email,
+		Message:    err.Error(),
+	}
+}
@@ -0,0 +1,340 @@
+//go:build unit
+
+package importservice
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/angusgmorrison/hexagonal/internal/primitive"
+	"github.com/angusgmorrison/hexagonal/internal/service/classservice"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStartImport(t *testing.T) {
+	t.Parallel()
+
+	t.Run("validates rows", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "validates rows ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			service    = New(logger, validate, atomicRepo, classservice.NewMockInterface(t))
+		)
+
+		_, err := service.StartImport(context.Background(), Rows{})
+
+		var validationErrs validator.ValidationErrors
+		require.ErrorAs(t, err, &validationErrs)
+	})
+
+	t.Run("refuses imports with too many rows", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "refuses imports with too many rows ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			service    = New(logger, validate, atomicRepo, classservice.NewMockInterface(t), WithMaxRows(1))
+			rows       = Rows{{Line: 2}, {Line: 3}}
+		)
+
+		_, err := service.StartImport(context.Background(), rows)
+
+		var tooManyRowsErr TooManyRowsError
+		require.ErrorAs(t, err, &tooManyRowsErr)
+		require.Equal(t, TooManyRowsError{Rows: 2, MaxRows: 1}, tooManyRowsErr)
+	})
+
+	t.Run("refuses imports while the maximum are running", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "refuses imports while the maximum are running ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			service    = New(
+				logger,
+				validate,
+				atomicRepo,
+				classservice.NewMockInterface(t),
+				WithMaxConcurrent(1),
+			).(*importService)
+			ctx     = context.Background()
+			rows    = Rows{{Line: 2, CourseCode: "SICP"}}
+			started []func()
+		)
+
+		// Imports are held until the test runs them.
+		service.run = func(f func()) { started = append(started, f) }
+
+		atomicRepo.On(
+			"Execute",
+			mock.Anything,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On("CreateImport", ctx, mock.AnythingOfType("Import")).Return(Import{ID: 1}, nil).Twice()
+
+		_, err := service.StartImport(ctx, rows)
+		require.NoError(t, err)
+
+		_, err = service.StartImport(ctx, rows)
+
+		var tooManyImportsErr TooManyImportsError
+		require.ErrorAs(t, err, &tooManyImportsErr)
+
+		// Once the running import finishes, another may start. The row's
+		// birthdate is invalid, so it fails without enrolling anyone.
+		repo.On("SaveProgress", mock.Anything, mock.AnythingOfType("Import"), mock.Anything).Return(nil)
+
+		require.Len(t, started, 1)
+		started[0]()
+
+		_, err = service.StartImport(ctx, rows)
+		require.NoError(t, err)
+	})
+
+	t.Run("enrolls rows in batches and records errors", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger       = log.New(os.Stdout, "enrolls rows in batches ", log.LstdFlags)
+			validate     = validator.New()
+			atomicRepo   = NewMockAtomicRepository(t)
+			repo         = NewMockRepository(t)
+			classService = classservice.NewMockInterface(t)
+			now          = time.Date(2026, time.October, 18, 12, 0, 0, 0, time.UTC)
+			service      = New(logger, validate, atomicRepo, classService, WithBatchSize(2), WithClock(func() time.Time {
+				return now
+			})).(*importService)
+			ctx = context.Background()
+		)
+
+		service.run = func(f func()) { f() }
+
+		rows := Rows{
+			{Line: 2, CourseCode: "SICP", Name: "Angus Morrison", Birthdate: "1990-03-04", Email: "angus@example.com"},
+			{Line: 3, CourseCode: "SICP", Name: "Ramdas Tifft", Birthdate: "1985-11-22", Email: "r.tifft@gmail.com"},
+			{Line: 4, CourseCode: "TAOCP", Name: "Ada Lovelace", Birthdate: "10/12/1815", Email: "ada@example.com"},
+			{Line: 5, CourseCode: "TAOCP", Name: "Alan Turing", Birthdate: "1912-06-23", Email: "alan@example.com"},
+		}
+
+		var (
+			angus    = student(t, "Angus Morrison", "1990-03-04", "angus@example.com")
+			ramdas   = student(t, "Ramdas Tifft", "1985-11-22", "r.tifft@gmail.com")
+			alan     = student(t, "Alan Turing", "1912-06-23", "alan@example.com")
+			enrolled = classservice.AlreadyEnrolledError{Students: classservice.Students{ramdas}}
+		)
+
+		atomicRepo.On(
+			"Execute",
+			mock.Anything,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		pending := Import{Status: StatusPending, TotalRows: 4, CreatedAt: now, UpdatedAt: now}
+		created := pending
+		created.ID = 1
+
+		repo.On("CreateImport", ctx, pending).Return(created, nil)
+
+		classService.On("Enroll", mock.Anything, classservice.EnrollmentRequest{
+			CourseCode: "SICP",
+			Students:   classservice.Students{angus, ramdas},
+		}).Return(enrolled)
+		classService.On("Enroll", mock.Anything, classservice.EnrollmentRequest{
+			CourseCode: "SICP",
+			Students:   classservice.Students{angus},
+		}).Return(nil)
+		classService.On("Enroll", mock.Anything, classservice.EnrollmentRequest{
+			CourseCode: "SICP",
+			Students:   classservice.Students{ramdas},
+		}).Return(enrolled)
+		classService.On("Enroll", mock.Anything, classservice.EnrollmentRequest{
+			CourseCode: "TAOCP",
+			Students:   classservice.Students{alan},
+		}).Return(nil)
+
+		running := created
+		running.Status = StatusRunning
+
+		repo.On("SaveProgress", mock.Anything, running, RowErrors(nil)).Return(nil).Once()
+
+		firstBatch := running
+		firstBatch.ProcessedRows = 2
+		firstBatch.FailedRows = 1
+
+		repo.On("SaveProgress", mock.Anything, firstBatch, RowErrors{
+			{Line: 3, CourseCode: "SICP", Email: "r.tifft@gmail.com", Message: enrolled.Error()},
+		}).Return(nil).Once()
+
+		completed := firstBatch
+		completed.Status = StatusCompleted
+		completed.ProcessedRows = 4
+		completed.FailedRows = 2
+		completed.CompletedAt = now
+
+		repo.On("SaveProgress", mock.Anything, completed, RowErrors{
+			{Line: 4, CourseCode: "TAOCP", Email: "ada@example.com", Message: "birthdate must be formatted as 2006-01-02"},
+		}).Return(nil).Once()
+
+		got, err := service.StartImport(ctx, rows)
+		require.NoError(t, err)
+		require.Equal(t, created, got)
+	})
+}
+
+func TestFailStalledImports(t *testing.T) {
+	t.Parallel()
+
+	var (
+		logger     = log.New(os.Stdout, "TestFailStalledImports ", log.LstdFlags)
+		validate   = validator.New()
+		atomicRepo = NewMockAtomicRepository(t)
+		repo       = NewMockRepository(t)
+		now        = time.Date(2026, time.October, 18, 12, 0, 0, 0, time.UTC)
+		service    = New(
+			logger,
+			validate,
+			atomicRepo,
+			classservice.NewMockInterface(t),
+			WithStallTimeout(time.Minute),
+			WithClock(func() time.Time { return now }),
+		)
+		ctx = context.Background()
+	)
+
+	atomicRepo.On(
+		"Execute",
+		ctx,
+		mock.AnythingOfType("AtomicOperation"),
+	).Return(func(ctx context.Context, op AtomicOperation) error {
+		return op(ctx, repo)
+	})
+
+	repo.On("FailStalledImports", ctx, now.Add(-time.Minute), now).Return(2, nil)
+
+	failed, err := service.FailStalledImports(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, failed)
+}
+
+func TestShutdown(t *testing.T) {
+	t.Parallel()
+
+	newService := func(t *testing.T) (*importService, func()) {
+		t.Helper()
+
+		var (
+			logger     = log.New(os.Stdout, "TestShutdown ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			service    = New(logger, validate, atomicRepo, classservice.NewMockInterface(t)).(*importService)
+			release    = make(chan struct{})
+		)
+
+		atomicRepo.On(
+			"Execute",
+			mock.Anything,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On("CreateImport", mock.Anything, mock.AnythingOfType("Import")).Return(Import{ID: 1}, nil)
+		repo.On("SaveProgress", mock.Anything, mock.AnythingOfType("Import"), mock.Anything).Return(nil).Maybe()
+
+		// The import is held in the background until released.
+		service.run = func(f func()) {
+			go func() {
+				<-release
+				f()
+			}()
+		}
+
+		// The row is invalid, so processing it enrolls no one.
+		_, err := service.StartImport(context.Background(), Rows{{Line: 2}})
+		require.NoError(t, err)
+
+		return service, func() { close(release) }
+	}
+
+	t.Run("waits for running imports", func(t *testing.T) {
+		t.Parallel()
+
+		service, release := newService(t)
+		release()
+
+		require.NoError(t, service.Shutdown(context.Background()))
+	})
+
+	t.Run("gives up when the context is done", func(t *testing.T) {
+		t.Parallel()
+
+		service, release := newService(t)
+		defer release()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		require.ErrorIs(t, service.Shutdown(ctx), context.Canceled)
+	})
+}
+
+func TestGetImport(t *testing.T) {
+	t.Parallel()
+
+	var (
+		logger     = log.New(os.Stdout, "TestGetImport ", log.LstdFlags)
+		validate   = validator.New()
+		atomicRepo = NewMockAtomicRepository(t)
+		repo       = NewMockRepository(t)
+		service    = New(logger, validate, atomicRepo, classservice.NewMockInterface(t))
+		ctx        = context.Background()
+	)
+
+	atomicRepo.On(
+		"Execute",
+		ctx,
+		mock.AnythingOfType("AtomicOperation"),
+	).Return(func(ctx context.Context, op AtomicOperation) error {
+		return op(ctx, repo)
+	})
+
+	notFound := ImportNotFoundError{ID: 2}
+	imp := Import{ID: 1, Status: StatusRunning, TotalRows: 3, ProcessedRows: 1}
+
+	repo.On("GetImport", ctx, int64(1)).Return(imp, nil)
+	repo.On("GetImport", ctx, int64(2)).Return(Import{}, notFound)
+
+	got, err := service.GetImport(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, imp, got)
+
+	_, err = service.GetImport(ctx, 2)
+	require.True(t, errors.Is(err, notFound))
+}
+
+func student(t *testing.T, name, rawBirthdate string, email primitive.EmailAddress) classservice.Student {
+	t.Helper()
+
+	birthdate, err := primitive.ParseBirthdate(rawBirthdate)
+	require.NoError(t, err, "parse birthdate")
+
+	return classservice.Student{Name: name, Birthdate: birthdate, Email: email}
+}
@@ -0,0 +1,133 @@
+package importservice
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/angusgmorrison/hexagonal/internal/service/classservice"
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	// defaultBatchSize is the number of rows enrolled between updates to an
+	// import's progress, unless overridden using WithBatchSize.
+	defaultBatchSize = 100
+
+	// defaultStallTimeout is the time after which an unfinished import whose
+	// progress hasn't been saved is presumed interrupted, unless overridden
+	// using WithStallTimeout.
+	defaultStallTimeout = 10 * time.Minute
+
+	// defaultMaxRows is the largest number of rows an import may list, unless
+	// overridden using WithMaxRows.
+	defaultMaxRows = 10000
+
+	// defaultMaxConcurrent is the number of imports that may be processed at
+	// once, unless overridden using WithMaxConcurrent.
+	defaultMaxConcurrent = 4
+)
+
+// Interface specifies the business operations of the service.
+type Interface interface {
+	StartImport(ctx context.Context, rows Rows) (Import, error)
+	GetImport(ctx context.Context, id int64) (Import, error)
+	FailStalledImports(ctx context.Context) (int, error)
+	Shutdown(ctx context.Context) error
+}
+
+// New configures and returns an Interface implementation. Rows are enrolled
+// through classService.
+func New(
+	logger logger,
+	validate *validator.Validate,
+	repo AtomicRepository,
+	classService classservice.Interface,
+	opts ...Option,
+) Interface {
+	svc := importService{
+		logger:        logger,
+		validate:      validate,
+		repo:          repo,
+		classService:  classService,
+		now:           time.Now,
+		batchSize:     defaultBatchSize,
+		stallTimeout:  defaultStallTimeout,
+		maxRows:       defaultMaxRows,
+		maxConcurrent: defaultMaxConcurrent,
+		run:           func(f func()) { go f() },
+	}
+
+	for _, opt := range opts {
+		opt(&svc)
+	}
+
+	svc.slots = make(chan struct{}, svc.maxConcurrent)
+
+	return &svc
+}
+
+// importService implements importservice.Interface.
+type importService struct {
+	logger       logger
+	validate     *validator.Validate
+	repo         AtomicRepository
+	classService classservice.Interface
+
+	// now returns the current time.
+	now func() time.Time
+
+	// batchSize is the number of rows enrolled between updates to an
+	// import's progress.
+	batchSize int
+
+	// stallTimeout is the time after which an unfinished import whose
+	// progress hasn't been saved is presumed interrupted.
+	stallTimeout time.Duration
+
+	// maxRows is the largest number of rows an import may list.
+	maxRows int
+
+	// maxConcurrent is the number of imports that may be processed at once.
+	maxConcurrent int
+
+	// slots holds a token for each import being processed, so that no more
+	// than maxConcurrent run at once.
+	slots chan struct{}
+
+	// run processes imports in the background.
+	run func(func())
+
+	// running tracks the imports being processed, so that shutdown can wait
+	// for them.
+	running sync.WaitGroup
+}
+
+type AtomicOperation func(context.Context, Repository) error
+
+type AtomicRepository interface {
+	Execute(context.Context, AtomicOperation) error
+}
+
+type Repository interface {
+	// CreateImport writes a new import to the repository and returns it with
+	// its ID populated.
+	CreateImport(ctx context.Context, imp Import) (Import, error)
+
+	// GetImport loads the import with the given ID and the errors recorded
+	// against its rows.
+	GetImport(ctx context.Context, id int64) (Import, error)
+
+	// SaveProgress overwrites the status, counters and completion time of an
+	// existing import and records the row errors given against it.
+	SaveProgress(ctx context.Context, imp Import, errs RowErrors) error
+
+	// FailStalledImports marks as failed every pending or running import last
+	// updated before updatedBefore, recording failedAt as the time of its
+	// update and completion, and returns the number of imports failed.
+	FailStalledImports(ctx context.Context, updatedBefore, failedAt time.Time) (int, error)
+}
+
+type logger interface {
+	Printf(format string, args ...any)
+}
@@ -0,0 +1,102 @@
+package importservice
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/angusgmorrison/hexagonal/internal/primitive"
+	"github.com/angusgmorrison/hexagonal/internal/service/classservice"
+)
+
+// Status describes how far an import has progressed.
+type Status string
+
+const (
+	// StatusPending imports have been recorded but not yet started.
+	StatusPending Status = "pending"
+
+	// StatusRunning imports are having their rows enrolled.
+	StatusRunning Status = "running"
+
+	// StatusCompleted imports have had every row processed, whether or not
+	// each row was enrolled successfully.
+	StatusCompleted Status = "completed"
+
+	// StatusFailed imports were interrupted before every row was processed,
+	// and will not be resumed.
+	StatusFailed Status = "failed"
+)
+
+// Import tracks the enrollment of a batch of rows.
+type Import struct {
+	ID            int64
+	Status        Status
+	TotalRows     uint32
+	ProcessedRows uint32
+	FailedRows    uint32
+
+	// Errors explain why each failed row couldn't be enrolled, ordered by
+	// line.
+	Errors RowErrors
+
+	CreatedAt time.Time
+
+	// UpdatedAt is the time at which the import's progress was last saved.
+	UpdatedAt time.Time
+
+	// CompletedAt is zero until the import is completed or fails.
+	CompletedAt time.Time
+}
+
+// Row is a request to enroll a student in a course. Line identifies the row in
+// its source file, so that errors can be traced back to it. The remaining
+// fields are unvalidated input.
+type Row struct {
+	Line       int
+	CourseCode string
+	Name       string
+	Birthdate  string
+	Email      primitive.EmailAddress
+}
+
+// student validates the row and returns the student it describes.
+func (r Row) student() (classservice.Student, error) {
+	for _, field := range []struct {
+		name  string
+		value string
+	}{
+		{name: "course_code", value: r.CourseCode},
+		{name: "name", value: r.Name},
+		{name: "birthdate", value: r.Birthdate},
+		{name: "email", value: string(r.Email)},
+	} {
+		if field.value == "" {
+			return classservice.Student{}, fmt.Errorf("%s is required", field.name)
+		}
+	}
+
+	birthdate, err := primitive.ParseBirthdate(r.Birthdate)
+	if err != nil {
+		return classservice.Student{}, fmt.Errorf("birthdate must be formatted as %s", primitive.BirthdateLayout)
+	}
+
+	return classservice.Student{
+		Name:      r.Name,
+		Birthdate: birthdate,
+		Email:     r.Email,
+	}, nil
+}
+
+// Rows is a convenience wrapper.
+type Rows []Row
+
+// RowError explains why a row couldn't be enrolled.
+type RowError struct {
+	Line       int
+	CourseCode string
+	Email      primitive.EmailAddress
+	Message    string
+}
+
+// RowErrors is a convenience wrapper.
+type RowErrors []RowError
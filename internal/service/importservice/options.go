@@ -0,0 +1,57 @@
+package importservice
+
+import "time"
+
+// Option configures optional behaviour of the service returned by New.
+type Option func(*importService)
+
+// WithBatchSize sets the number of rows enrolled between updates to an
+// import's progress. The default is 100. Sizes less than one are ignored.
+func WithBatchSize(size int) Option {
+	return func(svc *importService) {
+		if size > 0 {
+			svc.batchSize = size
+		}
+	}
+}
+
+// WithStallTimeout sets the time after which an unfinished import whose
+// progress hasn't been saved is presumed interrupted and may be failed by
+// FailStalledImports. The default is ten minutes. Timeouts less than or equal
+// to zero are ignored.
+func WithStallTimeout(timeout time.Duration) Option {
+	return func(svc *importService) {
+		if timeout > 0 {
+			svc.stallTimeout = timeout
+		}
+	}
+}
+
+// WithMaxRows sets the largest number of rows an import may list. Larger
+// imports are refused with TooManyRowsError. The default is 10,000. Limits less
+// than one are ignored.
+func WithMaxRows(limit int) Option {
+	return func(svc *importService) {
+		if limit > 0 {
+			svc.maxRows = limit
+		}
+	}
+}
+
+// WithMaxConcurrent sets the number of imports that may be processed at once.
+// Imports started while that many are running are refused with
+// TooManyImportsError. The default is four. Limits less than one are ignored.
+func WithMaxConcurrent(limit int) Option {
+	return func(svc *importService) {
+		if limit > 0 {
+			svc.maxConcurrent = limit
+		}
+	}
+}
+
+// WithClock replaces the function used by the service to tell the time.
+func WithClock(now func() time.Time) Option {
+	return func(svc *importService) {
+		svc.now = now
+	}
+}
@@ -0,0 +1,3 @@
+// Package instructorservice holds the business logic and data structures
+// associated with the instructor domain.
+package instructorservice
@@ -0,0 +1,26 @@
+package instructorservice
+
+import (
+	"fmt"
+
+	"github.com/angusgmorrison/hexagonal/internal/primitive"
+)
+
+// InstructorNotFoundError is returned when no instructor has the ID provided.
+type InstructorNotFoundError struct {
+	ID int64
+}
+
+func (infe InstructorNotFoundError) Error() string {
+	return fmt.Sprintf("no instructor with ID %d", infe.ID)
+}
+
+// EmailTakenError is returned when attempting to give an instructor an email
+// address that belongs to another instructor.
+type EmailTakenError struct {
+	Email primitive.EmailAddress
+}
+
+func (ete EmailTakenError) Error() string {
+	return fmt.Sprintf("email address %s belongs to another instructor", ete.Email)
+}
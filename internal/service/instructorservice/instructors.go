@@ -0,0 +1,172 @@
+package instructorservice
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/angusgmorrison/hexagonal/internal/primitive"
+)
+
+// CreateInstructor registers a new instructor. The instructor's ID is ignored
+// and assigned by the repository. No two instructors may share an email
+// address.
+func (svc *instructorService) CreateInstructor(ctx context.Context, i Instructor) (Instructor, error) {
+	if err := svc.validate.Struct(i); err != nil {
+		return Instructor{}, fmt.Errorf("CreateInstructor: %w", err)
+	}
+
+	var created Instructor
+
+	create := func(ctx context.Context, repo Repository) error {
+		if err := verifyEmailAvailable(ctx, repo, 0, i.Email); err != nil {
+			return err
+		}
+
+		var err error
+
+		created, err = repo.CreateInstructor(ctx, i)
+		if err != nil {
+			return fmt.Errorf("CreateInstructor: %w", err)
+		}
+
+		return nil
+	}
+
+	if err := svc.repo.Execute(ctx, create); err != nil {
+		return Instructor{}, err
+	}
+
+	return created, nil
+}
+
+// GetInstructor returns the instructor with the given ID.
+func (svc *instructorService) GetInstructor(ctx context.Context, id int64) (Instructor, error) {
+	if err := svc.validate.Var(id, "gt=0"); err != nil {
+		return Instructor{}, fmt.Errorf("GetInstructor: %w", err)
+	}
+
+	var instructor Instructor
+
+	get := func(ctx context.Context, repo Repository) error {
+		var err error
+
+		instructor, err = repo.GetInstructor(ctx, id)
+		if err != nil {
+			return fmt.Errorf("GetInstructor: %w", err)
+		}
+
+		return nil
+	}
+
+	if err := svc.repo.Execute(ctx, get); err != nil {
+		return Instructor{}, err
+	}
+
+	return instructor, nil
+}
+
+// ListInstructors returns every instructor, ordered by name.
+func (svc *instructorService) ListInstructors(ctx context.Context) (Instructors, error) {
+	var instructors Instructors
+
+	list := func(ctx context.Context, repo Repository) error {
+		var err error
+
+		instructors, err = repo.ListInstructors(ctx)
+		if err != nil {
+			return fmt.Errorf("ListInstructors: %w", err)
+		}
+
+		return nil
+	}
+
+	if err := svc.repo.Execute(ctx, list); err != nil {
+		return nil, err
+	}
+
+	return instructors, nil
+}
+
+// UpdateInstructor replaces the name and email address of the instructor with
+// the ID of i. The new email address must not belong to another instructor.
+func (svc *instructorService) UpdateInstructor(ctx context.Context, i Instructor) (Instructor, error) {
+	if err := svc.validate.Var(i.ID, "gt=0"); err != nil {
+		return Instructor{}, fmt.Errorf("UpdateInstructor: %w", err)
+	}
+
+	if err := svc.validate.Struct(i); err != nil {
+		return Instructor{}, fmt.Errorf("UpdateInstructor: %w", err)
+	}
+
+	var updated Instructor
+
+	update := func(ctx context.Context, repo Repository) error {
+		if _, err := repo.GetInstructor(ctx, i.ID); err != nil {
+			return fmt.Errorf("UpdateInstructor: %w", err)
+		}
+
+		if err := verifyEmailAvailable(ctx, repo, i.ID, i.Email); err != nil {
+			return err
+		}
+
+		var err error
+
+		updated, err = repo.UpdateInstructor(ctx, i)
+		if err != nil {
+			return fmt.Errorf("UpdateInstructor: %w", err)
+		}
+
+		return nil
+	}
+
+	if err := svc.repo.Execute(ctx, update); err != nil {
+		return Instructor{}, err
+	}
+
+	return updated, nil
+}
+
+// DeleteInstructor removes the instructor with the given ID, unassigning them
+// from every class they teach.
+func (svc *instructorService) DeleteInstructor(ctx context.Context, id int64) error {
+	if err := svc.validate.Var(id, "gt=0"); err != nil {
+		return fmt.Errorf("DeleteInstructor: %w", err)
+	}
+
+	del := func(ctx context.Context, repo Repository) error {
+		instructor, err := repo.GetInstructor(ctx, id)
+		if err != nil {
+			return fmt.Errorf("DeleteInstructor: %w", err)
+		}
+
+		if err := repo.DeleteInstructor(ctx, instructor); err != nil {
+			return fmt.Errorf("DeleteInstructor: %w", err)
+		}
+
+		return nil
+	}
+
+	return svc.repo.Execute(ctx, del)
+}
+
+// verifyEmailAvailable checks that no instructor other than the one with ID
+// ownID has the given email address. ownID is zero for new instructors.
+func verifyEmailAvailable(
+	ctx context.Context,
+	repo Repository,
+	ownID int64,
+	email primitive.EmailAddress,
+) error {
+	existing, err := repo.GetInstructorsByEmail(ctx, []primitive.EmailAddress{email})
+	if err != nil {
+		return fmt.Errorf("verify email available: %w", err)
+	}
+
+	for _, instructor := range existing {
+		if instructor.ID != ownID {
+			return EmailTakenError{Email: email}
+		}
+	}
+
+	return nil
+}
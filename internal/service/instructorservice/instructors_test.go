@@ -0,0 +1,247 @@
+//go:build unit
+
+package instructorservice
+
+import (
+	"context"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/angusgmorrison/hexagonal/internal/primitive"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateInstructor(t *testing.T) {
+	t.Parallel()
+
+	t.Run("validates Instructor", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "validates Instructor ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			service    = New(logger, validate, atomicRepo)
+		)
+
+		testCases := []struct {
+			name       string
+			instructor Instructor
+		}{
+			{name: "missing name", instructor: Instructor{Email: "gjs@mit.edu"}},
+			{name: "missing email", instructor: Instructor{Name: "Gerald Sussman"}},
+			{name: "invalid email", instructor: Instructor{Name: "Gerald Sussman", Email: "gjs"}},
+		}
+
+		for _, tc := range testCases {
+			tc := tc
+
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+
+				_, err := service.CreateInstructor(context.Background(), tc.instructor)
+
+				var validationErrs validator.ValidationErrors
+				require.ErrorAs(t, err, &validationErrs)
+			})
+		}
+	})
+
+	t.Run("rejects email belonging to another instructor", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "rejects email belonging to another instructor ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			service    = New(logger, validate, atomicRepo)
+			ctx        = context.Background()
+			instructor = defaultInstructor()
+			existing   = Instructor{ID: 2, Name: "Hal Abelson", Email: instructor.Email}
+		)
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On(
+			"GetInstructorsByEmail",
+			ctx,
+			[]primitive.EmailAddress{instructor.Email},
+		).Return(Instructors{existing}, nil)
+
+		_, err := service.CreateInstructor(ctx, instructor)
+		require.ErrorIs(t, err, EmailTakenError{Email: instructor.Email})
+	})
+
+	t.Run("creates instructor", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "creates instructor ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			service    = New(logger, validate, atomicRepo)
+			ctx        = context.Background()
+			instructor = defaultInstructor()
+			created    = instructor
+		)
+
+		created.ID = 1
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On("GetInstructorsByEmail", ctx, []primitive.EmailAddress{instructor.Email}).Return(Instructors{}, nil)
+		repo.On("CreateInstructor", ctx, instructor).Return(created, nil)
+
+		got, err := service.CreateInstructor(ctx, instructor)
+		require.NoError(t, err)
+		require.Equal(t, created, got, "unexpected instructor")
+	})
+}
+
+func TestUpdateInstructor(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		existing Instructors
+		wantErr  error
+	}{
+		{
+			name:     "keeps own email",
+			existing: Instructors{{ID: 1, Name: "Gerry Sussman", Email: "gjs@mit.edu"}},
+			wantErr:  nil,
+		},
+		{
+			name:     "rejects email belonging to another instructor",
+			existing: Instructors{{ID: 2, Name: "Hal Abelson", Email: "gjs@mit.edu"}},
+			wantErr:  EmailTakenError{Email: "gjs@mit.edu"},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				logger     = log.New(os.Stdout, tc.name+" ", log.LstdFlags)
+				validate   = validator.New()
+				atomicRepo = NewMockAtomicRepository(t)
+				repo       = NewMockRepository(t)
+				service    = New(logger, validate, atomicRepo)
+				ctx        = context.Background()
+				instructor = defaultInstructor()
+			)
+
+			instructor.ID = 1
+
+			atomicRepo.On(
+				"Execute",
+				ctx,
+				mock.AnythingOfType("AtomicOperation"),
+			).Return(func(ctx context.Context, op AtomicOperation) error {
+				return op(ctx, repo)
+			})
+
+			repo.On("GetInstructor", ctx, instructor.ID).Return(instructor, nil)
+			repo.On("GetInstructorsByEmail", ctx, []primitive.EmailAddress{instructor.Email}).Return(tc.existing, nil)
+
+			if tc.wantErr == nil {
+				repo.On("UpdateInstructor", ctx, instructor).Return(instructor, nil)
+			}
+
+			_, err := service.UpdateInstructor(ctx, instructor)
+			if tc.wantErr == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestDeleteInstructor(t *testing.T) {
+	t.Parallel()
+
+	t.Run("rejects unknown instructor", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "rejects unknown instructor ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			service    = New(logger, validate, atomicRepo)
+			ctx        = context.Background()
+			wantErr    = InstructorNotFoundError{ID: 1}
+		)
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On("GetInstructor", ctx, int64(1)).Return(Instructor{}, wantErr)
+
+		err := service.DeleteInstructor(ctx, 1)
+		require.ErrorIs(t, err, wantErr)
+	})
+
+	t.Run("deletes instructor", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			logger     = log.New(os.Stdout, "deletes instructor ", log.LstdFlags)
+			validate   = validator.New()
+			atomicRepo = NewMockAtomicRepository(t)
+			repo       = NewMockRepository(t)
+			service    = New(logger, validate, atomicRepo)
+			ctx        = context.Background()
+			instructor = defaultInstructor()
+		)
+
+		instructor.ID = 1
+
+		atomicRepo.On(
+			"Execute",
+			ctx,
+			mock.AnythingOfType("AtomicOperation"),
+		).Return(func(ctx context.Context, op AtomicOperation) error {
+			return op(ctx, repo)
+		})
+
+		repo.On("GetInstructor", ctx, instructor.ID).Return(instructor, nil)
+		repo.On("DeleteInstructor", ctx, instructor).Return(nil)
+
+		err := service.DeleteInstructor(ctx, instructor.ID)
+		require.NoError(t, err)
+	})
+}
+
+func defaultInstructor() Instructor {
+	return Instructor{
+		Name:  "Gerald Sussman",
+		Email: "gjs@mit.edu",
+	}
+}
@@ -0,0 +1,64 @@
+package instructorservice
+
+import (
+	"context"
+
+	"github.com/angusgmorrison/hexagonal/internal/primitive"
+	"github.com/go-playground/validator/v10"
+)
+
+// Interface specifies the business operations of the service.
+type Interface interface {
+	CreateInstructor(ctx context.Context, i Instructor) (Instructor, error)
+	GetInstructor(ctx context.Context, id int64) (Instructor, error)
+	ListInstructors(ctx context.Context) (Instructors, error)
+	UpdateInstructor(ctx context.Context, i Instructor) (Instructor, error)
+	DeleteInstructor(ctx context.Context, id int64) error
+}
+
+// New configures and returns an Interface implementation.
+func New(logger logger, validate *validator.Validate, repo AtomicRepository) Interface {
+	return &instructorService{
+		logger:   logger,
+		validate: validate,
+		repo:     repo,
+	}
+}
+
+// instructorService implements instructorservice.Interface.
+type instructorService struct {
+	logger   logger
+	validate *validator.Validate
+	repo     AtomicRepository
+}
+
+type AtomicOperation func(context.Context, Repository) error
+
+type AtomicRepository interface {
+	Execute(context.Context, AtomicOperation) error
+}
+
+type Repository interface {
+	// ListInstructors loads every instructor.
+	ListInstructors(ctx context.Context) (Instructors, error)
+
+	// GetInstructor loads the instructor with the given ID.
+	GetInstructor(ctx context.Context, id int64) (Instructor, error)
+
+	// GetInstructorsByEmail loads all the instructors corresponding to the
+	// email addresses provided.
+	GetInstructorsByEmail(ctx context.Context, emails []primitive.EmailAddress) (Instructors, error)
+
+	// CreateInstructor writes a new instructor to the repository.
+	CreateInstructor(ctx context.Context, i Instructor) (Instructor, error)
+
+	// UpdateInstructor overwrites the details of an existing instructor.
+	UpdateInstructor(ctx context.Context, i Instructor) (Instructor, error)
+
+	// DeleteInstructor removes an instructor and their teaching assignments.
+	DeleteInstructor(ctx context.Context, i Instructor) error
+}
+
+type logger interface {
+	Printf(format string, args ...any)
+}
@@ -0,0 +1,14 @@
+package instructorservice
+
+import "github.com/angusgmorrison/hexagonal/internal/primitive"
+
+// Instructor represents a member of staff who teaches classes. The classes an
+// instructor teaches belong to the class domain.
+type Instructor struct {
+	ID    int64
+	Name  string                 `validate:"required"`
+	Email primitive.EmailAddress `validate:"required,email"`
+}
+
+// Instructors is a convenience wrapper.
+type Instructors []Instructor
@@ -0,0 +1,88 @@
+// Package rulefile provides an implementation of classservice.RuleSource that
+// reads declarative enrollment rules from a JSON file.
+//
+// The file maps course codes to rules:
+//
+//	{
+//	  "ADV101": "student.age >= 16 && course.code startsWith \"ADV\""
+//	}
+//
+// The file is re-read whenever its modification time changes, so rules can be
+// changed without restarting the application.
+package rulefile
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/angusgmorrison/hexagonal/internal/service/classservice"
+)
+
+// Source satisfies classservice.RuleSource. It is safe for concurrent use.
+type Source struct {
+	path string
+
+	mu      sync.Mutex
+	modTime time.Time
+	rules   map[string]string
+}
+
+var _ classservice.RuleSource = (*Source)(nil)
+
+// New returns a Source that reads rules from the file at path, or an error if
+// the file can't be loaded.
+func New(path string) (*Source, error) {
+	source := Source{path: path}
+
+	if _, err := source.load(); err != nil {
+		return nil, err
+	}
+
+	return &source, nil
+}
+
+// EnrollmentRule returns the rule for the course with the given code, or the
+// empty string if the file specifies no rule for the course.
+func (s *Source) EnrollmentRule(_ context.Context, courseCode string) (string, error) {
+	rules, err := s.load()
+	if err != nil {
+		return "", fmt.Errorf("EnrollmentRule(%q): %w", courseCode, err)
+	}
+
+	return rules[courseCode], nil
+}
+
+// load returns the rules in the file, re-reading it only if it has been
+// modified since it was last read.
+func (s *Source) load() (map[string]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("stat rules file: %w", err)
+	}
+
+	if s.rules != nil && info.ModTime().Equal(s.modTime) {
+		return s.rules, nil
+	}
+
+	contents, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("read rules file: %w", err)
+	}
+
+	rules := make(map[string]string)
+	if err := json.Unmarshal(contents, &rules); err != nil {
+		return nil, fmt.Errorf("parse rules file %s: %w", s.path, err)
+	}
+
+	s.rules = rules
+	s.modTime = info.ModTime()
+
+	return rules, nil
+}
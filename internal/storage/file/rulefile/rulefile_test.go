@@ -0,0 +1,70 @@
+//go:build unit
+
+package rulefile
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSource(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "rules.json")
+	writeRules(t, path, `{"SICP": "student.age >= 16"}`, time.Now().Add(-time.Minute))
+
+	source, err := New(path)
+	require.NoError(t, err)
+
+	rule, err := source.EnrollmentRule(context.Background(), "SICP")
+	require.NoError(t, err)
+	require.Equal(t, "student.age >= 16", rule)
+
+	rule, err = source.EnrollmentRule(context.Background(), "TAOCP")
+	require.NoError(t, err)
+	require.Empty(t, rule, "expected no rule for course")
+
+	// Modifying the file replaces the rules.
+	writeRules(t, path, `{"TAOCP": "student.age >= 18"}`, time.Now())
+
+	rule, err = source.EnrollmentRule(context.Background(), "SICP")
+	require.NoError(t, err)
+	require.Empty(t, rule, "expected rule to be removed")
+
+	rule, err = source.EnrollmentRule(context.Background(), "TAOCP")
+	require.NoError(t, err)
+	require.Equal(t, "student.age >= 18", rule)
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	t.Run("file does not exist", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := New(filepath.Join(t.TempDir(), "missing.json"))
+		require.Error(t, err)
+	})
+
+	t.Run("file is not valid JSON", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "rules.json")
+		writeRules(t, path, `{"SICP": `, time.Now())
+
+		_, err := New(path)
+		require.Error(t, err)
+	})
+}
+
+func writeRules(t *testing.T, path, contents string, modTime time.Time) {
+	t.Helper()
+
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0o600))
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+}
@@ -0,0 +1,149 @@
+// Package authrepo provides implementations of authservice.AtomicRepository
+// and authservice.Repository for use with an SQL database.
+package authrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/angusgmorrison/hexagonal/internal/service/authservice"
+	"github.com/angusgmorrison/hexagonal/internal/storage/sql"
+	"github.com/angusgmorrison/hexagonal/internal/storage/sql/table/apikeys"
+)
+
+// AtomicRepository satisfies authservice.AtomicRepository.
+type AtomicRepository struct {
+	db sql.Database
+}
+
+var _ authservice.AtomicRepository = (*AtomicRepository)(nil)
+
+// NewAtomic instantiates a new AtomicRepository using the database provided.
+func NewAtomic(db sql.Database) *AtomicRepository {
+	return &AtomicRepository{db: db}
+}
+
+// Execute decorates the given AtomicOperation with a transaction. If the
+// AtomicOperation returns an error, the transaction is rolled back. Otherwise,
+// the transaction is committed.
+func (ar *AtomicRepository) Execute(ctx context.Context, op authservice.AtomicOperation) error {
+	tx, err := ar.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = tx.Rollback() }()
+
+	authRepoWithTransaction := Repository{operator: tx}
+
+	if err := op(ctx, &authRepoWithTransaction); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Repository satisfies authservice.Repository. It is agnostic as to whether
+// its sql.TableOperator is a database or transaction.
+type Repository struct {
+	operator sql.TableOperator
+}
+
+var _ authservice.Repository = (*Repository)(nil)
+
+// GetAPIKeyByHash returns the API key with the given hash.
+func (r *Repository) GetAPIKeyByHash(ctx context.Context, hash []byte) (authservice.APIKey, error) {
+	row, err := apikeys.FindByHash(ctx, r.operator, hash)
+	if err != nil {
+		var notFoundErr apikeys.APIKeyNotFoundError
+		if errors.As(err, &notFoundErr) {
+			err = authservice.InvalidCredentialsError{Reason: "unknown API key"}
+		}
+
+		return authservice.APIKey{}, fmt.Errorf("GetAPIKeyByHash: %w", err)
+	}
+
+	return apiKeyFromRow(row), nil
+}
+
+// GetAPIKeysByName returns the API keys with the given name.
+func (r *Repository) GetAPIKeysByName(ctx context.Context, name string) (authservice.APIKeys, error) {
+	rows, err := apikeys.SelectByName(ctx, r.operator, name)
+	if err != nil {
+		return nil, fmt.Errorf("GetAPIKeysByName(%q): %w", name, err)
+	}
+
+	return apiKeysFromRows(rows), nil
+}
+
+// ListAPIKeys returns every API key, ordered by ID.
+func (r *Repository) ListAPIKeys(ctx context.Context) (authservice.APIKeys, error) {
+	rows, err := apikeys.All(ctx, r.operator)
+	if err != nil {
+		return nil, fmt.Errorf("ListAPIKeys: %w", err)
+	}
+
+	return apiKeysFromRows(rows), nil
+}
+
+// CreateAPIKey inserts a new API key and returns it with its ID populated.
+func (r *Repository) CreateAPIKey(ctx context.Context, key authservice.APIKey) (authservice.APIKey, error) {
+	row := apikeys.Row{
+		Name:      key.Name,
+		KeyHash:   key.Hash,
+		CreatedAt: key.CreatedAt,
+	}
+
+	rows, err := apikeys.Insert(ctx, r.operator, []apikeys.Row{row})
+	if err != nil {
+		return authservice.APIKey{}, fmt.Errorf("CreateAPIKey: %w", err)
+	}
+
+	return apiKeyFromRow(rows[0]), nil
+}
+
+// RevokeAPIKey records the time at which the API key with the given ID was
+// revoked, unless it was revoked already.
+func (r *Repository) RevokeAPIKey(ctx context.Context, id int64, at time.Time) error {
+	rows, err := apikeys.Revoke(ctx, r.operator, id, at)
+	if err != nil {
+		return fmt.Errorf("RevokeAPIKey: %w", err)
+	}
+
+	if len(rows) == 0 {
+		return fmt.Errorf("RevokeAPIKey: %w", authservice.APIKeyNotFoundError{ID: id})
+	}
+
+	return nil
+}
+
+func apiKeyFromRow(row apikeys.Row) authservice.APIKey {
+	key := authservice.APIKey{
+		ID:        row.ID,
+		Name:      row.Name,
+		Hash:      row.KeyHash,
+		CreatedAt: row.CreatedAt,
+	}
+
+	if row.RevokedAt != nil {
+		key.RevokedAt = *row.RevokedAt
+	}
+
+	return key
+}
+
+func apiKeysFromRows(rows []apikeys.Row) authservice.APIKeys {
+	keys := make(authservice.APIKeys, 0, len(rows))
+
+	for _, row := range rows {
+		keys = append(keys, apiKeyFromRow(row))
+	}
+
+	return keys
+}
@@ -5,14 +5,28 @@ package classrepo
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/angusgmorrison/hexagonal/internal/primitive"
 	"github.com/angusgmorrison/hexagonal/internal/service/classservice"
 	"github.com/angusgmorrison/hexagonal/internal/storage/sql"
+	"github.com/angusgmorrison/hexagonal/internal/storage/sql/table/assignments"
+	"github.com/angusgmorrison/hexagonal/internal/storage/sql/table/attendance"
+	"github.com/angusgmorrison/hexagonal/internal/storage/sql/table/certificates"
+	"github.com/angusgmorrison/hexagonal/internal/storage/sql/table/commandresults"
 	"github.com/angusgmorrison/hexagonal/internal/storage/sql/table/courses"
 	"github.com/angusgmorrison/hexagonal/internal/storage/sql/table/enrollments"
+	"github.com/angusgmorrison/hexagonal/internal/storage/sql/table/instructors"
+	"github.com/angusgmorrison/hexagonal/internal/storage/sql/table/invoices"
+	"github.com/angusgmorrison/hexagonal/internal/storage/sql/table/reservations"
+	"github.com/angusgmorrison/hexagonal/internal/storage/sql/table/sections"
+	"github.com/angusgmorrison/hexagonal/internal/storage/sql/table/sessions"
 	"github.com/angusgmorrison/hexagonal/internal/storage/sql/table/students"
+	"github.com/angusgmorrison/hexagonal/internal/storage/sql/table/vouchercourses"
+	"github.com/angusgmorrison/hexagonal/internal/storage/sql/table/voucherredemptions"
+	"github.com/angusgmorrison/hexagonal/internal/storage/sql/table/vouchers"
 )
 
 // AtomicRepository satisfies classservice.AtomicRepository.
@@ -30,6 +44,10 @@ func NewAtomic(db sql.Database) *AtomicRepository {
 // Execute decorates the given AtomicOperation with a transaction. If the
 // AtomicOperation returns an error, the transaction is rolled back. Otherwise,
 // the transaction is committed.
+//
+// Transactions run at the database's default isolation level, so operations
+// that check the places remaining in a course must load it using
+// GetClassForUpdate to keep concurrent operations from overfilling it.
 func (ar *AtomicRepository) Execute(
 	ctx context.Context,
 	op classservice.AtomicOperation,
@@ -68,31 +86,915 @@ func (r *Repository) GetClassByCourseCode(
 	ctx context.Context,
 	courseCode string,
 ) (classservice.Class, error) {
-	courseRow, err := courses.FindByCode(ctx, r.operator, courseCode)
+	class, err := r.getClassByCode(ctx, courses.FindByCode, courseCode)
 	if err != nil {
 		return classservice.Class{}, fmt.Errorf("GetClassByCourseCode(%q): %w", courseCode, err)
 	}
 
-	studentRows, err := students.OnCourse(ctx, r.operator, courseRow.ID)
+	return class, nil
+}
+
+// GetClassForUpdate returns a course and all its enrolled students from the
+// course code provided, locking the course's row until the end of the
+// transaction. Operations that check the places remaining in a course load it
+// this way, so that concurrent operations on the same course take turns.
+func (r *Repository) GetClassForUpdate(
+	ctx context.Context,
+	courseCode string,
+) (classservice.Class, error) {
+	class, err := r.getClassByCode(ctx, courses.FindByCodeForUpdate, courseCode)
+	if err != nil {
+		return classservice.Class{}, fmt.Errorf("GetClassForUpdate(%q): %w", courseCode, err)
+	}
+
+	return class, nil
+}
+
+func (r *Repository) getClassByCode(
+	ctx context.Context,
+	find func(context.Context, sql.Queryer, string) (courses.Row, error),
+	courseCode string,
+) (classservice.Class, error) {
+	courseRow, err := find(ctx, r.operator, courseCode)
+	if err != nil {
+		var notFoundErr courses.CourseNotFoundError
+		if errors.As(err, &notFoundErr) {
+			err = classservice.CourseNotFoundError{CourseCode: courseCode}
+		}
+
+		return classservice.Class{}, err
+	}
+
+	return r.getClass(ctx, courseRow)
+}
+
+// GetClassesTaughtBy returns every class that the instructor is assigned to
+// teach, ordered by course code.
+func (r *Repository) GetClassesTaughtBy(
+	ctx context.Context,
+	instructor classservice.Instructor,
+) ([]classservice.Class, error) {
+	courseRows, err := courses.TaughtBy(ctx, r.operator, instructor.ID)
+	if err != nil {
+		return nil, fmt.Errorf("GetClassesTaughtBy(%d): %w", instructor.ID, err)
+	}
+
+	classes := make([]classservice.Class, 0, len(courseRows))
+
+	for _, courseRow := range courseRows {
+		class, err := r.getClass(ctx, courseRow)
+		if err != nil {
+			return nil, fmt.Errorf("GetClassesTaughtBy(%d): %w", instructor.ID, err)
+		}
+
+		classes = append(classes, class)
+	}
+
+	return classes, nil
+}
+
+// GetClasses loads every class, including those of cancelled courses, ordered
+// by course code.
+func (r *Repository) GetClasses(ctx context.Context) ([]classservice.Class, error) {
+	courseRows, err := courses.All(ctx, r.operator)
+	if err != nil {
+		return nil, fmt.Errorf("GetClasses: %w", err)
+	}
+
+	classes := make([]classservice.Class, 0, len(courseRows))
+
+	for _, courseRow := range courseRows {
+		class, err := r.getClass(ctx, courseRow)
+		if err != nil {
+			return nil, fmt.Errorf("GetClasses: %w", err)
+		}
+
+		classes = append(classes, class)
+	}
+
+	return classes, nil
+}
+
+// getClass loads the students, sections, reservations, instructors, sessions
+// and attendance of a course.
+func (r *Repository) getClass(ctx context.Context, courseRow courses.Row) (classservice.Class, error) {
+	studentRows, err := students.OnCourse(ctx, r.operator, courseRow.ID, enrollments.StatusActive)
+	if err != nil {
+		return classservice.Class{}, err
+	}
+
+	pendingRows, err := students.OnCourse(ctx, r.operator, courseRow.ID, enrollments.StatusPending)
+	if err != nil {
+		return classservice.Class{}, err
+	}
+
+	unpaidRows, err := students.OnCourse(ctx, r.operator, courseRow.ID, enrollments.StatusPendingPayment)
+	if err != nil {
+		return classservice.Class{}, err
+	}
+
+	class := classFromRows(courseRow, studentRows)
+	class.Pending = studentsFromRows(pendingRows)
+	class.AwaitingPayment = studentsFromRows(unpaidRows)
+
+	prerequisiteRows, err := courses.PrerequisitesOf(ctx, r.operator, courseRow.ID)
+	if err != nil {
+		return classservice.Class{}, err
+	}
+
+	for _, prerequisite := range prerequisiteRows {
+		class.Prerequisites = append(class.Prerequisites, prerequisite.Code)
+	}
+
+	class.Sections, err = r.getSections(ctx, courseRow.ID)
+	if err != nil {
+		return classservice.Class{}, err
+	}
+
+	reservationRows, err := reservations.OnCourse(ctx, r.operator, courseRow.ID)
+	if err != nil {
+		return classservice.Class{}, err
+	}
+
+	class.Reservations = reservationsFromRows(reservationRows)
+
+	instructorRows, err := instructors.OnCourse(ctx, r.operator, courseRow.ID)
+	if err != nil {
+		return classservice.Class{}, err
+	}
+
+	class.Instructors = instructorsFromRows(instructorRows)
+
+	sessionRows, err := sessions.OnCourse(ctx, r.operator, courseRow.ID)
+	if err != nil {
+		return classservice.Class{}, err
+	}
+
+	class.Sessions = sessionsFromRows(sessionRows)
+
+	summaries, err := attendance.SummarizeCourse(ctx, r.operator, courseRow.ID)
+	if err != nil {
+		return classservice.Class{}, err
+	}
+
+	class.Attendance = attendanceFromSummaries(summaries)
+
+	return class, nil
+}
+
+// getSections returns the sections of a course and the students enrolled in
+// each.
+func (r *Repository) getSections(ctx context.Context, courseID int64) (classservice.Sections, error) {
+	sectionRows, err := sections.OnCourse(ctx, r.operator, courseID)
+	if err != nil {
+		return nil, err
+	}
+
+	classSections := make(classservice.Sections, 0, len(sectionRows))
+
+	for _, sRow := range sectionRows {
+		studentRows, err := students.InSection(ctx, r.operator, sRow.ID, enrollments.StatusActive)
+		if err != nil {
+			return nil, err
+		}
+
+		pendingRows, err := students.InSection(ctx, r.operator, sRow.ID, enrollments.StatusPending)
+		if err != nil {
+			return nil, err
+		}
+
+		unpaidRows, err := students.InSection(ctx, r.operator, sRow.ID, enrollments.StatusPendingPayment)
+		if err != nil {
+			return nil, err
+		}
+
+		section := sectionFromRows(sRow, studentRows)
+		section.Pending = studentsFromRows(pendingRows)
+		section.AwaitingPayment = studentsFromRows(unpaidRows)
+		classSections = append(classSections, section)
+	}
+
+	return classSections, nil
+}
+
+// GetStudentsByEmail returns all the students whose email addresses are
+// contained in the slice provided.
+func (r *Repository) GetStudentsByEmail(
+	ctx context.Context,
+	emails []primitive.EmailAddress,
+) (classservice.Students, error) {
+	studentRows, err := students.SelectByEmail(ctx, r.operator, emails)
+	if err != nil {
+		return nil, fmt.Errorf("GetStudentsByEmail(%v): %w", emails, err)
+	}
+
+	return studentsFromRows(studentRows), nil
+}
+
+// GetCourseLoads returns the number of courses each of the given students is
+// enrolled in, including those awaiting payment. Each student's ID field must
+// be populated.
+func (r *Repository) GetCourseLoads(
+	ctx context.Context,
+	stu classservice.Students,
+) (classservice.CourseLoads, error) {
+	counts, err := enrollments.CountCurrentByStudent(ctx, r.operator, stu.IDs())
+	if err != nil {
+		return nil, fmt.Errorf("GetCourseLoads: %w", err)
+	}
+
+	loads := make(classservice.CourseLoads, len(counts))
+
+	for _, count := range counts {
+		loads[count.StudentID] = count.Count
+	}
+
+	return loads, nil
+}
+
+// GetEnrollments returns the active, pending and unpaid enrollments of each of
+// the given students, ordered by course code. Each student's ID field must be
+// populated.
+func (r *Repository) GetEnrollments(
+	ctx context.Context,
+	stu classservice.Students,
+) (classservice.StudentEnrollments, error) {
+	rows, err := enrollments.CurrentByStudent(ctx, r.operator, stu.IDs())
+	if err != nil {
+		return nil, fmt.Errorf("GetEnrollments: %w", err)
+	}
+
+	studentEnrollments := make(classservice.StudentEnrollments, len(stu))
+
+	for _, row := range rows {
+		enrollment := classservice.Enrollment{
+			CourseCode: row.CourseCode,
+			Status:     enrollmentStatusFromRow(row.Status),
+		}
+
+		if row.SectionCode != nil {
+			enrollment.SectionCode = *row.SectionCode
+		}
+
+		studentEnrollments[row.StudentID] = append(studentEnrollments[row.StudentID], enrollment)
+	}
+
+	return studentEnrollments, nil
+}
+
+func enrollmentStatusFromRow(status string) classservice.EnrollmentStatus {
+	switch status {
+	case enrollments.StatusPending:
+		return classservice.EnrollmentPending
+	case enrollments.StatusPendingPayment:
+		return classservice.EnrollmentAwaitingPayment
+	default:
+		return classservice.EnrollmentActive
+	}
+}
+
+// GetInstructor returns the instructor with the given ID.
+func (r *Repository) GetInstructor(ctx context.Context, id int64) (classservice.Instructor, error) {
+	row, err := instructors.FindByID(ctx, r.operator, id)
+	if err != nil {
+		var notFoundErr instructors.InstructorNotFoundError
+		if errors.As(err, &notFoundErr) {
+			err = classservice.InstructorNotFoundError{ID: id}
+		}
+
+		return classservice.Instructor{}, fmt.Errorf("GetInstructor(%d): %w", id, err)
+	}
+
+	return instructorFromRow(row), nil
+}
+
+// AssignInstructor records that an instructor teaches a course, and returns the
+// latest state of the class.
+func (r *Repository) AssignInstructor(
+	ctx context.Context,
+	course classservice.Course,
+	instructor classservice.Instructor,
+) (classservice.Class, error) {
+	rows := []assignments.Row{{CourseID: course.ID, InstructorID: instructor.ID}}
+
+	rows, err := assignments.Insert(ctx, r.operator, rows)
+	if err != nil {
+		return classservice.Class{}, fmt.Errorf("AssignInstructor: %w", err)
+	}
+
+	if len(rows) == 0 {
+		return classservice.Class{}, fmt.Errorf(
+			"AssignInstructor: instructor %d is already assigned to course %q", instructor.ID, course.Code)
+	}
+
+	class, err := r.GetClassByCourseCode(ctx, course.Code)
+	if err != nil {
+		return classservice.Class{}, fmt.Errorf("AssignInstructor: %w", err)
+	}
+
+	return class, nil
+}
+
+// UnassignInstructor records that an instructor no longer teaches a course, and
+// returns the latest state of the class.
+func (r *Repository) UnassignInstructor(
+	ctx context.Context,
+	course classservice.Course,
+	instructor classservice.Instructor,
+) (classservice.Class, error) {
+	rows, err := assignments.Delete(ctx, r.operator, course.ID, instructor.ID)
+	if err != nil {
+		return classservice.Class{}, fmt.Errorf("UnassignInstructor: %w", err)
+	}
+
+	if len(rows) == 0 {
+		return classservice.Class{}, fmt.Errorf(
+			"UnassignInstructor: instructor %d is not assigned to course %q", instructor.ID, course.Code)
+	}
+
+	class, err := r.GetClassByCourseCode(ctx, course.Code)
+	if err != nil {
+		return classservice.Class{}, fmt.Errorf("UnassignInstructor: %w", err)
+	}
+
+	return class, nil
+}
+
+// GetCompletions returns the courses each of the given students has completed,
+// in order of completion. Each student's ID field must be populated.
+func (r *Repository) GetCompletions(
+	ctx context.Context,
+	stu classservice.Students,
+) (classservice.StudentCompletions, error) {
+	rows, err := enrollments.CompletionsByStudent(ctx, r.operator, stu.IDs())
+	if err != nil {
+		return nil, fmt.Errorf("GetCompletions: %w", err)
+	}
+
+	completions := make(classservice.StudentCompletions, len(stu))
+
+	for _, row := range rows {
+		completions[row.StudentID] = append(completions[row.StudentID], classservice.Completion{
+			CourseCode:  row.CourseCode,
+			Grade:       row.Grade,
+			CompletedAt: row.CompletedAt,
+		})
+	}
+
+	return completions, nil
+}
+
+// CompleteEnrollment marks the active enrollment of a student in a course as
+// completed at time t with the given grade, and returns the latest state of the
+// class.
+func (r *Repository) CompleteEnrollment(
+	ctx context.Context,
+	course classservice.Course,
+	stu classservice.Student,
+	grade string,
+	t time.Time,
+) (classservice.Class, error) {
+	rows, err := enrollments.Complete(ctx, r.operator, course.ID, stu.ID, grade, t)
+	if err != nil {
+		return classservice.Class{}, fmt.Errorf("CompleteEnrollment: %w", err)
+	}
+
+	if len(rows) == 0 {
+		return classservice.Class{}, fmt.Errorf(
+			"CompleteEnrollment: no active enrollment of student %d in course %q", stu.ID, course.Code)
+	}
+
+	class, err := r.GetClassByCourseCode(ctx, course.Code)
+	if err != nil {
+		return classservice.Class{}, fmt.Errorf("CompleteEnrollment: %w", err)
+	}
+
+	return class, nil
+}
+
+// CreateSessions adds sessions starting at each of the given times to a
+// course, and returns the sessions created. Times at which the course already
+// has a session are skipped.
+func (r *Repository) CreateSessions(
+	ctx context.Context,
+	course classservice.Course,
+	startTimes []time.Time,
+) (classservice.Sessions, error) {
+	rows := make([]sessions.Row, 0, len(startTimes))
+	for _, startsAt := range startTimes {
+		rows = append(rows, sessions.Row{CourseID: course.ID, StartsAt: startsAt})
+	}
+
+	insertedRows, err := sessions.Insert(ctx, r.operator, rows)
+	if err != nil {
+		return nil, fmt.Errorf("CreateSessions: %w", err)
+	}
+
+	return sessionsFromRows(insertedRows), nil
+}
+
+// RecordAttendance records at time t whether students were present at
+// sessions of a course, and returns the latest state of the class. Each
+// student must be actively enrolled in the course.
+func (r *Repository) RecordAttendance(
+	ctx context.Context,
+	course classservice.Course,
+	records []classservice.AttendanceRecord,
+	t time.Time,
+) (classservice.Class, error) {
+	enrollmentRows, err := enrollments.OnCourse(ctx, r.operator, course.ID, enrollments.StatusActive)
+	if err != nil {
+		return classservice.Class{}, fmt.Errorf("RecordAttendance: %w", err)
+	}
+
+	enrollmentIDs := make(map[int64]int64, len(enrollmentRows))
+	for _, row := range enrollmentRows {
+		enrollmentIDs[row.StudentID] = row.ID
+	}
+
+	rows := make([]attendance.Row, 0, len(records))
+
+	for _, record := range records {
+		enrollmentID, ok := enrollmentIDs[record.Student.ID]
+		if !ok {
+			return classservice.Class{}, fmt.Errorf(
+				"RecordAttendance: no active enrollment of student %d in course %q", record.Student.ID, course.Code)
+		}
+
+		rows = append(rows, attendance.Row{
+			EnrollmentID: enrollmentID,
+			SessionID:    record.SessionID,
+			Present:      record.Present,
+			RecordedAt:   t,
+		})
+	}
+
+	if _, err := attendance.Upsert(ctx, r.operator, rows); err != nil {
+		return classservice.Class{}, fmt.Errorf("RecordAttendance: %w", err)
+	}
+
+	class, err := r.GetClassByCourseCode(ctx, course.Code)
+	if err != nil {
+		return classservice.Class{}, fmt.Errorf("RecordAttendance: %w", err)
+	}
+
+	return class, nil
+}
+
+// EnrollStudentsAwaitingPayment writes the enrollment of students in a course
+// that charges a fee, pending payment, and returns the latest state of the
+// class. The students are placed in section unless it is zero.
+func (r *Repository) EnrollStudentsAwaitingPayment(
+	ctx context.Context,
+	course classservice.Course,
+	section classservice.Section,
+	stu classservice.Students,
+) (classservice.Class, error) {
+	rows := enrollmentRowsFromCouseAndStudents(course, stu, enrollments.StatusPendingPayment)
+	for i := range rows {
+		rows[i].SectionID = sectionID(section)
+	}
+
+	if _, err := enrollments.Insert(ctx, r.operator, rows); err != nil {
+		return classservice.Class{}, fmt.Errorf("EnrollStudentsAwaitingPayment: %w", err)
+	}
+
+	class, err := r.GetClassByCourseCode(ctx, course.Code)
+	if err != nil {
+		return classservice.Class{}, fmt.Errorf("EnrollStudentsAwaitingPayment: %w", err)
+	}
+
+	return class, nil
+}
+
+// AwaitPayment moves the pending enrollment of a student to await payment,
+// placing them in section unless it is zero, and returns the latest state of
+// the class.
+func (r *Repository) AwaitPayment(
+	ctx context.Context,
+	course classservice.Course,
+	section classservice.Section,
+	stu classservice.Student,
+) (classservice.Class, error) {
+	class, err := r.transitionEnrollment(
+		ctx, course, stu, enrollments.StatusPending, enrollments.StatusPendingPayment, sectionID(section))
+	if err != nil {
+		return classservice.Class{}, fmt.Errorf("AwaitPayment: %w", err)
+	}
+
+	return class, nil
+}
+
+// SaveInvoice records an invoice issued for the enrollment of a student in a
+// course, which must be awaiting payment.
+func (r *Repository) SaveInvoice(ctx context.Context, course classservice.Course, inv classservice.Invoice) error {
+	enrollmentRows, err := enrollments.OnCourse(ctx, r.operator, course.ID, enrollments.StatusPendingPayment)
+	if err != nil {
+		return fmt.Errorf("SaveInvoice: %w", err)
+	}
+
+	for _, enrollment := range enrollmentRows {
+		if enrollment.StudentID != inv.Student.ID {
+			continue
+		}
+
+		row := invoices.Row{
+			Reference:    inv.ID,
+			EnrollmentID: enrollment.ID,
+			Amount:       inv.Amount.Amount,
+			Currency:     string(inv.Amount.Currency),
+			IssuedAt:     inv.IssuedAt,
+		}
+
+		if inv.VoucherCode != "" {
+			voucherRow, err := vouchers.FindByCode(ctx, r.operator, inv.VoucherCode)
+			if err != nil {
+				return fmt.Errorf("SaveInvoice: %w", err)
+			}
+
+			row.VoucherID = &voucherRow.ID
+		}
+
+		if _, err := invoices.Insert(ctx, r.operator, []invoices.Row{row}); err != nil {
+			return fmt.Errorf("SaveInvoice: %w", err)
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf(
+		"SaveInvoice: no enrollment of student %d in course %q is awaiting payment", inv.Student.ID, course.Code)
+}
+
+// GetInvoice returns the invoice with the given ID.
+func (r *Repository) GetInvoice(ctx context.Context, id string) (classservice.Invoice, error) {
+	billed, err := invoices.FindByReference(ctx, r.operator, id)
+	if err != nil {
+		var notFoundErr invoices.InvoiceNotFoundError
+		if errors.As(err, &notFoundErr) {
+			err = classservice.InvoiceNotFoundError{InvoiceID: id}
+		}
+
+		return classservice.Invoice{}, fmt.Errorf("GetInvoice: %w", err)
+	}
+
+	return invoiceFromBilled(billed), nil
+}
+
+// MarkInvoicePaid records that an invoice was paid at time t, activates the
+// enrollment it billed, and returns the latest state of the class.
+func (r *Repository) MarkInvoicePaid(
+	ctx context.Context,
+	course classservice.Course,
+	inv classservice.Invoice,
+	t time.Time,
+) (classservice.Class, error) {
+	rows, err := invoices.MarkPaid(ctx, r.operator, inv.ID, t)
+	if err != nil {
+		return classservice.Class{}, fmt.Errorf("MarkInvoicePaid: %w", err)
+	}
+
+	if len(rows) == 0 {
+		return classservice.Class{}, fmt.Errorf("MarkInvoicePaid: invoice %q is not outstanding", inv.ID)
+	}
+
+	class, err := r.transitionEnrollment(
+		ctx, course, inv.Student, enrollments.StatusPendingPayment, enrollments.StatusActive, nil)
+	if err != nil {
+		return classservice.Class{}, fmt.Errorf("MarkInvoicePaid: %w", err)
+	}
+
+	return class, nil
+}
+
+// CreateVoucher records a new voucher, restricting it to the courses named by
+// its CourseCodes, and returns it with its ID populated.
+func (r *Repository) CreateVoucher(ctx context.Context, voucher classservice.Voucher) (classservice.Voucher, error) {
+	courseRows := make([]courses.Row, 0, len(voucher.CourseCodes))
+
+	for _, code := range voucher.CourseCodes {
+		cRow, err := courses.FindByCode(ctx, r.operator, code)
+		if err != nil {
+			var notFoundErr courses.CourseNotFoundError
+			if errors.As(err, &notFoundErr) {
+				err = classservice.CourseNotFoundError{CourseCode: code}
+			}
+
+			return classservice.Voucher{}, fmt.Errorf("CreateVoucher: %w", err)
+		}
+
+		courseRows = append(courseRows, cRow)
+	}
+
+	rows, err := vouchers.Insert(ctx, r.operator, []vouchers.Row{rowFromVoucher(voucher)})
+	if err != nil {
+		return classservice.Voucher{}, fmt.Errorf("CreateVoucher: %w", err)
+	}
+
+	created := voucherFromRow(rows[0])
+	created.CourseCodes = voucher.CourseCodes
+
+	if len(courseRows) == 0 {
+		return created, nil
+	}
+
+	restrictions := make([]vouchercourses.Row, 0, len(courseRows))
+	for _, cRow := range courseRows {
+		restrictions = append(restrictions, vouchercourses.Row{VoucherID: created.ID, CourseID: cRow.ID})
+	}
+
+	if _, err := vouchercourses.Insert(ctx, r.operator, restrictions); err != nil {
+		return classservice.Voucher{}, fmt.Errorf("CreateVoucher: %w", err)
+	}
+
+	return created, nil
+}
+
+// GetVoucherByCode loads the voucher with the given code, together with the
+// codes of the courses for which it may be redeemed.
+func (r *Repository) GetVoucherByCode(ctx context.Context, code string) (classservice.Voucher, error) {
+	row, err := vouchers.FindByCode(ctx, r.operator, code)
+	if err != nil {
+		var notFoundErr vouchers.VoucherNotFoundError
+		if errors.As(err, &notFoundErr) {
+			err = classservice.VoucherNotFoundError{Code: code}
+		}
+
+		return classservice.Voucher{}, fmt.Errorf("GetVoucherByCode: %w", err)
+	}
+
+	return r.loadVoucher(ctx, row)
+}
+
+// RedeemVoucher adds uses to the number of times a voucher has been redeemed
+// and returns the updated voucher. If that would exceed the voucher's MaxUses,
+// the voucher is left unchanged and classservice.VoucherExhaustedError is
+// returned.
+func (r *Repository) RedeemVoucher(
+	ctx context.Context,
+	voucher classservice.Voucher,
+	uses uint32,
+) (classservice.Voucher, error) {
+	rows, err := vouchers.Redeem(ctx, r.operator, voucher.ID, uses)
+	if err != nil {
+		return classservice.Voucher{}, fmt.Errorf("RedeemVoucher: %w", err)
+	}
+
+	if len(rows) > 0 {
+		return r.loadVoucher(ctx, rows[0])
+	}
+
+	// Report how many uses remain, which may be fewer than when the voucher
+	// was loaded if it has since been redeemed concurrently.
+	current, err := r.GetVoucherByCode(ctx, voucher.Code)
+	if err != nil {
+		return classservice.Voucher{}, fmt.Errorf("RedeemVoucher: %w", err)
+	}
+
+	remaining, _ := current.Remaining()
+
+	return classservice.Voucher{}, classservice.VoucherExhaustedError{Code: voucher.Code, Remaining: remaining}
+}
+
+// RecordVoucherRedemptions records that each of the students has redeemed the
+// voucher. The students must have IDs. If any of them had already redeemed it,
+// classservice.VoucherAlreadyRedeemedError is returned listing them, and the
+// caller must roll back the redemptions recorded for the others.
+func (r *Repository) RecordVoucherRedemptions(
+	ctx context.Context,
+	voucher classservice.Voucher,
+	stu classservice.Students,
+) error {
+	rows := make([]voucherredemptions.Row, 0, len(stu))
+	for _, s := range stu {
+		rows = append(rows, voucherredemptions.Row{VoucherID: voucher.ID, StudentID: s.ID})
+	}
+
+	inserted, err := voucherredemptions.InsertNew(ctx, r.operator, rows)
 	if err != nil {
-		return classservice.Class{}, fmt.Errorf("GetClassByCourseCode(%q): %w", courseCode, err)
+		return fmt.Errorf("RecordVoucherRedemptions: %w", err)
+	}
+
+	if len(inserted) == len(rows) {
+		return nil
+	}
+
+	recorded := make(map[int64]bool, len(inserted))
+	for _, row := range inserted {
+		recorded[row.StudentID] = true
+	}
+
+	var redeemed classservice.Students
+
+	for _, s := range stu {
+		if !recorded[s.ID] {
+			redeemed = append(redeemed, s)
+		}
 	}
 
-	return classFromRows(courseRow, studentRows), nil
+	return classservice.VoucherAlreadyRedeemedError{Code: voucher.Code, Students: redeemed}
 }
 
-// GetStudentsByEmail returns all the students whose email addresses are
-// contained in the slice provided.
-func (r *Repository) GetStudentsByEmail(
+// RecordRequest records the ID of an enrollment request as an accepted
+// command, so that the request and its result are committed together.
+func (r *Repository) RecordRequest(ctx context.Context, requestID string, t time.Time) error {
+	row := commandresults.Row{
+		CommandID:   requestID,
+		Status:      commandresults.StatusAccepted,
+		ProcessedAt: t.UTC(),
+	}
+
+	inserted, err := commandresults.Insert(ctx, r.operator, []commandresults.Row{row})
+	if err != nil {
+		return fmt.Errorf("RecordRequest: %w", err)
+	}
+
+	if len(inserted) == 0 {
+		return classservice.DuplicateRequestError{RequestID: requestID}
+	}
+
+	return nil
+}
+
+// loadVoucher converts a voucher row to a classservice.Voucher, loading the
+// codes of the courses for which it may be redeemed.
+func (r *Repository) loadVoucher(ctx context.Context, row vouchers.Row) (classservice.Voucher, error) {
+	codes, err := vouchercourses.CourseCodesOf(ctx, r.operator, row.ID)
+	if err != nil {
+		return classservice.Voucher{}, fmt.Errorf("load voucher %q: %w", row.Code, err)
+	}
+
+	voucher := voucherFromRow(row)
+	voucher.CourseCodes = codes
+
+	return voucher, nil
+}
+
+// IssueCertificate records at time t that a student was issued a certificate
+// with the given code for the enrollment the completion records, and returns
+// it. If the enrollment already has a certificate, that certificate is
+// returned and code is discarded.
+func (r *Repository) IssueCertificate(
 	ctx context.Context,
-	emails []primitive.EmailAddress,
-) (classservice.Students, error) {
-	studentRows, err := students.SelectByEmail(ctx, r.operator, emails)
+	stu classservice.Student,
+	completion classservice.Completion,
+	code string,
+	t time.Time,
+) (classservice.Certificate, error) {
+	cRow, err := courses.FindByCode(ctx, r.operator, completion.CourseCode)
 	if err != nil {
-		return nil, fmt.Errorf("GetStudentsByEmail(%v): %w", emails, err)
+		var notFoundErr courses.CourseNotFoundError
+		if errors.As(err, &notFoundErr) {
+			err = classservice.CourseNotFoundError{CourseCode: completion.CourseCode}
+		}
+
+		return classservice.Certificate{}, fmt.Errorf("IssueCertificate: %w", err)
 	}
 
-	return studentsFromRows(studentRows), nil
+	completedRows, err := enrollments.OnCourse(ctx, r.operator, cRow.ID, enrollments.StatusCompleted)
+	if err != nil {
+		return classservice.Certificate{}, fmt.Errorf("IssueCertificate: %w", err)
+	}
+
+	var enrollmentID int64
+
+	for _, row := range completedRows {
+		if row.StudentID == stu.ID && row.CompletedAt != nil && row.CompletedAt.Equal(completion.CompletedAt) {
+			enrollmentID = row.ID
+			break
+		}
+	}
+
+	if enrollmentID == 0 {
+		return classservice.Certificate{}, fmt.Errorf(
+			"IssueCertificate: no enrollment of student %d in course %q completed at %s",
+			stu.ID, completion.CourseCode, completion.CompletedAt)
+	}
+
+	row := certificates.Row{Code: code, EnrollmentID: enrollmentID, IssuedAt: t}
+	if _, err := certificates.Insert(ctx, r.operator, []certificates.Row{row}); err != nil {
+		return classservice.Certificate{}, fmt.Errorf("IssueCertificate: %w", err)
+	}
+
+	issued, err := certificates.FindByEnrollment(ctx, r.operator, enrollmentID)
+	if err != nil {
+		return classservice.Certificate{}, fmt.Errorf("IssueCertificate: %w", err)
+	}
+
+	return certificateFromIssued(issued), nil
+}
+
+// GetCertificateByCode loads the certificate with the given code, together with
+// the student and course it was issued for.
+func (r *Repository) GetCertificateByCode(ctx context.Context, code string) (classservice.Certificate, error) {
+	issued, err := certificates.FindByCode(ctx, r.operator, code)
+	if err != nil {
+		var notFoundErr certificates.CertificateNotFoundError
+		if errors.As(err, &notFoundErr) {
+			err = classservice.CertificateNotFoundError{Code: code}
+		}
+
+		return classservice.Certificate{}, fmt.Errorf("GetCertificateByCode: %w", err)
+	}
+
+	return certificateFromIssued(issued), nil
+}
+
+// CancelCourse archives a course at time t, cancels all of its active and
+// pending enrollments, releases its reservations, and returns the latest state
+// of the class.
+func (r *Repository) CancelCourse(
+	ctx context.Context,
+	course classservice.Course,
+	t time.Time,
+) (classservice.Class, error) {
+	rows, err := courses.Archive(ctx, r.operator, course.ID, t)
+	if err != nil {
+		return classservice.Class{}, fmt.Errorf("CancelCourse: %w", err)
+	}
+
+	if len(rows) == 0 {
+		return classservice.Class{}, fmt.Errorf("CancelCourse: course %q is already archived", course.Code)
+	}
+
+	if _, err := enrollments.CancelOnCourse(ctx, r.operator, course.ID); err != nil {
+		return classservice.Class{}, fmt.Errorf("CancelCourse: %w", err)
+	}
+
+	if _, err := reservations.DeleteOnCourse(ctx, r.operator, course.ID); err != nil {
+		return classservice.Class{}, fmt.Errorf("CancelCourse: %w", err)
+	}
+
+	class, err := r.GetClassByCourseCode(ctx, course.Code)
+	if err != nil {
+		return classservice.Class{}, fmt.Errorf("CancelCourse: %w", err)
+	}
+
+	return class, nil
+}
+
+// Reserve holds a place in a course for a student until expiresAt, replacing
+// any reservation the student already holds in the course. The student's ID
+// field must be populated.
+func (r *Repository) Reserve(
+	ctx context.Context,
+	course classservice.Course,
+	stu classservice.Student,
+	expiresAt time.Time,
+) (classservice.Reservation, error) {
+	rows := []reservations.Row{{CourseID: course.ID, StudentID: stu.ID, ExpiresAt: expiresAt}}
+
+	rows, err := reservations.Upsert(ctx, r.operator, rows)
+	if err != nil {
+		return classservice.Reservation{}, fmt.Errorf("Reserve: %w", err)
+	}
+
+	return reservationFromRow(rows[0]), nil
+}
+
+// ReleaseReservations deletes the reservations held by the given students in a
+// course. Each student's ID field must be populated.
+func (r *Repository) ReleaseReservations(
+	ctx context.Context,
+	course classservice.Course,
+	stu classservice.Students,
+) error {
+	if _, err := reservations.DeleteByCourseAndStudents(ctx, r.operator, course.ID, stu.IDs()); err != nil {
+		return fmt.Errorf("ReleaseReservations: %w", err)
+	}
+
+	return nil
+}
+
+// ReleaseExpiredReservations deletes every reservation that expired at or
+// before t, and returns the number deleted and the codes of the courses they
+// were held in.
+func (r *Repository) ReleaseExpiredReservations(ctx context.Context, t time.Time) (int, []string, error) {
+	rows, err := reservations.DeleteExpired(ctx, r.operator, t)
+	if err != nil {
+		return 0, nil, fmt.Errorf("ReleaseExpiredReservations: %w", err)
+	}
+
+	if len(rows) == 0 {
+		return 0, nil, nil
+	}
+
+	courseIDs := make([]int64, 0, len(rows))
+	for _, row := range rows {
+		courseIDs = append(courseIDs, row.CourseID)
+	}
+
+	courseRows, err := courses.FindByIDs(ctx, r.operator, courseIDs)
+	if err != nil {
+		return 0, nil, fmt.Errorf("ReleaseExpiredReservations: %w", err)
+	}
+
+	courseCodes := make([]string, 0, len(courseRows))
+	for _, courseRow := range courseRows {
+		courseCodes = append(courseCodes, courseRow.Code)
+	}
+
+	return len(rows), courseCodes, nil
 }
 
 // EnrollStudents enrolls the given students in a course and returns the latest
@@ -102,7 +1004,7 @@ func (r *Repository) EnrollStudents(
 	course classservice.Course,
 	stu classservice.Students,
 ) (classservice.Class, error) {
-	rows := enrollmentRowsFromCouseAndStudents(course, stu)
+	rows := enrollmentRowsFromCouseAndStudents(course, stu, enrollments.StatusActive)
 
 	rows, err := enrollments.Insert(ctx, r.operator, rows)
 	if err != nil {
@@ -117,6 +1019,142 @@ func (r *Repository) EnrollStudents(
 	return class, nil
 }
 
+// EnrollStudentsInSection enrolls the given students in a section of a course
+// and returns the latest state of the class. Each student's ID field must be
+// populated.
+func (r *Repository) EnrollStudentsInSection(
+	ctx context.Context,
+	course classservice.Course,
+	section classservice.Section,
+	stu classservice.Students,
+) (classservice.Class, error) {
+	rows := enrollmentRowsFromCouseAndStudents(course, stu, enrollments.StatusActive)
+	for i := range rows {
+		rows[i].SectionID = &section.ID
+	}
+
+	if _, err := enrollments.Insert(ctx, r.operator, rows); err != nil {
+		return classservice.Class{}, fmt.Errorf("EnrollStudentsInSection: %w", err)
+	}
+
+	class, err := r.GetClassByCourseCode(ctx, course.Code)
+	if err != nil {
+		return classservice.Class{}, fmt.Errorf("EnrollStudentsInSection: %w", err)
+	}
+
+	return class, nil
+}
+
+// RequestEnrollment records that the given students are awaiting approval to
+// enroll in a course, and returns the latest state of the class. If section is
+// non-zero, the students asked to join it. Each student's ID field must be
+// populated.
+func (r *Repository) RequestEnrollment(
+	ctx context.Context,
+	course classservice.Course,
+	section classservice.Section,
+	stu classservice.Students,
+) (classservice.Class, error) {
+	rows := enrollmentRowsFromCouseAndStudents(course, stu, enrollments.StatusPending)
+	for i := range rows {
+		rows[i].SectionID = sectionID(section)
+	}
+
+	if _, err := enrollments.Insert(ctx, r.operator, rows); err != nil {
+		return classservice.Class{}, fmt.Errorf("RequestEnrollment: %w", err)
+	}
+
+	class, err := r.GetClassByCourseCode(ctx, course.Code)
+	if err != nil {
+		return classservice.Class{}, fmt.Errorf("RequestEnrollment: %w", err)
+	}
+
+	return class, nil
+}
+
+// ApproveEnrollment activates the pending enrollment of a student in a course,
+// placing them in the given section, and returns the latest state of the
+// class. section is zero if the course has no sections.
+func (r *Repository) ApproveEnrollment(
+	ctx context.Context,
+	course classservice.Course,
+	section classservice.Section,
+	stu classservice.Student,
+) (classservice.Class, error) {
+	class, err := r.transitionEnrollment(
+		ctx, course, stu, enrollments.StatusPending, enrollments.StatusActive, sectionID(section))
+	if err != nil {
+		return classservice.Class{}, fmt.Errorf("ApproveEnrollment: %w", err)
+	}
+
+	return class, nil
+}
+
+// RejectEnrollment marks the pending enrollment of a student in a course as
+// rejected, and returns the latest state of the class.
+func (r *Repository) RejectEnrollment(
+	ctx context.Context,
+	course classservice.Course,
+	stu classservice.Student,
+) (classservice.Class, error) {
+	class, err := r.transitionEnrollment(
+		ctx, course, stu, enrollments.StatusPending, enrollments.StatusRejected, nil)
+	if err != nil {
+		return classservice.Class{}, fmt.Errorf("RejectEnrollment: %w", err)
+	}
+
+	return class, nil
+}
+
+// UnenrollStudents withdraws the active enrollments of the given students from
+// a course, and returns the latest state of the class. Each student's ID field
+// must be populated.
+func (r *Repository) UnenrollStudents(
+	ctx context.Context,
+	course classservice.Course,
+	stu classservice.Students,
+) (classservice.Class, error) {
+	for _, student := range stu {
+		rows, err := enrollments.UpdateStatus(
+			ctx, r.operator, course.ID, student.ID, enrollments.StatusActive, enrollments.StatusWithdrawn, nil)
+		if err != nil {
+			return classservice.Class{}, fmt.Errorf("UnenrollStudents: %w", err)
+		}
+
+		if len(rows) == 0 {
+			return classservice.Class{}, fmt.Errorf(
+				"UnenrollStudents: no active enrollment of student %d in course %q", student.ID, course.Code)
+		}
+	}
+
+	class, err := r.GetClassByCourseCode(ctx, course.Code)
+	if err != nil {
+		return classservice.Class{}, fmt.Errorf("UnenrollStudents: %w", err)
+	}
+
+	return class, nil
+}
+
+func (r *Repository) transitionEnrollment(
+	ctx context.Context,
+	course classservice.Course,
+	stu classservice.Student,
+	from, to string,
+	sectionID *int64,
+) (classservice.Class, error) {
+	rows, err := enrollments.UpdateStatus(ctx, r.operator, course.ID, stu.ID, from, to, sectionID)
+	if err != nil {
+		return classservice.Class{}, err
+	}
+
+	if len(rows) == 0 {
+		return classservice.Class{}, fmt.Errorf(
+			"no %s enrollment of student %d in course %q", from, stu.ID, course.Code)
+	}
+
+	return r.GetClassByCourseCode(ctx, course.Code)
+}
+
 func classFromRows(cRow courses.Row, sRows []students.Row) classservice.Class {
 	return classservice.Class{
 		Course:   courseFromRow(cRow),
@@ -125,10 +1163,27 @@ func classFromRows(cRow courses.Row, sRows []students.Row) classservice.Class {
 }
 
 func courseFromRow(cRow courses.Row) classservice.Course {
-	return classservice.Course{
-		ID:       cRow.ID,
-		Code:     cRow.Code,
-		Capacity: cRow.Capacity,
+	course := classservice.Course{
+		ID:               cRow.ID,
+		Code:             cRow.Code,
+		Capacity:         cRow.Capacity,
+		RequiresApproval: cRow.RequiresApproval,
+		Cancelled:        cRow.ArchivedAt != nil,
+	}
+
+	if cRow.FeeAmount != nil && cRow.FeeCurrency != nil {
+		course.Fee = primitive.Money{Amount: *cRow.FeeAmount, Currency: primitive.Currency(*cRow.FeeCurrency)}
+	}
+
+	return course
+}
+
+func sectionFromRows(sRow sections.Row, studentRows []students.Row) classservice.Section {
+	return classservice.Section{
+		ID:       sRow.ID,
+		Code:     sRow.Code,
+		Capacity: sRow.Capacity,
+		Students: studentsFromRows(studentRows),
 	}
 }
 
@@ -142,15 +1197,175 @@ func studentsFromRows(sRows []students.Row) classservice.Students {
 			Birthdate: primitive.Birthdate(s.Birthdate),
 			Email:     s.Email,
 		}
+		if s.MaxCourseLoad != nil {
+			classStudent.MaxCourseLoad = *s.MaxCourseLoad
+		}
 		classStudents = append(classStudents, classStudent)
 	}
 
 	return classStudents
 }
 
+func reservationsFromRows(rows []reservations.Row) classservice.Reservations {
+	classReservations := make(classservice.Reservations, 0, len(rows))
+
+	for _, row := range rows {
+		classReservations = append(classReservations, reservationFromRow(row))
+	}
+
+	return classReservations
+}
+
+func reservationFromRow(row reservations.Row) classservice.Reservation {
+	return classservice.Reservation{
+		ID:        row.ID,
+		StudentID: row.StudentID,
+		ExpiresAt: row.ExpiresAt,
+	}
+}
+
+func sessionsFromRows(rows []sessions.Row) classservice.Sessions {
+	classSessions := make(classservice.Sessions, 0, len(rows))
+
+	for _, row := range rows {
+		classSessions = append(classSessions, classservice.Session{ID: row.ID, StartsAt: row.StartsAt})
+	}
+
+	return classSessions
+}
+
+func attendanceFromSummaries(summaries []attendance.Summary) classservice.StudentAttendance {
+	studentAttendance := make(classservice.StudentAttendance, len(summaries))
+
+	for _, summary := range summaries {
+		studentAttendance[summary.StudentID] = classservice.Attendance{
+			Attended: summary.Attended,
+			Recorded: summary.Recorded,
+		}
+	}
+
+	return studentAttendance
+}
+
+func invoiceFromBilled(billed invoices.Billed) classservice.Invoice {
+	inv := classservice.Invoice{
+		ID:         billed.Reference,
+		CourseCode: billed.CourseCode,
+		Student:    classservice.Student{ID: billed.StudentID, Email: billed.StudentEmail},
+		Amount:     primitive.Money{Amount: billed.Amount, Currency: primitive.Currency(billed.Currency)},
+		IssuedAt:   billed.IssuedAt,
+	}
+
+	if billed.VoucherCode != nil {
+		inv.VoucherCode = *billed.VoucherCode
+	}
+
+	if billed.PaidAt != nil {
+		inv.PaidAt = *billed.PaidAt
+	}
+
+	return inv
+}
+
+func voucherFromRow(row vouchers.Row) classservice.Voucher {
+	voucher := classservice.Voucher{
+		ID:   row.ID,
+		Code: row.Code,
+		Kind: classservice.DiscountKind(row.Kind),
+		Uses: row.Uses,
+	}
+
+	if row.Percent != nil {
+		voucher.Percent = *row.Percent
+	}
+
+	if row.Amount != nil && row.Currency != nil {
+		voucher.Amount = primitive.Money{Amount: *row.Amount, Currency: primitive.Currency(*row.Currency)}
+	}
+
+	if row.MaxUses != nil {
+		voucher.MaxUses = *row.MaxUses
+	}
+
+	if row.ValidFrom != nil {
+		voucher.ValidFrom = *row.ValidFrom
+	}
+
+	if row.ValidUntil != nil {
+		voucher.ValidUntil = *row.ValidUntil
+	}
+
+	return voucher
+}
+
+func rowFromVoucher(voucher classservice.Voucher) vouchers.Row {
+	row := vouchers.Row{
+		Code: voucher.Code,
+		Kind: string(voucher.Kind),
+		Uses: voucher.Uses,
+	}
+
+	switch voucher.Kind {
+	case classservice.DiscountPercentage:
+		row.Percent = &voucher.Percent
+	case classservice.DiscountFixed:
+		currency := string(voucher.Amount.Currency)
+		row.Amount = &voucher.Amount.Amount
+		row.Currency = &currency
+	}
+
+	if voucher.MaxUses > 0 {
+		row.MaxUses = &voucher.MaxUses
+	}
+
+	if !voucher.ValidFrom.IsZero() {
+		row.ValidFrom = &voucher.ValidFrom
+	}
+
+	if !voucher.ValidUntil.IsZero() {
+		row.ValidUntil = &voucher.ValidUntil
+	}
+
+	return row
+}
+
+func certificateFromIssued(issued certificates.Issued) classservice.Certificate {
+	return classservice.Certificate{
+		Code: issued.Code,
+		Student: classservice.Student{
+			ID:    issued.StudentID,
+			Name:  issued.StudentName,
+			Email: issued.StudentEmail,
+		},
+		CourseCode:  issued.CourseCode,
+		CourseTitle: issued.CourseTitle,
+		CompletedAt: issued.CompletedAt,
+		IssuedAt:    issued.IssuedAt,
+	}
+}
+
+func instructorsFromRows(rows []instructors.Row) classservice.Instructors {
+	classInstructors := make(classservice.Instructors, 0, len(rows))
+
+	for _, row := range rows {
+		classInstructors = append(classInstructors, instructorFromRow(row))
+	}
+
+	return classInstructors
+}
+
+func instructorFromRow(row instructors.Row) classservice.Instructor {
+	return classservice.Instructor{
+		ID:    row.ID,
+		Name:  row.Name,
+		Email: row.Email,
+	}
+}
+
 func enrollmentRowsFromCouseAndStudents(
 	c classservice.Course,
 	s classservice.Students,
+	status string,
 ) []enrollments.Row {
 	enrollmentRows := make([]enrollments.Row, 0, len(s))
 
@@ -158,9 +1373,20 @@ func enrollmentRowsFromCouseAndStudents(
 		row := enrollments.Row{
 			CourseID:  c.ID,
 			StudentID: stu.ID,
+			Status:    status,
 		}
 		enrollmentRows = append(enrollmentRows, row)
 	}
 
 	return enrollmentRows
 }
+
+// sectionID returns a pointer to the ID of the section, or nil if the section
+// is zero.
+func sectionID(section classservice.Section) *int64 {
+	if section.ID == 0 {
+		return nil
+	}
+
+	return &section.ID
+}
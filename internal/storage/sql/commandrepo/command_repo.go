@@ -0,0 +1,62 @@
+// Package commandrepo provides an implementation of queue.ResultStore for use
+// with an SQL database.
+package commandrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/angusgmorrison/hexagonal/internal/handler/queue"
+	"github.com/angusgmorrison/hexagonal/internal/storage/sql"
+	"github.com/angusgmorrison/hexagonal/internal/storage/sql/table/commandresults"
+)
+
+// ResultStore satisfies queue.ResultStore.
+type ResultStore struct {
+	operator sql.TableOperator
+	now      func() time.Time
+}
+
+var _ queue.ResultStore = (*ResultStore)(nil)
+
+// NewResultStore instantiates a new ResultStore using the database provided.
+func NewResultStore(db sql.Database) *ResultStore {
+	return &ResultStore{operator: db, now: time.Now}
+}
+
+// LoadResult returns the result recorded for the command with the given ID.
+func (rs *ResultStore) LoadResult(ctx context.Context, commandID string) (queue.Result, bool, error) {
+	row, err := commandresults.FindByCommandID(ctx, rs.operator, commandID)
+	if err != nil {
+		var notFoundErr commandresults.CommandResultNotFoundError
+		if errors.As(err, &notFoundErr) {
+			return queue.Result{}, false, nil
+		}
+
+		return queue.Result{}, false, fmt.Errorf("LoadResult(%q): %w", commandID, err)
+	}
+
+	return queue.Result{
+		CommandID: row.CommandID,
+		Status:    queue.ResultStatus(row.Status),
+		Error:     row.Error,
+	}, true, nil
+}
+
+// SaveResult records the result of a command, unless one is already recorded.
+func (rs *ResultStore) SaveResult(ctx context.Context, result queue.Result) error {
+	row := commandresults.Row{
+		CommandID:   result.CommandID,
+		Status:      string(result.Status),
+		Error:       result.Error,
+		ProcessedAt: rs.now().UTC(),
+	}
+
+	if _, err := commandresults.Insert(ctx, rs.operator, []commandresults.Row{row}); err != nil {
+		return fmt.Errorf("SaveResult(%q): %w", result.CommandID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,189 @@
+// Package importrepo provides implementations of importservice.AtomicRepository
+// and importservice.Repository for use with an SQL database.
+package importrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/angusgmorrison/hexagonal/internal/service/importservice"
+	"github.com/angusgmorrison/hexagonal/internal/storage/sql"
+	"github.com/angusgmorrison/hexagonal/internal/storage/sql/table/importerrors"
+	"github.com/angusgmorrison/hexagonal/internal/storage/sql/table/imports"
+)
+
+// AtomicRepository satisfies importservice.AtomicRepository.
+type AtomicRepository struct {
+	db sql.Database
+}
+
+var _ importservice.AtomicRepository = (*AtomicRepository)(nil)
+
+// NewAtomic instantiates a new AtomicRepository using the database provided.
+func NewAtomic(db sql.Database) *AtomicRepository {
+	return &AtomicRepository{db: db}
+}
+
+// Execute decorates the given AtomicOperation with a transaction. If the
+// AtomicOperation returns an error, the transaction is rolled back. Otherwise,
+// the transaction is committed.
+func (ar *AtomicRepository) Execute(
+	ctx context.Context,
+	op importservice.AtomicOperation,
+) error {
+	tx, err := ar.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = tx.Rollback() }()
+
+	importRepoWithTransaction := Repository{operator: tx}
+
+	if err := op(ctx, &importRepoWithTransaction); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Repository satisfies importservice.Repository. It is agnostic as to whether
+// its sql.TableOperator is a database or transaction.
+type Repository struct {
+	operator sql.TableOperator
+}
+
+var _ importservice.Repository = (*Repository)(nil)
+
+// CreateImport inserts a new import and returns it with its ID populated.
+func (r *Repository) CreateImport(ctx context.Context, imp importservice.Import) (importservice.Import, error) {
+	rows, err := imports.Insert(ctx, r.operator, []imports.Row{rowFromImport(imp)})
+	if err != nil {
+		return importservice.Import{}, fmt.Errorf("CreateImport: %w", err)
+	}
+
+	return importFromRow(rows[0], nil), nil
+}
+
+// GetImport returns the import with the given ID, together with the errors
+// recorded against its rows.
+func (r *Repository) GetImport(ctx context.Context, id int64) (importservice.Import, error) {
+	row, err := imports.FindByID(ctx, r.operator, id)
+	if err != nil {
+		var notFoundErr imports.ImportNotFoundError
+		if errors.As(err, &notFoundErr) {
+			err = importservice.ImportNotFoundError{ID: id}
+		}
+
+		return importservice.Import{}, fmt.Errorf("GetImport(%d): %w", id, err)
+	}
+
+	errorRows, err := importerrors.ByImport(ctx, r.operator, id)
+	if err != nil {
+		return importservice.Import{}, fmt.Errorf("GetImport(%d): %w", id, err)
+	}
+
+	return importFromRow(row, errorRows), nil
+}
+
+// FailStalledImports marks as failed every unfinished import last updated
+// before updatedBefore and returns the number failed.
+func (r *Repository) FailStalledImports(ctx context.Context, updatedBefore, failedAt time.Time) (int, error) {
+	rows, err := imports.FailStalled(ctx, r.operator, updatedBefore, failedAt)
+	if err != nil {
+		return 0, fmt.Errorf("FailStalledImports: %w", err)
+	}
+
+	return len(rows), nil
+}
+
+// SaveProgress updates an existing import and inserts the row errors given.
+func (r *Repository) SaveProgress(
+	ctx context.Context,
+	imp importservice.Import,
+	errs importservice.RowErrors,
+) error {
+	rows, err := imports.Update(ctx, r.operator, rowFromImport(imp))
+	if err != nil {
+		return fmt.Errorf("SaveProgress: %w", err)
+	}
+
+	if len(rows) == 0 {
+		return fmt.Errorf("SaveProgress: %w", importservice.ImportNotFoundError{ID: imp.ID})
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	errorRows := make([]importerrors.Row, 0, len(errs))
+
+	for _, rowErr := range errs {
+		errorRows = append(errorRows, importerrors.Row{
+			ImportID:   imp.ID,
+			Line:       rowErr.Line,
+			CourseCode: rowErr.CourseCode,
+			Email:      rowErr.Email,
+			Message:    rowErr.Message,
+		})
+	}
+
+	if _, err := importerrors.Insert(ctx, r.operator, errorRows); err != nil {
+		return fmt.Errorf("SaveProgress: %w", err)
+	}
+
+	return nil
+}
+
+func importFromRow(row imports.Row, errorRows []importerrors.Row) importservice.Import {
+	imp := importservice.Import{
+		ID:            row.ID,
+		Status:        importservice.Status(row.Status),
+		TotalRows:     row.TotalRows,
+		ProcessedRows: row.ProcessedRows,
+		FailedRows:    row.FailedRows,
+		Errors:        make(importservice.RowErrors, 0, len(errorRows)),
+		CreatedAt:     row.CreatedAt,
+		UpdatedAt:     row.UpdatedAt,
+	}
+
+	if row.CompletedAt != nil {
+		imp.CompletedAt = *row.CompletedAt
+	}
+
+	for _, errorRow := range errorRows {
+		imp.Errors = append(imp.Errors, importservice.RowError{
+			Line:       errorRow.Line,
+			CourseCode: errorRow.CourseCode,
+			Email:      errorRow.Email,
+			Message:    errorRow.Message,
+		})
+	}
+
+	return imp
+}
+
+func rowFromImport(imp importservice.Import) imports.Row {
+	row := imports.Row{
+		ID:            imp.ID,
+		Status:        string(imp.Status),
+		TotalRows:     imp.TotalRows,
+		ProcessedRows: imp.ProcessedRows,
+		FailedRows:    imp.FailedRows,
+		CreatedAt:     imp.CreatedAt,
+		UpdatedAt:     imp.UpdatedAt,
+	}
+
+	if !imp.CompletedAt.IsZero() {
+		completedAt := imp.CompletedAt
+		row.CompletedAt = &completedAt
+	}
+
+	return row
+}
@@ -0,0 +1,175 @@
+// Package instructorrepo provides implementations of
+// instructorservice.AtomicRepository and instructorservice.Repository for use
+// with an SQL database.
+package instructorrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/angusgmorrison/hexagonal/internal/primitive"
+	"github.com/angusgmorrison/hexagonal/internal/service/instructorservice"
+	"github.com/angusgmorrison/hexagonal/internal/storage/sql"
+	"github.com/angusgmorrison/hexagonal/internal/storage/sql/table/instructors"
+)
+
+// AtomicRepository satisfies instructorservice.AtomicRepository.
+type AtomicRepository struct {
+	db sql.Database
+}
+
+var _ instructorservice.AtomicRepository = (*AtomicRepository)(nil)
+
+// NewAtomic instantiates a new AtomicRepository using the database provided.
+func NewAtomic(db sql.Database) *AtomicRepository {
+	return &AtomicRepository{db: db}
+}
+
+// Execute decorates the given AtomicOperation with a transaction. If the
+// AtomicOperation returns an error, the transaction is rolled back. Otherwise,
+// the transaction is committed.
+func (ar *AtomicRepository) Execute(
+	ctx context.Context,
+	op instructorservice.AtomicOperation,
+) error {
+	tx, err := ar.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = tx.Rollback() }()
+
+	instructorRepoWithTransaction := Repository{operator: tx}
+
+	if err := op(ctx, &instructorRepoWithTransaction); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Repository satisfies instructorservice.Repository. It is agnostic as to
+// whether its sql.TableOperator is a database or transaction.
+type Repository struct {
+	operator sql.TableOperator
+}
+
+var _ instructorservice.Repository = (*Repository)(nil)
+
+// ListInstructors returns every instructor, ordered by name.
+func (r *Repository) ListInstructors(ctx context.Context) (instructorservice.Instructors, error) {
+	rows, err := instructors.All(ctx, r.operator)
+	if err != nil {
+		return nil, fmt.Errorf("ListInstructors: %w", err)
+	}
+
+	return instructorsFromRows(rows), nil
+}
+
+// GetInstructor returns the instructor with the given ID.
+func (r *Repository) GetInstructor(ctx context.Context, id int64) (instructorservice.Instructor, error) {
+	row, err := instructors.FindByID(ctx, r.operator, id)
+	if err != nil {
+		var notFoundErr instructors.InstructorNotFoundError
+		if errors.As(err, &notFoundErr) {
+			err = instructorservice.InstructorNotFoundError{ID: id}
+		}
+
+		return instructorservice.Instructor{}, fmt.Errorf("GetInstructor(%d): %w", id, err)
+	}
+
+	return instructorFromRow(row), nil
+}
+
+// GetInstructorsByEmail returns all the instructors whose email addresses are
+// contained in the slice provided.
+func (r *Repository) GetInstructorsByEmail(
+	ctx context.Context,
+	emails []primitive.EmailAddress,
+) (instructorservice.Instructors, error) {
+	rows, err := instructors.SelectByEmail(ctx, r.operator, emails)
+	if err != nil {
+		return nil, fmt.Errorf("GetInstructorsByEmail(%v): %w", emails, err)
+	}
+
+	return instructorsFromRows(rows), nil
+}
+
+// CreateInstructor inserts a new instructor and returns it with its ID
+// populated.
+func (r *Repository) CreateInstructor(
+	ctx context.Context,
+	instructor instructorservice.Instructor,
+) (instructorservice.Instructor, error) {
+	rows, err := instructors.Insert(ctx, r.operator, []instructors.Row{rowFromInstructor(instructor)})
+	if err != nil {
+		return instructorservice.Instructor{}, fmt.Errorf("CreateInstructor: %w", err)
+	}
+
+	return instructorFromRow(rows[0]), nil
+}
+
+// UpdateInstructor overwrites the name and email address of an existing
+// instructor, and returns the updated instructor.
+func (r *Repository) UpdateInstructor(
+	ctx context.Context,
+	instructor instructorservice.Instructor,
+) (instructorservice.Instructor, error) {
+	rows, err := instructors.Update(ctx, r.operator, rowFromInstructor(instructor))
+	if err != nil {
+		return instructorservice.Instructor{}, fmt.Errorf("UpdateInstructor: %w", err)
+	}
+
+	if len(rows) == 0 {
+		return instructorservice.Instructor{}, fmt.Errorf(
+			"UpdateInstructor: %w", instructorservice.InstructorNotFoundError{ID: instructor.ID})
+	}
+
+	return instructorFromRow(rows[0]), nil
+}
+
+// DeleteInstructor deletes an instructor. Their teaching assignments are
+// deleted with them.
+func (r *Repository) DeleteInstructor(ctx context.Context, instructor instructorservice.Instructor) error {
+	rows, err := instructors.Delete(ctx, r.operator, instructor.ID)
+	if err != nil {
+		return fmt.Errorf("DeleteInstructor: %w", err)
+	}
+
+	if len(rows) == 0 {
+		return fmt.Errorf("DeleteInstructor: %w", instructorservice.InstructorNotFoundError{ID: instructor.ID})
+	}
+
+	return nil
+}
+
+func instructorsFromRows(rows []instructors.Row) instructorservice.Instructors {
+	domainInstructors := make(instructorservice.Instructors, 0, len(rows))
+
+	for _, row := range rows {
+		domainInstructors = append(domainInstructors, instructorFromRow(row))
+	}
+
+	return domainInstructors
+}
+
+func instructorFromRow(row instructors.Row) instructorservice.Instructor {
+	return instructorservice.Instructor{
+		ID:    row.ID,
+		Name:  row.Name,
+		Email: row.Email,
+	}
+}
+
+func rowFromInstructor(instructor instructorservice.Instructor) instructors.Row {
+	return instructors.Row{
+		ID:    instructor.ID,
+		Name:  instructor.Name,
+		Email: instructor.Email,
+	}
+}
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS invoices;
+
+ALTER TABLE courses
+DROP CONSTRAINT IF EXISTS courses_fee_check,
+DROP COLUMN IF EXISTS fee_currency,
+DROP COLUMN IF EXISTS fee_amount;
@@ -0,0 +1,20 @@
+ALTER TABLE courses
+ADD COLUMN fee_amount BIGINT,
+ADD COLUMN fee_currency VARCHAR(3),
+ADD CONSTRAINT courses_fee_check CHECK ((fee_amount IS NULL) = (fee_currency IS NULL));
+
+CREATE TABLE invoices (
+  id BIGSERIAL PRIMARY KEY,
+  reference VARCHAR(255) NOT NULL,
+  enrollment_id BIGINT REFERENCES enrollments NOT NULL,
+  amount BIGINT NOT NULL,
+  currency VARCHAR(3) NOT NULL,
+  issued_at TIMESTAMPTZ NOT NULL,
+  paid_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX invoices_reference_idx
+ON invoices (reference);
+
+CREATE INDEX invoices_enrollment_id_idx
+ON invoices (enrollment_id);
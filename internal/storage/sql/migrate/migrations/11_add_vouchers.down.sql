@@ -0,0 +1,6 @@
+ALTER TABLE invoices
+DROP COLUMN IF EXISTS voucher_id;
+
+DROP TABLE IF EXISTS voucher_courses;
+
+DROP TABLE IF EXISTS vouchers;
@@ -0,0 +1,32 @@
+CREATE TABLE vouchers (
+  id BIGSERIAL PRIMARY KEY,
+  code VARCHAR(64) NOT NULL,
+  kind VARCHAR(16) NOT NULL,
+  percent INT,
+  amount BIGINT,
+  currency VARCHAR(3),
+  max_uses INT,
+  uses INT NOT NULL DEFAULT 0,
+  valid_from TIMESTAMPTZ,
+  valid_until TIMESTAMPTZ,
+  CONSTRAINT vouchers_kind_check CHECK (
+    (kind = 'percentage' AND percent BETWEEN 1 AND 100 AND amount IS NULL AND currency IS NULL) OR
+    (kind = 'fixed' AND percent IS NULL AND amount > 0 AND currency IS NOT NULL)
+  ),
+  CONSTRAINT vouchers_uses_check CHECK (max_uses IS NULL OR uses <= max_uses)
+);
+
+CREATE UNIQUE INDEX vouchers_code_idx
+ON vouchers (code);
+
+CREATE TABLE voucher_courses (
+  id BIGSERIAL PRIMARY KEY,
+  voucher_id BIGINT REFERENCES vouchers NOT NULL,
+  course_id BIGINT REFERENCES courses NOT NULL
+);
+
+CREATE UNIQUE INDEX voucher_courses_voucher_id_course_id_idx
+ON voucher_courses (voucher_id, course_id);
+
+ALTER TABLE invoices
+ADD COLUMN voucher_id BIGINT REFERENCES vouchers;
@@ -0,0 +1,12 @@
+CREATE TABLE certificates (
+  id BIGSERIAL PRIMARY KEY,
+  code VARCHAR(32) NOT NULL,
+  enrollment_id BIGINT REFERENCES enrollments NOT NULL,
+  issued_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE UNIQUE INDEX certificates_code_idx
+ON certificates (code);
+
+CREATE UNIQUE INDEX certificates_enrollment_id_idx
+ON certificates (enrollment_id);
@@ -0,0 +1,21 @@
+CREATE TABLE imports (
+  id BIGSERIAL PRIMARY KEY,
+  status VARCHAR(16) NOT NULL,
+  total_rows INT NOT NULL,
+  processed_rows INT NOT NULL DEFAULT 0,
+  failed_rows INT NOT NULL DEFAULT 0,
+  created_at TIMESTAMPTZ NOT NULL,
+  completed_at TIMESTAMPTZ
+);
+
+CREATE TABLE import_errors (
+  id BIGSERIAL PRIMARY KEY,
+  import_id BIGINT REFERENCES imports NOT NULL,
+  line INT NOT NULL,
+  course_code VARCHAR(255) NOT NULL,
+  email VARCHAR(255) NOT NULL,
+  message TEXT NOT NULL
+);
+
+CREATE INDEX import_errors_import_id_idx
+ON import_errors (import_id);
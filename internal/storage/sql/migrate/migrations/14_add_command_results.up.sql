@@ -0,0 +1,7 @@
+CREATE TABLE command_results (
+  id BIGSERIAL PRIMARY KEY,
+  command_id VARCHAR(255) UNIQUE NOT NULL,
+  status VARCHAR(16) NOT NULL,
+  error TEXT NOT NULL DEFAULT '',
+  processed_at TIMESTAMPTZ NOT NULL
+);
@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS api_keys;
+CREATE TABLE api_keys (
+  id BIGSERIAL PRIMARY KEY,
+  name VARCHAR(255) UNIQUE NOT NULL,
+  key_hash BYTEA UNIQUE NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL,
+  revoked_at TIMESTAMPTZ
+);
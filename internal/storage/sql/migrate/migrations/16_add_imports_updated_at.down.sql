@@ -0,0 +1,2 @@
+ALTER TABLE imports
+DROP COLUMN IF EXISTS updated_at;
@@ -0,0 +1,8 @@
+ALTER TABLE imports
+ADD COLUMN updated_at TIMESTAMPTZ;
+
+UPDATE imports
+SET updated_at = COALESCE(completed_at, created_at);
+
+ALTER TABLE imports
+ALTER COLUMN updated_at SET NOT NULL;
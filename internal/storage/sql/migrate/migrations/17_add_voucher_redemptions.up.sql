@@ -0,0 +1,17 @@
+CREATE TABLE voucher_redemptions (
+  id BIGSERIAL PRIMARY KEY,
+  voucher_id BIGINT REFERENCES vouchers NOT NULL,
+  student_id BIGINT REFERENCES students NOT NULL
+);
+
+CREATE UNIQUE INDEX voucher_redemptions_voucher_id_student_id_idx
+ON voucher_redemptions (voucher_id, student_id);
+
+-- Vouchers redeemed before redemptions were recorded are known only from the
+-- invoices they discounted.
+INSERT INTO voucher_redemptions (voucher_id, student_id)
+SELECT DISTINCT i.voucher_id, e.student_id
+FROM invoices i
+INNER JOIN enrollments e
+ON e.id = i.enrollment_id
+WHERE i.voucher_id IS NOT NULL;
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS enrollments_course_id_student_id_current_idx;
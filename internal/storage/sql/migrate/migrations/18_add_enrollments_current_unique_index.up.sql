@@ -0,0 +1,5 @@
+-- A student may have at most one current enrollment in each course, although
+-- they may have any number of past ones.
+CREATE UNIQUE INDEX enrollments_course_id_student_id_current_idx
+ON enrollments (course_id, student_id)
+WHERE status IN ('active', 'pending', 'pending_payment');
@@ -0,0 +1,4 @@
+ALTER TABLE enrollments
+DROP COLUMN IF EXISTS section_id;
+
+DROP TABLE IF EXISTS sections;
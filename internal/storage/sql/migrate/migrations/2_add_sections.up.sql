@@ -0,0 +1,15 @@
+CREATE TABLE sections (
+  id BIGSERIAL PRIMARY KEY,
+  course_id BIGINT REFERENCES courses NOT NULL,
+  code VARCHAR(255) NOT NULL,
+  capacity INT NOT NULL
+);
+
+CREATE UNIQUE INDEX sections_course_id_code_idx
+ON sections (course_id, code);
+
+ALTER TABLE enrollments
+ADD COLUMN section_id BIGINT REFERENCES sections;
+
+CREATE INDEX enrollments_section_id_idx
+ON enrollments (section_id);
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE students
+DROP COLUMN IF EXISTS max_course_load;
\ No newline at end of file
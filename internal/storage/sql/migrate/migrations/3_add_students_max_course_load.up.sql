@@ -0,0 +1,2 @@
+ALTER TABLE students
+ADD COLUMN max_course_load INT;
\ No newline at end of file
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS enrollments_course_id_status_idx;
+
+ALTER TABLE enrollments
+DROP COLUMN IF EXISTS status;
+
+ALTER TABLE courses
+DROP COLUMN IF EXISTS requires_approval;
\ No newline at end of file
@@ -0,0 +1,8 @@
+ALTER TABLE courses
+ADD COLUMN requires_approval BOOLEAN NOT NULL DEFAULT false;
+
+ALTER TABLE enrollments
+ADD COLUMN status VARCHAR(32) NOT NULL DEFAULT 'active';
+
+CREATE INDEX enrollments_course_id_status_idx
+ON enrollments (course_id, status);
\ No newline at end of file
@@ -0,0 +1,12 @@
+CREATE TABLE reservations (
+  id BIGSERIAL PRIMARY KEY,
+  course_id BIGINT REFERENCES courses NOT NULL,
+  student_id BIGINT REFERENCES students NOT NULL,
+  expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE UNIQUE INDEX reservations_course_id_student_id_idx
+ON reservations (course_id, student_id);
+
+CREATE INDEX reservations_expires_at_idx
+ON reservations (expires_at);
@@ -0,0 +1,2 @@
+ALTER TABLE courses
+ADD COLUMN archived_at TIMESTAMPTZ;
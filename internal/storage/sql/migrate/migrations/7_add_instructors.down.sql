@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS teaching_assignments;
+DROP TABLE IF EXISTS instructors;
@@ -0,0 +1,20 @@
+CREATE TABLE instructors (
+  id BIGSERIAL PRIMARY KEY,
+  name VARCHAR(255) NOT NULL,
+  email VARCHAR(255) NOT NULL
+);
+
+CREATE UNIQUE INDEX instructors_email_idx
+ON instructors (email);
+
+CREATE TABLE teaching_assignments (
+  id BIGSERIAL PRIMARY KEY,
+  course_id BIGINT REFERENCES courses NOT NULL,
+  instructor_id BIGINT REFERENCES instructors ON DELETE CASCADE NOT NULL
+);
+
+CREATE UNIQUE INDEX teaching_assignments_course_id_instructor_id_idx
+ON teaching_assignments (course_id, instructor_id);
+
+CREATE INDEX teaching_assignments_instructor_id_idx
+ON teaching_assignments (instructor_id);
@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS prerequisites;
+
+DROP INDEX IF EXISTS enrollments_student_id_status_idx;
+
+ALTER TABLE enrollments
+DROP COLUMN IF EXISTS completed_at;
+
+ALTER TABLE enrollments
+DROP COLUMN IF EXISTS grade;
@@ -0,0 +1,17 @@
+ALTER TABLE enrollments
+ADD COLUMN grade VARCHAR(32);
+
+ALTER TABLE enrollments
+ADD COLUMN completed_at TIMESTAMPTZ;
+
+CREATE INDEX enrollments_student_id_status_idx
+ON enrollments (student_id, status);
+
+CREATE TABLE prerequisites (
+  id BIGSERIAL PRIMARY KEY,
+  course_id BIGINT REFERENCES courses NOT NULL,
+  prerequisite_id BIGINT REFERENCES courses NOT NULL
+);
+
+CREATE UNIQUE INDEX prerequisites_course_id_prerequisite_id_idx
+ON prerequisites (course_id, prerequisite_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS attendance;
+DROP TABLE IF EXISTS course_sessions;
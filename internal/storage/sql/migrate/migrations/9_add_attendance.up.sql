@@ -0,0 +1,22 @@
+CREATE TABLE course_sessions (
+  id BIGSERIAL PRIMARY KEY,
+  course_id BIGINT REFERENCES courses NOT NULL,
+  starts_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE UNIQUE INDEX course_sessions_course_id_starts_at_idx
+ON course_sessions (course_id, starts_at);
+
+CREATE TABLE attendance (
+  id BIGSERIAL PRIMARY KEY,
+  enrollment_id BIGINT REFERENCES enrollments NOT NULL,
+  session_id BIGINT REFERENCES course_sessions ON DELETE CASCADE NOT NULL,
+  present BOOLEAN NOT NULL,
+  recorded_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE UNIQUE INDEX attendance_enrollment_id_session_id_idx
+ON attendance (enrollment_id, session_id);
+
+CREATE INDEX attendance_session_id_idx
+ON attendance (session_id);
@@ -26,4 +26,112 @@ VALUES
   ('Matheo Travieso', '1984-04-11', 'mat@travieso.com'),
   ('Rhodri Murray', '1998-12-01', 'murrayboi98@hotmail.com'),
   ('Dobrila Starr', '1989-08-21', 'dob.starr@googlemail.com'),
-  ('Ampelius Fabian', '1990-11-22', 'amp-fab@btinternet.com');
\ No newline at end of file
+  ('Ampelius Fabian', '1990-11-22', 'amp-fab@btinternet.com');
+
+-- Create a course divided into two sections.
+INSERT INTO courses (title, code, capacity, description)
+VALUES (
+  'The Art of Computer Programming',
+  'TAOCP',
+  6,
+  'A comprehensive monograph on algorithms.'
+);
+
+INSERT INTO sections (course_id, code, capacity)
+SELECT courses.id, section.code, 3
+FROM courses
+CROSS JOIN (VALUES ('A'), ('B')) AS section (code)
+WHERE courses.code = 'TAOCP';
+-- Create a course whose enrollments must be approved.
+INSERT INTO courses (title, code, capacity, description, requires_approval)
+VALUES (
+  'Advanced Compiler Construction',
+  'ADV101',
+  4,
+  'A graduate seminar on optimizing compilers. Enrollment is by approval only.',
+  true
+);
+
+-- Create instructors and assign them to courses.
+INSERT INTO instructors (name, email)
+VALUES
+  ('Gerald Sussman', 'gjs@mit.edu'),
+  ('Donald Knuth', 'knuth@stanford.edu'),
+  ('Frances Allen', 'fran.allen@ibm.com');
+
+INSERT INTO teaching_assignments (course_id, instructor_id)
+SELECT courses.id, instructors.id
+FROM courses
+INNER JOIN instructors
+ON (courses.code, instructors.email) IN (
+  ('SICP', 'gjs@mit.edu'),
+  ('TAOCP', 'knuth@stanford.edu'),
+  ('ADV101', 'fran.allen@ibm.com')
+);
+
+-- Create a course with a prerequisite and a fee, and a student who has passed
+-- the prerequisite.
+INSERT INTO courses (title, code, capacity, description, fee_amount, fee_currency)
+VALUES (
+  'Lisp in Small Pieces',
+  'LISP',
+  4,
+  'Implementing Lisp interpreters and compilers. Students must have passed SICP.',
+  12500,
+  'GBP'
+);
+
+INSERT INTO prerequisites (course_id, prerequisite_id)
+SELECT courses.id, prerequisites.id
+FROM courses
+INNER JOIN courses prerequisites
+ON prerequisites.code = 'SICP'
+WHERE courses.code = 'LISP';
+
+INSERT INTO enrollments (course_id, student_id, status, grade, completed_at)
+SELECT courses.id, students.id, 'completed', 'A', now()
+FROM courses
+INNER JOIN students
+ON students.email = 'r.tifft@gmail.com'
+WHERE courses.code = 'SICP';
+
+-- Schedule weekly sessions of SICP and record attendance at the first.
+INSERT INTO course_sessions (course_id, starts_at)
+SELECT courses.id, session.starts_at
+FROM courses
+CROSS JOIN (VALUES
+  (TIMESTAMPTZ '2022-09-05 10:00:00+00'),
+  (TIMESTAMPTZ '2022-09-12 10:00:00+00'),
+  (TIMESTAMPTZ '2022-09-19 10:00:00+00')
+) AS session (starts_at)
+WHERE courses.code = 'SICP';
+
+INSERT INTO attendance (enrollment_id, session_id, present, recorded_at)
+SELECT enrollments.id, course_sessions.id, students.email <> 'blandinus@gmail.com', now()
+FROM enrollments
+INNER JOIN students
+ON students.id = enrollments.student_id
+INNER JOIN course_sessions
+ON course_sessions.course_id = enrollments.course_id
+AND course_sessions.starts_at = '2022-09-05 10:00:00+00'
+WHERE enrollments.status = 'active';
+
+-- Create vouchers: one for any course that charges a fee, and a capped one for
+-- LISP only.
+INSERT INTO vouchers (code, kind, percent, amount, currency, max_uses)
+VALUES
+  ('WELCOME10', 'percentage', 10, NULL, NULL, NULL),
+  ('LISP25', 'fixed', NULL, 2500, 'GBP', 10);
+
+INSERT INTO voucher_courses (voucher_id, course_id)
+SELECT vouchers.id, courses.id
+FROM vouchers
+INNER JOIN courses
+ON courses.code = 'LISP'
+WHERE vouchers.code = 'LISP25';
+
+-- Create an API key for development, hxk_development, stored as its SHA-256
+-- hash.
+INSERT INTO api_keys (name, key_hash, created_at)
+VALUES
+  ('development', decode('92b0fb5ca342609d160c973638bd8ab30d2e7fad1bbe9e2bf1a9173d12212811', 'hex'), now());
@@ -0,0 +1,125 @@
+// Package apikeys operates on a database api_keys table, which stores the
+// hashes of the API keys issued to clients, and represents its rows. It is
+// driver-agnostic.
+package apikeys
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"time"
+
+	"github.com/angusgmorrison/hexagonal/internal/storage/sql"
+)
+
+//go:embed queries
+var _queries embed.FS
+
+// Row represents a row of the api_keys table.
+type Row struct {
+	ID        int64     `db:"id"`
+	Name      string    `db:"name"`
+	KeyHash   []byte    `db:"key_hash"`
+	CreatedAt time.Time `db:"created_at"`
+
+	// RevokedAt is nil unless the key has been revoked.
+	RevokedAt *time.Time `db:"revoked_at"`
+}
+
+// All returns every row, ordered by ID.
+func All(ctx context.Context, q sql.Queryer) ([]Row, error) {
+	query, err := _queries.ReadFile("queries/select_api_keys.sql")
+	if err != nil {
+		return nil, fmt.Errorf("read queries/select_api_keys.sql: %w", err)
+	}
+
+	var results []Row
+
+	if err := q.Query(ctx, &results, string(query)); err != nil {
+		return nil, fmt.Errorf("All: %w", err)
+	}
+
+	return results, nil
+}
+
+// FindByHash returns a row based on the hash of its key.
+func FindByHash(ctx context.Context, q sql.Queryer, hash []byte) (Row, error) {
+	query, err := _queries.ReadFile("queries/find_api_key_by_hash.sql")
+	if err != nil {
+		return Row{}, fmt.Errorf("read queries/find_api_key_by_hash.sql: %w", err)
+	}
+
+	results := make([]Row, 0, 1)
+
+	if err := q.Query(ctx, &results, string(query), hash); err != nil {
+		return Row{}, fmt.Errorf("FindByHash: %w", err)
+	}
+
+	if len(results) == 0 {
+		return Row{}, APIKeyNotFoundError{}
+	}
+
+	return results[0], nil
+}
+
+// SelectByName returns the rows with the given name.
+func SelectByName(ctx context.Context, q sql.Queryer, name string) ([]Row, error) {
+	query, err := _queries.ReadFile("queries/select_api_keys_by_name.sql")
+	if err != nil {
+		return nil, fmt.Errorf("read queries/select_api_keys_by_name.sql: %w", err)
+	}
+
+	var results []Row
+
+	if err := q.Query(ctx, &results, string(query), name); err != nil {
+		return nil, fmt.Errorf("SelectByName(%q): %w", name, err)
+	}
+
+	return results, nil
+}
+
+// Insert inserts the given keys into the table and returns the rows inserted.
+func Insert(ctx context.Context, bq sql.BindQueryer, keys []Row) ([]Row, error) {
+	query, err := _queries.ReadFile("queries/insert_api_keys.sql")
+	if err != nil {
+		return nil, fmt.Errorf("read queries/insert_api_keys.sql: %w", err)
+	}
+
+	boundQuery, positionalArgs, err := bq.Bind(string(query), keys)
+	if err != nil {
+		return nil, fmt.Errorf("bind queries/insert_api_keys.sql: %w", err)
+	}
+
+	inserted := make([]Row, 0, len(keys))
+
+	if err := bq.Query(ctx, &inserted, boundQuery, positionalArgs...); err != nil {
+		return nil, fmt.Errorf("Insert: %w", err)
+	}
+
+	return inserted, nil
+}
+
+// Revoke sets the revocation time of the key with the given ID, unless it's
+// already set, and returns the updated rows.
+func Revoke(ctx context.Context, q sql.Queryer, id int64, at time.Time) ([]Row, error) {
+	query, err := _queries.ReadFile("queries/revoke_api_key.sql")
+	if err != nil {
+		return nil, fmt.Errorf("read queries/revoke_api_key.sql: %w", err)
+	}
+
+	var results []Row
+
+	if err := q.Query(ctx, &results, string(query), id, at); err != nil {
+		return nil, fmt.Errorf("Revoke(%d): %w", id, err)
+	}
+
+	return results, nil
+}
+
+// APIKeyNotFoundError is returned when searching for a key by its hash
+// returns no results. The hash is omitted, since it identifies a credential.
+type APIKeyNotFoundError struct{}
+
+func (aknfe APIKeyNotFoundError) Error() string {
+	return "no API key with the given hash"
+}
@@ -0,0 +1,3 @@
+INSERT INTO api_keys (name, key_hash, created_at)
+VALUES (:name, :key_hash, :created_at)
+RETURNING *;
@@ -0,0 +1,3 @@
+SELECT id, name, key_hash, created_at, revoked_at
+FROM api_keys
+ORDER BY id;
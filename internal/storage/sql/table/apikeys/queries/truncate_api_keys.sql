@@ -0,0 +1 @@
+TRUNCATE TABLE api_keys;
\ No newline at end of file
@@ -0,0 +1,61 @@
+// Package assignments operates on a database teaching_assignments table, which
+// joins instructors to the courses they teach, and represents its rows. It is
+// driver-agnostic.
+package assignments
+
+import (
+	"context"
+	"embed"
+	"fmt"
+
+	"github.com/angusgmorrison/hexagonal/internal/storage/sql"
+)
+
+//go:embed queries
+var _queries embed.FS
+
+// Row represents a row of the teaching_assignments table.
+type Row struct {
+	ID           int64 `db:"id"`
+	CourseID     int64 `db:"course_id"`
+	InstructorID int64 `db:"instructor_id"`
+}
+
+// Insert inserts the given assignments into the table, and returns the rows
+// inserted. Assignments that already exist are skipped and aren't returned.
+func Insert(ctx context.Context, bq sql.BindQueryer, assignments []Row) ([]Row, error) {
+	query, err := _queries.ReadFile("queries/insert_assignments.sql")
+	if err != nil {
+		return nil, fmt.Errorf("read queries/insert_assignments.sql: %w", err)
+	}
+
+	boundQuery, positionalArgs, err := bq.Bind(string(query), assignments)
+	if err != nil {
+		return nil, fmt.Errorf("bind queries/insert_assignments.sql: %w", err)
+	}
+
+	results := make([]Row, 0, len(assignments))
+
+	if err := bq.Query(ctx, &results, boundQuery, positionalArgs...); err != nil {
+		return nil, fmt.Errorf("Insert: %w", err)
+	}
+
+	return results, nil
+}
+
+// Delete deletes the assignment of the instructor with the given ID to the
+// course with the given ID, and returns the deleted rows.
+func Delete(ctx context.Context, q sql.Queryer, courseID, instructorID int64) ([]Row, error) {
+	query, err := _queries.ReadFile("queries/delete_assignment.sql")
+	if err != nil {
+		return nil, fmt.Errorf("read queries/delete_assignment.sql: %w", err)
+	}
+
+	var results []Row
+
+	if err := q.Query(ctx, &results, string(query), courseID, instructorID); err != nil {
+		return nil, fmt.Errorf("Delete(%d, %d): %w", courseID, instructorID, err)
+	}
+
+	return results, nil
+}
@@ -0,0 +1,4 @@
+DELETE FROM teaching_assignments
+WHERE course_id = $1
+AND instructor_id = $2
+RETURNING *;
@@ -0,0 +1,5 @@
+INSERT INTO teaching_assignments (course_id, instructor_id)
+VALUES
+  (:course_id, :instructor_id)
+ON CONFLICT (course_id, instructor_id) DO NOTHING
+RETURNING *;
@@ -0,0 +1 @@
+TRUNCATE TABLE teaching_assignments;
\ No newline at end of file
@@ -0,0 +1,73 @@
+// Package attendance operates on a database attendance table, which records
+// whether each enrolled student attended each session of their course, and
+// represents its rows. It is driver-agnostic.
+package attendance
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"time"
+
+	"github.com/angusgmorrison/hexagonal/internal/storage/sql"
+)
+
+//go:embed queries
+var _queries embed.FS
+
+// Row represents a row of the attendance table.
+type Row struct {
+	ID           int64     `db:"id"`
+	EnrollmentID int64     `db:"enrollment_id"`
+	SessionID    int64     `db:"session_id"`
+	Present      bool      `db:"present"`
+	RecordedAt   time.Time `db:"recorded_at"`
+}
+
+// Upsert inserts the given rows into the attendance table. Where attendance
+// has already been recorded for an enrollment and session, it is replaced.
+func Upsert(ctx context.Context, bq sql.BindQueryer, rows []Row) ([]Row, error) {
+	query, err := _queries.ReadFile("queries/upsert_attendance.sql")
+	if err != nil {
+		return nil, fmt.Errorf("read queries/upsert_attendance.sql: %w", err)
+	}
+
+	boundQuery, positionalArgs, err := bq.Bind(string(query), rows)
+	if err != nil {
+		return nil, fmt.Errorf("bind queries/upsert_attendance.sql: %w", err)
+	}
+
+	results := make([]Row, 0, len(rows))
+
+	if err := bq.Query(ctx, &results, boundQuery, positionalArgs...); err != nil {
+		return nil, fmt.Errorf("Upsert: %w", err)
+	}
+
+	return results, nil
+}
+
+// Summary is the number of sessions of a course for which a student's
+// attendance was recorded, and the number of those they attended.
+type Summary struct {
+	StudentID int64  `db:"student_id"`
+	Attended  uint32 `db:"attended"`
+	Recorded  uint32 `db:"recorded"`
+}
+
+// SummarizeCourse returns the attendance of each student actively enrolled in
+// the course with the given ID. Students with no recorded attendance are
+// omitted.
+func SummarizeCourse(ctx context.Context, q sql.Queryer, courseID int64) ([]Summary, error) {
+	query, err := _queries.ReadFile("queries/summarize_attendance_on_course.sql")
+	if err != nil {
+		return nil, fmt.Errorf("read queries/summarize_attendance_on_course.sql: %w", err)
+	}
+
+	var results []Summary
+
+	if err := q.Query(ctx, &results, string(query), courseID); err != nil {
+		return nil, fmt.Errorf("SummarizeCourse(%d): %w", courseID, err)
+	}
+
+	return results, nil
+}
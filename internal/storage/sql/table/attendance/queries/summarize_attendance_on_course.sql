@@ -0,0 +1,9 @@
+SELECT e.student_id,
+  COUNT(*) FILTER (WHERE a.present) AS attended,
+  COUNT(*) AS recorded
+FROM attendance a
+INNER JOIN enrollments e
+ON e.id = a.enrollment_id
+WHERE e.course_id = $1
+AND e.status = 'active'
+GROUP BY e.student_id;
@@ -0,0 +1 @@
+TRUNCATE TABLE attendance;
\ No newline at end of file
@@ -0,0 +1,5 @@
+INSERT INTO attendance (enrollment_id, session_id, present, recorded_at)
+VALUES (:enrollment_id, :session_id, :present, :recorded_at)
+ON CONFLICT (enrollment_id, session_id)
+DO UPDATE SET present = EXCLUDED.present, recorded_at = EXCLUDED.recorded_at
+RETURNING *;
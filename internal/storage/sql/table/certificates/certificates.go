@@ -0,0 +1,114 @@
+// Package certificates operates on a database certificates table, which
+// records the certificates of completion issued to students who passed a
+// course, and represents its rows. It is driver-agnostic.
+package certificates
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"time"
+
+	"github.com/angusgmorrison/hexagonal/internal/primitive"
+	"github.com/angusgmorrison/hexagonal/internal/storage/sql"
+)
+
+//go:embed queries
+var _queries embed.FS
+
+// Row represents a row of the certificates table. Each certificate attests to
+// a single completed enrollment, and Code is the verification code printed on
+// it.
+type Row struct {
+	ID           int64     `db:"id"`
+	Code         string    `db:"code"`
+	EnrollmentID int64     `db:"enrollment_id"`
+	IssuedAt     time.Time `db:"issued_at"`
+}
+
+// Issued is a certificate row together with the student and course of the
+// completed enrollment it attests to.
+type Issued struct {
+	Row
+	StudentID    int64                  `db:"student_id"`
+	StudentName  string                 `db:"student_name"`
+	StudentEmail primitive.EmailAddress `db:"student_email"`
+	CourseCode   string                 `db:"course_code"`
+	CourseTitle  string                 `db:"course_title"`
+	CompletedAt  time.Time              `db:"completed_at"`
+}
+
+// Insert inserts the given rows into the certificates table, skipping any row
+// for an enrollment that already has a certificate. It returns the rows
+// inserted.
+func Insert(ctx context.Context, bq sql.BindQueryer, rows []Row) ([]Row, error) {
+	query, err := _queries.ReadFile("queries/insert_certificates.sql")
+	if err != nil {
+		return nil, fmt.Errorf("read queries/insert_certificates.sql: %w", err)
+	}
+
+	boundQuery, positionalArgs, err := bq.Bind(string(query), rows)
+	if err != nil {
+		return nil, fmt.Errorf("bind queries/insert_certificates.sql: %w", err)
+	}
+
+	results := make([]Row, 0, len(rows))
+
+	if err := bq.Query(ctx, &results, boundQuery, positionalArgs...); err != nil {
+		return nil, fmt.Errorf("Insert: %w", err)
+	}
+
+	return results, nil
+}
+
+// FindByCode returns the certificate with the given code, together with the
+// student and course it was issued for.
+func FindByCode(ctx context.Context, q sql.Queryer, code string) (Issued, error) {
+	query, err := _queries.ReadFile("queries/find_certificate_by_code.sql")
+	if err != nil {
+		return Issued{}, fmt.Errorf("read queries/find_certificate_by_code.sql: %w", err)
+	}
+
+	var results []Issued
+
+	if err := q.Query(ctx, &results, string(query), code); err != nil {
+		return Issued{}, fmt.Errorf("FindByCode(%q): %w", code, err)
+	}
+
+	if len(results) == 0 {
+		return Issued{}, CertificateNotFoundError{Code: code}
+	}
+
+	return results[0], nil
+}
+
+// FindByEnrollment returns the certificate issued for the enrollment with the
+// given ID, together with the student and course it was issued for.
+func FindByEnrollment(ctx context.Context, q sql.Queryer, enrollmentID int64) (Issued, error) {
+	query, err := _queries.ReadFile("queries/find_certificate_by_enrollment.sql")
+	if err != nil {
+		return Issued{}, fmt.Errorf("read queries/find_certificate_by_enrollment.sql: %w", err)
+	}
+
+	var results []Issued
+
+	if err := q.Query(ctx, &results, string(query), enrollmentID); err != nil {
+		return Issued{}, fmt.Errorf("FindByEnrollment(%d): %w", enrollmentID, err)
+	}
+
+	if len(results) == 0 {
+		return Issued{}, fmt.Errorf("FindByEnrollment(%d): no certificate issued", enrollmentID)
+	}
+
+	return results[0], nil
+}
+
+// CertificateNotFoundError is returned when searching for a certificate by code
+// returns no results.
+type CertificateNotFoundError struct {
+	Code string
+}
+
+func (cnfe CertificateNotFoundError) Error() string {
+	return fmt.Sprintf("certificate %q not found", cnfe.Code)
+}
@@ -0,0 +1,11 @@
+SELECT ce.id, ce.code, ce.enrollment_id, ce.issued_at,
+  s.id AS student_id, s.name AS student_name, s.email AS student_email,
+  c.code AS course_code, c.title AS course_title, e.completed_at
+FROM certificates ce
+INNER JOIN enrollments e
+ON e.id = ce.enrollment_id
+INNER JOIN courses c
+ON c.id = e.course_id
+INNER JOIN students s
+ON s.id = e.student_id
+WHERE ce.enrollment_id = $1;
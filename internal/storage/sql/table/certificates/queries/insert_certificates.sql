@@ -0,0 +1,4 @@
+INSERT INTO certificates (code, enrollment_id, issued_at)
+VALUES (:code, :enrollment_id, :issued_at)
+ON CONFLICT (enrollment_id) DO NOTHING
+RETURNING *;
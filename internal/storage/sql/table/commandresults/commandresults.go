@@ -0,0 +1,81 @@
+// Package commandresults operates on a database command_results table, which
+// records the outcome of commands consumed from message queues, and represents
+// its rows. It is driver-agnostic.
+package commandresults
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"time"
+
+	"github.com/angusgmorrison/hexagonal/internal/storage/sql"
+)
+
+//go:embed queries
+var _queries embed.FS
+
+// StatusAccepted is the status of a command that was carried out.
+const StatusAccepted = "accepted"
+
+// Row represents a row of the command_results table. Error is empty unless the
+// command was rejected.
+type Row struct {
+	ID          int64     `db:"id"`
+	CommandID   string    `db:"command_id"`
+	Status      string    `db:"status"`
+	Error       string    `db:"error"`
+	ProcessedAt time.Time `db:"processed_at"`
+}
+
+// FindByCommandID returns a row based on its command ID.
+func FindByCommandID(ctx context.Context, q sql.Queryer, commandID string) (Row, error) {
+	query, err := _queries.ReadFile("queries/find_command_result_by_command_id.sql")
+	if err != nil {
+		return Row{}, fmt.Errorf("read queries/find_command_result_by_command_id.sql: %w", err)
+	}
+
+	results := make([]Row, 0, 1)
+
+	if err := q.Query(ctx, &results, string(query), commandID); err != nil {
+		return Row{}, fmt.Errorf("FindByCommandID(%q): %w", commandID, err)
+	}
+
+	if len(results) == 0 {
+		return Row{}, CommandResultNotFoundError{CommandID: commandID}
+	}
+
+	return results[0], nil
+}
+
+// Insert inserts the given results into the table, skipping those whose
+// command ID is already recorded, and returns the rows inserted.
+func Insert(ctx context.Context, bq sql.BindQueryer, results []Row) ([]Row, error) {
+	query, err := _queries.ReadFile("queries/insert_command_results.sql")
+	if err != nil {
+		return nil, fmt.Errorf("read queries/insert_command_results.sql: %w", err)
+	}
+
+	boundQuery, positionalArgs, err := bq.Bind(string(query), results)
+	if err != nil {
+		return nil, fmt.Errorf("bind queries/insert_command_results.sql: %w", err)
+	}
+
+	inserted := make([]Row, 0, len(results))
+
+	if err := bq.Query(ctx, &inserted, boundQuery, positionalArgs...); err != nil {
+		return nil, fmt.Errorf("Insert: %w", err)
+	}
+
+	return inserted, nil
+}
+
+// CommandResultNotFoundError is returned when searching for a result by
+// command ID returns no results.
+type CommandResultNotFoundError struct {
+	CommandID string
+}
+
+func (crnfe CommandResultNotFoundError) Error() string {
+	return fmt.Sprintf("no result for command %q", crnfe.CommandID)
+}
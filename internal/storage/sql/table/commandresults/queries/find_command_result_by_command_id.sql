@@ -0,0 +1,3 @@
+SELECT id, command_id, status, error, processed_at
+FROM command_results
+WHERE command_id = $1;
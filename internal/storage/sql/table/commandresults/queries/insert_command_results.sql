@@ -0,0 +1,4 @@
+INSERT INTO command_results (command_id, status, error, processed_at)
+VALUES (:command_id, :status, :error, :processed_at)
+ON CONFLICT (command_id) DO NOTHING
+RETURNING *;
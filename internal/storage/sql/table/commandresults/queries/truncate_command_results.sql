@@ -0,0 +1 @@
+TRUNCATE TABLE command_results;
\ No newline at end of file
@@ -6,8 +6,10 @@ import (
 	"context"
 	"embed"
 	"fmt"
+	"time"
 
 	"github.com/angusgmorrison/hexagonal/internal/storage/sql"
+	"github.com/jmoiron/sqlx"
 )
 
 //go:embed queries
@@ -15,24 +17,54 @@ var _queries embed.FS
 
 // Row represents a row of the courses table.
 type Row struct {
-	ID          int64  `db:"id"`
-	Code        string `db:"code"`
-	Title       string `db:"title"`
-	Capacity    uint32 `db:"capacity"`
-	Description string `db:"description"`
+	ID               int64  `db:"id"`
+	Code             string `db:"code"`
+	Title            string `db:"title"`
+	Capacity         uint32 `db:"capacity"`
+	Description      string `db:"description"`
+	RequiresApproval bool   `db:"requires_approval"`
+
+	// FeeAmount and FeeCurrency describe the fee charged for the course, in
+	// minor units of the currency. Both are nil if the course is free.
+	FeeAmount   *int64  `db:"fee_amount"`
+	FeeCurrency *string `db:"fee_currency"`
+
+	// ArchivedAt is the time at which the course was cancelled, or nil if the
+	// course is running.
+	ArchivedAt *time.Time `db:"archived_at"`
 }
 
 // FindByCode returns a row based on its course code.
 func FindByCode(ctx context.Context, q sql.Queryer, code string) (Row, error) {
-	query, err := _queries.ReadFile("queries/find_course_by_code.sql")
+	row, err := findByCode(ctx, q, "queries/find_course_by_code.sql", code)
 	if err != nil {
-		return Row{}, fmt.Errorf("read queries/find_course_by_code.sql: %w", err)
+		return Row{}, fmt.Errorf("FindByCode(%q): %w", code, err)
+	}
+
+	return row, nil
+}
+
+// FindByCodeForUpdate returns a row based on its course code, locking it until
+// the end of the transaction q belongs to.
+func FindByCodeForUpdate(ctx context.Context, q sql.Queryer, code string) (Row, error) {
+	row, err := findByCode(ctx, q, "queries/find_course_by_code_for_update.sql", code)
+	if err != nil {
+		return Row{}, fmt.Errorf("FindByCodeForUpdate(%q): %w", code, err)
+	}
+
+	return row, nil
+}
+
+func findByCode(ctx context.Context, q sql.Queryer, path, code string) (Row, error) {
+	query, err := _queries.ReadFile(path)
+	if err != nil {
+		return Row{}, fmt.Errorf("read %s: %w", path, err)
 	}
 
 	results := make([]Row, 0, 1)
 
 	if err := q.Query(ctx, &results, string(query), code); err != nil {
-		return Row{}, fmt.Errorf("FindByCode(%q): %w", code, err)
+		return Row{}, err
 	}
 
 	if len(results) == 0 {
@@ -42,6 +74,79 @@ func FindByCode(ctx context.Context, q sql.Queryer, code string) (Row, error) {
 	return results[0], nil
 }
 
+// FindByIDs returns the rows of the courses with the given IDs, ordered by
+// course code. IDs with no matching course are ignored.
+func FindByIDs(ctx context.Context, rq sql.RebindQueryer, ids []int64) ([]Row, error) {
+	query, err := _queries.ReadFile("queries/select_courses_by_ids.sql")
+	if err != nil {
+		return nil, fmt.Errorf("read queries/select_courses_by_ids.sql: %w", err)
+	}
+
+	inQuery, positionalArgs, err := sqlx.In(string(query), ids)
+	if err != nil {
+		return nil, fmt.Errorf("generate IN query with course IDs: %w", err)
+	}
+
+	results := make([]Row, 0, len(ids))
+
+	if err := rq.Query(ctx, &results, rq.Rebind(inQuery), positionalArgs...); err != nil {
+		return nil, fmt.Errorf("FindByIDs(%v): %w", ids, err)
+	}
+
+	return results, nil
+}
+
+// All returns the rows of every course, including cancelled courses, ordered
+// by course code.
+func All(ctx context.Context, q sql.Queryer) ([]Row, error) {
+	query, err := _queries.ReadFile("queries/select_courses.sql")
+	if err != nil {
+		return nil, fmt.Errorf("read queries/select_courses.sql: %w", err)
+	}
+
+	var results []Row
+
+	if err := q.Query(ctx, &results, string(query)); err != nil {
+		return nil, fmt.Errorf("All: %w", err)
+	}
+
+	return results, nil
+}
+
+// TaughtBy returns the rows of all courses that the instructor with the given
+// ID is assigned to teach, ordered by course code.
+func TaughtBy(ctx context.Context, q sql.Queryer, instructorID int64) ([]Row, error) {
+	query, err := _queries.ReadFile("queries/select_courses_taught_by.sql")
+	if err != nil {
+		return nil, fmt.Errorf("read queries/select_courses_taught_by.sql: %w", err)
+	}
+
+	var results []Row
+
+	if err := q.Query(ctx, &results, string(query), instructorID); err != nil {
+		return nil, fmt.Errorf("TaughtBy(%d): %w", instructorID, err)
+	}
+
+	return results, nil
+}
+
+// PrerequisitesOf returns the rows of all courses that students must pass
+// before enrolling in the course with the given ID, ordered by course code.
+func PrerequisitesOf(ctx context.Context, q sql.Queryer, courseID int64) ([]Row, error) {
+	query, err := _queries.ReadFile("queries/select_prerequisites_of_course.sql")
+	if err != nil {
+		return nil, fmt.Errorf("read queries/select_prerequisites_of_course.sql: %w", err)
+	}
+
+	var results []Row
+
+	if err := q.Query(ctx, &results, string(query), courseID); err != nil {
+		return nil, fmt.Errorf("PrerequisitesOf(%d): %w", courseID, err)
+	}
+
+	return results, nil
+}
+
 // Insert inserts the given courses into the table.
 func Insert(ctx context.Context, bq sql.BindQueryer, courses []Row) ([]Row, error) {
 	query, err := _queries.ReadFile("queries/insert_courses.sql")
@@ -63,6 +168,24 @@ func Insert(ctx context.Context, bq sql.BindQueryer, courses []Row) ([]Row, erro
 	return results, nil
 }
 
+// Archive marks the course with the given ID as archived at time t, and
+// returns the updated rows, which are empty if the course was already
+// archived.
+func Archive(ctx context.Context, q sql.Queryer, id int64, t time.Time) ([]Row, error) {
+	query, err := _queries.ReadFile("queries/archive_course.sql")
+	if err != nil {
+		return nil, fmt.Errorf("read queries/archive_course.sql: %w", err)
+	}
+
+	var results []Row
+
+	if err := q.Query(ctx, &results, string(query), id, t); err != nil {
+		return nil, fmt.Errorf("Archive(%d): %w", id, err)
+	}
+
+	return results, nil
+}
+
 // CourseNotFoundError is returned when searching for a course by code returns
 // no results.
 type CourseNotFoundError struct {
@@ -0,0 +1,5 @@
+UPDATE courses
+SET archived_at = $2
+WHERE id = $1
+AND archived_at IS NULL
+RETURNING *;
@@ -1,3 +1,3 @@
-SELECT id, code, title, capacity, description
+SELECT id, code, title, capacity, description, requires_approval, fee_amount, fee_currency, archived_at
 FROM courses
 WHERE code = $1;
\ No newline at end of file
@@ -0,0 +1,4 @@
+SELECT id, code, title, capacity, description, requires_approval, fee_amount, fee_currency, archived_at
+FROM courses
+WHERE code = $1
+FOR UPDATE;
@@ -1,4 +1,4 @@
-INSERT INTO courses (title, code, capacity, description)
+INSERT INTO courses (title, code, capacity, description, requires_approval, fee_amount, fee_currency, archived_at)
 VALUES
-  (:title, :code, :capacity, :description)
+  (:title, :code, :capacity, :description, :requires_approval, :fee_amount, :fee_currency, :archived_at)
 RETURNING *;
\ No newline at end of file
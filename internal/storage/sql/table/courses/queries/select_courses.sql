@@ -0,0 +1,3 @@
+SELECT id, code, title, capacity, description, requires_approval, fee_amount, fee_currency, archived_at
+FROM courses
+ORDER BY code;
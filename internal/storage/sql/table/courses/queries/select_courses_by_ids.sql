@@ -0,0 +1,4 @@
+SELECT id, code, title, capacity, description, requires_approval, fee_amount, fee_currency, archived_at
+FROM courses
+WHERE id IN (?)
+ORDER BY code;
@@ -0,0 +1,6 @@
+SELECT c.id, c.code, c.title, c.capacity, c.description, c.requires_approval, c.fee_amount, c.fee_currency, c.archived_at
+FROM courses c
+INNER JOIN teaching_assignments ta
+ON c.id = ta.course_id
+WHERE ta.instructor_id = $1
+ORDER BY c.code;
@@ -6,15 +6,51 @@ import (
 	"context"
 	"embed"
 	"fmt"
+	"time"
 
 	"github.com/angusgmorrison/hexagonal/internal/storage/sql"
+	"github.com/jmoiron/sqlx"
+)
+
+// Statuses of an enrollment.
+const (
+	// StatusActive enrollments hold a place in a course.
+	StatusActive = "active"
+
+	// StatusPending enrollments are awaiting approval.
+	StatusPending = "pending"
+
+	// StatusPendingPayment enrollments hold a place in a course that charges a
+	// fee, and become active once the student's invoice is paid.
+	StatusPendingPayment = "pending_payment"
+
+	// StatusRejected enrollments were refused approval.
+	StatusRejected = "rejected"
+
+	// StatusWithdrawn enrollments were once active, but the student has left
+	// the course.
+	StatusWithdrawn = "withdrawn"
+
+	// StatusCancelled enrollments were ended by the cancellation of their
+	// course.
+	StatusCancelled = "cancelled"
+
+	// StatusCompleted enrollments were once active, and the student has been
+	// awarded a final grade.
+	StatusCompleted = "completed"
 )
 
 // Row represents a row of the enrollments table.
 type Row struct {
-	ID        int64 `db:"id"`
-	CourseID  int64 `db:"course_id"`
-	StudentID int64 `db:"student_id"`
+	ID        int64  `db:"id"`
+	CourseID  int64  `db:"course_id"`
+	SectionID *int64 `db:"section_id"`
+	StudentID int64  `db:"student_id"`
+	Status    string `db:"status"`
+
+	// Grade and CompletedAt are set when the enrollment is completed.
+	Grade       *string    `db:"grade"`
+	CompletedAt *time.Time `db:"completed_at"`
 }
 
 //go:embed queries
@@ -40,3 +76,195 @@ func Insert(ctx context.Context, bq sql.BindQueryer, rows []Row) ([]Row, error)
 
 	return results, nil
 }
+
+// UpdateStatus transitions the enrollment of a student in a course from one
+// status to another. If sectionID is non-nil, the enrollment is placed in that
+// section; otherwise, its section is unchanged. It returns the updated rows,
+// which are empty if the student has no enrollment in the course with status
+// from.
+func UpdateStatus(
+	ctx context.Context,
+	q sql.Queryer,
+	courseID, studentID int64,
+	from, to string,
+	sectionID *int64,
+) ([]Row, error) {
+	query, err := _queries.ReadFile("queries/update_enrollment_status.sql")
+	if err != nil {
+		return nil, fmt.Errorf("read queries/update_enrollment_status.sql: %w", err)
+	}
+
+	var results []Row
+
+	if err := q.Query(ctx, &results, string(query), courseID, studentID, from, to, sectionID); err != nil {
+		return nil, fmt.Errorf("UpdateStatus(%d, %d, %q, %q): %w", courseID, studentID, from, to, err)
+	}
+
+	return results, nil
+}
+
+// OnCourse returns the rows of the enrollments in the course with the given ID
+// that have the given status.
+func OnCourse(ctx context.Context, q sql.Queryer, courseID int64, status string) ([]Row, error) {
+	query, err := _queries.ReadFile("queries/select_enrollments_on_course.sql")
+	if err != nil {
+		return nil, fmt.Errorf("read queries/select_enrollments_on_course.sql: %w", err)
+	}
+
+	var results []Row
+
+	if err := q.Query(ctx, &results, string(query), courseID, status); err != nil {
+		return nil, fmt.Errorf("OnCourse(%d, %q): %w", courseID, status, err)
+	}
+
+	return results, nil
+}
+
+// CancelOnCourse marks every active, pending or unpaid enrollment in the course
+// with the given ID as cancelled, and returns the updated rows.
+func CancelOnCourse(ctx context.Context, q sql.Queryer, courseID int64) ([]Row, error) {
+	query, err := _queries.ReadFile("queries/cancel_enrollments_on_course.sql")
+	if err != nil {
+		return nil, fmt.Errorf("read queries/cancel_enrollments_on_course.sql: %w", err)
+	}
+
+	var results []Row
+
+	if err := q.Query(ctx, &results, string(query), courseID); err != nil {
+		return nil, fmt.Errorf("CancelOnCourse(%d): %w", courseID, err)
+	}
+
+	return results, nil
+}
+
+// Complete marks the active enrollment of a student in a course as completed at
+// time t with the given grade. It returns the updated rows, which are empty if
+// the student has no active enrollment in the course.
+func Complete(
+	ctx context.Context,
+	q sql.Queryer,
+	courseID, studentID int64,
+	grade string,
+	t time.Time,
+) ([]Row, error) {
+	query, err := _queries.ReadFile("queries/complete_enrollment.sql")
+	if err != nil {
+		return nil, fmt.Errorf("read queries/complete_enrollment.sql: %w", err)
+	}
+
+	var results []Row
+
+	if err := q.Query(ctx, &results, string(query), courseID, studentID, grade, t); err != nil {
+		return nil, fmt.Errorf("Complete(%d, %d, %q): %w", courseID, studentID, grade, err)
+	}
+
+	return results, nil
+}
+
+// Completion represents the completed enrollment of a student in a course.
+type Completion struct {
+	StudentID   int64     `db:"student_id"`
+	CourseCode  string    `db:"course_code"`
+	Grade       string    `db:"grade"`
+	CompletedAt time.Time `db:"completed_at"`
+}
+
+// CompletionsByStudent returns the completed enrollments of the given
+// students, ordered by completion time.
+func CompletionsByStudent(
+	ctx context.Context,
+	rq sql.RebindQueryer,
+	studentIDs []int64,
+) ([]Completion, error) {
+	query, err := _queries.ReadFile("queries/select_completions_by_student.sql")
+	if err != nil {
+		return nil, fmt.Errorf("read queries/select_completions_by_student.sql: %w", err)
+	}
+
+	inQuery, positionalArgs, err := sqlx.In(string(query), studentIDs)
+	if err != nil {
+		return nil, fmt.Errorf("generate IN query with student IDs: %w", err)
+	}
+
+	boundQuery := rq.Rebind(inQuery)
+
+	var results []Completion
+
+	if err := rq.Query(ctx, &results, boundQuery, positionalArgs...); err != nil {
+		return nil, fmt.Errorf("CompletionsByStudent(%v): %w", studentIDs, err)
+	}
+
+	return results, nil
+}
+
+// StudentCount represents the number of enrollments held by a student.
+type StudentCount struct {
+	StudentID int64  `db:"student_id"`
+	Count     uint32 `db:"count"`
+}
+
+// CountCurrentByStudent returns the number of active enrollments and
+// enrollments pending payment held by each of the given students. Students
+// with no such enrollments are omitted from the results.
+func CountCurrentByStudent(
+	ctx context.Context,
+	rq sql.RebindQueryer,
+	studentIDs []int64,
+) ([]StudentCount, error) {
+	query, err := _queries.ReadFile("queries/count_current_enrollments_by_student.sql")
+	if err != nil {
+		return nil, fmt.Errorf("read queries/count_current_enrollments_by_student.sql: %w", err)
+	}
+
+	inQuery, positionalArgs, err := sqlx.In(string(query), studentIDs)
+	if err != nil {
+		return nil, fmt.Errorf("generate IN query with student IDs: %w", err)
+	}
+
+	boundQuery := rq.Rebind(inQuery)
+
+	results := make([]StudentCount, 0, len(studentIDs))
+
+	if err := rq.Query(ctx, &results, boundQuery, positionalArgs...); err != nil {
+		return nil, fmt.Errorf("CountCurrentByStudent(%v): %w", studentIDs, err)
+	}
+
+	return results, nil
+}
+
+// Current represents an active, pending or unpaid enrollment of a student in a
+// course. SectionCode is nil if the student hasn't been placed in a section.
+type Current struct {
+	StudentID   int64   `db:"student_id"`
+	CourseCode  string  `db:"course_code"`
+	SectionCode *string `db:"section_code"`
+	Status      string  `db:"status"`
+}
+
+// CurrentByStudent returns the active, pending and unpaid enrollments of the
+// given students, ordered by student and course code.
+func CurrentByStudent(
+	ctx context.Context,
+	rq sql.RebindQueryer,
+	studentIDs []int64,
+) ([]Current, error) {
+	query, err := _queries.ReadFile("queries/select_current_enrollments_by_student.sql")
+	if err != nil {
+		return nil, fmt.Errorf("read queries/select_current_enrollments_by_student.sql: %w", err)
+	}
+
+	inQuery, positionalArgs, err := sqlx.In(string(query), studentIDs)
+	if err != nil {
+		return nil, fmt.Errorf("generate IN query with student IDs: %w", err)
+	}
+
+	boundQuery := rq.Rebind(inQuery)
+
+	var results []Current
+
+	if err := rq.Query(ctx, &results, boundQuery, positionalArgs...); err != nil {
+		return nil, fmt.Errorf("CurrentByStudent(%v): %w", studentIDs, err)
+	}
+
+	return results, nil
+}
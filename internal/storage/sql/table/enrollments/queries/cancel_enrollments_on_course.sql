@@ -0,0 +1,5 @@
+UPDATE enrollments
+SET status = 'cancelled'
+WHERE course_id = $1
+AND status IN ('active', 'pending', 'pending_payment')
+RETURNING *;
@@ -0,0 +1,6 @@
+UPDATE enrollments
+SET status = 'completed', grade = $3, completed_at = $4
+WHERE course_id = $1
+AND student_id = $2
+AND status = 'active'
+RETURNING *;
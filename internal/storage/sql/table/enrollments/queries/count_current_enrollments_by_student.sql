@@ -0,0 +1,5 @@
+SELECT student_id, COUNT(*) AS count
+FROM enrollments
+WHERE student_id IN (?)
+AND status IN ('active', 'pending_payment')
+GROUP BY student_id;
@@ -1,3 +1,3 @@
-INSERT INTO enrollments (course_id, student_id)
-VALUES (:course_id, :student_id)
+INSERT INTO enrollments (course_id, section_id, student_id, status)
+VALUES (:course_id, :section_id, :student_id, :status)
 RETURNING *;
\ No newline at end of file
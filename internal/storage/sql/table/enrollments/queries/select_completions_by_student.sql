@@ -0,0 +1,7 @@
+SELECT e.student_id, c.code AS course_code, e.grade, e.completed_at
+FROM enrollments e
+INNER JOIN courses c
+ON c.id = e.course_id
+WHERE e.student_id IN (?)
+AND e.status = 'completed'
+ORDER BY e.completed_at, c.code;
@@ -0,0 +1,9 @@
+SELECT e.student_id, c.code AS course_code, s.code AS section_code, e.status
+FROM enrollments e
+INNER JOIN courses c
+ON c.id = e.course_id
+LEFT JOIN sections s
+ON s.id = e.section_id
+WHERE e.student_id IN (?)
+AND e.status IN ('active', 'pending', 'pending_payment')
+ORDER BY e.student_id, c.code;
@@ -0,0 +1,4 @@
+SELECT id, course_id, section_id, student_id, status, grade, completed_at
+FROM enrollments
+WHERE course_id = $1
+AND status = $2;
@@ -1 +1 @@
-TRUNCATE TABLE enrollments;
\ No newline at end of file
+TRUNCATE TABLE enrollments CASCADE;
\ No newline at end of file
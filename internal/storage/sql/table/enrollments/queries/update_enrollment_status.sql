@@ -0,0 +1,6 @@
+UPDATE enrollments
+SET status = $4, section_id = COALESCE($5, section_id)
+WHERE course_id = $1
+AND student_id = $2
+AND status = $3
+RETURNING *;
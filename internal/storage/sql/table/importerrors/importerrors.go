@@ -0,0 +1,65 @@
+// Package importerrors operates on a database import_errors table, which
+// records why rows of bulk enrollment imports couldn't be enrolled, and
+// represents its rows. It is driver-agnostic.
+package importerrors
+
+import (
+	"context"
+	"embed"
+	"fmt"
+
+	"github.com/angusgmorrison/hexagonal/internal/primitive"
+	"github.com/angusgmorrison/hexagonal/internal/storage/sql"
+)
+
+//go:embed queries
+var _queries embed.FS
+
+// Row represents a row of the import_errors table. Line identifies the failed
+// row in the imported file.
+type Row struct {
+	ID         int64                  `db:"id"`
+	ImportID   int64                  `db:"import_id"`
+	Line       int                    `db:"line"`
+	CourseCode string                 `db:"course_code"`
+	Email      primitive.EmailAddress `db:"email"`
+	Message    string                 `db:"message"`
+}
+
+// ByImport returns the errors recorded against the import with the given ID,
+// ordered by line.
+func ByImport(ctx context.Context, q sql.Queryer, importID int64) ([]Row, error) {
+	query, err := _queries.ReadFile("queries/select_import_errors_by_import.sql")
+	if err != nil {
+		return nil, fmt.Errorf("read queries/select_import_errors_by_import.sql: %w", err)
+	}
+
+	var results []Row
+
+	if err := q.Query(ctx, &results, string(query), importID); err != nil {
+		return nil, fmt.Errorf("ByImport(%d): %w", importID, err)
+	}
+
+	return results, nil
+}
+
+// Insert inserts the given import errors into the table.
+func Insert(ctx context.Context, bq sql.BindQueryer, importErrors []Row) ([]Row, error) {
+	query, err := _queries.ReadFile("queries/insert_import_errors.sql")
+	if err != nil {
+		return nil, fmt.Errorf("read queries/insert_import_errors.sql: %w", err)
+	}
+
+	boundQuery, positionalArgs, err := bq.Bind(string(query), importErrors)
+	if err != nil {
+		return nil, fmt.Errorf("bind queries/insert_import_errors.sql: %w", err)
+	}
+
+	results := make([]Row, 0, len(importErrors))
+
+	if err := bq.Query(ctx, &results, boundQuery, positionalArgs...); err != nil {
+		return nil, fmt.Errorf("Insert: %w", err)
+	}
+
+	return results, nil
+}
@@ -0,0 +1,3 @@
+INSERT INTO import_errors (import_id, line, course_code, email, message)
+VALUES (:import_id, :line, :course_code, :email, :message)
+RETURNING *;
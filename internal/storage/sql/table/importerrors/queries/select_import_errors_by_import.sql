@@ -0,0 +1,4 @@
+SELECT id, import_id, line, course_code, email, message
+FROM import_errors
+WHERE import_id = $1
+ORDER BY line, id;
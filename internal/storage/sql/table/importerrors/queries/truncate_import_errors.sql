@@ -0,0 +1 @@
+TRUNCATE TABLE import_errors CASCADE;
\ No newline at end of file
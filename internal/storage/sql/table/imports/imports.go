@@ -0,0 +1,128 @@
+// Package imports operates on a database imports table, which tracks the
+// progress of bulk enrollment imports, and represents its rows. It is
+// driver-agnostic.
+package imports
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"time"
+
+	"github.com/angusgmorrison/hexagonal/internal/storage/sql"
+)
+
+//go:embed queries
+var _queries embed.FS
+
+// Row represents a row of the imports table. Status is "pending", "running",
+// "completed" or "failed".
+type Row struct {
+	ID            int64     `db:"id"`
+	Status        string    `db:"status"`
+	TotalRows     uint32    `db:"total_rows"`
+	ProcessedRows uint32    `db:"processed_rows"`
+	FailedRows    uint32    `db:"failed_rows"`
+	CreatedAt     time.Time `db:"created_at"`
+	UpdatedAt     time.Time `db:"updated_at"`
+
+	// CompletedAt is nil until the import is completed.
+	CompletedAt *time.Time `db:"completed_at"`
+}
+
+// FindByID returns a row based on its ID.
+func FindByID(ctx context.Context, q sql.Queryer, id int64) (Row, error) {
+	query, err := _queries.ReadFile("queries/find_import_by_id.sql")
+	if err != nil {
+		return Row{}, fmt.Errorf("read queries/find_import_by_id.sql: %w", err)
+	}
+
+	results := make([]Row, 0, 1)
+
+	if err := q.Query(ctx, &results, string(query), id); err != nil {
+		return Row{}, fmt.Errorf("FindByID(%d): %w", id, err)
+	}
+
+	if len(results) == 0 {
+		return Row{}, ImportNotFoundError{ID: id}
+	}
+
+	return results[0], nil
+}
+
+// Insert inserts the given imports into the table.
+func Insert(ctx context.Context, bq sql.BindQueryer, imports []Row) ([]Row, error) {
+	query, err := _queries.ReadFile("queries/insert_imports.sql")
+	if err != nil {
+		return nil, fmt.Errorf("read queries/insert_imports.sql: %w", err)
+	}
+
+	boundQuery, positionalArgs, err := bq.Bind(string(query), imports)
+	if err != nil {
+		return nil, fmt.Errorf("bind queries/insert_imports.sql: %w", err)
+	}
+
+	results := make([]Row, 0, len(imports))
+
+	if err := bq.Query(ctx, &results, boundQuery, positionalArgs...); err != nil {
+		return nil, fmt.Errorf("Insert: %w", err)
+	}
+
+	return results, nil
+}
+
+// Update overwrites the status, counters and completion time of the import
+// with the ID of the given row, and returns the updated rows, which are empty
+// if no such import exists.
+func Update(ctx context.Context, q sql.Queryer, row Row) ([]Row, error) {
+	query, err := _queries.ReadFile("queries/update_import.sql")
+	if err != nil {
+		return nil, fmt.Errorf("read queries/update_import.sql: %w", err)
+	}
+
+	var results []Row
+
+	if err := q.Query(
+		ctx,
+		&results,
+		string(query),
+		row.ID,
+		row.Status,
+		row.ProcessedRows,
+		row.FailedRows,
+		row.UpdatedAt,
+		row.CompletedAt,
+	); err != nil {
+		return nil, fmt.Errorf("Update(%d): %w", row.ID, err)
+	}
+
+	return results, nil
+}
+
+// FailStalled marks as failed every pending or running import last updated
+// before updatedBefore, recording failedAt as the time of its update and
+// completion, and returns the failed rows.
+func FailStalled(ctx context.Context, q sql.Queryer, updatedBefore, failedAt time.Time) ([]Row, error) {
+	query, err := _queries.ReadFile("queries/fail_stalled_imports.sql")
+	if err != nil {
+		return nil, fmt.Errorf("read queries/fail_stalled_imports.sql: %w", err)
+	}
+
+	var results []Row
+
+	if err := q.Query(ctx, &results, string(query), updatedBefore, failedAt); err != nil {
+		return nil, fmt.Errorf("FailStalled: %w", err)
+	}
+
+	return results, nil
+}
+
+// ImportNotFoundError is returned when searching for an import by ID returns
+// no results.
+type ImportNotFoundError struct {
+	ID int64
+}
+
+func (infe ImportNotFoundError) Error() string {
+	return fmt.Sprintf("no import with ID %d", infe.ID)
+}
@@ -0,0 +1,4 @@
+UPDATE imports
+SET status = 'failed', updated_at = $2, completed_at = $2
+WHERE status IN ('pending', 'running') AND updated_at < $1
+RETURNING *;
@@ -0,0 +1,3 @@
+SELECT id, status, total_rows, processed_rows, failed_rows, created_at, completed_at
+FROM imports
+WHERE id = $1;
@@ -0,0 +1,3 @@
+INSERT INTO imports (status, total_rows, processed_rows, failed_rows, created_at, updated_at, completed_at)
+VALUES (:status, :total_rows, :processed_rows, :failed_rows, :created_at, :updated_at, :completed_at)
+RETURNING *;
@@ -0,0 +1 @@
+TRUNCATE TABLE imports CASCADE;
\ No newline at end of file
@@ -0,0 +1,4 @@
+UPDATE imports
+SET status = $2, processed_rows = $3, failed_rows = $4, updated_at = $5, completed_at = $6
+WHERE id = $1
+RETURNING *;
@@ -0,0 +1,170 @@
+// Package instructors operates on a database instructors table and represents
+// its rows. It is driver-agnostic.
+package instructors
+
+import (
+	"context"
+	"embed"
+	"fmt"
+
+	"github.com/angusgmorrison/hexagonal/internal/primitive"
+	"github.com/angusgmorrison/hexagonal/internal/storage/sql"
+	"github.com/jmoiron/sqlx"
+)
+
+//go:embed queries
+var _queries embed.FS
+
+// Row represents a row of the instructors table.
+type Row struct {
+	ID    int64                  `db:"id"`
+	Name  string                 `db:"name"`
+	Email primitive.EmailAddress `db:"email"`
+}
+
+// All returns every row of the table, ordered by name.
+func All(ctx context.Context, q sql.Queryer) ([]Row, error) {
+	query, err := _queries.ReadFile("queries/select_instructors.sql")
+	if err != nil {
+		return nil, fmt.Errorf("read queries/select_instructors.sql: %w", err)
+	}
+
+	var results []Row
+
+	if err := q.Query(ctx, &results, string(query)); err != nil {
+		return nil, fmt.Errorf("All: %w", err)
+	}
+
+	return results, nil
+}
+
+// FindByID returns a row based on its ID.
+func FindByID(ctx context.Context, q sql.Queryer, id int64) (Row, error) {
+	query, err := _queries.ReadFile("queries/find_instructor_by_id.sql")
+	if err != nil {
+		return Row{}, fmt.Errorf("read queries/find_instructor_by_id.sql: %w", err)
+	}
+
+	results := make([]Row, 0, 1)
+
+	if err := q.Query(ctx, &results, string(query), id); err != nil {
+		return Row{}, fmt.Errorf("FindByID(%d): %w", id, err)
+	}
+
+	if len(results) == 0 {
+		return Row{}, InstructorNotFoundError{ID: id}
+	}
+
+	return results[0], nil
+}
+
+// SelectByEmail returns all instructors whose email addresses are present in
+// the given slice.
+func SelectByEmail(
+	ctx context.Context,
+	rq sql.RebindQueryer,
+	emails []primitive.EmailAddress,
+) ([]Row, error) {
+	query, err := _queries.ReadFile("queries/select_instructors_by_email.sql")
+	if err != nil {
+		return nil, fmt.Errorf("read queries/select_instructors_by_email.sql: %w", err)
+	}
+
+	inQuery, positionalArgs, err := sqlx.In(string(query), emails)
+	if err != nil {
+		return nil, fmt.Errorf("generate IN query with emails: %w", err)
+	}
+
+	boundQuery := rq.Rebind(inQuery)
+
+	results := make([]Row, 0, len(emails))
+
+	if err := rq.Query(ctx, &results, boundQuery, positionalArgs...); err != nil {
+		return nil, fmt.Errorf("SelectByEmail(%+v): %w", emails, err)
+	}
+
+	return results, nil
+}
+
+// OnCourse returns the rows of all instructors assigned to teach the course
+// with the given ID, ordered by name.
+func OnCourse(ctx context.Context, q sql.Queryer, courseID int64) ([]Row, error) {
+	query, err := _queries.ReadFile("queries/select_instructors_on_course.sql")
+	if err != nil {
+		return nil, fmt.Errorf("read queries/select_instructors_on_course.sql: %w", err)
+	}
+
+	var results []Row
+
+	if err := q.Query(ctx, &results, string(query), courseID); err != nil {
+		return nil, fmt.Errorf("OnCourse(%d): %w", courseID, err)
+	}
+
+	return results, nil
+}
+
+// Insert inserts the given instructors into the table.
+func Insert(ctx context.Context, bq sql.BindQueryer, instructors []Row) ([]Row, error) {
+	query, err := _queries.ReadFile("queries/insert_instructors.sql")
+	if err != nil {
+		return nil, fmt.Errorf("read queries/insert_instructors.sql: %w", err)
+	}
+
+	boundQuery, positionalArgs, err := bq.Bind(string(query), instructors)
+	if err != nil {
+		return nil, fmt.Errorf("bind queries/insert_instructors.sql: %w", err)
+	}
+
+	results := make([]Row, 0, len(instructors))
+
+	if err := bq.Query(ctx, &results, boundQuery, positionalArgs...); err != nil {
+		return nil, fmt.Errorf("Insert: %w", err)
+	}
+
+	return results, nil
+}
+
+// Update overwrites the name and email address of the instructor with the ID
+// of the given row, and returns the updated rows, which are empty if no such
+// instructor exists.
+func Update(ctx context.Context, q sql.Queryer, row Row) ([]Row, error) {
+	query, err := _queries.ReadFile("queries/update_instructor.sql")
+	if err != nil {
+		return nil, fmt.Errorf("read queries/update_instructor.sql: %w", err)
+	}
+
+	var results []Row
+
+	if err := q.Query(ctx, &results, string(query), row.ID, row.Name, row.Email); err != nil {
+		return nil, fmt.Errorf("Update(%d): %w", row.ID, err)
+	}
+
+	return results, nil
+}
+
+// Delete deletes the instructor with the given ID, together with their
+// teaching assignments, and returns the deleted rows.
+func Delete(ctx context.Context, q sql.Queryer, id int64) ([]Row, error) {
+	query, err := _queries.ReadFile("queries/delete_instructor.sql")
+	if err != nil {
+		return nil, fmt.Errorf("read queries/delete_instructor.sql: %w", err)
+	}
+
+	var results []Row
+
+	if err := q.Query(ctx, &results, string(query), id); err != nil {
+		return nil, fmt.Errorf("Delete(%d): %w", id, err)
+	}
+
+	return results, nil
+}
+
+// InstructorNotFoundError is returned when searching for an instructor by ID
+// returns no results.
+type InstructorNotFoundError struct {
+	ID int64
+}
+
+func (infe InstructorNotFoundError) Error() string {
+	return fmt.Sprintf("no instructor with ID %d", infe.ID)
+}
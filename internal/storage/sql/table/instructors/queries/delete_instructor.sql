@@ -0,0 +1,3 @@
+DELETE FROM instructors
+WHERE id = $1
+RETURNING *;
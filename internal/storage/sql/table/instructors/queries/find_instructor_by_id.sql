@@ -0,0 +1,3 @@
+SELECT id, name, email
+FROM instructors
+WHERE id = $1;
@@ -0,0 +1,4 @@
+INSERT INTO instructors (name, email)
+VALUES
+  (:name, :email)
+RETURNING *;
@@ -0,0 +1,6 @@
+SELECT i.id, i.name, i.email
+FROM instructors i
+INNER JOIN teaching_assignments ta
+ON i.id = ta.instructor_id
+WHERE ta.course_id = $1
+ORDER BY i.name, i.id;
@@ -0,0 +1 @@
+TRUNCATE TABLE instructors CASCADE;
\ No newline at end of file
@@ -0,0 +1,4 @@
+UPDATE instructors
+SET name = $2, email = $3
+WHERE id = $1
+RETURNING *;
@@ -0,0 +1,117 @@
+// Package invoices operates on a database invoices table, which records the
+// invoices issued for the enrollments of students in courses that charge a
+// fee, and represents its rows. It is driver-agnostic.
+package invoices
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"time"
+
+	"github.com/angusgmorrison/hexagonal/internal/primitive"
+	"github.com/angusgmorrison/hexagonal/internal/storage/sql"
+)
+
+//go:embed queries
+var _queries embed.FS
+
+// Row represents a row of the invoices table. Reference is the identifier
+// assigned to the invoice by the payment provider. Amount is in minor units of
+// Currency.
+type Row struct {
+	ID           int64     `db:"id"`
+	Reference    string    `db:"reference"`
+	EnrollmentID int64     `db:"enrollment_id"`
+	Amount       int64     `db:"amount"`
+	Currency     string    `db:"currency"`
+	IssuedAt     time.Time `db:"issued_at"`
+
+	// VoucherID is the ID of the voucher that discounted the invoice, or nil
+	// if the student redeemed none.
+	VoucherID *int64 `db:"voucher_id"`
+
+	// PaidAt is the time at which the invoice was paid, or nil if it is
+	// outstanding.
+	PaidAt *time.Time `db:"paid_at"`
+}
+
+// Billed is an invoice row together with the course and student whose
+// enrollment it bills, and the code of the voucher that discounted it, if any.
+type Billed struct {
+	Row
+	CourseCode   string                 `db:"course_code"`
+	StudentID    int64                  `db:"student_id"`
+	StudentEmail primitive.EmailAddress `db:"student_email"`
+	VoucherCode  *string                `db:"voucher_code"`
+}
+
+// FindByReference returns the invoice with the given reference, together with
+// the course and student it bills.
+func FindByReference(ctx context.Context, q sql.Queryer, reference string) (Billed, error) {
+	query, err := _queries.ReadFile("queries/find_invoice_by_reference.sql")
+	if err != nil {
+		return Billed{}, fmt.Errorf("read queries/find_invoice_by_reference.sql: %w", err)
+	}
+
+	var results []Billed
+
+	if err := q.Query(ctx, &results, string(query), reference); err != nil {
+		return Billed{}, fmt.Errorf("FindByReference(%q): %w", reference, err)
+	}
+
+	if len(results) == 0 {
+		return Billed{}, InvoiceNotFoundError{Reference: reference}
+	}
+
+	return results[0], nil
+}
+
+// Insert inserts the given rows into the invoices table.
+func Insert(ctx context.Context, bq sql.BindQueryer, rows []Row) ([]Row, error) {
+	query, err := _queries.ReadFile("queries/insert_invoices.sql")
+	if err != nil {
+		return nil, fmt.Errorf("read queries/insert_invoices.sql: %w", err)
+	}
+
+	boundQuery, positionalArgs, err := bq.Bind(string(query), rows)
+	if err != nil {
+		return nil, fmt.Errorf("bind queries/insert_invoices.sql: %w", err)
+	}
+
+	results := make([]Row, 0, len(rows))
+
+	if err := bq.Query(ctx, &results, boundQuery, positionalArgs...); err != nil {
+		return nil, fmt.Errorf("Insert: %w", err)
+	}
+
+	return results, nil
+}
+
+// MarkPaid records that the outstanding invoice with the given reference was
+// paid at time t. It returns the updated rows, which are empty if no such
+// invoice is outstanding.
+func MarkPaid(ctx context.Context, q sql.Queryer, reference string, t time.Time) ([]Row, error) {
+	query, err := _queries.ReadFile("queries/mark_invoice_paid.sql")
+	if err != nil {
+		return nil, fmt.Errorf("read queries/mark_invoice_paid.sql: %w", err)
+	}
+
+	var results []Row
+
+	if err := q.Query(ctx, &results, string(query), reference, t); err != nil {
+		return nil, fmt.Errorf("MarkPaid(%q): %w", reference, err)
+	}
+
+	return results, nil
+}
+
+// InvoiceNotFoundError is returned when searching for an invoice by reference
+// returns no results.
+type InvoiceNotFoundError struct {
+	Reference string
+}
+
+func (infe InvoiceNotFoundError) Error() string {
+	return fmt.Sprintf("no invoice with reference %q", infe.Reference)
+}
@@ -0,0 +1,12 @@
+SELECT i.id, i.reference, i.enrollment_id, i.amount, i.currency, i.issued_at, i.voucher_id, i.paid_at,
+  c.code AS course_code, s.id AS student_id, s.email AS student_email, v.code AS voucher_code
+FROM invoices i
+INNER JOIN enrollments e
+ON e.id = i.enrollment_id
+INNER JOIN courses c
+ON c.id = e.course_id
+INNER JOIN students s
+ON s.id = e.student_id
+LEFT JOIN vouchers v
+ON v.id = i.voucher_id
+WHERE i.reference = $1;
@@ -0,0 +1,3 @@
+INSERT INTO invoices (reference, enrollment_id, amount, currency, issued_at, voucher_id, paid_at)
+VALUES (:reference, :enrollment_id, :amount, :currency, :issued_at, :voucher_id, :paid_at)
+RETURNING *;
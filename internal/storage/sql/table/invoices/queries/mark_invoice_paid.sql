@@ -0,0 +1,5 @@
+UPDATE invoices
+SET paid_at = $2
+WHERE reference = $1
+AND paid_at IS NULL
+RETURNING *;
@@ -0,0 +1 @@
+TRUNCATE TABLE invoices;
\ No newline at end of file
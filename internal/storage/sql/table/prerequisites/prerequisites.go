@@ -0,0 +1,45 @@
+// Package prerequisites operates on a database prerequisites table, which
+// names the courses that students must pass before enrolling in another, and
+// represents its rows. It is driver-agnostic.
+package prerequisites
+
+import (
+	"context"
+	"embed"
+	"fmt"
+
+	"github.com/angusgmorrison/hexagonal/internal/storage/sql"
+)
+
+//go:embed queries
+var _queries embed.FS
+
+// Row represents a row of the prerequisites table. Students must pass the
+// course with ID PrerequisiteID before enrolling in the course with ID
+// CourseID.
+type Row struct {
+	ID             int64 `db:"id"`
+	CourseID       int64 `db:"course_id"`
+	PrerequisiteID int64 `db:"prerequisite_id"`
+}
+
+// Insert inserts the given prerequisites into the table.
+func Insert(ctx context.Context, bq sql.BindQueryer, prerequisites []Row) ([]Row, error) {
+	query, err := _queries.ReadFile("queries/insert_prerequisites.sql")
+	if err != nil {
+		return nil, fmt.Errorf("read queries/insert_prerequisites.sql: %w", err)
+	}
+
+	boundQuery, positionalArgs, err := bq.Bind(string(query), prerequisites)
+	if err != nil {
+		return nil, fmt.Errorf("bind queries/insert_prerequisites.sql: %w", err)
+	}
+
+	results := make([]Row, 0, len(prerequisites))
+
+	if err := bq.Query(ctx, &results, boundQuery, positionalArgs...); err != nil {
+		return nil, fmt.Errorf("Insert: %w", err)
+	}
+
+	return results, nil
+}
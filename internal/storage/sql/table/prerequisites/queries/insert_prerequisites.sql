@@ -0,0 +1,4 @@
+INSERT INTO prerequisites (course_id, prerequisite_id)
+VALUES
+  (:course_id, :prerequisite_id)
+RETURNING *;
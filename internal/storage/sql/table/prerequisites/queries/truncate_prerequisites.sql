@@ -0,0 +1 @@
+TRUNCATE TABLE prerequisites;
\ No newline at end of file
@@ -0,0 +1,3 @@
+DELETE FROM reservations
+WHERE expires_at <= $1
+RETURNING *;
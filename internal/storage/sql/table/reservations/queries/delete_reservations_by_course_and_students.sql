@@ -0,0 +1,4 @@
+DELETE FROM reservations
+WHERE course_id = ?
+AND student_id IN (?)
+RETURNING *;
@@ -0,0 +1,3 @@
+DELETE FROM reservations
+WHERE course_id = $1
+RETURNING *;
@@ -0,0 +1,4 @@
+SELECT id, course_id, student_id, expires_at
+FROM reservations
+WHERE course_id = $1
+ORDER BY expires_at;
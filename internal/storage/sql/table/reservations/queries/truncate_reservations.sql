@@ -0,0 +1 @@
+TRUNCATE TABLE reservations;
\ No newline at end of file
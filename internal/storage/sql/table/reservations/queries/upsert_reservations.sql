@@ -0,0 +1,5 @@
+INSERT INTO reservations (course_id, student_id, expires_at)
+VALUES (:course_id, :student_id, :expires_at)
+ON CONFLICT (course_id, student_id)
+DO UPDATE SET expires_at = EXCLUDED.expires_at
+RETURNING *;
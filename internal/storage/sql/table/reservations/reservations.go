@@ -0,0 +1,126 @@
+// Package reservations operates on a database reservations table and
+// represents its rows. It is driver-agnostic.
+package reservations
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"time"
+
+	"github.com/angusgmorrison/hexagonal/internal/storage/sql"
+	"github.com/jmoiron/sqlx"
+)
+
+//go:embed queries
+var _queries embed.FS
+
+// Row represents a row of the reservations table.
+type Row struct {
+	ID        int64     `db:"id"`
+	CourseID  int64     `db:"course_id"`
+	StudentID int64     `db:"student_id"`
+	ExpiresAt time.Time `db:"expires_at"`
+}
+
+// OnCourse returns the rows of all reservations of places in the course with
+// the given ID, including those that have expired.
+func OnCourse(ctx context.Context, q sql.Queryer, courseID int64) ([]Row, error) {
+	query, err := _queries.ReadFile("queries/select_reservations_on_course.sql")
+	if err != nil {
+		return nil, fmt.Errorf("read queries/select_reservations_on_course.sql: %w", err)
+	}
+
+	var results []Row
+
+	if err := q.Query(ctx, &results, string(query), courseID); err != nil {
+		return nil, fmt.Errorf("OnCourse(%d): %w", courseID, err)
+	}
+
+	return results, nil
+}
+
+// Upsert inserts the given rows into the reservations table. Where a student
+// already holds a reservation in the course, its expiry is replaced.
+func Upsert(ctx context.Context, bq sql.BindQueryer, rows []Row) ([]Row, error) {
+	query, err := _queries.ReadFile("queries/upsert_reservations.sql")
+	if err != nil {
+		return nil, fmt.Errorf("read queries/upsert_reservations.sql: %w", err)
+	}
+
+	boundQuery, positionalArgs, err := bq.Bind(string(query), rows)
+	if err != nil {
+		return nil, fmt.Errorf("bind queries/upsert_reservations.sql: %w", err)
+	}
+
+	results := make([]Row, 0, len(rows))
+
+	if err := bq.Query(ctx, &results, boundQuery, positionalArgs...); err != nil {
+		return nil, fmt.Errorf("Upsert: %w", err)
+	}
+
+	return results, nil
+}
+
+// DeleteByCourseAndStudents deletes the reservations held by the given
+// students in the course with the given ID, and returns the deleted rows.
+func DeleteByCourseAndStudents(
+	ctx context.Context,
+	rq sql.RebindQueryer,
+	courseID int64,
+	studentIDs []int64,
+) ([]Row, error) {
+	query, err := _queries.ReadFile("queries/delete_reservations_by_course_and_students.sql")
+	if err != nil {
+		return nil, fmt.Errorf("read queries/delete_reservations_by_course_and_students.sql: %w", err)
+	}
+
+	inQuery, positionalArgs, err := sqlx.In(string(query), courseID, studentIDs)
+	if err != nil {
+		return nil, fmt.Errorf("generate IN query with student IDs: %w", err)
+	}
+
+	boundQuery := rq.Rebind(inQuery)
+
+	results := make([]Row, 0, len(studentIDs))
+
+	if err := rq.Query(ctx, &results, boundQuery, positionalArgs...); err != nil {
+		return nil, fmt.Errorf("DeleteByCourseAndStudents(%d, %v): %w", courseID, studentIDs, err)
+	}
+
+	return results, nil
+}
+
+// DeleteOnCourse deletes every reservation of a place in the course with the
+// given ID, and returns the deleted rows.
+func DeleteOnCourse(ctx context.Context, q sql.Queryer, courseID int64) ([]Row, error) {
+	query, err := _queries.ReadFile("queries/delete_reservations_on_course.sql")
+	if err != nil {
+		return nil, fmt.Errorf("read queries/delete_reservations_on_course.sql: %w", err)
+	}
+
+	var results []Row
+
+	if err := q.Query(ctx, &results, string(query), courseID); err != nil {
+		return nil, fmt.Errorf("DeleteOnCourse(%d): %w", courseID, err)
+	}
+
+	return results, nil
+}
+
+// DeleteExpired deletes every reservation that expired at or before t, and
+// returns the deleted rows.
+func DeleteExpired(ctx context.Context, q sql.Queryer, t time.Time) ([]Row, error) {
+	query, err := _queries.ReadFile("queries/delete_expired_reservations.sql")
+	if err != nil {
+		return nil, fmt.Errorf("read queries/delete_expired_reservations.sql: %w", err)
+	}
+
+	var results []Row
+
+	if err := q.Query(ctx, &results, string(query), t); err != nil {
+		return nil, fmt.Errorf("DeleteExpired(%s): %w", t, err)
+	}
+
+	return results, nil
+}
@@ -0,0 +1,4 @@
+INSERT INTO sections (course_id, code, capacity)
+VALUES
+  (:course_id, :code, :capacity)
+RETURNING *;
@@ -0,0 +1,4 @@
+SELECT id, course_id, code, capacity
+FROM sections
+WHERE course_id = $1
+ORDER BY code;
@@ -0,0 +1 @@
+TRUNCATE TABLE sections CASCADE;
\ No newline at end of file
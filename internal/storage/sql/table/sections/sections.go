@@ -0,0 +1,60 @@
+// Package sections operates on a database sections table and represents its
+// rows. It is driver-agnostic.
+package sections
+
+import (
+	"context"
+	"embed"
+	"fmt"
+
+	"github.com/angusgmorrison/hexagonal/internal/storage/sql"
+)
+
+//go:embed queries
+var _queries embed.FS
+
+// Row represents a row of the sections table.
+type Row struct {
+	ID       int64  `db:"id"`
+	CourseID int64  `db:"course_id"`
+	Code     string `db:"code"`
+	Capacity uint32 `db:"capacity"`
+}
+
+// OnCourse returns the rows of all sections belonging to the course with the
+// given ID, ordered by section code.
+func OnCourse(ctx context.Context, q sql.Queryer, courseID int64) ([]Row, error) {
+	query, err := _queries.ReadFile("queries/select_sections_on_course.sql")
+	if err != nil {
+		return nil, fmt.Errorf("read queries/select_sections_on_course.sql: %w", err)
+	}
+
+	var results []Row
+
+	if err := q.Query(ctx, &results, string(query), courseID); err != nil {
+		return nil, fmt.Errorf("OnCourse(%d): %w", courseID, err)
+	}
+
+	return results, nil
+}
+
+// Insert inserts the given sections into the table.
+func Insert(ctx context.Context, bq sql.BindQueryer, sections []Row) ([]Row, error) {
+	query, err := _queries.ReadFile("queries/insert_sections.sql")
+	if err != nil {
+		return nil, fmt.Errorf("read queries/insert_sections.sql: %w", err)
+	}
+
+	boundQuery, positionalArgs, err := bq.Bind(string(query), sections)
+	if err != nil {
+		return nil, fmt.Errorf("bind queries/insert_sections.sql: %w", err)
+	}
+
+	results := make([]Row, 0, len(sections))
+
+	if err := bq.Query(ctx, &results, boundQuery, positionalArgs...); err != nil {
+		return nil, fmt.Errorf("Insert: %w", err)
+	}
+
+	return results, nil
+}
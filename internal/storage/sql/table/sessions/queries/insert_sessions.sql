@@ -0,0 +1,4 @@
+INSERT INTO course_sessions (course_id, starts_at)
+VALUES (:course_id, :starts_at)
+ON CONFLICT (course_id, starts_at) DO NOTHING
+RETURNING *;
@@ -0,0 +1,4 @@
+SELECT id, course_id, starts_at
+FROM course_sessions
+WHERE course_id = $1
+ORDER BY starts_at;
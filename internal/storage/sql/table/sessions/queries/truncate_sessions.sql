@@ -0,0 +1 @@
+TRUNCATE TABLE course_sessions CASCADE;
\ No newline at end of file
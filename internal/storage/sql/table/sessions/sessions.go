@@ -0,0 +1,63 @@
+// Package sessions operates on a database course_sessions table, which lists
+// the scheduled meetings of each course, and represents its rows. It is
+// driver-agnostic.
+package sessions
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"time"
+
+	"github.com/angusgmorrison/hexagonal/internal/storage/sql"
+)
+
+//go:embed queries
+var _queries embed.FS
+
+// Row represents a row of the course_sessions table.
+type Row struct {
+	ID       int64     `db:"id"`
+	CourseID int64     `db:"course_id"`
+	StartsAt time.Time `db:"starts_at"`
+}
+
+// OnCourse returns the rows of all sessions of the course with the given ID, in
+// chronological order.
+func OnCourse(ctx context.Context, q sql.Queryer, courseID int64) ([]Row, error) {
+	query, err := _queries.ReadFile("queries/select_sessions_on_course.sql")
+	if err != nil {
+		return nil, fmt.Errorf("read queries/select_sessions_on_course.sql: %w", err)
+	}
+
+	var results []Row
+
+	if err := q.Query(ctx, &results, string(query), courseID); err != nil {
+		return nil, fmt.Errorf("OnCourse(%d): %w", courseID, err)
+	}
+
+	return results, nil
+}
+
+// Insert inserts the given rows into the course_sessions table, and returns
+// the inserted rows. Rows duplicating an existing session of the same course
+// are skipped.
+func Insert(ctx context.Context, bq sql.BindQueryer, rows []Row) ([]Row, error) {
+	query, err := _queries.ReadFile("queries/insert_sessions.sql")
+	if err != nil {
+		return nil, fmt.Errorf("read queries/insert_sessions.sql: %w", err)
+	}
+
+	boundQuery, positionalArgs, err := bq.Bind(string(query), rows)
+	if err != nil {
+		return nil, fmt.Errorf("bind queries/insert_sessions.sql: %w", err)
+	}
+
+	results := make([]Row, 0, len(rows))
+
+	if err := bq.Query(ctx, &results, boundQuery, positionalArgs...); err != nil {
+		return nil, fmt.Errorf("Insert: %w", err)
+	}
+
+	return results, nil
+}
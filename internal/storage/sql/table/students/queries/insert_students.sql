@@ -1,4 +1,4 @@
-INSERT INTO students (name, birthdate, email)
+INSERT INTO students (name, birthdate, email, max_course_load)
 VALUES
-  (:name, :birthdate, :email)
+  (:name, :birthdate, :email, :max_course_load)
 RETURNING *;
\ No newline at end of file
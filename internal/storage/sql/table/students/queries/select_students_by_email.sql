@@ -1,3 +1,3 @@
-SELECT id, name, birthdate, email
+SELECT id, name, birthdate, email, max_course_load
 FROM students
 WHERE email IN (?);
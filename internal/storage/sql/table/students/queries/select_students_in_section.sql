@@ -0,0 +1,6 @@
+SELECT s.id, s.name, s.birthdate, s.email, s.max_course_load
+FROM students s
+INNER JOIN enrollments e
+ON s.id = e.student_id
+WHERE e.section_id = $1
+AND e.status = $2;
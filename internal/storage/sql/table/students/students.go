@@ -18,15 +18,16 @@ var _queries embed.FS
 
 // Row represents a row of the students table.
 type Row struct {
-	ID        int64                  `db:"id"`
-	Name      string                 `db:"name"`
-	Birthdate time.Time              `db:"birthdate"`
-	Email     primitive.EmailAddress `db:"email"`
+	ID            int64                  `db:"id"`
+	Name          string                 `db:"name"`
+	Birthdate     time.Time              `db:"birthdate"`
+	Email         primitive.EmailAddress `db:"email"`
+	MaxCourseLoad *uint32                `db:"max_course_load"`
 }
 
-// OnCourse returns the rows of all students enrolled in the course with the
-// given ID.
-func OnCourse(ctx context.Context, q sql.Queryer, courseID int64) ([]Row, error) {
+// OnCourse returns the rows of all students whose enrollment in the course with
+// the given ID has the given status.
+func OnCourse(ctx context.Context, q sql.Queryer, courseID int64, status string) ([]Row, error) {
 	query, err := _queries.ReadFile("queries/select_students_on_course.sql")
 	if err != nil {
 		return nil, fmt.Errorf("read queries/select_students_on_course.sql: %w", err)
@@ -34,8 +35,25 @@ func OnCourse(ctx context.Context, q sql.Queryer, courseID int64) ([]Row, error)
 
 	var results []Row
 
-	if err := q.Query(ctx, &results, string(query), courseID); err != nil {
-		return nil, fmt.Errorf("OnCourse(%d): %w", courseID, err)
+	if err := q.Query(ctx, &results, string(query), courseID, status); err != nil {
+		return nil, fmt.Errorf("OnCourse(%d, %q): %w", courseID, status, err)
+	}
+
+	return results, nil
+}
+
+// InSection returns the rows of all students whose enrollment in the section
+// with the given ID has the given status.
+func InSection(ctx context.Context, q sql.Queryer, sectionID int64, status string) ([]Row, error) {
+	query, err := _queries.ReadFile("queries/select_students_in_section.sql")
+	if err != nil {
+		return nil, fmt.Errorf("read queries/select_students_in_section.sql: %w", err)
+	}
+
+	var results []Row
+
+	if err := q.Query(ctx, &results, string(query), sectionID, status); err != nil {
+		return nil, fmt.Errorf("InSection(%d, %q): %w", sectionID, status, err)
 	}
 
 	return results, nil
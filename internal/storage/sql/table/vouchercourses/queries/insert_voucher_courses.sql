@@ -0,0 +1,4 @@
+INSERT INTO voucher_courses (voucher_id, course_id)
+VALUES
+  (:voucher_id, :course_id)
+RETURNING *;
@@ -0,0 +1,6 @@
+SELECT c.code
+FROM voucher_courses vc
+INNER JOIN courses c
+ON c.id = vc.course_id
+WHERE vc.voucher_id = $1
+ORDER BY c.code;
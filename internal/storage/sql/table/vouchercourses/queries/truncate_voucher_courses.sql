@@ -0,0 +1 @@
+TRUNCATE TABLE voucher_courses;
\ No newline at end of file
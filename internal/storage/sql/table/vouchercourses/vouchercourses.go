@@ -0,0 +1,62 @@
+// Package vouchercourses operates on a database voucher_courses table, which
+// restricts vouchers to the courses for which they may be redeemed, and
+// represents its rows. It is driver-agnostic.
+package vouchercourses
+
+import (
+	"context"
+	"embed"
+	"fmt"
+
+	"github.com/angusgmorrison/hexagonal/internal/storage/sql"
+)
+
+//go:embed queries
+var _queries embed.FS
+
+// Row represents a row of the voucher_courses table. The voucher with ID
+// VoucherID may be redeemed for the course with ID CourseID. Vouchers without
+// rows may be redeemed for any course.
+type Row struct {
+	ID        int64 `db:"id"`
+	VoucherID int64 `db:"voucher_id"`
+	CourseID  int64 `db:"course_id"`
+}
+
+// Insert inserts the given rows into the voucher_courses table.
+func Insert(ctx context.Context, bq sql.BindQueryer, rows []Row) ([]Row, error) {
+	query, err := _queries.ReadFile("queries/insert_voucher_courses.sql")
+	if err != nil {
+		return nil, fmt.Errorf("read queries/insert_voucher_courses.sql: %w", err)
+	}
+
+	boundQuery, positionalArgs, err := bq.Bind(string(query), rows)
+	if err != nil {
+		return nil, fmt.Errorf("bind queries/insert_voucher_courses.sql: %w", err)
+	}
+
+	results := make([]Row, 0, len(rows))
+
+	if err := bq.Query(ctx, &results, boundQuery, positionalArgs...); err != nil {
+		return nil, fmt.Errorf("Insert: %w", err)
+	}
+
+	return results, nil
+}
+
+// CourseCodesOf returns the codes of the courses for which the voucher with the
+// given ID may be redeemed, in alphabetical order.
+func CourseCodesOf(ctx context.Context, q sql.Queryer, voucherID int64) ([]string, error) {
+	query, err := _queries.ReadFile("queries/select_course_codes_of_voucher.sql")
+	if err != nil {
+		return nil, fmt.Errorf("read queries/select_course_codes_of_voucher.sql: %w", err)
+	}
+
+	var codes []string
+
+	if err := q.Query(ctx, &codes, string(query), voucherID); err != nil {
+		return nil, fmt.Errorf("CourseCodesOf(%d): %w", voucherID, err)
+	}
+
+	return codes, nil
+}
@@ -0,0 +1,5 @@
+INSERT INTO voucher_redemptions (voucher_id, student_id)
+VALUES
+  (:voucher_id, :student_id)
+ON CONFLICT (voucher_id, student_id) DO NOTHING
+RETURNING *;
@@ -0,0 +1 @@
+TRUNCATE TABLE voucher_redemptions;
\ No newline at end of file
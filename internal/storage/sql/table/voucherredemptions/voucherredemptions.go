@@ -0,0 +1,47 @@
+// Package voucherredemptions operates on a database voucher_redemptions table,
+// which records the students who have redeemed each voucher, and represents
+// its rows. It is driver-agnostic.
+package voucherredemptions
+
+import (
+	"context"
+	"embed"
+	"fmt"
+
+	"github.com/angusgmorrison/hexagonal/internal/storage/sql"
+)
+
+//go:embed queries
+var _queries embed.FS
+
+// Row represents a row of the voucher_redemptions table. The student with ID
+// StudentID has redeemed the voucher with ID VoucherID. Each student may
+// redeem each voucher once.
+type Row struct {
+	ID        int64 `db:"id"`
+	VoucherID int64 `db:"voucher_id"`
+	StudentID int64 `db:"student_id"`
+}
+
+// InsertNew inserts the given rows into the voucher_redemptions table, skipping
+// those whose student has already redeemed the voucher, and returns the rows
+// inserted.
+func InsertNew(ctx context.Context, bq sql.BindQueryer, rows []Row) ([]Row, error) {
+	query, err := _queries.ReadFile("queries/insert_new_voucher_redemptions.sql")
+	if err != nil {
+		return nil, fmt.Errorf("read queries/insert_new_voucher_redemptions.sql: %w", err)
+	}
+
+	boundQuery, positionalArgs, err := bq.Bind(string(query), rows)
+	if err != nil {
+		return nil, fmt.Errorf("bind queries/insert_new_voucher_redemptions.sql: %w", err)
+	}
+
+	results := make([]Row, 0, len(rows))
+
+	if err := bq.Query(ctx, &results, boundQuery, positionalArgs...); err != nil {
+		return nil, fmt.Errorf("InsertNew: %w", err)
+	}
+
+	return results, nil
+}
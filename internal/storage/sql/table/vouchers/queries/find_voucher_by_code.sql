@@ -0,0 +1,3 @@
+SELECT id, code, kind, percent, amount, currency, max_uses, uses, valid_from, valid_until
+FROM vouchers
+WHERE code = $1;
@@ -0,0 +1,3 @@
+INSERT INTO vouchers (code, kind, percent, amount, currency, max_uses, uses, valid_from, valid_until)
+VALUES (:code, :kind, :percent, :amount, :currency, :max_uses, :uses, :valid_from, :valid_until)
+RETURNING *;
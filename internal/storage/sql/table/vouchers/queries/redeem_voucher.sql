@@ -0,0 +1,5 @@
+UPDATE vouchers
+SET uses = uses + $2
+WHERE id = $1
+AND (max_uses IS NULL OR uses + $2 <= max_uses)
+RETURNING *;
@@ -0,0 +1 @@
+TRUNCATE TABLE vouchers CASCADE;
\ No newline at end of file
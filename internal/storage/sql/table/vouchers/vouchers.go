@@ -0,0 +1,112 @@
+// Package vouchers operates on a database vouchers table, which defines the
+// discounts that students may redeem when enrolling in courses that charge a
+// fee, and represents its rows. It is driver-agnostic.
+package vouchers
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"time"
+
+	"github.com/angusgmorrison/hexagonal/internal/storage/sql"
+)
+
+//go:embed queries
+var _queries embed.FS
+
+// Row represents a row of the vouchers table. Kind is "percentage" or "fixed".
+// Percentage vouchers take Percent percent off the fee and have a nil Amount
+// and Currency. Fixed vouchers take Amount minor units of Currency off the fee
+// and have a nil Percent.
+type Row struct {
+	ID       int64   `db:"id"`
+	Code     string  `db:"code"`
+	Kind     string  `db:"kind"`
+	Percent  *uint32 `db:"percent"`
+	Amount   *int64  `db:"amount"`
+	Currency *string `db:"currency"`
+
+	// MaxUses is the number of times the voucher may be redeemed, or nil if
+	// its use is unlimited.
+	MaxUses *uint32 `db:"max_uses"`
+	Uses    uint32  `db:"uses"`
+
+	// ValidFrom and ValidUntil bound the period in which the voucher may be
+	// redeemed. Either may be nil to leave the period open.
+	ValidFrom  *time.Time `db:"valid_from"`
+	ValidUntil *time.Time `db:"valid_until"`
+}
+
+// FindByCode returns the voucher with the given code.
+func FindByCode(ctx context.Context, q sql.Queryer, code string) (Row, error) {
+	query, err := _queries.ReadFile("queries/find_voucher_by_code.sql")
+	if err != nil {
+		return Row{}, fmt.Errorf("read queries/find_voucher_by_code.sql: %w", err)
+	}
+
+	results := make([]Row, 0, 1)
+
+	if err := q.Query(ctx, &results, string(query), code); err != nil {
+		return Row{}, fmt.Errorf("FindByCode(%q): %w", code, err)
+	}
+
+	if len(results) == 0 {
+		return Row{}, VoucherNotFoundError{Code: code}
+	}
+
+	return results[0], nil
+}
+
+// Insert inserts the given rows into the vouchers table.
+func Insert(ctx context.Context, bq sql.BindQueryer, rows []Row) ([]Row, error) {
+	query, err := _queries.ReadFile("queries/insert_vouchers.sql")
+	if err != nil {
+		return nil, fmt.Errorf("read queries/insert_vouchers.sql: %w", err)
+	}
+
+	boundQuery, positionalArgs, err := bq.Bind(string(query), rows)
+	if err != nil {
+		return nil, fmt.Errorf("bind queries/insert_vouchers.sql: %w", err)
+	}
+
+	results := make([]Row, 0, len(rows))
+
+	if err := bq.Query(ctx, &results, boundQuery, positionalArgs...); err != nil {
+		return nil, fmt.Errorf("Insert: %w", err)
+	}
+
+	return results, nil
+}
+
+// Redeem adds uses to the number of times the voucher with the given ID has
+// been redeemed, provided that doing so doesn't exceed its maximum uses. It
+// returns the updated rows, which are empty if the voucher can't be redeemed
+// that many times.
+//
+// The check and the increment are made by a single statement, so concurrent
+// redemptions are serialized by the row lock and can't exceed the maximum.
+func Redeem(ctx context.Context, q sql.Queryer, id int64, uses uint32) ([]Row, error) {
+	query, err := _queries.ReadFile("queries/redeem_voucher.sql")
+	if err != nil {
+		return nil, fmt.Errorf("read queries/redeem_voucher.sql: %w", err)
+	}
+
+	var results []Row
+
+	if err := q.Query(ctx, &results, string(query), id, uses); err != nil {
+		return nil, fmt.Errorf("Redeem(%d, %d): %w", id, uses, err)
+	}
+
+	return results, nil
+}
+
+// VoucherNotFoundError is returned when searching for a voucher by code returns
+// no results.
+type VoucherNotFoundError struct {
+	Code string
+}
+
+func (vnfe VoucherNotFoundError) Error() string {
+	return fmt.Sprintf("no voucher with code %q", vnfe.Code)
+}
@@ -0,0 +1,27 @@
+// Package csvformula protects CSV files from formula injection, in which a cell
+// written as text is evaluated as a formula when the file is opened in a
+// spreadsheet application.
+package csvformula
+
+import "strings"
+
+// Escape prefixes cells that spreadsheet applications would evaluate as
+// formulas with an apostrophe, so that they're displayed as text instead.
+func Escape(cell string) string {
+	if cell != "" && strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+		return "'" + cell
+	}
+
+	return cell
+}
+
+// EscapeRecord returns a copy of record with every cell escaped by Escape.
+func EscapeRecord(record []string) []string {
+	escaped := make([]string, len(record))
+
+	for i, cell := range record {
+		escaped[i] = Escape(cell)
+	}
+
+	return escaped
+}
@@ -0,0 +1,47 @@
+//go:build unit
+
+package csvformula
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEscape(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		cell string
+		want string
+	}{
+		{cell: "", want: ""},
+		{cell: "Angus Morrison", want: "Angus Morrison"},
+		{cell: "angus@example.com", want: "angus@example.com"},
+		{cell: "=HYPERLINK(\"http://evil\")", want: "'=HYPERLINK(\"http://evil\")"},
+		{cell: "+1+1", want: "'+1+1"},
+		{cell: "-1+1", want: "'-1+1"},
+		{cell: "@SUM(A1)", want: "'@SUM(A1)"},
+		{cell: "\t=1", want: "'\t=1"},
+		{cell: "\r=1", want: "'\r=1"},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.cell, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tc.want, Escape(tc.cell))
+		})
+	}
+}
+
+func TestEscapeRecord(t *testing.T) {
+	t.Parallel()
+
+	record := []string{"email", "=cmd"}
+
+	require.Equal(t, []string{"email", "'=cmd"}, EscapeRecord(record))
+	require.Equal(t, []string{"email", "=cmd"}, record, "record modified")
+}
@@ -0,0 +1,217 @@
+// Package pdf writes simple single-page PDF documents containing text, lines
+// and rectangles, without any dependency outside the standard library.
+//
+// Text is set in the standard Helvetica and Times fonts, which every PDF
+// reader provides, so no fonts are embedded. Text is encoded using
+// WinAnsiEncoding, and characters outside it are replaced by '?'.
+//
+// Coordinates are measured in points (1/72 inch) from the bottom-left corner
+// of the page.
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Page sizes in points.
+const (
+	A4Width  = 595.28
+	A4Height = 841.89
+)
+
+// Font is one of the standard fonts available in every PDF reader.
+type Font int
+
+// The fonts that can be used in a Document.
+const (
+	Helvetica Font = iota
+	HelveticaBold
+	TimesRoman
+	TimesItalic
+	numFonts
+)
+
+var baseFonts = [numFonts]string{
+	Helvetica:     "Helvetica",
+	HelveticaBold: "Helvetica-Bold",
+	TimesRoman:    "Times-Roman",
+	TimesItalic:   "Times-Italic",
+}
+
+func (f Font) String() string {
+	if f < 0 || f >= numFonts {
+		return fmt.Sprintf("Font(%d)", int(f))
+	}
+
+	return baseFonts[f]
+}
+
+// resourceName is the name by which the page's content stream refers to the
+// font.
+func (f Font) resourceName() string {
+	return fmt.Sprintf("F%d", int(f)+1)
+}
+
+// Color is an RGB color with components between 0 and 1.
+type Color struct {
+	R, G, B float64
+}
+
+// Black is the default color of text and lines.
+var Black = Color{}
+
+// Document is a single-page PDF document. The zero Document is not usable;
+// create Documents with New.
+type Document struct {
+	width, height float64
+	title         string
+	content       bytes.Buffer
+}
+
+// New returns an empty Document with a page of the given size.
+func New(width, height float64) *Document {
+	return &Document{width: width, height: height}
+}
+
+// Width returns the width of the page.
+func (d *Document) Width() float64 {
+	return d.width
+}
+
+// Height returns the height of the page.
+func (d *Document) Height() float64 {
+	return d.height
+}
+
+// SetTitle sets the title recorded in the document's metadata.
+func (d *Document) SetTitle(title string) {
+	d.title = title
+}
+
+// Text writes s in the given font, size and color with its baseline starting
+// at (x, y).
+func (d *Document) Text(font Font, size float64, color Color, x, y float64, s string) {
+	fmt.Fprintf(&d.content, "BT\n%s rg\n/%s %s Tf\n%s %s Td\n(%s) Tj\nET\n",
+		color.operands(), font.resourceName(), num(size), num(x), num(y), escape(encode(s)))
+}
+
+// CenteredText writes s centered horizontally on the page with its baseline at
+// height y.
+func (d *Document) CenteredText(font Font, size float64, color Color, y float64, s string) {
+	d.Text(font, size, color, (d.width-TextWidth(font, size, s))/2, y, s)
+}
+
+// Line draws a straight line from (x1, y1) to (x2, y2).
+func (d *Document) Line(width float64, color Color, x1, y1, x2, y2 float64) {
+	fmt.Fprintf(&d.content, "%s RG\n%s w\n%s %s m\n%s %s l\nS\n",
+		color.operands(), num(width), num(x1), num(y1), num(x2), num(y2))
+}
+
+// Rect draws the outline of a rectangle with its bottom-left corner at (x, y).
+func (d *Document) Rect(width float64, color Color, x, y, w, h float64) {
+	fmt.Fprintf(&d.content, "%s RG\n%s w\n%s %s %s %s re\nS\n",
+		color.operands(), num(width), num(x), num(y), num(w), num(h))
+}
+
+// WriteTo writes the complete document to w.
+func (d *Document) WriteTo(w io.Writer) (int64, error) {
+	var (
+		buf     bytes.Buffer
+		offsets []int
+	)
+
+	object := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	// The header's second line marks the file as binary for transfer
+	// programs, as recommended by the specification.
+	buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	var fontRefs strings.Builder
+	for f := Font(0); f < numFonts; f++ {
+		fmt.Fprintf(&fontRefs, " /%s %d 0 R", f.resourceName(), 5+int(f))
+	}
+
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object("<< /Type /Pages /Kids [3 0 R] /Count 1 >>")
+	object(fmt.Sprintf(
+		"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << /Font <<%s >> >> /Contents 4 0 R >>",
+		num(d.width), num(d.height), fontRefs.String()))
+	object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", d.content.Len(), d.content.String()))
+
+	for f := Font(0); f < numFonts; f++ {
+		object(fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", f))
+	}
+
+	object(fmt.Sprintf("<< /Title (%s) /Producer (hexagonal) >>", escape(encode(d.title))))
+	info := len(offsets)
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R /Info %d 0 R >>\nstartxref\n%d\n%%%%EOF\n",
+		len(offsets)+1, info, xref)
+
+	return buf.WriteTo(w)
+}
+
+// Bytes returns the complete document.
+func (d *Document) Bytes() []byte {
+	var buf bytes.Buffer
+	_, _ = d.WriteTo(&buf)
+
+	return buf.Bytes()
+}
+
+func (c Color) operands() string {
+	return fmt.Sprintf("%s %s %s", num(c.R), num(c.G), num(c.B))
+}
+
+// num formats a number compactly, as PDF readers don't accept exponents.
+func num(f float64) string {
+	s := fmt.Sprintf("%.2f", f)
+	s = strings.TrimRight(s, "0")
+
+	return strings.TrimSuffix(s, ".")
+}
+
+// encode converts s to WinAnsiEncoding, which agrees with Latin-1 for the
+// printable characters other than those between 0x80 and 0x9f.
+func encode(s string) []byte {
+	encoded := make([]byte, 0, len(s))
+
+	for _, r := range s {
+		switch {
+		case r >= 0x20 && r <= 0x7e, r >= 0xa0 && r <= 0xff:
+			encoded = append(encoded, byte(r))
+		default:
+			encoded = append(encoded, '?')
+		}
+	}
+
+	return encoded
+}
+
+// escape escapes the characters that are special in PDF literal strings.
+func escape(b []byte) string {
+	var sb strings.Builder
+
+	for _, c := range b {
+		if c == '(' || c == ')' || c == '\\' {
+			sb.WriteByte('\\')
+		}
+
+		sb.WriteByte(c)
+	}
+
+	return sb.String()
+}
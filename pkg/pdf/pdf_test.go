@@ -0,0 +1,91 @@
+//go:build unit
+
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocument(t *testing.T) {
+	t.Parallel()
+
+	doc := New(A4Height, A4Width)
+	doc.SetTitle("Certificate (draft)")
+	doc.Rect(2, Black, 20, 20, A4Height-40, A4Width-40)
+	doc.CenteredText(HelveticaBold, 24, Black, 400, `Ramdas Tifft \ Café`)
+	doc.Line(1, Color{R: 0.5, G: 0.5, B: 0.5}, 100, 300, 200, 300.5)
+
+	out := doc.Bytes()
+
+	t.Run("is delimited by header and trailer", func(t *testing.T) {
+		t.Parallel()
+
+		require.True(t, bytes.HasPrefix(out, []byte("%PDF-1.4\n")))
+		require.True(t, bytes.HasSuffix(out, []byte("%%EOF\n")))
+	})
+
+	t.Run("cross-reference table locates every object", func(t *testing.T) {
+		t.Parallel()
+
+		startxref := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(out)
+		require.NotNil(t, startxref)
+
+		xrefOffset, err := strconv.Atoi(string(startxref[1]))
+		require.NoError(t, err)
+		require.True(t, bytes.HasPrefix(out[xrefOffset:], []byte("xref\n")))
+
+		entries := regexp.MustCompile(`(\d{10}) 00000 n \n`).FindAllSubmatch(out[xrefOffset:], -1)
+		require.Len(t, entries, 9)
+
+		for i, entry := range entries {
+			offset, err := strconv.Atoi(string(entry[1]))
+			require.NoError(t, err)
+			require.True(t, bytes.HasPrefix(out[offset:], []byte(fmt.Sprintf("%d 0 obj\n", i+1))),
+				"object %d not found at offset %d", i+1, offset)
+		}
+	})
+
+	t.Run("stream length matches its content", func(t *testing.T) {
+		t.Parallel()
+
+		match := regexp.MustCompile(`(?s)<< /Length (\d+) >>\nstream\n(.*?)endstream`).FindSubmatch(out)
+		require.NotNil(t, match)
+
+		length, err := strconv.Atoi(string(match[1]))
+		require.NoError(t, err)
+		require.Equal(t, length, len(match[2]))
+	})
+
+	t.Run("escapes and encodes text", func(t *testing.T) {
+		t.Parallel()
+
+		require.Contains(t, string(out), "(Ramdas Tifft \\\\ Caf\xe9) Tj")
+		require.Contains(t, string(out), "/Title (Certificate \\(draft\\))")
+	})
+
+	t.Run("formats numbers without exponents", func(t *testing.T) {
+		t.Parallel()
+
+		require.Contains(t, string(out), "100 300 m\n200 300.5 l\n")
+		require.Contains(t, string(out), "0.5 0.5 0.5 RG\n")
+	})
+}
+
+func TestTextWidth(t *testing.T) {
+	t.Parallel()
+
+	for font := Font(0); font < numFonts; font++ {
+		for i, width := range widths[font] {
+			require.NotZero(t, width, "%s has no width for %q", font, rune(' '+i))
+		}
+	}
+
+	require.InDelta(t, 5.56*2+2.78, TextWidth(Helvetica, 10, "a b"), 1e-9)
+	require.InDelta(t, TextWidth(TimesRoman, 12, "n"), TextWidth(TimesRoman, 12, "ñ"), 1e-9)
+}
@@ -0,0 +1,231 @@
+package rule
+
+import (
+	"fmt"
+	"strings"
+)
+
+// node is an element of a rule's syntax tree.
+type node interface {
+	pos() int
+}
+
+type literalNode struct {
+	offset int
+	typ    Type
+	value  any
+}
+
+func (n *literalNode) pos() int { return n.offset }
+
+type variableNode struct {
+	offset int
+	name   string
+}
+
+func (n *variableNode) pos() int { return n.offset }
+
+type notNode struct {
+	offset  int
+	operand node
+}
+
+func (n *notNode) pos() int { return n.offset }
+
+type binaryNode struct {
+	op     string
+	offset int
+	left   node
+	right  node
+}
+
+func (n *binaryNode) pos() int { return n.offset }
+
+// checker infers the type of each node in a syntax tree, recording every type
+// error it finds.
+type checker struct {
+	env  Env
+	errs ErrorList
+}
+
+func (c *checker) errorf(offset int, format string, args ...any) {
+	c.errs = append(c.errs, &Error{Offset: offset, Message: fmt.Sprintf(format, args...)})
+}
+
+// check returns the type of n, or Invalid if n or any of its children is
+// ill-typed.
+func (c *checker) check(n node) Type {
+	switch n := n.(type) {
+	case *literalNode:
+		return n.typ
+	case *variableNode:
+		typ, ok := c.env[n.name]
+		if !ok {
+			c.errorf(n.offset, "unknown variable %q", n.name)
+
+			return Invalid
+		}
+
+		return typ
+	case *notNode:
+		typ := c.check(n.operand)
+		if typ != Bool && typ != Invalid {
+			c.errorf(n.offset, "operator ! requires bool, not %s", typ)
+
+			return Invalid
+		}
+
+		return typ
+	case *binaryNode:
+		return c.checkBinary(n)
+	default:
+		panic(fmt.Sprintf("unknown node type %T", n))
+	}
+}
+
+func (c *checker) checkBinary(n *binaryNode) Type {
+	left, right := c.check(n.left), c.check(n.right)
+	if left == Invalid || right == Invalid {
+		return Invalid
+	}
+
+	var ok bool
+
+	switch n.op {
+	case "&&", "||":
+		ok = left == Bool && right == Bool
+	case "==", "!=":
+		ok = left == right
+	case "<", "<=", ">", ">=":
+		ok = left == right && (left == Int || left == String)
+	case "startsWith", "endsWith", "contains":
+		ok = left == String && right == String
+	}
+
+	if !ok {
+		c.errorf(n.offset, "operator %s cannot be applied to %s and %s", n.op, left, right)
+
+		return Invalid
+	}
+
+	return Bool
+}
+
+// eval evaluates a type-checked syntax tree.
+func eval(n node, env Env, vars Vars) (any, error) {
+	switch n := n.(type) {
+	case *literalNode:
+		return n.value, nil
+	case *variableNode:
+		return lookup(n.name, env[n.name], vars)
+	case *notNode:
+		operand, err := eval(n.operand, env, vars)
+		if err != nil {
+			return nil, err
+		}
+
+		return !operand.(bool), nil
+	case *binaryNode:
+		return evalBinary(n, env, vars)
+	default:
+		panic(fmt.Sprintf("unknown node type %T", n))
+	}
+}
+
+func evalBinary(n *binaryNode, env Env, vars Vars) (any, error) {
+	left, err := eval(n.left, env, vars)
+	if err != nil {
+		return nil, err
+	}
+
+	// Logical operators short-circuit.
+	switch n.op {
+	case "&&":
+		if !left.(bool) {
+			return false, nil
+		}
+
+		return eval(n.right, env, vars)
+	case "||":
+		if left.(bool) {
+			return true, nil
+		}
+
+		return eval(n.right, env, vars)
+	}
+
+	right, err := eval(n.right, env, vars)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.op {
+	case "==":
+		return left == right, nil
+	case "!=":
+		return left != right, nil
+	case "startsWith":
+		return strings.HasPrefix(left.(string), right.(string)), nil
+	case "endsWith":
+		return strings.HasSuffix(left.(string), right.(string)), nil
+	case "contains":
+		return strings.Contains(left.(string), right.(string)), nil
+	}
+
+	cmp := compare(left, right)
+
+	switch n.op {
+	case "<":
+		return cmp < 0, nil
+	case "<=":
+		return cmp <= 0, nil
+	case ">":
+		return cmp > 0, nil
+	default:
+		return cmp >= 0, nil
+	}
+}
+
+// compare returns -1, 0 or 1 as a is less than, equal to or greater than b,
+// which must both be int64 or both be string.
+func compare(a, b any) int {
+	switch a := a.(type) {
+	case int64:
+		b := b.(int64)
+
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		default:
+			return 0
+		}
+	default:
+		return strings.Compare(a.(string), b.(string))
+	}
+}
+
+func lookup(name string, typ Type, vars Vars) (any, error) {
+	value, ok := vars[name]
+	if !ok {
+		return nil, fmt.Errorf("no value for variable %q", name)
+	}
+
+	var valid bool
+
+	switch typ {
+	case Bool:
+		_, valid = value.(bool)
+	case Int:
+		_, valid = value.(int64)
+	case String:
+		_, valid = value.(string)
+	}
+
+	if !valid {
+		return nil, fmt.Errorf("variable %q must be %s, not %T", name, typ, value)
+	}
+
+	return value, nil
+}
@@ -0,0 +1,328 @@
+package rule
+
+import (
+	"fmt"
+	"strconv"
+	"unicode"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenInt
+	tokenString
+	tokenBool
+	tokenOperator
+	tokenLeftParen
+	tokenRightParen
+)
+
+type token struct {
+	kind   tokenKind
+	text   string
+	offset int
+}
+
+// wordOperators are binary operators spelled as identifiers.
+var wordOperators = map[string]bool{
+	"startsWith": true,
+	"endsWith":   true,
+	"contains":   true,
+}
+
+// precedence returns the binding power of a binary operator. Higher binds
+// tighter. Zero means the token is not a binary operator.
+func precedence(op string) int {
+	switch op {
+	case "||":
+		return 1
+	case "&&":
+		return 2
+	case "==", "!=", "<", "<=", ">", ">=", "startsWith", "endsWith", "contains":
+		return 3
+	default:
+		return 0
+	}
+}
+
+// lexer splits the source of a rule into tokens.
+type lexer struct {
+	source string
+	offset int
+}
+
+func (l *lexer) next() (token, error) {
+	for l.offset < len(l.source) {
+		r, width := utf8.DecodeRuneInString(l.source[l.offset:])
+		if !unicode.IsSpace(r) {
+			break
+		}
+
+		l.offset += width
+	}
+
+	start := l.offset
+	if start >= len(l.source) {
+		return token{kind: tokenEOF, offset: start}, nil
+	}
+
+	r, width := utf8.DecodeRuneInString(l.source[start:])
+
+	switch {
+	case r == '(':
+		l.offset += width
+
+		return token{kind: tokenLeftParen, text: "(", offset: start}, nil
+	case r == ')':
+		l.offset += width
+
+		return token{kind: tokenRightParen, text: ")", offset: start}, nil
+	case r == '"':
+		return l.lexString()
+	case unicode.IsDigit(r):
+		for l.offset < len(l.source) && isDigit(l.source[l.offset]) {
+			l.offset++
+		}
+
+		return token{kind: tokenInt, text: l.source[start:l.offset], offset: start}, nil
+	case r == '_' || unicode.IsLetter(r):
+		return l.lexWord(), nil
+	}
+
+	for _, op := range []string{"&&", "||", "==", "!=", "<=", ">=", "<", ">", "!"} {
+		if len(l.source[start:]) >= len(op) && l.source[start:start+len(op)] == op {
+			l.offset += len(op)
+
+			return token{kind: tokenOperator, text: op, offset: start}, nil
+		}
+	}
+
+	return token{}, &Error{Offset: start, Message: fmt.Sprintf("unexpected character %q", r)}
+}
+
+func (l *lexer) lexString() (token, error) {
+	start := l.offset
+	l.offset++ // Opening quote.
+
+	for l.offset < len(l.source) {
+		switch l.source[l.offset] {
+		case '\\':
+			l.offset += 2
+		case '"':
+			l.offset++
+
+			value, err := strconv.Unquote(l.source[start:l.offset])
+			if err != nil {
+				return token{}, &Error{Offset: start, Message: "invalid string literal"}
+			}
+
+			return token{kind: tokenString, text: value, offset: start}, nil
+		default:
+			l.offset++
+		}
+	}
+
+	return token{}, &Error{Offset: start, Message: "unterminated string literal"}
+}
+
+func (l *lexer) lexWord() token {
+	start := l.offset
+
+	for l.offset < len(l.source) {
+		r, width := utf8.DecodeRuneInString(l.source[l.offset:])
+		if r != '_' && r != '.' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			break
+		}
+
+		l.offset += width
+	}
+
+	word := l.source[start:l.offset]
+
+	switch {
+	case word == "true" || word == "false":
+		return token{kind: tokenBool, text: word, offset: start}
+	case wordOperators[word]:
+		return token{kind: tokenOperator, text: word, offset: start}
+	default:
+		return token{kind: tokenIdent, text: word, offset: start}
+	}
+}
+
+func isDigit(b byte) bool {
+	return '0' <= b && b <= '9'
+}
+
+// parser builds a syntax tree from the tokens of a rule by precedence
+// climbing.
+type parser struct {
+	lexer lexer
+	token token
+
+	// depth is the number of negations and parentheses enclosing the current
+	// token.
+	depth int
+}
+
+func parse(source string) (node, error) {
+	if len(source) > MaxSourceLength {
+		return nil, ErrorList{{
+			Offset:  MaxSourceLength,
+			Message: fmt.Sprintf("rule is longer than %d bytes", MaxSourceLength),
+		}}
+	}
+
+	p := parser{lexer: lexer{source: source}}
+	if err := p.advance(); err != nil {
+		return nil, ErrorList{err.(*Error)}
+	}
+
+	root, err := p.parseExpr(1)
+	if err != nil {
+		return nil, ErrorList{err.(*Error)}
+	}
+
+	if p.token.kind != tokenEOF {
+		return nil, ErrorList{p.unexpected()}
+	}
+
+	return root, nil
+}
+
+func (p *parser) advance() error {
+	tok, err := p.lexer.next()
+	if err != nil {
+		return err
+	}
+
+	p.token = tok
+
+	return nil
+}
+
+// parseExpr parses a sequence of operands joined by binary operators of at
+// least minPrecedence.
+func (p *parser) parseExpr(minPrecedence int) (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.token.kind == tokenOperator && precedence(p.token.text) >= minPrecedence {
+		op := p.token
+
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		right, err := p.parseExpr(precedence(op.text) + 1)
+		if err != nil {
+			return nil, err
+		}
+
+		left = &binaryNode{op: op.text, offset: op.offset, left: left, right: right}
+	}
+
+	return left, nil
+}
+
+// enter descends into a negation or parenthesized expression, returning an
+// error if that would nest them deeper than MaxDepth. The caller must call
+// p.leave once the nested expression is parsed.
+func (p *parser) enter() error {
+	if p.depth == MaxDepth {
+		return &Error{Offset: p.token.offset, Message: fmt.Sprintf("rule is nested deeper than %d levels", MaxDepth)}
+	}
+
+	p.depth++
+
+	return nil
+}
+
+func (p *parser) leave() {
+	p.depth--
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if p.token.kind == tokenOperator && p.token.text == "!" {
+		op := p.token
+
+		if err := p.enter(); err != nil {
+			return nil, err
+		}
+		defer p.leave()
+
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		return &notNode{offset: op.offset, operand: operand}, nil
+	}
+
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	tok := p.token
+
+	switch tok.kind {
+	case tokenInt:
+		value, err := strconv.ParseInt(tok.text, 10, 64)
+		if err != nil {
+			return nil, &Error{Offset: tok.offset, Message: fmt.Sprintf("integer %s out of range", tok.text)}
+		}
+
+		return &literalNode{offset: tok.offset, typ: Int, value: value}, p.advance()
+	case tokenString:
+		return &literalNode{offset: tok.offset, typ: String, value: tok.text}, p.advance()
+	case tokenBool:
+		return &literalNode{offset: tok.offset, typ: Bool, value: tok.text == "true"}, p.advance()
+	case tokenIdent:
+		return &variableNode{offset: tok.offset, name: tok.text}, p.advance()
+	case tokenLeftParen:
+		if err := p.enter(); err != nil {
+			return nil, err
+		}
+		defer p.leave()
+
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		inner, err := p.parseExpr(1)
+		if err != nil {
+			return nil, err
+		}
+
+		if p.token.kind != tokenRightParen {
+			return nil, &Error{Offset: p.token.offset, Message: fmt.Sprintf("expected ), found %s", describe(p.token))}
+		}
+
+		return inner, p.advance()
+	default:
+		return nil, p.unexpected()
+	}
+}
+
+func (p *parser) unexpected() *Error {
+	return &Error{Offset: p.token.offset, Message: fmt.Sprintf("unexpected %s", describe(p.token))}
+}
+
+func describe(tok token) string {
+	switch tok.kind {
+	case tokenEOF:
+		return "end of rule"
+	case tokenString:
+		return strconv.Quote(tok.text)
+	default:
+		return tok.text
+	}
+}
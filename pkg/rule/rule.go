@@ -0,0 +1,141 @@
+// Package rule implements a small language of boolean expressions, allowing
+// business rules to be declared as data rather than code.
+//
+// A rule compares variables and literals and combines the results:
+//
+//	student.age >= 16 && course.code startsWith "ADV"
+//
+// Literals are integers, double-quoted strings and the booleans true and
+// false. Variables are named by dotted identifiers and must be declared, along
+// with their types, in the Env used to compile the rule.
+//
+// The operators, from lowest to highest precedence, are:
+//
+//	||                                      logical or
+//	&&                                      logical and
+//	== != < <= > >=                         comparison
+//	startsWith endsWith contains            string comparison
+//	!                                       logical not
+//
+// Parentheses may be used to group expressions. Rules are limited to
+// MaxSourceLength bytes and MaxDepth levels of nesting.
+package rule
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Limits on the size of rules, which protect the parser and evaluator, both of
+// which are recursive, from rules that would exhaust the stack.
+const (
+	// MaxSourceLength is the maximum length of a rule in bytes.
+	MaxSourceLength = 4096
+
+	// MaxDepth is the maximum number of negations and parentheses that may
+	// enclose any part of a rule.
+	MaxDepth = 64
+)
+
+// Type is the type of a variable or expression.
+type Type int
+
+// The types of value a rule can operate on.
+const (
+	Invalid Type = iota
+	Bool
+	Int
+	String
+)
+
+func (t Type) String() string {
+	switch t {
+	case Bool:
+		return "bool"
+	case Int:
+		return "int"
+	case String:
+		return "string"
+	default:
+		return "invalid"
+	}
+}
+
+// Env declares the variables available to a rule and their types.
+type Env map[string]Type
+
+// Vars holds the values of variables when a rule is evaluated. Bool variables
+// must hold bool values, Int variables int64 values, and String variables
+// string values.
+type Vars map[string]any
+
+// Program is a compiled rule, ready to be evaluated.
+type Program struct {
+	source string
+	root   node
+	env    Env
+}
+
+// Compile parses and type-checks a rule against the variables declared in
+// env. The rule must evaluate to a boolean.
+//
+// If the rule is invalid, the error returned is an ErrorList describing each
+// problem found.
+func Compile(source string, env Env) (*Program, error) {
+	root, err := parse(source)
+	if err != nil {
+		return nil, err
+	}
+
+	c := checker{env: env}
+
+	if typ := c.check(root); typ != Bool && typ != Invalid {
+		c.errorf(root.pos(), "rule must evaluate to bool, not %s", typ)
+	}
+
+	if len(c.errs) > 0 {
+		return nil, c.errs
+	}
+
+	return &Program{source: source, root: root, env: env}, nil
+}
+
+// Source returns the text of the rule from which the Program was compiled.
+func (p *Program) Source() string {
+	return p.source
+}
+
+// Eval evaluates the rule using the values of vars. An error is returned if
+// any variable declared by the Program's Env and used by the rule is missing
+// from vars or holds a value of the wrong type.
+func (p *Program) Eval(vars Vars) (bool, error) {
+	result, err := eval(p.root, p.env, vars)
+	if err != nil {
+		return false, err
+	}
+
+	return result.(bool), nil
+}
+
+// Error describes a problem found at a byte offset of a rule's source.
+type Error struct {
+	Offset  int
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("offset %d: %s", e.Offset, e.Message)
+}
+
+// ErrorList is a list of problems found when compiling a rule.
+type ErrorList []*Error
+
+func (el ErrorList) Error() string {
+	messages := make([]string, 0, len(el))
+
+	for _, e := range el {
+		messages = append(messages, e.Error())
+	}
+
+	return strings.Join(messages, "; ")
+}
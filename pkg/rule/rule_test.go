@@ -0,0 +1,146 @@
+//go:build unit
+
+package rule
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testEnv() Env {
+	return Env{
+		"student.age":   Int,
+		"student.name":  String,
+		"course.code":   String,
+		"course.closed": Bool,
+	}
+}
+
+func testVars() Vars {
+	return Vars{
+		"student.age":   int64(17),
+		"student.name":  "Ramdas Tifft",
+		"course.code":   "ADV101",
+		"course.closed": false,
+	}
+}
+
+func TestCompile(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name       string
+		source     string
+		wantOffset int
+	}{
+		{name: "empty rule", source: "", wantOffset: 0},
+		{name: "unknown variable", source: "student.height > 150", wantOffset: 0},
+		{name: "mismatched comparison", source: `student.age == "17"`, wantOffset: 12},
+		{name: "string operator on int", source: `student.age startsWith "1"`, wantOffset: 12},
+		{name: "non-bool rule", source: "student.age", wantOffset: 0},
+		{name: "negated int", source: "!student.age", wantOffset: 0},
+		{name: "unterminated string", source: `course.code == "ADV`, wantOffset: 15},
+		{name: "unbalanced parentheses", source: "(student.age > 16", wantOffset: 17},
+		{name: "trailing tokens", source: "course.closed true", wantOffset: 14},
+		{name: "unexpected character", source: "student.age # 16", wantOffset: 12},
+		{name: "too long", source: strings.Repeat(" ", MaxSourceLength) + "true", wantOffset: MaxSourceLength},
+		{name: "nested too deeply", source: strings.Repeat("!", MaxDepth+1) + "true", wantOffset: MaxDepth},
+		{
+			name:       "parenthesized too deeply",
+			source:     strings.Repeat("(", MaxDepth+1) + "true" + strings.Repeat(")", MaxDepth+1),
+			wantOffset: MaxDepth,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := Compile(tc.source, testEnv())
+
+			var errs ErrorList
+			require.ErrorAs(t, err, &errs)
+			require.NotEmpty(t, errs)
+			require.Equal(t, tc.wantOffset, errs[0].Offset, "unexpected offset: %s", errs)
+		})
+	}
+
+	t.Run("accepts rules nested up to MaxDepth", func(t *testing.T) {
+		t.Parallel()
+
+		source := strings.Repeat("!", MaxDepth-1) + "(course.closed)"
+
+		_, err := Compile(source, testEnv())
+		require.NoError(t, err)
+	})
+
+	t.Run("reports every type error", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Compile(`student.age == "a" || course.code > 3`, testEnv())
+
+		var errs ErrorList
+		require.ErrorAs(t, err, &errs)
+		require.Len(t, errs, 2)
+	})
+}
+
+func TestProgramEval(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		source string
+		want   bool
+	}{
+		{source: "true", want: true},
+		{source: "!course.closed", want: true},
+		{source: "student.age >= 16", want: true},
+		{source: "student.age > 17", want: false},
+		{source: `student.age >= 16 && course.code startsWith "ADV"`, want: true},
+		{source: `student.age >= 18 && course.code startsWith "ADV"`, want: false},
+		{source: `student.age >= 18 || course.code endsWith "101"`, want: true},
+		{source: `student.name contains "Tifft"`, want: true},
+		{source: `course.code == "ADV101" && !(student.age < 16 || course.closed)`, want: true},
+		{source: `student.name < "Ramdas"`, want: false},
+		{source: `course.code != "ADV\"101"`, want: true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.source, func(t *testing.T) {
+			t.Parallel()
+
+			program, err := Compile(tc.source, testEnv())
+			require.NoError(t, err)
+
+			got, err := program.Eval(testVars())
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+
+	t.Run("missing variable", func(t *testing.T) {
+		t.Parallel()
+
+		program, err := Compile("student.age >= 16", testEnv())
+		require.NoError(t, err)
+
+		_, err = program.Eval(Vars{})
+		require.Error(t, err)
+	})
+
+	t.Run("variable of wrong type", func(t *testing.T) {
+		t.Parallel()
+
+		program, err := Compile("student.age >= 16", testEnv())
+		require.NoError(t, err)
+
+		_, err = program.Eval(Vars{"student.age": 17})
+		require.Error(t, err)
+	})
+}